@@ -0,0 +1,14 @@
+package tfpautomation
+
+import "embed"
+
+// Assets holds the provisioning scripts and the Terraform module skeletons, so generating a module does not depend on
+// a checkout of the repository. They are read through the framework/assets package. Only the .tf files of the modules
+// are embedded, so the state, variables and provider caches of a local run never end up in the binary.
+//
+//go:embed framework/set/provisioning/imported/*.sh
+//go:embed framework/set/resources/*/*.sh framework/set/resources/*/*.ps1
+//go:embed framework/set/resources/*/*/*.sh
+//go:embed framework/set/resources/*/*/*/*.sh framework/set/resources/*/*/*/*.conf
+//go:embed modules/*/*.tf modules/*/*/*.tf
+var Assets embed.FS
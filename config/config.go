@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"path"
 	"runtime"
+	"strings"
 
 	"github.com/imdario/mergo"
 	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
@@ -18,6 +19,7 @@ import (
 	linode "github.com/rancher/tfp-automation/config/nodeproviders/linode"
 	vsphere "github.com/rancher/tfp-automation/config/nodeproviders/vsphere"
 	"github.com/rancher/tfp-automation/defaults/configs"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type TestClientName string
@@ -38,6 +40,14 @@ const (
 	RancherPrivileged PSACT = "rancher-privileged"
 	RancherRestricted PSACT = "rancher-restricted"
 
+	IPv4      = "ipv4"
+	IPv6      = "ipv6"
+	DualStack = "dualstack"
+
+	Windows2019 = "2019"
+	Windows2022 = "2022"
+	Windows2025 = "2025"
+
 	defaultFilename      = "defaults.yaml"
 	provisioningFilename = "provisioning.yaml"
 )
@@ -83,6 +93,48 @@ func (c TestClientName) String() string {
 	return string(c)
 }
 
+type AgentEnvVar struct {
+	Name  string `json:"name,omitempty" yaml:"name,omitempty"`
+	Value string `json:"value,omitempty" yaml:"value,omitempty"`
+}
+
+type AgentToleration struct {
+	Effect   string `json:"effect,omitempty" yaml:"effect,omitempty"`
+	Key      string `json:"key,omitempty" yaml:"key,omitempty"`
+	Operator string `json:"operator,omitempty" yaml:"operator,omitempty"`
+	Seconds  int64  `json:"seconds,omitempty" yaml:"seconds,omitempty"`
+	Value    string `json:"value,omitempty" yaml:"value,omitempty"`
+}
+
+type AgentResourceRequirements struct {
+	CPULimit      string `json:"cpuLimit,omitempty" yaml:"cpuLimit,omitempty"`
+	CPURequest    string `json:"cpuRequest,omitempty" yaml:"cpuRequest,omitempty"`
+	MemoryLimit   string `json:"memoryLimit,omitempty" yaml:"memoryLimit,omitempty"`
+	MemoryRequest string `json:"memoryRequest,omitempty" yaml:"memoryRequest,omitempty"`
+}
+
+type AgentPriorityClass struct {
+	PreemptionPolicy string `json:"preemptionPolicy,omitempty" yaml:"preemptionPolicy,omitempty"`
+	Value            int64  `json:"value,omitempty" yaml:"value,omitempty"`
+}
+
+type AgentPodDisruptionBudget struct {
+	MaxUnavailable string `json:"maxUnavailable,omitempty" yaml:"maxUnavailable,omitempty"`
+	MinAvailable   string `json:"minAvailable,omitempty" yaml:"minAvailable,omitempty"`
+}
+
+type AgentSchedulingCustomization struct {
+	PodDisruptionBudget *AgentPodDisruptionBudget `json:"podDisruptionBudget,omitempty" yaml:"podDisruptionBudget,omitempty"`
+	PriorityClass       *AgentPriorityClass       `json:"priorityClass,omitempty" yaml:"priorityClass,omitempty"`
+}
+
+type AgentDeploymentCustomization struct {
+	AppendTolerations            []AgentToleration             `json:"appendTolerations,omitempty" yaml:"appendTolerations,omitempty"`
+	OverrideAffinity             map[string]any                `json:"overrideAffinity,omitempty" yaml:"overrideAffinity,omitempty"`
+	OverrideResourceRequirements *AgentResourceRequirements    `json:"overrideResourceRequirements,omitempty" yaml:"overrideResourceRequirements,omitempty"`
+	SchedulingCustomization      *AgentSchedulingCustomization `json:"schedulingCustomization,omitempty" yaml:"schedulingCustomization,omitempty"`
+}
+
 type DataDirectories struct {
 	SystemAgentPath  string `json:"systemAgentPath,omitempty" yaml:"systemAgentPath,omitempty"`
 	ProvisioningPath string `json:"provisioningPath,omitempty" yaml:"provisioningPath,omitempty"`
@@ -96,23 +148,50 @@ type FeatureFlags struct {
 }
 
 type Nodepool struct {
-	Quantity          int64  `json:"quantity,omitempty" yaml:"quantity,omitempty"`
-	Etcd              bool   `json:"etcd,omitempty" yaml:"etcd,omitempty"`
-	Controlplane      bool   `json:"controlplane,omitempty" yaml:"controlplane,omitempty"`
-	Windows           bool   `json:"windows,omitempty" yaml:"windows,omitempty"`
-	DiskSize          int64  `json:"diskSize,omitempty" yaml:"diskSize,omitempty"`
-	Worker            bool   `json:"worker,omitempty" yaml:"worker,omitempty"`
-	InstanceType      string `json:"instanceType,omitempty" yaml:"instanceType,omitempty"`
-	DesiredSize       int64  `json:"desiredSize,omitempty" yaml:"desiredSize,omitempty"`
-	MaxSize           int64  `json:"maxSize,omitempty" yaml:"maxSize,omitempty"`
-	MinSize           int64  `json:"minSize,omitempty" yaml:"minSize,omitempty"`
-	MaxPodsConstraint int64  `json:"maxPodsConstraint,omitempty" yaml:"maxPodsConstraint,omitempty"`
+	Quantity              int64             `json:"quantity,omitempty" yaml:"quantity,omitempty"`
+	Etcd                  bool              `json:"etcd,omitempty" yaml:"etcd,omitempty"`
+	Controlplane          bool              `json:"controlplane,omitempty" yaml:"controlplane,omitempty"`
+	Windows               bool              `json:"windows,omitempty" yaml:"windows,omitempty"`
+	WindowsVersion        string            `json:"windowsVersion,omitempty" yaml:"windowsVersion,omitempty"`
+	DiskSize              int64             `json:"diskSize,omitempty" yaml:"diskSize,omitempty"`
+	Worker                bool              `json:"worker,omitempty" yaml:"worker,omitempty"`
+	InstanceType          string            `json:"instanceType,omitempty" yaml:"instanceType,omitempty"`
+	DesiredSize           int64             `json:"desiredSize,omitempty" yaml:"desiredSize,omitempty"`
+	MaxSize               int64             `json:"maxSize,omitempty" yaml:"maxSize,omitempty"`
+	MinSize               int64             `json:"minSize,omitempty" yaml:"minSize,omitempty"`
+	MaxPodsConstraint     int64             `json:"maxPodsConstraint,omitempty" yaml:"maxPodsConstraint,omitempty"`
+	Name                  string            `json:"name,omitempty" yaml:"name,omitempty"`
+	Mode                  string            `json:"mode,omitempty" yaml:"mode,omitempty"`
+	KubernetesVersion     string            `json:"kubernetesVersion,omitempty" yaml:"kubernetesVersion,omitempty"`
+	Labels                map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Taints                []string          `json:"taints,omitempty" yaml:"taints,omitempty"`
+	EnableAutoscaling     bool              `json:"enableAutoscaling,omitempty" yaml:"enableAutoscaling,omitempty"`
+	Spot                  bool              `json:"spot,omitempty" yaml:"spot,omitempty"`
+	SpotInstanceTypes     []string          `json:"spotInstanceTypes,omitempty" yaml:"spotInstanceTypes,omitempty"`
+	LaunchTemplateID      string            `json:"launchTemplateId,omitempty" yaml:"launchTemplateId,omitempty"`
+	LaunchTemplateVersion int64             `json:"launchTemplateVersion,omitempty" yaml:"launchTemplateVersion,omitempty"`
+}
+
+type Networking struct {
+	ClusterCIDR     string `json:"clusterCIDR,omitempty" yaml:"clusterCIDR,omitempty"`
+	IPFamily        string `json:"ipFamily,omitempty" yaml:"ipFamily,omitempty" default:"ipv4"`
+	ServiceCIDR     string `json:"serviceCIDR,omitempty" yaml:"serviceCIDR,omitempty"`
+	StackPreference string `json:"stackPreference,omitempty" yaml:"stackPreference,omitempty"`
+}
+
+type MachineSelectorConfig struct {
+	Config               map[string]any        `json:"config,omitempty" yaml:"config,omitempty"`
+	MachineLabelSelector *metav1.LabelSelector `json:"machineLabelSelector,omitempty" yaml:"machineLabelSelector,omitempty"`
 }
 
 type Proxy struct {
 	ProxyBastion string `json:"proxyBastion,omitempty" yaml:"proxyBastion,omitempty"`
 }
 
+type HostedImport struct {
+	Bastion string `json:"bastion,omitempty" yaml:"bastion,omitempty"`
+}
+
 type PrivateRegistries struct {
 	AuthConfigSecretName   string `json:"authConfigSecretName,omitempty" yaml:"authConfigSecretName,omitempty"`
 	CABundle               string `json:"caBundle,omitempty" yaml:"caBundle,omitempty"`
@@ -128,35 +207,52 @@ type PrivateRegistries struct {
 	Username               string `json:"username,omitempty" yaml:"username,omitempty"`
 }
 
+type RancherBackup struct {
+	ChartVersion           string `json:"chartVersion,omitempty" yaml:"chartVersion,omitempty"`
+	MinIO                  bool   `json:"minio,omitempty" yaml:"minio,omitempty"`
+	ResourceSetName        string `json:"resourceSetName,omitempty" yaml:"resourceSetName,omitempty"`
+	RestoreRancherHostname string `json:"restoreRancherHostname,omitempty" yaml:"restoreRancherHostname,omitempty"`
+	S3AccessKey            string `json:"s3AccessKey,omitempty" yaml:"s3AccessKey,omitempty"`
+	S3Bucket               string `json:"s3Bucket,omitempty" yaml:"s3Bucket,omitempty"`
+	S3Endpoint             string `json:"s3Endpoint,omitempty" yaml:"s3Endpoint,omitempty"`
+	S3Folder               string `json:"s3Folder,omitempty" yaml:"s3Folder,omitempty"`
+	S3Region               string `json:"s3Region,omitempty" yaml:"s3Region,omitempty"`
+	S3SecretKey            string `json:"s3SecretKey,omitempty" yaml:"s3SecretKey,omitempty"`
+}
+
 type Standalone struct {
-	BootstrapPassword              string        `json:"bootstrapPassword,omitempty" yaml:"bootstrapPassword,omitempty"`
-	CertManagerVersion             string        `json:"certManagerVersion,omitempty" yaml:"certManagerVersion,omitempty"`
-	ChartVersion                   string        `json:"chartVersion,omitempty" yaml:"chartVersion,omitempty"`
-	FeatureFlags                   *FeatureFlags `json:"featureFlags,omitempty" yaml:"featureFlags,omitempty"`
-	K3SVersion                     string        `json:"k3sVersion,omitempty" yaml:"k3sVersion,omitempty"`
-	RancherAgentImage              string        `json:"rancherAgentImage,omitempty" yaml:"rancherAgentImage,omitempty"`
-	RancherChartRepository         string        `json:"rancherChartRepository,omitempty" yaml:"rancherChartRepository,omitempty"`
-	RancherHostname                string        `json:"rancherHostname,omitempty" yaml:"rancherHostname,omitempty"`
-	RancherImage                   string        `json:"rancherImage,omitempty" yaml:"rancherImage,omitempty"`
-	RancherTagVersion              string        `json:"rancherTagVersion,omitempty" yaml:"rancherTagVersion,omitempty"`
-	RegistryUsername               string        `json:"registryUsername,omitempty" yaml:"registryUsername,omitempty"`
-	RegistryPassword               string        `json:"registryPassword,omitempty" yaml:"registryPassword,omitempty"`
-	Repo                           string        `json:"repo,omitempty" yaml:"repo,omitempty"`
-	OSUser                         string        `json:"osUser,omitempty" yaml:"osUser,omitempty"`
-	OSGroup                        string        `json:"osGroup,omitempty" yaml:"osGroup,omitempty"`
-	RKE2Version                    string        `json:"rke2Version,omitempty" yaml:"rke2Version,omitempty"`
-	UpgradeAirgapRancher           bool          `json:"upgradeAirgapRancher,omitempty" yaml:"upgradeAirgapRancher,omitempty"`
-	UpgradeDualStackRancher        bool          `json:"upgradeDualStackRancher,omitempty" yaml:"upgradeDualStackRancher,omitempty"`
-	UpgradeIPv6Rancher             bool          `json:"upgradeIPv6Rancher,omitempty" yaml:"upgradeIPv6Rancher,omitempty"`
-	UpgradeLocalCluster            bool          `json:"upgradeLocalCluster,omitempty" yaml:"upgradeLocalCluster,omitempty"`
-	UpgradeProxyRancher            bool          `json:"upgradeProxyRancher,omitempty" yaml:"upgradeProxyRancher,omitempty"`
-	UpgradeRancher                 bool          `json:"upgradeRancher,omitempty" yaml:"upgradeRancher,omitempty"`
-	UpgradedRancherChartRepository string        `json:"upgradedRancherChartRepository,omitempty" yaml:"upgradedRancherChartRepository,omitempty"`
-	UpgradedRancherChartVersion    string        `json:"upgradedRancherChartVersion,omitempty" yaml:"upgradedRancherChartVersion,omitempty"`
-	UpgradedRancherImage           string        `json:"upgradedRancherImage,omitempty" yaml:"upgradedRancherImage,omitempty"`
-	UpgradedRancherAgentImage      string        `json:"upgradedRancherAgentImage,omitempty" yaml:"upgradedRancherAgentImage,omitempty"`
-	UpgradedRancherRepo            string        `json:"upgradedRancherRepo,omitempty" yaml:"upgradedRancherRepo,omitempty"`
-	UpgradedRancherTagVersion      string        `json:"upgradedRancherTagVersion,omitempty" yaml:"upgradedRancherTagVersion,omitempty"`
+	BootstrapPassword              string         `json:"bootstrapPassword,omitempty" yaml:"bootstrapPassword,omitempty"`
+	CertManagerVersion             string         `json:"certManagerVersion,omitempty" yaml:"certManagerVersion,omitempty"`
+	ChartVersion                   string         `json:"chartVersion,omitempty" yaml:"chartVersion,omitempty"`
+	FeatureFlags                   *FeatureFlags  `json:"featureFlags,omitempty" yaml:"featureFlags,omitempty"`
+	K3SVersion                     string         `json:"k3sVersion,omitempty" yaml:"k3sVersion,omitempty"`
+	LocalClusterUpgradeStrategy    string         `json:"localClusterUpgradeStrategy,omitempty" yaml:"localClusterUpgradeStrategy,omitempty"`
+	RancherAgentImage              string         `json:"rancherAgentImage,omitempty" yaml:"rancherAgentImage,omitempty"`
+	RancherBackup                  *RancherBackup `json:"rancherBackup,omitempty" yaml:"rancherBackup,omitempty"`
+	RancherChartRepository         string         `json:"rancherChartRepository,omitempty" yaml:"rancherChartRepository,omitempty"`
+	RancherHostname                string         `json:"rancherHostname,omitempty" yaml:"rancherHostname,omitempty"`
+	RancherImage                   string         `json:"rancherImage,omitempty" yaml:"rancherImage,omitempty"`
+	RancherTagVersion              string         `json:"rancherTagVersion,omitempty" yaml:"rancherTagVersion,omitempty"`
+	RegistryUsername               string         `json:"registryUsername,omitempty" yaml:"registryUsername,omitempty"`
+	RegistryPassword               string         `json:"registryPassword,omitempty" yaml:"registryPassword,omitempty"`
+	Repo                           string         `json:"repo,omitempty" yaml:"repo,omitempty"`
+	OSUser                         string         `json:"osUser,omitempty" yaml:"osUser,omitempty"`
+	OSGroup                        string         `json:"osGroup,omitempty" yaml:"osGroup,omitempty"`
+	RKE2Version                    string         `json:"rke2Version,omitempty" yaml:"rke2Version,omitempty"`
+	UpgradeAirgapRancher           bool           `json:"upgradeAirgapRancher,omitempty" yaml:"upgradeAirgapRancher,omitempty"`
+	UpgradeDualStackRancher        bool           `json:"upgradeDualStackRancher,omitempty" yaml:"upgradeDualStackRancher,omitempty"`
+	UpgradeIPv6Rancher             bool           `json:"upgradeIPv6Rancher,omitempty" yaml:"upgradeIPv6Rancher,omitempty"`
+	UpgradeLocalCluster            bool           `json:"upgradeLocalCluster,omitempty" yaml:"upgradeLocalCluster,omitempty"`
+	UpgradeProxyRancher            bool           `json:"upgradeProxyRancher,omitempty" yaml:"upgradeProxyRancher,omitempty"`
+	UpgradeRancher                 bool           `json:"upgradeRancher,omitempty" yaml:"upgradeRancher,omitempty"`
+	UpgradedRancherChartRepository string         `json:"upgradedRancherChartRepository,omitempty" yaml:"upgradedRancherChartRepository,omitempty"`
+	UpgradedRancherChartVersion    string         `json:"upgradedRancherChartVersion,omitempty" yaml:"upgradedRancherChartVersion,omitempty"`
+	UpgradedRancherImage           string         `json:"upgradedRancherImage,omitempty" yaml:"upgradedRancherImage,omitempty"`
+	UpgradedRancherAgentImage      string         `json:"upgradedRancherAgentImage,omitempty" yaml:"upgradedRancherAgentImage,omitempty"`
+	UpgradedRancherRepo            string         `json:"upgradedRancherRepo,omitempty" yaml:"upgradedRancherRepo,omitempty"`
+	UpgradedRancherTagVersion      string         `json:"upgradedRancherTagVersion,omitempty" yaml:"upgradedRancherTagVersion,omitempty"`
+	UpgradedK3SVersion             string         `json:"upgradedK3SVersion,omitempty" yaml:"upgradedK3SVersion,omitempty"`
+	UpgradedRKE2Version            string         `json:"upgradedRKE2Version,omitempty" yaml:"upgradedRKE2Version,omitempty"`
 }
 
 type StandaloneRegistry struct {
@@ -179,54 +275,70 @@ type StandaloneRegistry struct {
 	UseAuthGlobalRegistry      bool   `json:"useAuthGlobalRegistry,omitempty" yaml:"useAuthGlobalRegistry,omitempty" default:"true"`
 }
 
+type Topology struct {
+	Servers       int64 `json:"servers,omitempty" yaml:"servers,omitempty" default:"3"`
+	Agents        int64 `json:"agents,omitempty" yaml:"agents,omitempty"`
+	DedicatedEtcd int64 `json:"dedicatedEtcd,omitempty" yaml:"dedicatedEtcd,omitempty"`
+}
+
 type TerraformConfig struct {
-	AWSConfig                           aws.Config                   `json:"awsConfig,omitempty" yaml:"awsConfig,omitempty"`
-	AWSCredentials                      aws.Credentials              `json:"awsCredentials,omitempty" yaml:"awsCredentials,omitempty"`
-	AzureConfig                         azure.Config                 `json:"azureConfig,omitempty" yaml:"azureConfig,omitempty"`
-	AzureCredentials                    azure.Credentials            `json:"azureCredentials,omitempty" yaml:"azureCredentials,omitempty"`
-	GoogleConfig                        google.Config                `json:"googleConfig,omitempty" yaml:"googleConfig,omitempty"`
-	GoogleCredentials                   google.Credentials           `json:"googleCredentials,omitempty" yaml:"googleCredentials,omitempty"`
-	HarvesterConfig                     harvester.Config             `json:"harvesterConfig,omitempty" yaml:"harvesterConfig,omitempty"`
-	HarvesterCredentials                harvester.Credentials        `json:"harvesterCredentials,omitempty" yaml:"harvesterCredentials,omitempty"`
-	LinodeConfig                        linode.Config                `json:"linodeConfig,omitempty" yaml:"linodeConfig,omitempty"`
-	LinodeCredentials                   linode.Credentials           `json:"linodeCredentials,omitempty" yaml:"linodeCredentials,omitempty"`
-	VsphereConfig                       vsphere.Config               `json:"vsphereConfig,omitempty" yaml:"vsphereConfig,omitempty"`
-	VsphereCredentials                  vsphere.Credentials          `json:"vsphereCredentials,omitempty" yaml:"vsphereCredentials,omitempty"`
-	ADConfig                            authproviders.ADConfig       `json:"adConfig,omitempty" yaml:"adConfig,omitempty"`
-	AzureADConfig                       authproviders.AzureADConfig  `json:"azureADConfig,omitempty" yaml:"azureADConfig,omitempty"`
-	GithubConfig                        authproviders.GithubConfig   `json:"githubConfig,omitempty" yaml:"githubConfig,omitempty"`
-	OktaConfig                          authproviders.OktaConfig     `json:"oktaConfig,omitempty" yaml:"oktaConfig,omitempty"`
-	OpenLDAPConfig                      authproviders.OpenLDAPConfig `json:"openLDAPConfig,omitempty" yaml:"openLDAPConfig,omitempty"`
-	AirgapBastion                       string                       `json:"airgapBastion,omitempty" yaml:"airgapBastion,omitempty"`
-	AuthProvider                        string                       `json:"authProvider,omitempty" yaml:"authProvider,omitempty"`
-	ResourcePrefix                      string                       `json:"resourcePrefix,omitempty" yaml:"resourcePrefix,omitempty"`
-	CNI                                 string                       `json:"cni,omitempty" yaml:"cni,omitempty"`
-	ChartValues                         string                       `json:"chartValues,omitempty" yaml:"chartValues,omitempty"`
-	DataDirectories                     *DataDirectories             `json:"dataDirectories,omitempty" yaml:"dataDirectories,omitempty"`
-	DisableKubeProxy                    string                       `json:"disable-kube-proxy,omitempty" yaml:"disable-kube-proxy,omitempty"`
-	DefaultClusterRoleForProjectMembers string                       `json:"defaultClusterRoleForProjectMembers,omitempty" yaml:"defaultClusterRoleForProjectMembers,omitempty"`
-	DownstreamClusterProvider           string                       `json:"downstreamClusterProvider,omitempty" yaml:"downstreamClusterProvider,omitempty"`
-	EnableNetworkPolicy                 bool                         `json:"enableNetworkPolicy,omitempty" yaml:"enableNetworkPolicy,omitempty"`
-	ETCD                                *rkev1.ETCD                  `json:"etcd,omitempty" yaml:"etcd,omitempty"`
-	GenerateV3Token                     bool                         `json:"generateV3Token,omitempty" yaml:"generateV3Token,omitempty" default:"false"`
-	LocalAuthEndpoint                   bool                         `json:"localAuthEndpoint,omitempty" yaml:"localAuthEndpoint,omitempty" default:"false"`
-	LocalCluster                        string                       `json:"localCluster,omitempty" yaml:"localCluster,omitempty" default:"rke2"`
-	LocalHostedCluster                  bool                         `json:"localHostedCluster,omitempty" yaml:"localHostedCluster,omitempty"`
-	ARMAchitecture                      bool                         `json:"armArchitecture,omitempty" yaml:"armArchitecture,omitempty" default:"false"`
-	MixedArchitecture                   bool                         `json:"mixedArchitecture,omitempty" yaml:"mixedArchitecture,omitempty" default:"false"`
-	Module                              string                       `json:"module,omitempty" yaml:"module,omitempty"`
-	NetworkPlugin                       string                       `json:"networkPlugin,omitempty" yaml:"networkPlugin,omitempty"`
-	PartnerRC                           bool                         `json:"partnerRC,omitempty" yaml:"partnerRC,omitempty" default:"false"`
-	PrivateFullChainPath                string                       `json:"privateFullChainPath,omitempty" yaml:"privateFullChainPath,omitempty"`
-	PrivateCertKeyPath                  string                       `json:"privateCertKeyPath,omitempty" yaml:"privateCertKeyPath,omitempty"`
-	PrivateKeyPath                      string                       `json:"privateKeyPath,omitempty" yaml:"privateKeyPath,omitempty"`
-	PrivateRegistries                   *PrivateRegistries           `json:"privateRegistries,omitempty" yaml:"privateRegistries,omitempty"`
-	Proxy                               *Proxy                       `json:"proxy,omitempty" yaml:"proxy,omitempty"`
-	Provider                            string                       `json:"provider,omitempty" yaml:"provider,omitempty"`
-	Standalone                          *Standalone                  `json:"standalone,omitempty" yaml:"standalone,omitempty"`
-	StandaloneRegistry                  *StandaloneRegistry          `json:"standaloneRegistry,omitempty" yaml:"standaloneRegistry,omitempty"`
-	TimeSleep                           string                       `json:"timeSleep,omitempty" yaml:"timeSleep,omitempty"`
-	WindowsPrivateKeyPath               string                       `json:"windowsPrivateKeyPath,omitempty" yaml:"windowsPrivateKeyPath,omitempty"`
+	AWSConfig                           aws.Config                    `json:"awsConfig,omitempty" yaml:"awsConfig,omitempty"`
+	AWSCredentials                      aws.Credentials               `json:"awsCredentials,omitempty" yaml:"awsCredentials,omitempty"`
+	AzureConfig                         azure.Config                  `json:"azureConfig,omitempty" yaml:"azureConfig,omitempty"`
+	AzureCredentials                    azure.Credentials             `json:"azureCredentials,omitempty" yaml:"azureCredentials,omitempty"`
+	GoogleConfig                        google.Config                 `json:"googleConfig,omitempty" yaml:"googleConfig,omitempty"`
+	GoogleCredentials                   google.Credentials            `json:"googleCredentials,omitempty" yaml:"googleCredentials,omitempty"`
+	HarvesterConfig                     harvester.Config              `json:"harvesterConfig,omitempty" yaml:"harvesterConfig,omitempty"`
+	HarvesterCredentials                harvester.Credentials         `json:"harvesterCredentials,omitempty" yaml:"harvesterCredentials,omitempty"`
+	LinodeConfig                        linode.Config                 `json:"linodeConfig,omitempty" yaml:"linodeConfig,omitempty"`
+	LinodeCredentials                   linode.Credentials            `json:"linodeCredentials,omitempty" yaml:"linodeCredentials,omitempty"`
+	VsphereConfig                       vsphere.Config                `json:"vsphereConfig,omitempty" yaml:"vsphereConfig,omitempty"`
+	VsphereCredentials                  vsphere.Credentials           `json:"vsphereCredentials,omitempty" yaml:"vsphereCredentials,omitempty"`
+	ADConfig                            authproviders.ADConfig        `json:"adConfig,omitempty" yaml:"adConfig,omitempty"`
+	AzureADConfig                       authproviders.AzureADConfig   `json:"azureADConfig,omitempty" yaml:"azureADConfig,omitempty"`
+	GithubConfig                        authproviders.GithubConfig    `json:"githubConfig,omitempty" yaml:"githubConfig,omitempty"`
+	OktaConfig                          authproviders.OktaConfig      `json:"oktaConfig,omitempty" yaml:"oktaConfig,omitempty"`
+	OpenLDAPConfig                      authproviders.OpenLDAPConfig  `json:"openLDAPConfig,omitempty" yaml:"openLDAPConfig,omitempty"`
+	AgentEnvVars                        []AgentEnvVar                 `json:"agentEnvVars,omitempty" yaml:"agentEnvVars,omitempty"`
+	AirgapBastion                       string                        `json:"airgapBastion,omitempty" yaml:"airgapBastion,omitempty"`
+	AuthProvider                        string                        `json:"authProvider,omitempty" yaml:"authProvider,omitempty"`
+	ResourcePrefix                      string                        `json:"resourcePrefix,omitempty" yaml:"resourcePrefix,omitempty"`
+	CNI                                 string                        `json:"cni,omitempty" yaml:"cni,omitempty"`
+	ChartValues                         string                        `json:"chartValues,omitempty" yaml:"chartValues,omitempty"`
+	CloudCredentialSecretName           string                        `json:"cloudCredentialSecretName,omitempty" yaml:"cloudCredentialSecretName,omitempty"`
+	ClusterAgentCustomization           *AgentDeploymentCustomization `json:"clusterAgentCustomization,omitempty" yaml:"clusterAgentCustomization,omitempty"`
+	DataDirectories                     *DataDirectories              `json:"dataDirectories,omitempty" yaml:"dataDirectories,omitempty"`
+	DisableKubeProxy                    string                        `json:"disable-kube-proxy,omitempty" yaml:"disable-kube-proxy,omitempty"`
+	DefaultClusterRoleForProjectMembers string                        `json:"defaultClusterRoleForProjectMembers,omitempty" yaml:"defaultClusterRoleForProjectMembers,omitempty"`
+	DownstreamClusterProvider           string                        `json:"downstreamClusterProvider,omitempty" yaml:"downstreamClusterProvider,omitempty"`
+	EnableCloudProvider                 bool                          `json:"enableCloudProvider,omitempty" yaml:"enableCloudProvider,omitempty"`
+	EnableNetworkPolicy                 bool                          `json:"enableNetworkPolicy,omitempty" yaml:"enableNetworkPolicy,omitempty"`
+	ETCD                                *rkev1.ETCD                   `json:"etcd,omitempty" yaml:"etcd,omitempty"`
+	FleetAgentCustomization             *AgentDeploymentCustomization `json:"fleetAgentCustomization,omitempty" yaml:"fleetAgentCustomization,omitempty"`
+	GenerateV3Token                     bool                          `json:"generateV3Token,omitempty" yaml:"generateV3Token,omitempty" default:"false"`
+	HostedImport                        *HostedImport                 `json:"hostedImport,omitempty" yaml:"hostedImport,omitempty"`
+	LocalAuthEndpoint                   bool                          `json:"localAuthEndpoint,omitempty" yaml:"localAuthEndpoint,omitempty" default:"false"`
+	LocalCluster                        string                        `json:"localCluster,omitempty" yaml:"localCluster,omitempty" default:"rke2"`
+	LocalHostedCluster                  bool                          `json:"localHostedCluster,omitempty" yaml:"localHostedCluster,omitempty"`
+	MachineGlobalConfig                 map[string]any                `json:"machineGlobalConfig,omitempty" yaml:"machineGlobalConfig,omitempty"`
+	MachineSelectorConfig               []MachineSelectorConfig       `json:"machineSelectorConfig,omitempty" yaml:"machineSelectorConfig,omitempty"`
+	ARMAchitecture                      bool                          `json:"armArchitecture,omitempty" yaml:"armArchitecture,omitempty" default:"false"`
+	MixedArchitecture                   bool                          `json:"mixedArchitecture,omitempty" yaml:"mixedArchitecture,omitempty" default:"false"`
+	Module                              string                        `json:"module,omitempty" yaml:"module,omitempty"`
+	NetworkPlugin                       string                        `json:"networkPlugin,omitempty" yaml:"networkPlugin,omitempty"`
+	Networking                          *Networking                   `json:"networking,omitempty" yaml:"networking,omitempty"`
+	PartnerRC                           bool                          `json:"partnerRC,omitempty" yaml:"partnerRC,omitempty" default:"false"`
+	PrivateFullChainPath                string                        `json:"privateFullChainPath,omitempty" yaml:"privateFullChainPath,omitempty"`
+	PrivateCertKeyPath                  string                        `json:"privateCertKeyPath,omitempty" yaml:"privateCertKeyPath,omitempty"`
+	PrivateKeyPath                      string                        `json:"privateKeyPath,omitempty" yaml:"privateKeyPath,omitempty"`
+	PrivateRegistries                   *PrivateRegistries            `json:"privateRegistries,omitempty" yaml:"privateRegistries,omitempty"`
+	Proxy                               *Proxy                        `json:"proxy,omitempty" yaml:"proxy,omitempty"`
+	Provider                            string                        `json:"provider,omitempty" yaml:"provider,omitempty"`
+	Standalone                          *Standalone                   `json:"standalone,omitempty" yaml:"standalone,omitempty"`
+	StandaloneRegistry                  *StandaloneRegistry           `json:"standaloneRegistry,omitempty" yaml:"standaloneRegistry,omitempty"`
+	TimeSleep                           string                        `json:"timeSleep,omitempty" yaml:"timeSleep,omitempty"`
+	Topology                            *Topology                     `json:"topology,omitempty" yaml:"topology,omitempty"`
+	WindowsPrivateKeyPath               string                        `json:"windowsPrivateKeyPath,omitempty" yaml:"windowsPrivateKeyPath,omitempty"`
 }
 
 type Snapshots struct {
@@ -236,18 +348,41 @@ type Snapshots struct {
 	SnapshotRestore string `json:"snapshotRestore,omitempty" yaml:"snapshotRestore,omitempty"`
 }
 
+type PermutationSpec struct {
+	Path   string            `json:"path,omitempty" yaml:"path,omitempty"`
+	Values []any             `json:"values,omitempty" yaml:"values,omitempty"`
+	Rules  []PermutationRule `json:"rules,omitempty" yaml:"rules,omitempty"`
+}
+
+type PermutationRule struct {
+	When any               `json:"when,omitempty" yaml:"when,omitempty"`
+	Then []PermutationSpec `json:"then,omitempty" yaml:"then,omitempty"`
+}
+
+type PermutationReduction struct {
+	Mode     string `json:"mode,omitempty" yaml:"mode,omitempty"`
+	Strength int    `json:"strength,omitempty" yaml:"strength,omitempty"`
+	Seed     int64  `json:"seed,omitempty" yaml:"seed,omitempty"`
+}
+
 type TerratestConfig struct {
-	AKSKubernetesVersion string     `json:"aksKubernetesVersion,omitempty" yaml:"aksKubernetesVersion,omitempty"`
-	EKSKubernetesVersion string     `json:"eksKubernetesVersion,omitempty" yaml:"eksKubernetesVersion,omitempty"`
-	GKEKubernetesVersion string     `json:"gkeKubernetesVersion,omitempty" yaml:"gkeKubernetesVersion,omitempty"`
-	KubernetesVersion    string     `json:"kubernetesVersion,omitempty" yaml:"kubernetesVersion,omitempty"`
-	LocalQaseReporting   bool       `json:"localQaseReporting,omitempty" yaml:"localQaseReporting,omitempty" default:"false"`
-	Nodepools            []Nodepool `json:"nodepools,omitempty" yaml:"nodepools,omitempty"`
-	PathToRepo           string     `json:"pathToRepo,omitempty" yaml:"pathToRepo,omitempty"`
-	PSACT                string     `json:"psact,omitempty" yaml:"psact,omitempty"`
-	SnapshotInput        Snapshots  `json:"snapshotInput,omitempty" yaml:"snapshotInput,omitempty"`
-	StandaloneLogging    bool       `json:"standaloneLogging,omitempty" yaml:"standaloneLogging,omitempty"`
-	TFLogging            bool       `json:"tfLogging,omitempty" yaml:"tfLogging,omitempty"`
+	AKSKubernetesVersion         string                `json:"aksKubernetesVersion,omitempty" yaml:"aksKubernetesVersion,omitempty"`
+	EKSKubernetesVersion         string                `json:"eksKubernetesVersion,omitempty" yaml:"eksKubernetesVersion,omitempty"`
+	GKEKubernetesVersion         string                `json:"gkeKubernetesVersion,omitempty" yaml:"gkeKubernetesVersion,omitempty"`
+	KubernetesVersion            string                `json:"kubernetesVersion,omitempty" yaml:"kubernetesVersion,omitempty"`
+	LocalQaseReporting           bool                  `json:"localQaseReporting,omitempty" yaml:"localQaseReporting,omitempty" default:"false"`
+	Nodepools                    []Nodepool            `json:"nodepools,omitempty" yaml:"nodepools,omitempty"`
+	PathToRepo                   string                `json:"pathToRepo,omitempty" yaml:"pathToRepo,omitempty"`
+	Permutations                 []PermutationSpec     `json:"permutations,omitempty" yaml:"permutations,omitempty"`
+	PermutationReduction         *PermutationReduction `json:"permutationReduction,omitempty" yaml:"permutationReduction,omitempty"`
+	PSACT                        string                `json:"psact,omitempty" yaml:"psact,omitempty"`
+	SnapshotInput                Snapshots             `json:"snapshotInput,omitempty" yaml:"snapshotInput,omitempty"`
+	StandaloneLogging            bool                  `json:"standaloneLogging,omitempty" yaml:"standaloneLogging,omitempty"`
+	TFLogging                    bool                  `json:"tfLogging,omitempty" yaml:"tfLogging,omitempty"`
+	UpgradeModules               []string              `json:"upgradeModules,omitempty" yaml:"upgradeModules,omitempty"`
+	UpgradedAKSKubernetesVersion string                `json:"upgradedAKSKubernetesVersion,omitempty" yaml:"upgradedAKSKubernetesVersion,omitempty"`
+	UpgradedEKSKubernetesVersion string                `json:"upgradedEKSKubernetesVersion,omitempty" yaml:"upgradedEKSKubernetesVersion,omitempty"`
+	UpgradedGKEKubernetesVersion string                `json:"upgradedGKEKubernetesVersion,omitempty" yaml:"upgradedGKEKubernetesVersion,omitempty"`
 }
 
 // LoadTFPConfigs loads the TFP configurations from the provided map
@@ -257,6 +392,7 @@ func LoadTFPConfigs(cattleConfig map[string]any) (*rancher.Config, *TerraformCon
 
 	terraformConfig := new(TerraformConfig)
 	operations.LoadObjectFromMap(TerraformConfigurationFileKey, cattleConfig, terraformConfig)
+	terraformConfig.Networking = resolveNetworking(terraformConfig)
 
 	terratestConfig := new(TerratestConfig)
 	operations.LoadObjectFromMap(TerratestConfigurationFileKey, cattleConfig, terratestConfig)
@@ -269,6 +405,42 @@ func LoadTFPConfigs(cattleConfig map[string]any) (*rancher.Config, *TerraformCon
 	return rancherConfig, terraformConfig, terratestConfig, standaloneConfig
 }
 
+// resolveNetworking returns the networking settings of the given config. Settings missing from the networking section
+// fall back to the ones that used to live in the AWS config, so older configs keep working.
+func resolveNetworking(terraformConfig *TerraformConfig) *Networking {
+	networking := Networking{}
+	if terraformConfig.Networking != nil {
+		networking = *terraformConfig.Networking
+	}
+
+	awsConfig := terraformConfig.AWSConfig
+
+	if networking.ClusterCIDR == "" {
+		networking.ClusterCIDR = awsConfig.ClusterCIDR
+	}
+
+	if networking.ServiceCIDR == "" {
+		networking.ServiceCIDR = awsConfig.ServiceCIDR
+	}
+
+	if networking.StackPreference == "" && awsConfig.Networking != nil {
+		networking.StackPreference = awsConfig.Networking.StackPreference
+	}
+
+	if networking.IPFamily == "" {
+		switch {
+		case awsConfig.IPv6AddressOnly || awsConfig.IPFamily == IPv6:
+			networking.IPFamily = IPv6
+		case awsConfig.EnablePrimaryIPv6 || strings.Contains(networking.ClusterCIDR, ","):
+			networking.IPFamily = DualStack
+		default:
+			networking.IPFamily = IPv4
+		}
+	}
+
+	return &networking
+}
+
 // LoadPackageDefaults loads the specified filename in the same package as the test
 func LoadPackageDefaults(cattleConfig map[string]any, filePath string) (map[string]any, error) {
 	if filePath == "" {
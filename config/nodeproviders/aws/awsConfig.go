@@ -25,6 +25,7 @@ type Config struct {
 	EKSRegion             string      `json:"eksRegion,omitempty" yaml:"eksRegion,omitempty"`
 	EnablePrimaryIPv6     bool        `json:"enablePrimaryIPv6,omitempty" yaml:"enablePrimaryIPv6,omitempty" default:"false"`
 	HTTPProtocolIPv6      string      `json:"httpProtocolIPv6,omitempty" yaml:"httpProtocolIPv6,omitempty" default:"disabled"`
+	IAMInstanceProfile    string      `json:"iamInstanceProfile,omitempty" yaml:"iamInstanceProfile,omitempty"`
 	IPAddressType         string      `json:"ipAddressType,omitempty" yaml:"ipAddressType,omitempty" default:"ipv4"`
 	IPv6AddressCount      string      `json:"ipv6AddressCount,omitempty" yaml:"ipv6AddressCount,omitempty"`
 	IPv6AddressOnly       bool        `json:"ipv6AddressOnly,omitempty" yaml:"ipv6AddressOnly,omitempty" default:"false"`
@@ -41,9 +42,11 @@ type Config struct {
 	Timeout               string      `json:"timeout,omitempty" yaml:"timeout,omitempty"`
 	Windows2019AMI        string      `json:"windows2019AMI,omitempty" yaml:"windows2019AMI,omitempty"`
 	Windows2022AMI        string      `json:"windows2022AMI,omitempty" yaml:"windows2022AMI,omitempty"`
+	Windows2025AMI        string      `json:"windows2025AMI,omitempty" yaml:"windows2025AMI,omitempty"`
 	WindowsAWSUser        string      `json:"windowsAWSUser,omitempty" yaml:"windowsAWSUser,omitempty"`
 	Windows2019Password   string      `json:"windows2019Password,omitempty" yaml:"windows2019Password,omitempty"`
 	Windows2022Password   string      `json:"windows2022Password,omitempty" yaml:"windows2022Password,omitempty"`
+	Windows2025Password   string      `json:"windows2025Password,omitempty" yaml:"windows2025Password,omitempty"`
 	WindowsInstanceType   string      `json:"windowsInstanceType,omitempty" yaml:"windowsInstanceType,omitempty"`
 	WindowsKeyName        string      `json:"windowsKeyName,omitempty" yaml:"windowsKeyName,omitempty"`
 	WindowsVolumeType     string      `json:"windowsVolumeType,omitempty" yaml:"windowsVolumeType,omitempty"`
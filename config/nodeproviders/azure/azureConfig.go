@@ -41,4 +41,10 @@ type Config struct {
 	UsePrivateIP            bool     `json:"usePrivateIp,omitempty" yaml:"usePrivateIp,omitempty"`
 	VMSize                  string   `json:"vmSize,omitempty" yaml:"vmSize,omitempty"`
 	Vnet                    string   `json:"vnet,omitempty" yaml:"vnet,omitempty"`
+	Windows2019SKU          string   `json:"windows2019SKU,omitempty" yaml:"windows2019SKU,omitempty"`
+	Windows2022SKU          string   `json:"windows2022SKU,omitempty" yaml:"windows2022SKU,omitempty"`
+	Windows2025SKU          string   `json:"windows2025SKU,omitempty" yaml:"windows2025SKU,omitempty"`
+	WindowsPassword         string   `json:"windowsPassword,omitempty" yaml:"windowsPassword,omitempty"`
+	WindowsSize             string   `json:"windowsSize,omitempty" yaml:"windowsSize,omitempty"`
+	WindowsUser             string   `json:"windowsUser,omitempty" yaml:"windowsUser,omitempty"`
 }
@@ -1,12 +1,34 @@
 package harvester
 
+type Disk struct {
+	Name             string `json:"name,omitempty" yaml:"name,omitempty"`
+	Size             string `json:"size,omitempty" yaml:"size,omitempty"`
+	StorageClassName string `json:"storageClassName,omitempty" yaml:"storageClassName,omitempty"`
+	Bus              string `json:"bus,omitempty" yaml:"bus,omitempty"`
+}
+
+type PCIDevice struct {
+	Name       string `json:"name,omitempty" yaml:"name,omitempty"`
+	DeviceName string `json:"deviceName,omitempty" yaml:"deviceName,omitempty"`
+	Instance   string `json:"instance,omitempty" yaml:"instance,omitempty"`
+}
+
 type Config struct {
-	DiskSize     string   `json:"diskSize,omitempty" yaml:"diskSize,omitempty"`
-	CPUCount     string   `json:"cpuCount,omitempty" yaml:"cpuCount,omitempty"`
-	MemorySize   string   `json:"memorySize,omitempty" yaml:"memorySize,omitempty"`
-	NetworkNames []string `json:"networkNames,omitempty" yaml:"networkNames,omitempty"`
-	ImageName    string   `json:"imageName,omitempty" yaml:"imageName,omitempty"`
-	SSHUser      string   `json:"sshUser,omitempty" yaml:"sshUser,omitempty"`
-	VMNamespace  string   `json:"vmNamespace,omitempty" yaml:"vmNamespace,omitempty"`
-	UserData     string   `json:"userData,omitempty" yaml:"userData,omitempty"`
+	AirgapNameserver      string            `json:"airgapNameserver,omitempty" yaml:"airgapNameserver,omitempty"`
+	AirgapNetworkName     string            `json:"airgapNetworkName,omitempty" yaml:"airgapNetworkName,omitempty"`
+	DiskSize              string            `json:"diskSize,omitempty" yaml:"diskSize,omitempty"`
+	Disks                 []Disk            `json:"disks,omitempty" yaml:"disks,omitempty"`
+	CPUCount              string            `json:"cpuCount,omitempty" yaml:"cpuCount,omitempty"`
+	MemorySize            string            `json:"memorySize,omitempty" yaml:"memorySize,omitempty"`
+	NetworkNames          []string          `json:"networkNames,omitempty" yaml:"networkNames,omitempty"`
+	NetworkData           string            `json:"networkData,omitempty" yaml:"networkData,omitempty"`
+	NetworkDataSecretName string            `json:"networkDataSecretName,omitempty" yaml:"networkDataSecretName,omitempty"`
+	ImageName             string            `json:"imageName,omitempty" yaml:"imageName,omitempty"`
+	NodeSelector          map[string]string `json:"nodeSelector,omitempty" yaml:"nodeSelector,omitempty"`
+	PCIDevices            []PCIDevice       `json:"pciDevices,omitempty" yaml:"pciDevices,omitempty"`
+	SSHUser               string            `json:"sshUser,omitempty" yaml:"sshUser,omitempty"`
+	VMAffinity            string            `json:"vmAffinity,omitempty" yaml:"vmAffinity,omitempty"`
+	VMNamespace           string            `json:"vmNamespace,omitempty" yaml:"vmNamespace,omitempty"`
+	UserData              string            `json:"userData,omitempty" yaml:"userData,omitempty"`
+	UserDataSecretName    string            `json:"userDataSecretName,omitempty" yaml:"userDataSecretName,omitempty"`
 }
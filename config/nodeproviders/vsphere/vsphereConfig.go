@@ -1,6 +1,8 @@
 package vsphere
 
 type Config struct {
+	AirgapNameserver       string   `json:"airgapNameserver,omitempty" yaml:"airgapNameserver,omitempty"`
+	AirgapNetwork          string   `json:"airgapNetwork,omitempty" yaml:"airgapNetwork,omitempty"`
 	Boot2dockerURL         string   `json:"boot2dockerURL,omitempty" yaml:"boot2dockerURL,omitempty"`
 	Cfgparam               []string `json:"cfgparam,omitempty" yaml:"cfgparam,omitempty"`
 	CloneFrom              string   `json:"cloneFrom,omitempty" yaml:"cloneFrom,omitempty"`
@@ -18,6 +20,7 @@ type Config struct {
 	Folder                 string   `json:"folder,omitempty" yaml:"folder,omitempty"`
 	GuestID                string   `json:"guestID,omitempty" yaml:"guestID,omitempty"`
 	HostSystem             string   `json:"hostSystem,omitempty" yaml:"hostSystem,omitempty"`
+	LibraryCloneFrom       string   `json:"libraryCloneFrom,omitempty" yaml:"libraryCloneFrom,omitempty"`
 	MemorySize             string   `json:"memorySize,omitempty" yaml:"memorySize,omitempty"`
 	Network                []string `json:"network,omitempty" yaml:"network,omitempty"`
 	OS                     string   `json:"os,omitempty" yaml:"os,omitempty"`
@@ -32,5 +35,11 @@ type Config struct {
 	VappIpprotocol         string   `json:"vappIpprotocol,omitempty" yaml:"vappIpprotocol,omitempty"`
 	VappProperty           []string `json:"vappProperty,omitempty" yaml:"vappProperty,omitempty"`
 	VappTransport          string   `json:"vappTransport,omitempty" yaml:"vappTransport,omitempty"`
+	VMCloneFrom            string   `json:"vmCloneFrom,omitempty" yaml:"vmCloneFrom,omitempty"`
 	VsphereUser            string   `json:"vsphereUser,omitempty" yaml:"vsphereUser,omitempty"`
+	Windows2019Template    string   `json:"windows2019Template,omitempty" yaml:"windows2019Template,omitempty"`
+	Windows2022Template    string   `json:"windows2022Template,omitempty" yaml:"windows2022Template,omitempty"`
+	Windows2025Template    string   `json:"windows2025Template,omitempty" yaml:"windows2025Template,omitempty"`
+	WindowsPassword        string   `json:"windowsPassword,omitempty" yaml:"windowsPassword,omitempty"`
+	WindowsUser            string   `json:"windowsUser,omitempty" yaml:"windowsUser,omitempty"`
 }
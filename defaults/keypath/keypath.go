@@ -4,6 +4,7 @@ const (
 	AirgapKeyPath        = "/modules/airgap"
 	AirgapRKE2KeyPath    = "/modules/airgapRKE2"
 	AirgapK3SKeyPath     = "/modules/airgapK3S"
+	BackupKeyPath        = "/modules/backup"
 	DualStackKeyPath     = "/modules/dualstack"
 	DualStackRKE2KeyPath = "/modules/dualstackRKE2"
 	DualStackK3SKeyPath  = "/modules/dualstackK3S"
@@ -19,6 +20,7 @@ const (
 	RKEKeyPath           = "/modules/rke"
 	RKE2KeyPath          = "/modules/rke2"
 	RancherKeyPath       = "/modules/rancher2"
+	RestoreKeyPath       = "/modules/restore"
 	SanityKeyPath        = "/modules/sanity"
 	UpgradeKeyPath       = "/modules/upgrade"
 )
@@ -9,12 +9,28 @@ const (
 	NodeDriverAzureK3S  = "azure_k3s_nodedriver"
 
 	CustomAWSRKE2            = "aws_rke2_custom"
+	CustomAWSRKE2Windows     = "aws_rke2_windows_custom"
 	CustomAWSRKE2Windows2019 = "aws_rke2_windows_2019_custom"
 	CustomAWSRKE2Windows2022 = "aws_rke2_windows_2022_custom"
+	CustomAWSRKE2Windows2025 = "aws_rke2_windows_2025_custom"
 	CustomAWSK3S             = "aws_k3s_custom"
 
-	CustomVsphereRKE2 = "vsphere_rke2_custom"
-	CustomVsphereK3S  = "vsphere_k3s_custom"
+	CustomVsphereRKE2        = "vsphere_rke2_custom"
+	CustomVsphereRKE2Windows = "vsphere_rke2_windows_custom"
+	CustomVsphereK3S         = "vsphere_k3s_custom"
+
+	CustomAzureRKE2        = "azure_rke2_custom"
+	CustomAzureRKE2Windows = "azure_rke2_windows_custom"
+	CustomAzureK3S         = "azure_k3s_custom"
+
+	CustomGoogleRKE2 = "google_rke2_custom"
+	CustomGoogleK3S  = "google_k3s_custom"
+
+	CustomHarvesterRKE2 = "harvester_rke2_custom"
+	CustomHarvesterK3S  = "harvester_k3s_custom"
+
+	CustomLinodeRKE2 = "linode_rke2_custom"
+	CustomLinodeK3S  = "linode_k3s_custom"
 
 	AWS               = "aws"
 	NodeDriverAWSRKE2 = "aws_rke2_nodedriver"
@@ -27,13 +43,27 @@ const (
 	NodeDriverHarvesterK3S  = "harvester_k3s_nodedriver"
 
 	ImportedAWSRKE2            = "aws_rke2_imported"
+	ImportedAWSRKE2Windows     = "aws_rke2_windows_imported"
 	ImportedAWSRKE2Windows2019 = "aws_rke2_windows_2019_imported"
 	ImportedAWSRKE2Windows2022 = "aws_rke2_windows_2022_imported"
+	ImportedAWSRKE2Windows2025 = "aws_rke2_windows_2025_imported"
 	ImportedAWSK3S             = "aws_k3s_imported"
 
 	ImportedVsphereRKE2 = "vsphere_rke2_imported"
 	ImportedVsphereK3S  = "vsphere_k3s_imported"
 
+	ImportedAzureRKE2 = "azure_rke2_imported"
+	ImportedAzureK3S  = "azure_k3s_imported"
+
+	ImportedGoogleRKE2 = "google_rke2_imported"
+	ImportedGoogleK3S  = "google_k3s_imported"
+
+	ImportedHarvesterRKE2 = "harvester_rke2_imported"
+	ImportedHarvesterK3S  = "harvester_k3s_imported"
+
+	ImportedLinodeRKE2 = "linode_rke2_imported"
+	ImportedLinodeK3S  = "linode_k3s_imported"
+
 	NodeDriverLinodeRKE2 = "linode_rke2_nodedriver"
 	NodeDriverLinodeK3S  = "linode_k3s_nodedriver"
 
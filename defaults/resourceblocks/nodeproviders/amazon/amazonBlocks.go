@@ -20,6 +20,9 @@ const (
 	Zone          = "zone"
 	RootSize      = "root_size"
 
+	IAMInstanceProfile = "iam_instance_profile"
+	Tags               = "tags"
+
 	NodeGroups   = "node_groups"
 	DiskSize     = "disk_size"
 	InstanceType = "instance_type"
@@ -29,6 +32,13 @@ const (
 	MaxSize      = "max_size"
 	MinSize      = "min_size"
 
+	Labels                = "labels"
+	LaunchTemplate        = "launch_template"
+	LaunchTemplateID      = "id"
+	LaunchTemplateVersion = "version"
+	RequestSpotInstances  = "request_spot_instances"
+	SpotInstanceTypes     = "spot_instance_types"
+
 	EnablePrimaryIPv6 = "enable_primary_ipv6"
 	HTTPProtocolIPv6  = "http_protocol_ipv6"
 	IPv6AddressCount  = "ipv6_address_count"
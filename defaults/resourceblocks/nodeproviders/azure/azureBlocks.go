@@ -22,6 +22,12 @@ const (
 	NetworkDNSServiceIP     = "network_dns_service_ip"
 	NetworkDockerBridgeCIDR = "network_docker_bridge_cidr"
 	NetworkServiceCIDR      = "network_service_cidr"
+	NetworkPodCIDR          = "network_pod_cidr"
+
+	NetworkProfile = "network_profile"
+	IPVersions     = "ip_versions"
+	PodCIDRs       = "pod_cidrs"
+	ServiceCIDRs   = "service_cidrs"
 
 	AvailabilitySet   = "availability_set"
 	CustomData        = "custom_data"
@@ -42,6 +48,11 @@ const (
 
 	AvailabilityZones   = "availability_zones"
 	Count               = "count"
+	EnableAutoScaling   = "enable_auto_scaling"
+	Labels              = "labels"
+	MaxCount            = "max_count"
+	MaxPods             = "max_pods"
+	MinCount            = "min_count"
 	NodePoolMode        = "mode"
 	OrchestratorVersion = "orchestrator_version"
 	OSDiskSizeGB        = "os_disk_size_gb"
@@ -14,10 +14,11 @@ const (
 	GKEConfig    = "gke_config_v2"
 	GoogleConfig = "google_config"
 
-	ClusterAddOns = "cluster_addons"
-	Config        = "config"
-	Management    = "management"
-	NodePools     = "node_pools"
+	ClusterAddOns      = "cluster_addons"
+	IPAllocationPolicy = "ip_allocation_policy"
+	Config             = "config"
+	Management         = "management"
+	NodePools          = "node_pools"
 
 	DiskSizeGb        = "disk_size_gb"
 	ImageType         = "image_type"
@@ -26,6 +27,22 @@ const (
 	MaxPodsConstraint = "max_pods_constraint"
 	Version           = "version"
 
+	Autoscaling  = "autoscaling"
+	Effect       = "effect"
+	Enabled      = "enabled"
+	Key          = "key"
+	Labels       = "labels"
+	MaxNodeCount = "max_node_count"
+	MinNodeCount = "min_node_count"
+	Preemptible  = "preemptible"
+	Taints       = "taints"
+	Value        = "value"
+
+	ClusterIPv4CIDRBlock  = "cluster_ipv4_cidr_block"
+	ServicesIPv4CIDRBlock = "services_ipv4_cidr_block"
+	UseIPAliases          = "use_ip_aliases"
+	StackType             = "stack_type"
+
 	DiskSize     = "disk_size"
 	DiskType     = "disk_type"
 	MachineImage = "machine_image"
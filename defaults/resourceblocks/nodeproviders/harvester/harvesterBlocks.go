@@ -12,12 +12,14 @@ const (
 	MemorySize  = "memory_size"
 	SSHUser     = "ssh_user"
 	UserData    = "user_data"
+	VMAffinity  = "vm_affinity"
 
 	DiskInfo  = "disk_info"
 	DiskBus   = "disk_bus"
 	DiskSize  = "disk_size"
 	ImageName = "image_name"
 
+	NetworkData  = "network_data"
 	NetworkInfo  = "network_info"
 	NetworkModel = "network_model"
 	NetworkName  = "network_name"
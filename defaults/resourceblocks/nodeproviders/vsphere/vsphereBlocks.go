@@ -29,4 +29,17 @@ const (
 	SSHPort          = "ssh_port"
 	SSHUser          = "ssh_user"
 	SSHUserGroup     = "ssh_user_group"
+
+	CustomAttributes       = "custom_attributes"
+	OS                     = "os"
+	Tags                   = "tags"
+	VappIPAllocationPolicy = "vapp_ip_allocation_policy"
+	VappIPProtocol         = "vapp_ip_protocol"
+	VappProperty           = "vapp_property"
+	VappTransport          = "vapp_transport"
+
+	CreationTypeLegacy   = "legacy"
+	CreationTypeLibrary  = "library"
+	CreationTypeTemplate = "template"
+	CreationTypeVM       = "vm"
 )
@@ -0,0 +1,85 @@
+package assets
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	tfpautomation "github.com/rancher/tfp-automation"
+	"github.com/sirupsen/logrus"
+)
+
+const overrideDirEnv = "TFP_ASSETS_DIR"
+
+// ReadFile returns the content of the asset at the given path, relative to the root of the repository. When
+// TFP_ASSETS_DIR is set and holds the asset, the local copy is returned instead of the bundled one.
+func ReadFile(name string) ([]byte, error) {
+	name = clean(name)
+
+	dir := os.Getenv(overrideDirEnv)
+	if dir != "" {
+		content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if err == nil {
+			logrus.Debugf("Using %s from %s", name, dir)
+			return content, nil
+		}
+
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	return tfpautomation.Assets.ReadFile(name)
+}
+
+// MaterializeModule writes the skeleton of the given module, e.g. modules/sanity/aws, into dir. Files that already
+// exist in dir are left untouched, so a checkout of the repository keeps working as is. Modules without a skeleton are
+// skipped.
+func MaterializeModule(module, dir string) error {
+	root := clean(module)
+
+	source := fs.FS(tfpautomation.Assets)
+	overrideDir := os.Getenv(overrideDirEnv)
+	if overrideDir != "" {
+		_, err := os.Stat(filepath.Join(overrideDir, filepath.FromSlash(root)))
+		if err == nil {
+			source = os.DirFS(overrideDir)
+		}
+	}
+
+	err := fs.WalkDir(source, root, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(dir, filepath.FromSlash(strings.TrimPrefix(name, root)))
+		if entry.IsDir() {
+			return os.MkdirAll(target, os.ModePerm)
+		}
+
+		_, err = os.Stat(target)
+		if err == nil {
+			return nil
+		}
+
+		content, err := fs.ReadFile(source, name)
+		if err != nil {
+			return err
+		}
+
+		return os.WriteFile(target, content, 0644)
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+// clean is a helper function that turns a repository path, with or without a leading slash, into an asset name.
+func clean(name string) string {
+	return strings.TrimPrefix(path.Clean(filepath.ToSlash(name)), "/")
+}
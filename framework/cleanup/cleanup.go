@@ -9,7 +9,9 @@ import (
 	"github.com/rancher/shepherd/pkg/config"
 	"github.com/rancher/tfp-automation/defaults/configs"
 	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/framework/timing"
 	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
 )
 
 // Cleanup is a function that will run terraform destroy and cleanup Terraform resources.
@@ -19,7 +21,8 @@ func Cleanup(t *testing.T, terraformOptions *terraform.Options, keyPath string)
 
 	if *rancherConfig.Cleanup {
 		logrus.Infof("Cleaning up Terraform resources...")
-		terraform.Destroy(t, terraformOptions)
+		_, err := timing.DestroyE(t, terraformOptions)
+		require.NoError(t, err)
 
 		if !strings.Contains(keyPath, keypath.RancherKeyPath) {
 			err := TFFilesCleanup(keyPath)
@@ -0,0 +1,23 @@
+package format
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// PublicSSHKey is a function that will return the public key of the given private SSH key in the authorized_keys format.
+func PublicSSHKey(privateKeyPath string) (string, error) {
+	privateKey, err := os.ReadFile(privateKeyPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read private key file: %w", err)
+	}
+
+	signer, err := ssh.ParsePrivateKey(privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	return string(ssh.MarshalAuthorizedKey(signer.PublicKey())), nil
+}
@@ -26,6 +26,7 @@ const (
 	Image        = "image"
 	Label        = "label"
 	Metadata     = "metadata"
+	Output       = "output"
 	Port         = "port"
 	Ports        = "ports"
 	Resource     = "resource"
@@ -66,6 +67,8 @@ const (
 	Provisioner      = "provisioner"
 	PublicKey        = "public_key"
 	RemoteExec       = "remote-exec"
+	LocalExec        = "local-exec"
+	Command          = "command"
 	Ssh              = "ssh"
 	WinRM            = "winrm"
 	UseNTLM          = "use_ntlm"
@@ -76,6 +79,7 @@ const (
 	Self             = "self"
 	Windows          = "windows"
 	PublicIp         = "public_ip"
+	PublicDNS        = "public_dns"
 	IPV6Addresses    = "ipv6_addresses"
 	PrivateIp        = "private_ip"
 	DefaultIPAddress = "default_ip_address"
@@ -27,5 +27,13 @@ const (
 	AzurePublicIP                                      = "azurerm_public_ip"
 	AzureResourceGroup                                 = "azurerm_resource_group"
 	AzureSubnet                                        = "azurerm_subnet"
+	AzureVirtualMachineExtension                       = "azurerm_virtual_machine_extension"
 	AzureVirtualNetwork                                = "azurerm_virtual_network"
+	AzureWindowsVirtualMachine                         = "azurerm_windows_virtual_machine"
+
+	WindowsPublisher = "MicrosoftWindowsServer"
+	WindowsOffer     = "WindowsServer"
+	Windows2019SKU   = "2019-datacenter-gensecond"
+	Windows2022SKU   = "2022-datacenter-g2"
+	Windows2025SKU   = "2025-datacenter-g2"
 )
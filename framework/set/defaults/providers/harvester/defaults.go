@@ -5,9 +5,10 @@ const (
 	CPU       = "cpu"
 	Memory    = "memory"
 
-	CloudInit          = "cloudinit"
-	UserDataSecretName = "user_data_secret_name"
-	NetworkData        = "network_data"
+	CloudInit             = "cloudinit"
+	UserDataSecretName    = "user_data_secret_name"
+	NetworkData           = "network_data"
+	NetworkDataSecretName = "network_data_secret_name"
 
 	Disk                    = "disk"
 	EFI                     = "efi"
@@ -47,4 +48,7 @@ const (
 	Bridge             = "bridge"
 	RestartAfterUpdate = "restart_after_update"
 	RootDisk           = "rootdisk"
+
+	NodeSelector     = "node_selector"
+	StorageClassName = "storage_class_name"
 )
@@ -4,27 +4,34 @@ const (
 	VsphereSource = "vmware/vsphere"
 
 	Vsphere                       = "vsphere"
+	VsphereAirgapNetwork          = "vsphere_airgap_network"
 	VsphereDatacenter             = "vsphere_datacenter"
 	VsphereDatastore              = "vsphere_datastore"
 	VsphereComputeCluster         = "vsphere_compute_cluster"
+	VsphereContentLibrary         = "vsphere_content_library"
+	VsphereContentLibraryItem     = "vsphere_content_library_item"
 	VsphereResourcePool           = "vsphere_resource_pool"
 	VsphereNetwork                = "vsphere_network"
 	VsphereServer                 = "vsphere_server"
 	VsphereVirtualMachine         = "vsphere_virtual_machine"
 	VsphereVirtualMachineTemplate = "vsphere_virtual_machine_template"
+	VsphereWindowsTemplate        = "vsphere_windows_template"
 	CDROM                         = "cdrom"
 	Vapp                          = "vapp"
 	VappProperties                = "properties"
 
 	CloudInit        = "cloudinit"
+	CustomAttributes = "custom_attributes"
 	Customize        = "customize"
 	Disk             = "disk"
 	ExtraConfig      = "extra_config"
 	Folder           = "folder"
 	LinuxOptions     = "linux_options"
+	WindowsOptions   = "windows_options"
 	Memory           = "memory"
 	NetworkID        = "network_id"
 	NetworkInterface = "network_interface"
 	NumCPUs          = "num_cpus"
 	Size             = "size"
+	Tags             = "tags"
 )
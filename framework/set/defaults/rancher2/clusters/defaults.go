@@ -2,6 +2,7 @@ package clusters
 
 const (
 	CloudCredentialID = "cloud_credential_id"
+	Imported          = "imported"
 
 	MachineConfig         = "machine_config"
 	MachineGlobalConfig   = "machine_global_config"
@@ -13,8 +14,12 @@ const (
 	RancherClusterID      = "cluster_id"
 	Quantity              = "quantity"
 	ChartValues           = "chart_values"
+	AdditionalManifest    = "additional_manifest"
 
 	AgentEnvVars                        = "agent_env_vars"
+	ClusterAgentCustomization           = "cluster_agent_deployment_customization"
+	FleetAgentCustomization             = "fleet_agent_deployment_customization"
+	CloudCredentialSecretName           = "cloud_credential_secret_name"
 	RkeConfig                           = "rke_config"
 	Networking                          = "networking"
 	StackPreference                     = "stack_preference"
@@ -10,9 +10,11 @@ import (
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/framework/set/defaults/general"
 	"github.com/rancher/tfp-automation/framework/set/defaults/providers/aws"
+	harvesterDefaults "github.com/rancher/tfp-automation/framework/set/defaults/providers/harvester"
 	"github.com/rancher/tfp-automation/framework/set/defaults/rancher2"
 	"github.com/rancher/tfp-automation/framework/set/defaults/rancher2/clusters"
 	customnodepools "github.com/rancher/tfp-automation/framework/set/provisioning/custom/nodepools"
+	"github.com/rancher/tfp-automation/framework/set/resources/providers/harvester"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -27,7 +29,7 @@ func SetLocals(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
 	localsBlockBody := localsBlock.Body()
 
 	if strings.Contains(terraformConfig.Module, general.Custom) {
-		if terraformConfig.DownstreamClusterProvider == aws.Aws {
+		if terraformConfig.Provider == aws.Aws {
 			expression, err := customnodepools.BuildAWSPublicIPExpression(terraformConfig, terratestConfig)
 			if err != nil {
 				return nil, err
@@ -38,6 +40,19 @@ func SetLocals(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
 			}
 
 			localsBlockBody.SetAttributeRaw(allPublicIPs, value)
+		} else if expression := customnodepools.BuildPublicIPExpression(terraformConfig, terratestConfig); expression != "" {
+			value := hclwrite.Tokens{
+				{Type: hclsyntax.TokenIdent, Bytes: []byte(expression)},
+			}
+
+			localsBlockBody.SetAttributeRaw(allPublicIPs, value)
+		}
+
+		if terraformConfig.Provider == harvesterDefaults.Harvester {
+			err := harvester.SetLocalAttributes(localsBlockBody, terraformConfig)
+			if err != nil {
+				return nil, err
+			}
 		}
 	}
 
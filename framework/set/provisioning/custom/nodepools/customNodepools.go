@@ -7,6 +7,10 @@ import (
 
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/framework/set/defaults/providers/aws"
+	"github.com/rancher/tfp-automation/framework/set/defaults/providers/azure"
+	"github.com/rancher/tfp-automation/framework/set/defaults/providers/google"
+	"github.com/rancher/tfp-automation/framework/set/defaults/providers/harvester"
+	"github.com/rancher/tfp-automation/framework/set/defaults/providers/linode"
 	"github.com/rancher/tfp-automation/framework/set/defaults/rancher2/clusters"
 	rancher2resources "github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 )
@@ -118,6 +122,42 @@ func BuildAWSPublicIPExpression(terraformConfig *config.TerraformConfig, terrate
 	return fmt.Sprintf("flatten([%s])", strings.Join(groupExpressions, ", ")), nil
 }
 
+// AzureNodeNames returns one name per non-Windows node, as Azure instances are created as one resource per node.
+func AzureNodeNames(terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig) []string {
+	totalNodeCount := TotalNodeCount(terratestConfig)
+	nodeNames := make([]string, 0, totalNodeCount)
+
+	for i := int64(0); i < totalNodeCount; i++ {
+		nodeNames = append(nodeNames, fmt.Sprintf("%s-node-%d", terraformConfig.ResourcePrefix, i))
+	}
+
+	return nodeNames
+}
+
+// BuildPublicIPExpression builds the Terraform expression for all instance public IPs of the providers that create the
+// custom cluster nodes outside of AWS and vSphere. An empty expression is returned for any other provider.
+func BuildPublicIPExpression(terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig) string {
+	prefix := terraformConfig.ResourcePrefix
+
+	switch terraformConfig.Provider {
+	case azure.Azure:
+		publicIPs := make([]string, 0, TotalNodeCount(terratestConfig))
+		for _, nodeName := range AzureNodeNames(terraformConfig, terratestConfig) {
+			publicIPs = append(publicIPs, fmt.Sprintf("%s.%s-%s.ip_address", azure.AzurePublicIP, azure.AzurePublicIP, nodeName))
+		}
+
+		return fmt.Sprintf("[%s]", strings.Join(publicIPs, ", "))
+	case google.Google:
+		return fmt.Sprintf("%s.%s[*].network_interface[0].access_config[0].nat_ip", google.GoogleComputeInstance, prefix)
+	case harvester.Harvester:
+		return fmt.Sprintf("%s.%s[*].network_interface[0].ip_address", harvester.HarvesterVirtualMachine, prefix)
+	case linode.Linode:
+		return fmt.Sprintf("%s.%s[*].ip_address", linode.LinodeInstance, prefix)
+	default:
+		return ""
+	}
+}
+
 func buildPoolRoleFlags(pool config.Nodepool) string {
 	roleFlags := make([]string, 0, 3)
 
@@ -0,0 +1,139 @@
+package nodepools
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/defaults/general"
+	"github.com/rancher/tfp-automation/framework/set/defaults/providers/aws"
+	"github.com/rancher/tfp-automation/framework/set/defaults/providers/azure"
+	"github.com/rancher/tfp-automation/framework/set/defaults/providers/vsphere"
+)
+
+const (
+	windows = "windows"
+
+	// Windows computer names are limited to 15 characters, leaving room for a hyphen and a two digit index.
+	maxWindowsComputerNamePrefix = 12
+)
+
+// WindowsVersion returns the Windows Server version of the Windows nodepools. The windowsVersion set on the nodepools
+// takes precedence over the version found in the module name, i.e. aws_rke2_windows_2019_custom. Windows Server 2022
+// is used when neither is set.
+func WindowsVersion(terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig) (string, error) {
+	var version string
+
+	for _, pool := range terratestConfig.Nodepools {
+		if !pool.Windows || pool.WindowsVersion == "" {
+			continue
+		}
+
+		if version != "" && version != pool.WindowsVersion {
+			return "", fmt.Errorf("windows nodepools must share the same windowsVersion, found %s and %s", version, pool.WindowsVersion)
+		}
+
+		version = pool.WindowsVersion
+	}
+
+	if version == "" {
+		for _, moduleVersion := range []string{config.Windows2019, config.Windows2022, config.Windows2025} {
+			if strings.Contains(terraformConfig.Module, "_"+moduleVersion+"_") {
+				version = moduleVersion
+			}
+		}
+	}
+
+	switch version {
+	case "":
+		return config.Windows2022, nil
+	case config.Windows2019, config.Windows2022, config.Windows2025:
+		return version, nil
+	default:
+		return "", fmt.Errorf("unsupported windowsVersion %s, expected one of %s, %s or %s", version, config.Windows2019,
+			config.Windows2022, config.Windows2025)
+	}
+}
+
+// WindowsCredentials returns the user and password used to connect to the Windows nodes over WinRM.
+func WindowsCredentials(terraformConfig *config.TerraformConfig, version string) (string, string) {
+	switch terraformConfig.Provider {
+	case aws.Aws:
+		switch version {
+		case config.Windows2019:
+			return terraformConfig.AWSConfig.WindowsAWSUser, terraformConfig.AWSConfig.Windows2019Password
+		case config.Windows2025:
+			return terraformConfig.AWSConfig.WindowsAWSUser, terraformConfig.AWSConfig.Windows2025Password
+		default:
+			return terraformConfig.AWSConfig.WindowsAWSUser, terraformConfig.AWSConfig.Windows2022Password
+		}
+	case azure.Azure:
+		return terraformConfig.AzureConfig.WindowsUser, terraformConfig.AzureConfig.WindowsPassword
+	case vsphere.Vsphere:
+		return terraformConfig.VsphereConfig.WindowsUser, terraformConfig.VsphereConfig.WindowsPassword
+	default:
+		return "", ""
+	}
+}
+
+// WindowsComputerNamePrefix returns the resource prefix shortened to fit in a Windows computer name.
+func WindowsComputerNamePrefix(terraformConfig *config.TerraformConfig) string {
+	prefix := strings.ReplaceAll(terraformConfig.ResourcePrefix, "_", "-")
+	if len(prefix) > maxWindowsComputerNamePrefix {
+		prefix = prefix[:maxWindowsComputerNamePrefix]
+	}
+
+	return strings.TrimSuffix(prefix, "-")
+}
+
+// AzureWindowsNodeNames returns one name per Windows node, as Azure instances are created as one resource per node.
+func AzureWindowsNodeNames(terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig) []string {
+	windowsNodeCount := WindowsNodeCount(terratestConfig)
+	nodeNames := make([]string, 0, windowsNodeCount)
+
+	for i := int64(0); i < windowsNodeCount; i++ {
+		nodeNames = append(nodeNames, fmt.Sprintf("%s-%s-%d", terraformConfig.ResourcePrefix, windows, i))
+	}
+
+	return nodeNames
+}
+
+// BuildWindowsHostExpression builds the Terraform expression of the Windows node address, indexed by count.index.
+func BuildWindowsHostExpression(terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig) string {
+	resourceName := terraformConfig.ResourcePrefix + "-" + windows
+	countIndex := general.Count + "." + general.Index
+
+	switch terraformConfig.Provider {
+	case azure.Azure:
+		publicIPs := make([]string, 0, WindowsNodeCount(terratestConfig))
+		for _, nodeName := range AzureWindowsNodeNames(terraformConfig, terratestConfig) {
+			publicIPs = append(publicIPs, fmt.Sprintf("%s.%s-%s.ip_address", azure.AzurePublicIP, azure.AzurePublicIP, nodeName))
+		}
+
+		return fmt.Sprintf("[%s][%s]", strings.Join(publicIPs, ", "), countIndex)
+	case vsphere.Vsphere:
+		return fmt.Sprintf("%s.%s[%s].%s", vsphere.VsphereVirtualMachine, resourceName, countIndex, general.DefaultIPAddress)
+	default:
+		return fmt.Sprintf("%s.%s[%s].%s", aws.AwsInstance, resourceName, countIndex, general.PublicIp)
+	}
+}
+
+// WindowsInstanceResources returns the Terraform resources that create the Windows nodes, used as dependencies of the
+// resources that need the Windows nodes to be reachable.
+func WindowsInstanceResources(terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig) []string {
+	resourceName := terraformConfig.ResourcePrefix + "-" + windows
+
+	switch terraformConfig.Provider {
+	case azure.Azure:
+		resources := []string{}
+		for _, nodeName := range AzureWindowsNodeNames(terraformConfig, terratestConfig) {
+			resources = append(resources, azure.AzureVirtualMachineExtension+"."+nodeName)
+		}
+
+		return resources
+	case vsphere.Vsphere:
+		return []string{vsphere.VsphereVirtualMachine + "." + resourceName}
+	default:
+		return []string{aws.AwsInstance + "." + resourceName}
+	}
+}
@@ -9,6 +9,10 @@ import (
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/framework/set/defaults/general"
 	"github.com/rancher/tfp-automation/framework/set/defaults/providers/aws"
+	"github.com/rancher/tfp-automation/framework/set/defaults/providers/azure"
+	"github.com/rancher/tfp-automation/framework/set/defaults/providers/google"
+	"github.com/rancher/tfp-automation/framework/set/defaults/providers/harvester"
+	"github.com/rancher/tfp-automation/framework/set/defaults/providers/linode"
 	"github.com/rancher/tfp-automation/framework/set/defaults/providers/vsphere"
 	"github.com/rancher/tfp-automation/framework/set/defaults/rancher2"
 	"github.com/rancher/tfp-automation/framework/set/defaults/rancher2/clusters"
@@ -26,10 +30,10 @@ func CustomNullResource(rootBody *hclwrite.Body, terraformConfig *config.Terrafo
 	nullResourceBlockBody := nullResourceBlock.Body()
 
 	var countExpression string
-	if strings.Contains(terraformConfig.Provider, aws.Aws) {
-		countExpression = general.Length + `(` + general.Local + `.` + allPublicIPs + `)`
-	} else if strings.Contains(terraformConfig.Provider, vsphere.Vsphere) {
+	if strings.Contains(terraformConfig.Provider, vsphere.Vsphere) {
 		countExpression = general.Length + `(` + vsphere.VsphereVirtualMachine + `.` + terraformConfig.ResourcePrefix + `)`
+	} else {
+		countExpression = general.Length + `(` + general.Local + `.` + allPublicIPs + `)`
 	}
 
 	nullResourceBlockBody.SetAttributeRaw(general.Count, hclwrite.TokensForIdentifier(countExpression))
@@ -51,13 +55,19 @@ func CustomNullResource(rootBody *hclwrite.Body, terraformConfig *config.Terrafo
 
 	connectionBlockBody.SetAttributeValue(general.Type, cty.StringVal(general.Ssh))
 
-	var hostExpression string
+	hostExpression := fmt.Sprintf(`%s.%s[%s.%s]`, general.Local, allPublicIPs, general.Count, general.Index)
 
 	switch terraformConfig.Provider {
 	case aws.Aws:
 		connectionBlockBody.SetAttributeValue(general.User, cty.StringVal(terraformConfig.AWSConfig.AWSUser))
-		hostExpression = fmt.Sprintf(`%s.%s[%s.%s]`, general.Local, allPublicIPs, general.Count, general.Index)
-
+	case azure.Azure:
+		connectionBlockBody.SetAttributeValue(general.User, cty.StringVal(terraformConfig.AzureConfig.SSHUser))
+	case google.Google:
+		connectionBlockBody.SetAttributeValue(general.User, cty.StringVal(terraformConfig.GoogleConfig.SSHUser))
+	case harvester.Harvester:
+		connectionBlockBody.SetAttributeValue(general.User, cty.StringVal(terraformConfig.HarvesterConfig.SSHUser))
+	case linode.Linode:
+		connectionBlockBody.SetAttributeValue(general.User, cty.StringVal(terraformConfig.Standalone.OSUser))
 	case vsphere.Vsphere:
 		connectionBlockBody.SetAttributeValue(general.User, cty.StringVal(terraformConfig.VsphereConfig.VsphereUser))
 		hostExpression = fmt.Sprintf(`"${%s.%s[%s.%s].%s}"`, vsphere.VsphereVirtualMachine, terraformConfig.ResourcePrefix, general.Count, general.Index, general.DefaultIPAddress)
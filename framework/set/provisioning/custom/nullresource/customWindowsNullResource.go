@@ -6,22 +6,26 @@ import (
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
-	"github.com/rancher/tfp-automation/defaults/modules"
 	"github.com/rancher/tfp-automation/framework/set/defaults/general"
-	"github.com/rancher/tfp-automation/framework/set/defaults/providers/aws"
 	"github.com/rancher/tfp-automation/framework/set/defaults/rancher2"
 	"github.com/rancher/tfp-automation/framework/set/defaults/rancher2/clusters"
+	customnodepools "github.com/rancher/tfp-automation/framework/set/provisioning/custom/nodepools"
 	"github.com/zclconf/go-cty/cty"
 )
 
 // CustomWindowsNullResource is a function that will set the Windows null_resource configurations in the main.tf file,
 // to register the nodes to the cluster
-func CustomWindowsNullResource(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, clusterName string) error {
+func CustomWindowsNullResource(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
+	clusterName string) error {
+	windowsVersion, err := customnodepools.WindowsVersion(terraformConfig, terratestConfig)
+	if err != nil {
+		return err
+	}
+
 	nullResourceBlock := rootBody.AppendNewBlock(general.Resource, []string{general.NullResource, general.RegisterNodes + "-" + clusterName + "-windows"})
 	nullResourceBlockBody := nullResourceBlock.Body()
 
-	countExpression := general.Length + `(` + aws.AwsInstance + `.` + clusterName + `-windows)`
-	nullResourceBlockBody.SetAttributeRaw(general.Count, hclwrite.TokensForIdentifier(countExpression))
+	nullResourceBlockBody.SetAttributeValue(general.Count, cty.NumberIntVal(customnodepools.WindowsNodeCount(terratestConfig)))
 
 	provisionerBlock := nullResourceBlockBody.AppendNewBlock(general.Provisioner, []string{general.RemoteExec})
 	provisionerBlockBody := provisionerBlock.Body()
@@ -29,19 +33,15 @@ func CustomWindowsNullResource(rootBody *hclwrite.Body, terraformConfig *config.
 	connectionBlock := provisionerBlockBody.AppendNewBlock(general.Connection, nil)
 	connectionBlockBody := connectionBlock.Body()
 
-	connectionBlockBody.SetAttributeValue(general.Type, cty.StringVal(general.WinRM))
-	connectionBlockBody.SetAttributeValue(general.User, cty.StringVal(terraformConfig.AWSConfig.WindowsAWSUser))
-
-	if strings.Contains(terraformConfig.Module, modules.CustomAWSRKE2Windows2019) {
-		connectionBlockBody.SetAttributeValue(general.Password, cty.StringVal(terraformConfig.AWSConfig.Windows2019Password))
-	} else if strings.Contains(terraformConfig.Module, modules.CustomAWSRKE2Windows2022) {
-		connectionBlockBody.SetAttributeValue(general.Password, cty.StringVal(terraformConfig.AWSConfig.Windows2022Password))
-	}
+	windowsUser, windowsPassword := customnodepools.WindowsCredentials(terraformConfig, windowsVersion)
 
+	connectionBlockBody.SetAttributeValue(general.Type, cty.StringVal(general.WinRM))
+	connectionBlockBody.SetAttributeValue(general.User, cty.StringVal(windowsUser))
+	connectionBlockBody.SetAttributeValue(general.Password, cty.StringVal(windowsPassword))
 	connectionBlockBody.SetAttributeValue(general.Insecure, cty.BoolVal(true))
 	connectionBlockBody.SetAttributeValue(general.UseNTLM, cty.BoolVal(true))
 
-	hostExpression := aws.AwsInstance + `.` + clusterName + `-windows[` + general.Count + `.` + general.Index + `].` + general.PublicIp
+	hostExpression := customnodepools.BuildWindowsHostExpression(terraformConfig, terratestConfig)
 	host := hclwrite.Tokens{
 		{Type: hclsyntax.TokenIdent, Bytes: []byte(hostExpression)},
 	}
@@ -54,12 +54,12 @@ func CustomWindowsNullResource(rootBody *hclwrite.Body, terraformConfig *config.
 
 	provisionerBlockBody.SetAttributeRaw(general.Inline, regCommand)
 
-	clusterV2Expression := `[` + rancher2.ClusterV2 + `.` + clusterName + `]`
-	clusterV2 := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(clusterV2Expression)},
+	dependsOnResources := append([]string{rancher2.ClusterV2 + `.` + clusterName}, customnodepools.WindowsInstanceResources(terraformConfig, terratestConfig)...)
+	dependsOn := hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(`[` + strings.Join(dependsOnResources, ", ") + `]`)},
 	}
 
-	nullResourceBlockBody.SetAttributeRaw(general.DependsOn, clusterV2)
+	nullResourceBlockBody.SetAttributeRaw(general.DependsOn, dependsOn)
 
 	return nil
 }
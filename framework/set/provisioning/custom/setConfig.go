@@ -11,10 +11,18 @@ import (
 	"github.com/rancher/tfp-automation/defaults/clustertypes"
 	"github.com/rancher/tfp-automation/framework/set/defaults/general"
 	awsDefaults "github.com/rancher/tfp-automation/framework/set/defaults/providers/aws"
+	azureDefaults "github.com/rancher/tfp-automation/framework/set/defaults/providers/azure"
+	googleDefaults "github.com/rancher/tfp-automation/framework/set/defaults/providers/google"
+	harvesterDefaults "github.com/rancher/tfp-automation/framework/set/defaults/providers/harvester"
+	linodeDefaults "github.com/rancher/tfp-automation/framework/set/defaults/providers/linode"
 	vsphereDefaults "github.com/rancher/tfp-automation/framework/set/defaults/providers/vsphere"
 	customnodepools "github.com/rancher/tfp-automation/framework/set/provisioning/custom/nodepools"
 	"github.com/rancher/tfp-automation/framework/set/provisioning/custom/nullresource"
 	"github.com/rancher/tfp-automation/framework/set/resources/providers/aws"
+	"github.com/rancher/tfp-automation/framework/set/resources/providers/azure"
+	"github.com/rancher/tfp-automation/framework/set/resources/providers/google"
+	"github.com/rancher/tfp-automation/framework/set/resources/providers/harvester"
+	"github.com/rancher/tfp-automation/framework/set/resources/providers/linode"
 	"github.com/rancher/tfp-automation/framework/set/resources/providers/vsphere"
 )
 
@@ -57,20 +65,78 @@ func SetCustomRKE2K3s(terraformConfig *config.TerraformConfig, terratestConfig *
 		vsphere.CreateVsphereDatastore(rootBody, terraformConfig, dataCenterValue)
 		rootBody.AppendNewline()
 
-		vsphere.CreateVsphereVirtualMachineTemplate(rootBody, terraformConfig, dataCenterValue)
+		err := vsphere.CreateVsphereVirtualMachineTemplate(rootBody, terraformConfig, dataCenterValue)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		rootBody.AppendNewline()
+
+		err = vsphere.CreateVsphereVirtualMachine(rootBody, terraformConfig, terratestConfig, terraformConfig.ResourcePrefix)
+		if err != nil {
+			return nil, nil, err
+		}
+	case azureDefaults.Azure:
+		azure.CreateAzureResourceGroup(rootBody, terraformConfig)
 		rootBody.AppendNewline()
 
-		vsphere.CreateVsphereVirtualMachine(rootBody, terraformConfig, terratestConfig, terraformConfig.ResourcePrefix)
+		azure.CreateAzureVirtualNetwork(rootBody, terraformConfig)
+		rootBody.AppendNewline()
+
+		azure.CreateAzureSubnet(rootBody, terraformConfig)
+		rootBody.AppendNewline()
+
+		azure.CreateAzureNetworkSecurityGroup(rootBody, terraformConfig)
+		rootBody.AppendNewline()
+
+		for _, nodeName := range customnodepools.AzureNodeNames(terraformConfig, terratestConfig) {
+			azure.CreateAzurePublicIP(rootBody, terraformConfig, nodeName)
+			rootBody.AppendNewline()
+
+			azure.CreateAzureNetworkInterface(rootBody, terraformConfig, nodeName)
+			rootBody.AppendNewline()
+
+			azure.CreateAzureNetworkInterfaceSecurityGroupAssociation(rootBody, terraformConfig, nodeName)
+			rootBody.AppendNewline()
+
+			azure.CreateAzureInstances(rootBody, terraformConfig, nodeName)
+			rootBody.AppendNewline()
+		}
+	case googleDefaults.Google:
+		google.CreateGoogleCloudFirewalls(rootBody, terraformConfig)
+		rootBody.AppendNewline()
+
+		google.CreateGoogleCloudInstances(rootBody, terraformConfig, terratestConfig, terraformConfig.ResourcePrefix)
+	case harvesterDefaults.Harvester:
+		err := harvester.CreateHarvesterInstances(rootBody, terraformConfig, terratestConfig, terraformConfig.ResourcePrefix)
+		if err != nil {
+			return nil, nil, err
+		}
+	case linodeDefaults.Linode:
+		err := linode.CreateLinodeNodeInstances(rootBody, terraformConfig, terratestConfig, terraformConfig.ResourcePrefix)
+		if err != nil {
+			return nil, nil, err
+		}
+	default:
+		return nil, nil, fmt.Errorf("custom clusters are not supported on the %s provider", terraformConfig.Provider)
 	}
 
 	if strings.Contains(terraformConfig.Module, clustertypes.WINDOWS) {
 		rootBody.AppendNewline()
-		aws.CreateWindowsAWSInstances(rootBody, terraformConfig, terratestConfig, terraformConfig.ResourcePrefix)
+
+		err := createWindowsInstances(rootBody, terraformConfig, terratestConfig)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	rootBody.AppendNewline()
 
-	SetRancher2ClusterV2(rootBody, terraformConfig, terratestConfig)
+	err := SetRancher2ClusterV2(rootBody, terraformConfig, terratestConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	rootBody.AppendNewline()
 
 	nullresource.CustomNullResource(rootBody, terraformConfig, terratestConfig)
@@ -3,24 +3,18 @@ package custom
 import (
 	"strings"
 
-	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/clustertypes"
 	"github.com/rancher/tfp-automation/framework/set/defaults/general"
-	"github.com/rancher/tfp-automation/framework/set/defaults/providers/aws"
 	"github.com/rancher/tfp-automation/framework/set/defaults/rancher2"
 	"github.com/rancher/tfp-automation/framework/set/defaults/rancher2/clusters"
+	customnodepools "github.com/rancher/tfp-automation/framework/set/provisioning/custom/nodepools"
 	v2 "github.com/rancher/tfp-automation/framework/set/provisioning/nodedriver"
 	"github.com/zclconf/go-cty/cty"
 )
 
-func isIPv6CIDRFirst(clusterCIDR string) bool {
-	firstCIDR := strings.TrimSpace(strings.Split(clusterCIDR, ",")[0])
-	return strings.Contains(firstCIDR, ":")
-}
-
 // SetRancher2ClusterV2 is a function that will set the rancher2_cluster_v2 configurations in the main.tf file.
 func SetRancher2ClusterV2(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig) error {
 	rancher2ClusterV2Block := rootBody.AppendNewBlock(general.Resource, []string{rancher2.ClusterV2, terraformConfig.ResourcePrefix})
@@ -29,6 +23,11 @@ func SetRancher2ClusterV2(rootBody *hclwrite.Body, terraformConfig *config.Terra
 	rancher2ClusterV2BlockBody.SetAttributeValue(general.ResourceName, cty.StringVal(terraformConfig.ResourcePrefix))
 	rancher2ClusterV2BlockBody.SetAttributeValue(clusters.KubernetesVersion, cty.StringVal(terratestConfig.KubernetesVersion))
 
+	err := v2.SetAgentConfig(terraformConfig, rancher2ClusterV2BlockBody)
+	if err != nil {
+		return err
+	}
+
 	if terraformConfig.LocalAuthEndpoint {
 		err := v2.SetLocalAuthEndpoint(terraformConfig, rancher2ClusterV2BlockBody)
 		if err != nil {
@@ -39,40 +38,22 @@ func SetRancher2ClusterV2(rootBody *hclwrite.Body, terraformConfig *config.Terra
 	rkeConfigBlock := rancher2ClusterV2BlockBody.AppendNewBlock(clusters.RkeConfig, nil)
 	rkeConfigBlockBody := rkeConfigBlock.Body()
 
-	var cidrValues hclwrite.Tokens
-
-	if terraformConfig.AWSConfig.EnablePrimaryIPv6 {
-		if strings.Contains(terraformConfig.Module, clustertypes.K3S) {
-			cidrValues = hclwrite.TokensForTraversal(hcl.Traversal{
-				hcl.TraverseRoot{Name: "<<EOF\ncluster-cidr: " + terraformConfig.AWSConfig.ClusterCIDR + "\nservice-cidr: " +
-					terraformConfig.AWSConfig.ServiceCIDR + "\nflannel-ipv6-masq: true" + "\ningress-controller: \"traefik\"\nEOF"},
-			})
-		} else if terraformConfig.AWSConfig.IPv6AddressOnly || (strings.Contains(terraformConfig.Module, clustertypes.RKE2) && isIPv6CIDRFirst(terraformConfig.AWSConfig.ClusterCIDR)) {
-			cidrValues = hclwrite.TokensForTraversal(hcl.Traversal{
-				hcl.TraverseRoot{Name: "<<EOF\ncluster-cidr: " + terraformConfig.AWSConfig.ClusterCIDR + "\nservice-cidr: " +
-					terraformConfig.AWSConfig.ServiceCIDR + "\ningress-controller: \"traefik\"\nkube-apiserver-arg:\n  - \"bind-address=::\"\nkube-controller-manager-arg:\n  - \"bind-address=::\"\nkube-scheduler-arg:\n  - \"bind-address=::\"\nEOF"},
-			})
-		} else {
-			cidrValues = hclwrite.TokensForTraversal(hcl.Traversal{
-				hcl.TraverseRoot{Name: "<<EOF\ncluster-cidr: " + terraformConfig.AWSConfig.ClusterCIDR + "\nservice-cidr: " +
-					terraformConfig.AWSConfig.ServiceCIDR + "\ningress-controller: \"traefik\"\nEOF"},
-			})
-		}
-
-	} else {
-		cidrValues = hclwrite.TokensForTraversal(hcl.Traversal{
-			hcl.TraverseRoot{Name: "<<EOF\ningress-controller: \"traefik\"\nEOF"},
-		})
+	globalConfig, err := v2.MachineGlobalConfig(terraformConfig)
+	if err != nil {
+		return err
 	}
 
-	rkeConfigBlockBody.SetAttributeRaw(clusters.MachineGlobalConfig, cidrValues)
+	rkeConfigBlockBody.SetAttributeRaw(clusters.MachineGlobalConfig, globalConfig)
+
+	err = v2.SetMachineSelectorConfigs(rkeConfigBlockBody, terraformConfig)
+	if err != nil {
+		return err
+	}
 
-	if terraformConfig.AWSConfig.Networking != nil {
-		if terraformConfig.AWSConfig.Networking.StackPreference != "" {
-			err := v2.SetNetworkingConfig(rkeConfigBlockBody, terraformConfig)
-			if err != nil {
-				return err
-			}
+	if terraformConfig.Networking != nil && terraformConfig.Networking.StackPreference != "" {
+		err := v2.SetNetworkingConfig(rkeConfigBlockBody, terraformConfig)
+		if err != nil {
+			return err
 		}
 	}
 
@@ -101,7 +82,7 @@ func SetRancher2ClusterV2(rootBody *hclwrite.Body, terraformConfig *config.Terra
 	}
 
 	if strings.Contains(terraformConfig.Module, clustertypes.CUSTOM) && strings.Contains(terraformConfig.Module, clustertypes.WINDOWS) {
-		dependsOnBlock := `[` + aws.AwsInstance + `.` + terraformConfig.ResourcePrefix + `-windows]`
+		dependsOnBlock := `[` + strings.Join(customnodepools.WindowsInstanceResources(terraformConfig, terratestConfig), ", ") + `]`
 
 		server := hclwrite.Tokens{
 			{Type: hclsyntax.TokenIdent, Bytes: []byte(dependsOnBlock)},
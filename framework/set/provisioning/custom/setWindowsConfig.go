@@ -1,18 +1,71 @@
 package custom
 
 import (
+	"fmt"
 	"os"
 
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/defaults/general"
+	awsDefaults "github.com/rancher/tfp-automation/framework/set/defaults/providers/aws"
+	azureDefaults "github.com/rancher/tfp-automation/framework/set/defaults/providers/azure"
+	vsphereDefaults "github.com/rancher/tfp-automation/framework/set/defaults/providers/vsphere"
+	customnodepools "github.com/rancher/tfp-automation/framework/set/provisioning/custom/nodepools"
 	"github.com/rancher/tfp-automation/framework/set/provisioning/custom/nullresource"
+	"github.com/rancher/tfp-automation/framework/set/resources/providers/aws"
+	"github.com/rancher/tfp-automation/framework/set/resources/providers/azure"
+	"github.com/rancher/tfp-automation/framework/set/resources/providers/vsphere"
 )
 
 // SetCustomRKE2Windows is a function that will set the custom RKE2 cluster configurations in the main.tf file.
 func SetCustomRKE2Windows(terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
 	newFile *hclwrite.File, rootBody *hclwrite.Body, file *os.File) (*hclwrite.File, *os.File, error) {
-	nullresource.CustomWindowsNullResource(rootBody, terraformConfig, terraformConfig.ResourcePrefix)
+	err := nullresource.CustomWindowsNullResource(rootBody, terraformConfig, terratestConfig, terraformConfig.ResourcePrefix)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	rootBody.AppendNewline()
 
 	return newFile, file, nil
 }
+
+// createWindowsInstances is a helper function that will create the Windows nodes of the custom cluster, using the image
+// of the Windows Server version set on the Windows nodepools.
+func createWindowsInstances(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig) error {
+	windowsVersion, err := customnodepools.WindowsVersion(terraformConfig, terratestConfig)
+	if err != nil {
+		return err
+	}
+
+	switch terraformConfig.Provider {
+	case awsDefaults.Aws:
+		aws.CreateWindowsAWSInstances(rootBody, terraformConfig, terratestConfig, terraformConfig.ResourcePrefix, windowsVersion)
+	case azureDefaults.Azure:
+		for i, nodeName := range customnodepools.AzureWindowsNodeNames(terraformConfig, terratestConfig) {
+			azure.CreateAzurePublicIP(rootBody, terraformConfig, nodeName)
+			rootBody.AppendNewline()
+
+			azure.CreateAzureNetworkInterface(rootBody, terraformConfig, nodeName)
+			rootBody.AppendNewline()
+
+			azure.CreateAzureNetworkInterfaceSecurityGroupAssociation(rootBody, terraformConfig, nodeName)
+			rootBody.AppendNewline()
+
+			azure.CreateAzureWindowsInstances(rootBody, terraformConfig, nodeName, i, windowsVersion)
+			rootBody.AppendNewline()
+		}
+	case vsphereDefaults.Vsphere:
+		dataCenterExpression := general.Data + `.` + vsphereDefaults.VsphereDatacenter + `.` + vsphereDefaults.VsphereDatacenter + `.id`
+		dataCenterValue := hclwrite.Tokens{
+			{Type: hclsyntax.TokenIdent, Bytes: []byte(dataCenterExpression)},
+		}
+
+		return vsphere.CreateWindowsVsphereVirtualMachine(rootBody, terraformConfig, terratestConfig, dataCenterValue, windowsVersion)
+	default:
+		return fmt.Errorf("custom Windows nodes are not supported on the %s provider", terraformConfig.Provider)
+	}
+
+	return nil
+}
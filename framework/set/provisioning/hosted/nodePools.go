@@ -0,0 +1,38 @@
+package hosted
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+// gkeTaintEffects maps the Kubernetes taint effects to the ones of the GKE API.
+var gkeTaintEffects = map[string]string{
+	"NoSchedule":       "NO_SCHEDULE",
+	"PreferNoSchedule": "PREFER_NO_SCHEDULE",
+	"NoExecute":        "NO_EXECUTE",
+}
+
+// labelsVal is a helper function that returns the given node pool labels as a map value.
+func labelsVal(labels map[string]string) cty.Value {
+	values := map[string]cty.Value{}
+	for key, value := range labels {
+		values[key] = cty.StringVal(value)
+	}
+
+	return cty.MapVal(values)
+}
+
+// ParseTaint is a function that splits a node pool taint in the key=value:Effect format, as taken by AKS, and validates
+// its effect.
+func ParseTaint(taint string) (string, string, string, error) {
+	keyValue, effect, found := strings.Cut(taint, ":")
+	if !found || gkeTaintEffects[effect] == "" {
+		return "", "", "", fmt.Errorf("invalid taint %s, expected the key=value:Effect format", taint)
+	}
+
+	key, value, _ := strings.Cut(keyValue, "=")
+
+	return key, value, effect, nil
+}
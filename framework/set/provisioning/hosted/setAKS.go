@@ -7,15 +7,22 @@ import (
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/providers"
 	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/azure"
 	format "github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults/general"
 	"github.com/rancher/tfp-automation/framework/set/defaults/rancher2"
 	"github.com/rancher/tfp-automation/framework/set/defaults/rancher2/clusters"
+	"github.com/rancher/tfp-automation/framework/set/resources/hosted/cluster"
 	resources "github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 	"github.com/zclconf/go-cty/cty"
 )
 
+const (
+	aksIPv4 = "IPv4"
+	aksIPv6 = "IPv6"
+)
+
 // SetAKS is a function that will set the AKS configurations in the main.tf file.
 func SetAKS(terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig, newFile *hclwrite.File, rootBody *hclwrite.Body,
 	file *os.File) (*hclwrite.File, *os.File, error) {
@@ -47,15 +54,56 @@ func SetAKS(terraformConfig *config.TerraformConfig, terratestConfig *config.Ter
 	}
 
 	aksConfigBlockBody.SetAttributeRaw(clusters.CloudCredentialID, cloudCredID)
-	aksConfigBlockBody.SetAttributeValue(azure.OutboundType, cty.StringVal(terraformConfig.AzureConfig.OutboundType))
-	aksConfigBlockBody.SetAttributeValue(azure.ResourceGroup, cty.StringVal(terraformConfig.AzureConfig.ResourceGroup))
-	aksConfigBlockBody.SetAttributeValue(azure.ResourceLocation, cty.StringVal(terraformConfig.AzureConfig.ResourceLocation))
-	aksConfigBlockBody.SetAttributeValue(azure.DNSPrefix, cty.StringVal(terraformConfig.ResourcePrefix))
-	aksConfigBlockBody.SetAttributeValue(clusters.KubernetesVersion, cty.StringVal(terratestConfig.KubernetesVersion))
-	aksConfigBlockBody.SetAttributeValue(azure.NetworkPlugin, cty.StringVal(terraformConfig.AzureConfig.NetworkPlugin))
-	aksConfigBlockBody.SetAttributeValue(azure.NetworkDNSServiceIP, cty.StringVal(terraformConfig.AzureConfig.NetworkDNSServiceIP))
-	aksConfigBlockBody.SetAttributeValue(azure.NetworkDockerBridgeCIDR, cty.StringVal(terraformConfig.AzureConfig.NetworkDockerBridgeCIDR))
-	aksConfigBlockBody.SetAttributeValue(azure.NetworkServiceCIDR, cty.StringVal(terraformConfig.AzureConfig.NetworkServiceCIDR))
+
+	// Imported clusters keep the settings they were created with, Rancher only manages the node pools set below.
+	if terraformConfig.HostedImport != nil {
+		aksConfigBlockBody.SetAttributeValue(azure.ResourceGroup, cty.StringVal(cluster.AKSResourceGroup(terraformConfig)))
+		aksConfigBlockBody.SetAttributeValue(azure.ResourceLocation, cty.StringVal(terraformConfig.AzureConfig.Location))
+
+		err := setHostedImport(rootBody, clusterBlockBody, aksConfigBlockBody, terraformConfig, providers.AKS)
+		if err != nil {
+			return nil, nil, err
+		}
+	} else {
+		aksConfigBlockBody.SetAttributeValue(azure.OutboundType, cty.StringVal(terraformConfig.AzureConfig.OutboundType))
+		aksConfigBlockBody.SetAttributeValue(azure.ResourceGroup, cty.StringVal(terraformConfig.AzureConfig.ResourceGroup))
+		aksConfigBlockBody.SetAttributeValue(azure.ResourceLocation, cty.StringVal(terraformConfig.AzureConfig.ResourceLocation))
+		aksConfigBlockBody.SetAttributeValue(azure.DNSPrefix, cty.StringVal(terraformConfig.ResourcePrefix))
+		aksConfigBlockBody.SetAttributeValue(clusters.KubernetesVersion, cty.StringVal(terratestConfig.KubernetesVersion))
+		aksConfigBlockBody.SetAttributeValue(azure.NetworkPlugin, cty.StringVal(terraformConfig.AzureConfig.NetworkPlugin))
+		aksConfigBlockBody.SetAttributeValue(azure.NetworkDNSServiceIP, cty.StringVal(terraformConfig.AzureConfig.NetworkDNSServiceIP))
+		aksConfigBlockBody.SetAttributeValue(azure.NetworkDockerBridgeCIDR, cty.StringVal(terraformConfig.AzureConfig.NetworkDockerBridgeCIDR))
+		aksConfigBlockBody.SetAttributeValue(azure.NetworkServiceCIDR, cty.StringVal(terraformConfig.AzureConfig.NetworkServiceCIDR))
+
+		dualStack, err := hostedDualStack(terraformConfig, "AKS")
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// Dual-stack clusters take both the IPv4 and the IPv6 CIDRs through the network profile.
+		if dualStack {
+			networkProfileBlock := aksConfigBlockBody.AppendNewBlock(azure.NetworkProfile, nil)
+			networkProfileBlockBody := networkProfileBlock.Body()
+
+			networkProfileBlockBody.SetAttributeRaw(azure.IPVersions, format.ListOfStrings([]string{aksIPv4, aksIPv6}))
+
+			if terraformConfig.Networking.ClusterCIDR != "" {
+				networkProfileBlockBody.SetAttributeRaw(azure.PodCIDRs, format.ListOfStrings(hostedCIDRs(terraformConfig.Networking.ClusterCIDR)))
+			}
+
+			if terraformConfig.Networking.ServiceCIDR != "" {
+				networkProfileBlockBody.SetAttributeRaw(azure.ServiceCIDRs, format.ListOfStrings(hostedCIDRs(terraformConfig.Networking.ServiceCIDR)))
+			}
+		} else if terraformConfig.Networking != nil {
+			if terraformConfig.Networking.ClusterCIDR != "" {
+				aksConfigBlockBody.SetAttributeValue(azure.NetworkPodCIDR, cty.StringVal(terraformConfig.Networking.ClusterCIDR))
+			}
+
+			if terraformConfig.Networking.ServiceCIDR != "" {
+				aksConfigBlockBody.SetAttributeValue(azure.NetworkServiceCIDR, cty.StringVal(terraformConfig.Networking.ServiceCIDR))
+			}
+		}
+	}
 
 	availabilityZones := format.ListOfStrings(terraformConfig.AzureConfig.AvailabilityZones)
 
@@ -70,15 +118,59 @@ func SetAKS(terraformConfig *config.TerraformConfig, terratestConfig *config.Ter
 		nodePoolsBlock := aksConfigBlockBody.AppendNewBlock(azure.NodePools, nil)
 		nodePoolsBlockBody := nodePoolsBlock.Body()
 
+		// AKS pool names must be unique, so only the first pool keeps the name of the Azure config as is.
+		name := pool.Name
+		if name == "" {
+			name = terraformConfig.AzureConfig.Name
+			if count > 0 {
+				name += poolNum
+			}
+		}
+
+		mode := pool.Mode
+		if mode == "" {
+			mode = terraformConfig.AzureConfig.Mode
+		}
+
+		vmSize := pool.InstanceType
+		if vmSize == "" {
+			vmSize = terraformConfig.AzureConfig.VMSize
+		}
+
+		// The node pools follow the control plane version, unless they are upgraded separately.
+		orchestratorVersion := pool.KubernetesVersion
+		if orchestratorVersion == "" {
+			orchestratorVersion = terratestConfig.KubernetesVersion
+		}
+
 		nodePoolsBlockBody.SetAttributeRaw(azure.AvailabilityZones, availabilityZones)
-		nodePoolsBlockBody.SetAttributeValue(azure.NodePoolMode, cty.StringVal(terraformConfig.AzureConfig.Mode))
-		nodePoolsBlockBody.SetAttributeValue(general.ResourceName, cty.StringVal(terraformConfig.AzureConfig.Name))
+		nodePoolsBlockBody.SetAttributeValue(azure.NodePoolMode, cty.StringVal(mode))
+		nodePoolsBlockBody.SetAttributeValue(general.ResourceName, cty.StringVal(name))
 		nodePoolsBlockBody.SetAttributeValue(azure.Count, cty.NumberIntVal(pool.Quantity))
-		nodePoolsBlockBody.SetAttributeValue(azure.OrchestratorVersion, cty.StringVal(terratestConfig.KubernetesVersion))
+		nodePoolsBlockBody.SetAttributeValue(azure.OrchestratorVersion, cty.StringVal(orchestratorVersion))
 		nodePoolsBlockBody.SetAttributeValue(azure.OSDiskSizeGB, cty.NumberIntVal(terraformConfig.AzureConfig.OSDiskSizeGB))
-		nodePoolsBlockBody.SetAttributeValue(azure.VMSize, cty.StringVal(terraformConfig.AzureConfig.VMSize))
+		nodePoolsBlockBody.SetAttributeValue(azure.VMSize, cty.StringVal(vmSize))
+
+		if pool.MaxPodsConstraint > 0 {
+			nodePoolsBlockBody.SetAttributeValue(azure.MaxPods, cty.NumberIntVal(pool.MaxPodsConstraint))
+		}
+
+		if pool.EnableAutoscaling {
+			nodePoolsBlockBody.SetAttributeValue(azure.EnableAutoScaling, cty.BoolVal(true))
+			nodePoolsBlockBody.SetAttributeValue(azure.MinCount, cty.NumberIntVal(pool.MinSize))
+			nodePoolsBlockBody.SetAttributeValue(azure.MaxCount, cty.NumberIntVal(pool.MaxSize))
+		}
+
+		if len(pool.Labels) > 0 {
+			nodePoolsBlockBody.SetAttributeValue(azure.Labels, labelsVal(pool.Labels))
+		}
+
+		poolTaints := pool.Taints
+		if len(poolTaints) == 0 {
+			poolTaints = terraformConfig.AzureConfig.Taints
+		}
 
-		taints := format.ListOfStrings(terraformConfig.AzureConfig.Taints)
+		taints := format.ListOfStrings(poolTaints)
 		nodePoolsBlockBody.SetAttributeRaw(azure.Taints, taints)
 	}
 
@@ -1,6 +1,7 @@
 package hosted
 
 import (
+	"fmt"
 	"os"
 	"strconv"
 	"strings"
@@ -8,7 +9,9 @@ import (
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/providers"
 	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/amazon"
+	format "github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults/general"
 	"github.com/rancher/tfp-automation/framework/set/defaults/providers/aws"
 	"github.com/rancher/tfp-automation/framework/set/defaults/rancher2"
@@ -47,12 +50,26 @@ func SetEKS(terraformConfig *config.TerraformConfig, terratestConfig *config.Ter
 
 	eksConfigBlockBody.SetAttributeRaw(clusters.CloudCredentialID, cloudCredID)
 	eksConfigBlockBody.SetAttributeValue(aws.Region, cty.StringVal(terraformConfig.AWSConfig.EKSRegion))
-	eksConfigBlockBody.SetAttributeValue(clusters.KubernetesVersion, cty.StringVal(terratestConfig.KubernetesVersion))
-	eksConfigBlockBody.SetAttributeValue(amazon.PrivateAccess, cty.BoolVal(terraformConfig.AWSConfig.PrivateAccess))
-	eksConfigBlockBody.SetAttributeValue(amazon.PublicAccess, cty.BoolVal(terraformConfig.AWSConfig.PublicAccess))
 
-	if terraformConfig.Standalone != nil && !strings.Contains(terraformConfig.Standalone.RancherTagVersion, "v2.13") {
-		eksConfigBlockBody.SetAttributeValue(amazon.IPFamily, cty.StringVal(terraformConfig.AWSConfig.IPFamily))
+	// Imported clusters keep the settings they were created with, Rancher only manages the node groups set below.
+	if terraformConfig.HostedImport != nil {
+		err := setHostedImport(rootBody, clusterBlockBody, eksConfigBlockBody, terraformConfig, providers.EKS)
+		if err != nil {
+			return nil, nil, err
+		}
+	} else {
+		eksConfigBlockBody.SetAttributeValue(clusters.KubernetesVersion, cty.StringVal(terratestConfig.KubernetesVersion))
+		eksConfigBlockBody.SetAttributeValue(amazon.PrivateAccess, cty.BoolVal(terraformConfig.AWSConfig.PrivateAccess))
+		eksConfigBlockBody.SetAttributeValue(amazon.PublicAccess, cty.BoolVal(terraformConfig.AWSConfig.PublicAccess))
+
+		ipFamily, err := eksIPFamily(terraformConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if terraformConfig.Standalone != nil && !strings.Contains(terraformConfig.Standalone.RancherTagVersion, "v2.13") {
+			eksConfigBlockBody.SetAttributeValue(amazon.IPFamily, cty.StringVal(ipFamily))
+		}
 	}
 
 	for count, pool := range terratestConfig.Nodepools {
@@ -66,13 +83,104 @@ func SetEKS(terraformConfig *config.TerraformConfig, terratestConfig *config.Ter
 		nodePoolsBlock := eksConfigBlockBody.AppendNewBlock(amazon.NodeGroups, nil)
 		nodePoolsBlockBody := nodePoolsBlock.Body()
 
-		nodePoolsBlockBody.SetAttributeValue(general.ResourceName, cty.StringVal(terraformConfig.ResourcePrefix+`-pool`+poolNum))
+		name := pool.Name
+		if name == "" {
+			name = terraformConfig.ResourcePrefix + `-pool` + poolNum
+		}
+
+		nodePoolsBlockBody.SetAttributeValue(general.ResourceName, cty.StringVal(name))
 		nodePoolsBlockBody.SetAttributeValue(amazon.DiskSize, cty.NumberIntVal(pool.DiskSize))
-		nodePoolsBlockBody.SetAttributeValue(amazon.InstanceType, cty.StringVal(pool.InstanceType))
+
+		// Spot node groups take their instance types from the spot instance types instead.
+		if pool.Spot {
+			nodePoolsBlockBody.SetAttributeValue(amazon.RequestSpotInstances, cty.BoolVal(true))
+			nodePoolsBlockBody.SetAttributeRaw(amazon.SpotInstanceTypes, format.ListOfStrings(pool.SpotInstanceTypes))
+		} else {
+			nodePoolsBlockBody.SetAttributeValue(amazon.InstanceType, cty.StringVal(pool.InstanceType))
+		}
+
 		nodePoolsBlockBody.SetAttributeValue(amazon.DesiredSize, cty.NumberIntVal(pool.DesiredSize))
 		nodePoolsBlockBody.SetAttributeValue(amazon.MaxSize, cty.NumberIntVal(pool.MaxSize))
 		nodePoolsBlockBody.SetAttributeValue(amazon.MinSize, cty.NumberIntVal(pool.MinSize))
+
+		// Node groups without a version follow the control plane version they were created with.
+		if pool.KubernetesVersion != "" {
+			nodePoolsBlockBody.SetAttributeValue(general.Version, cty.StringVal(pool.KubernetesVersion))
+		}
+
+		if len(pool.Labels) > 0 {
+			nodePoolsBlockBody.SetAttributeValue(amazon.Labels, labelsVal(pool.Labels))
+		}
+
+		if pool.LaunchTemplateID != "" {
+			launchTemplateBlock := nodePoolsBlockBody.AppendNewBlock(amazon.LaunchTemplate, nil)
+			launchTemplateBlockBody := launchTemplateBlock.Body()
+
+			launchTemplateBlockBody.SetAttributeValue(amazon.LaunchTemplateID, cty.StringVal(pool.LaunchTemplateID))
+
+			if pool.LaunchTemplateVersion > 0 {
+				launchTemplateBlockBody.SetAttributeValue(amazon.LaunchTemplateVersion, cty.NumberIntVal(pool.LaunchTemplateVersion))
+			}
+		}
 	}
 
 	return newFile, file, nil
 }
+
+// eksIPFamily is a helper function that returns the IP family of the EKS cluster. EKS does not support dual-stack
+// clusters, so they are rejected.
+func eksIPFamily(terraformConfig *config.TerraformConfig) (string, error) {
+	if terraformConfig.Networking == nil {
+		return config.IPv4, nil
+	}
+
+	switch terraformConfig.Networking.IPFamily {
+	case config.IPv6:
+		return config.IPv6, nil
+	case config.DualStack:
+		return "", fmt.Errorf("EKS clusters do not support %s networking", config.DualStack)
+	default:
+		return config.IPv4, nil
+	}
+}
+
+// hostedDualStack is a helper function that reports whether the given AKS or GKE cluster is dual-stack. Both keep an
+// IPv4 stack, so IPv6 only clusters are rejected instead of creating a dual-stack cluster.
+func hostedDualStack(terraformConfig *config.TerraformConfig, kind string) (bool, error) {
+	if terraformConfig.Networking == nil {
+		return false, nil
+	}
+
+	switch terraformConfig.Networking.IPFamily {
+	case config.IPv6:
+		return false, fmt.Errorf("%s clusters do not support %s only networking, use %s instead", kind, config.IPv6, config.DualStack)
+	case config.DualStack:
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// hostedCIDRs is a helper function that splits the comma separated CIDRs of the networking config.
+func hostedCIDRs(cidrs string) []string {
+	var list []string
+	for _, cidr := range strings.Split(cidrs, ",") {
+		cidr = strings.TrimSpace(cidr)
+		if cidr != "" {
+			list = append(list, cidr)
+		}
+	}
+
+	return list
+}
+
+// hostedIPv4CIDR is a helper function that returns the first IPv4 CIDR of the comma separated CIDRs.
+func hostedIPv4CIDR(cidrs string) string {
+	for _, cidr := range hostedCIDRs(cidrs) {
+		if !strings.Contains(cidr, ":") {
+			return cidr
+		}
+	}
+
+	return ""
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/providers"
 	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/google"
 	"github.com/rancher/tfp-automation/framework/set/defaults/general"
 	"github.com/rancher/tfp-automation/framework/set/defaults/rancher2"
@@ -20,6 +21,7 @@ const (
 	autoRepair               = "auto_repair"
 	autoUpgrade              = "auto_upgrade"
 	builtin                  = "builtin"
+	gkeDualStack             = "IPV4_IPV6"
 	googleDriver             = "google"
 	httpLoadBalancing        = "http_load_balancing"
 	horizontalPodAutoscaling = "horizontal_pod_autoscaling"
@@ -79,17 +81,57 @@ func SetGKE(terraformConfig *config.TerraformConfig, terratestConfig *config.Ter
 	}
 
 	gkeConfigBlockBody.SetAttributeRaw(google.GoogleCredentialSecret, cloudCredSecret)
-	gkeConfigBlockBody.SetAttributeValue(google.Region, cty.StringVal(terraformConfig.GoogleConfig.Region))
-	gkeConfigBlockBody.SetAttributeValue(google.ProjectID, cty.StringVal(terraformConfig.GoogleConfig.ProjectID))
-	gkeConfigBlockBody.SetAttributeValue(clusters.KubernetesVersion, cty.StringVal(terratestConfig.KubernetesVersion))
-	gkeConfigBlockBody.SetAttributeValue(google.Network, cty.StringVal(terraformConfig.GoogleConfig.Network))
-	gkeConfigBlockBody.SetAttributeValue(google.Subnetwork, cty.StringVal(terraformConfig.GoogleConfig.Subnetwork))
 
-	clusterAddOnsBlock := gkeConfigBlockBody.AppendNewBlock(google.ClusterAddOns, nil)
-	clusterAddOnsBlockBody := clusterAddOnsBlock.Body()
+	// Imported clusters keep the settings they were created with, Rancher only manages the node pools set below. The
+	// create-gke-cluster.sh script creates a zonal cluster.
+	if terraformConfig.HostedImport != nil {
+		gkeConfigBlockBody.SetAttributeValue(google.Zone, cty.StringVal(terraformConfig.GoogleConfig.Zone))
+		gkeConfigBlockBody.SetAttributeValue(google.ProjectID, cty.StringVal(terraformConfig.GoogleConfig.ProjectID))
 
-	clusterAddOnsBlockBody.SetAttributeValue(httpLoadBalancing, cty.BoolVal(true))
-	clusterAddOnsBlockBody.SetAttributeValue(horizontalPodAutoscaling, cty.BoolVal(true))
+		err := setHostedImport(rootBody, clusterBlockBody, gkeConfigBlockBody, terraformConfig, providers.GKE)
+		if err != nil {
+			return nil, nil, err
+		}
+	} else {
+		gkeConfigBlockBody.SetAttributeValue(google.Region, cty.StringVal(terraformConfig.GoogleConfig.Region))
+		gkeConfigBlockBody.SetAttributeValue(google.ProjectID, cty.StringVal(terraformConfig.GoogleConfig.ProjectID))
+		gkeConfigBlockBody.SetAttributeValue(clusters.KubernetesVersion, cty.StringVal(terratestConfig.KubernetesVersion))
+		gkeConfigBlockBody.SetAttributeValue(google.Network, cty.StringVal(terraformConfig.GoogleConfig.Network))
+		gkeConfigBlockBody.SetAttributeValue(google.Subnetwork, cty.StringVal(terraformConfig.GoogleConfig.Subnetwork))
+
+		dualStack, err := hostedDualStack(terraformConfig, "GKE")
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// GKE takes the IPv6 ranges of dual-stack clusters from the subnetwork, so only the IPv4 CIDRs are set.
+		if dualStack || (terraformConfig.Networking != nil && terraformConfig.Networking.ClusterCIDR != "") {
+			ipAllocationPolicyBlock := gkeConfigBlockBody.AppendNewBlock(google.IPAllocationPolicy, nil)
+			ipAllocationPolicyBlockBody := ipAllocationPolicyBlock.Body()
+
+			ipAllocationPolicyBlockBody.SetAttributeValue(google.UseIPAliases, cty.BoolVal(true))
+
+			clusterCIDR := hostedIPv4CIDR(terraformConfig.Networking.ClusterCIDR)
+			if clusterCIDR != "" {
+				ipAllocationPolicyBlockBody.SetAttributeValue(google.ClusterIPv4CIDRBlock, cty.StringVal(clusterCIDR))
+			}
+
+			serviceCIDR := hostedIPv4CIDR(terraformConfig.Networking.ServiceCIDR)
+			if serviceCIDR != "" {
+				ipAllocationPolicyBlockBody.SetAttributeValue(google.ServicesIPv4CIDRBlock, cty.StringVal(serviceCIDR))
+			}
+
+			if dualStack {
+				ipAllocationPolicyBlockBody.SetAttributeValue(google.StackType, cty.StringVal(gkeDualStack))
+			}
+		}
+
+		clusterAddOnsBlock := gkeConfigBlockBody.AppendNewBlock(google.ClusterAddOns, nil)
+		clusterAddOnsBlockBody := clusterAddOnsBlock.Body()
+
+		clusterAddOnsBlockBody.SetAttributeValue(httpLoadBalancing, cty.BoolVal(true))
+		clusterAddOnsBlockBody.SetAttributeValue(horizontalPodAutoscaling, cty.BoolVal(true))
+	}
 
 	for count, pool := range terratestConfig.Nodepools {
 		poolNum := strconv.Itoa(count)
@@ -104,16 +146,63 @@ func SetGKE(terraformConfig *config.TerraformConfig, terratestConfig *config.Ter
 
 		nodePoolsBlockBody.SetAttributeValue(google.InitialNodeCount, cty.NumberIntVal(pool.Quantity))
 		nodePoolsBlockBody.SetAttributeValue(google.MaxPodsConstraint, cty.NumberIntVal(pool.MaxPodsConstraint))
-		nodePoolsBlockBody.SetAttributeValue(general.ResourceName, cty.StringVal(terraformConfig.ResourcePrefix+`-pool`+poolNum))
-		nodePoolsBlockBody.SetAttributeValue(google.Version, cty.StringVal(terratestConfig.KubernetesVersion))
+		name := pool.Name
+		if name == "" {
+			name = terraformConfig.ResourcePrefix + `-pool` + poolNum
+		}
+
+		machineType := pool.InstanceType
+		if machineType == "" {
+			machineType = terraformConfig.GoogleConfig.MachineType
+		}
+
+		// The node pools follow the control plane version, unless they are upgraded separately.
+		version := pool.KubernetesVersion
+		if version == "" {
+			version = terratestConfig.KubernetesVersion
+		}
+
+		nodePoolsBlockBody.SetAttributeValue(general.ResourceName, cty.StringVal(name))
+		nodePoolsBlockBody.SetAttributeValue(google.Version, cty.StringVal(version))
+
+		if pool.EnableAutoscaling {
+			autoscalingBlock := nodePoolsBlockBody.AppendNewBlock(google.Autoscaling, nil)
+			autoscalingBlockBody := autoscalingBlock.Body()
+
+			autoscalingBlockBody.SetAttributeValue(google.Enabled, cty.BoolVal(true))
+			autoscalingBlockBody.SetAttributeValue(google.MinNodeCount, cty.NumberIntVal(pool.MinSize))
+			autoscalingBlockBody.SetAttributeValue(google.MaxNodeCount, cty.NumberIntVal(pool.MaxSize))
+		}
 
 		configBlock := nodePoolsBlockBody.AppendNewBlock(google.Config, nil)
 		configBlockBody := configBlock.Body()
 
 		configBlockBody.SetAttributeValue(google.ImageType, cty.StringVal(terraformConfig.GoogleConfig.ImageType))
-		configBlockBody.SetAttributeValue(google.MachineType, cty.StringVal(terraformConfig.GoogleConfig.MachineType))
+		configBlockBody.SetAttributeValue(google.MachineType, cty.StringVal(machineType))
 		configBlockBody.SetAttributeValue(google.DiskSizeGb, cty.NumberIntVal(terraformConfig.GoogleConfig.Size))
 
+		if pool.Spot {
+			configBlockBody.SetAttributeValue(google.Preemptible, cty.BoolVal(true))
+		}
+
+		if len(pool.Labels) > 0 {
+			configBlockBody.SetAttributeValue(google.Labels, labelsVal(pool.Labels))
+		}
+
+		for _, poolTaint := range pool.Taints {
+			key, value, effect, err := ParseTaint(poolTaint)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			taintsBlock := configBlockBody.AppendNewBlock(google.Taints, nil)
+			taintsBlockBody := taintsBlock.Body()
+
+			taintsBlockBody.SetAttributeValue(google.Key, cty.StringVal(key))
+			taintsBlockBody.SetAttributeValue(google.Value, cty.StringVal(value))
+			taintsBlockBody.SetAttributeValue(google.Effect, cty.StringVal(gkeTaintEffects[effect]))
+		}
+
 		managementBlock := nodePoolsBlockBody.AppendNewBlock(google.Management, nil)
 		managementBlockBody := managementBlock.Body()
 
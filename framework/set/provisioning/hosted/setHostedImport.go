@@ -0,0 +1,41 @@
+package hosted
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/defaults/general"
+	"github.com/rancher/tfp-automation/framework/set/defaults/rancher2/clusters"
+	"github.com/rancher/tfp-automation/framework/set/resources/hosted/cluster"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// setHostedImport is a helper function that will create the cloud cluster of a hosted import cluster from the bastion of the
+// hosted import config, and import it through the given hosted config block. Rancher only registers the cluster once the
+// cloud cluster exists.
+func setHostedImport(rootBody, clusterBlockBody, hostedConfigBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
+	provider string) error {
+	if terraformConfig.HostedImport.Bastion == "" {
+		return fmt.Errorf("hosted import requires a bastion to create the %s cluster from", provider)
+	}
+
+	rootBody.AppendNewline()
+
+	nullResource, err := cluster.ImportHostedCluster(rootBody, terraformConfig, terraformConfig.HostedImport.Bastion, provider)
+	if err != nil {
+		return err
+	}
+
+	hostedConfigBlockBody.SetAttributeValue(general.ResourceName, cty.StringVal(terraformConfig.ResourcePrefix))
+	hostedConfigBlockBody.SetAttributeValue(clusters.Imported, cty.BoolVal(true))
+
+	dependsOnCluster := hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(`[` + nullResource + `]`)},
+	}
+
+	clusterBlockBody.SetAttributeRaw(general.DependsOn, dependsOnCluster)
+
+	return nil
+}
@@ -4,17 +4,15 @@ import (
 	"encoding/base64"
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
-	"github.com/rancher/tfp-automation/defaults/keypath"
-	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/defaults/clustertypes"
+	"github.com/rancher/tfp-automation/framework/assets"
 	"github.com/rancher/tfp-automation/framework/set/defaults/general"
 	"github.com/rancher/tfp-automation/framework/set/provisioning/imported/nullresource"
-	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -29,11 +27,9 @@ const (
 // ImportNodes is a function that will import the nodes to the cluster
 func ImportNodes(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
 	nodeOnePublicIP, importCommand string, additionalServerNodeNames, additionalAgentNodeNames []string) error {
-	userDir, _ := rancher2.SetKeyPath(keypath.RancherKeyPath, terratestConfig.PathToRepo, terraformConfig.Provider)
+	scriptPath := "framework/set/provisioning/imported/import-nodes.sh"
 
-	scriptPath := filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/provisioning/imported/import-nodes.sh")
-
-	scriptContent, err := os.ReadFile(scriptPath)
+	scriptContent, err := assets.ReadFile(scriptPath)
 	if err != nil {
 		return err
 	}
@@ -66,8 +62,8 @@ func ImportNodes(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfi
 
 	var dependsOnServer string
 
-	switch terraformConfig.Module {
-	case modules.ImportedAWSRKE2Windows2019, modules.ImportedAWSRKE2Windows2022:
+	switch {
+	case strings.Contains(terraformConfig.Module, clustertypes.WINDOWS):
 		dependsOnServer = `[` + general.TimeSleep + `.` + general.TimeSleep + `-` + terraformConfig.ResourcePrefix + `-import_wins` + `]`
 	default:
 		if len(additionalServerNodeNames) == 0 && len(additionalAgentNodeNames) == 0 {
@@ -8,6 +8,10 @@ import (
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/framework/set/defaults/general"
 	"github.com/rancher/tfp-automation/framework/set/defaults/providers/aws"
+	"github.com/rancher/tfp-automation/framework/set/defaults/providers/azure"
+	"github.com/rancher/tfp-automation/framework/set/defaults/providers/google"
+	"github.com/rancher/tfp-automation/framework/set/defaults/providers/harvester"
+	"github.com/rancher/tfp-automation/framework/set/defaults/providers/linode"
 	"github.com/rancher/tfp-automation/framework/set/defaults/providers/vsphere"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -37,6 +41,14 @@ func CreateImportedNullResource(rootBody *hclwrite.Body, terraformConfig *config
 	switch terraformConfig.Provider {
 	case aws.Aws:
 		connectionBlockBody.SetAttributeValue(general.User, cty.StringVal(terraformConfig.AWSConfig.AWSUser))
+	case azure.Azure:
+		connectionBlockBody.SetAttributeValue(general.User, cty.StringVal(terraformConfig.AzureConfig.SSHUser))
+	case google.Google:
+		connectionBlockBody.SetAttributeValue(general.User, cty.StringVal(terraformConfig.GoogleConfig.SSHUser))
+	case harvester.Harvester:
+		connectionBlockBody.SetAttributeValue(general.User, cty.StringVal(terraformConfig.HarvesterConfig.SSHUser))
+	case linode.Linode:
+		connectionBlockBody.SetAttributeValue(general.User, cty.StringVal(terraformConfig.Standalone.OSUser))
 	case vsphere.Vsphere:
 		connectionBlockBody.SetAttributeValue(general.User, cty.StringVal(terraformConfig.VsphereConfig.VsphereUser))
 	}
@@ -55,6 +67,22 @@ func CreateImportedNullResource(rootBody *hclwrite.Body, terraformConfig *config
 		for _, nodeName := range linuxNodeNames {
 			dependsOnResources = append(dependsOnResources, aws.AwsInstance+"."+nodeName)
 		}
+	case azure.Azure:
+		for _, nodeName := range linuxNodeNames {
+			dependsOnResources = append(dependsOnResources, azure.AzureLinuxVirtualMachine+"."+nodeName)
+		}
+	case google.Google:
+		for _, nodeName := range linuxNodeNames {
+			dependsOnResources = append(dependsOnResources, google.GoogleComputeInstance+"."+nodeName)
+		}
+	case harvester.Harvester:
+		for _, nodeName := range linuxNodeNames {
+			dependsOnResources = append(dependsOnResources, harvester.HarvesterVirtualMachine+"."+nodeName)
+		}
+	case linode.Linode:
+		for _, nodeName := range linuxNodeNames {
+			dependsOnResources = append(dependsOnResources, linode.LinodeInstance+"."+nodeName)
+		}
 	case vsphere.Vsphere:
 		for _, nodeName := range linuxNodeNames {
 			dependsOnResources = append(dependsOnResources, vsphere.VsphereVirtualMachine+"."+nodeName)
@@ -1,21 +1,19 @@
 package nullresource
 
 import (
-	"strings"
-
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
-	"github.com/rancher/tfp-automation/defaults/modules"
 	"github.com/rancher/tfp-automation/framework/set/defaults/general"
 	"github.com/rancher/tfp-automation/framework/set/defaults/providers/aws"
 	customnodepools "github.com/rancher/tfp-automation/framework/set/provisioning/custom/nodepools"
 	"github.com/zclconf/go-cty/cty"
 )
 
-// CreateImportedWindowsNullResource is a helper function that will create the null_resource for the Windows node.
+// CreateImportedWindowsNullResource is a helper function that will create the null_resource for the Windows node of the
+// given Windows Server version.
 func CreateImportedWindowsNullResource(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
-	resourceName string) (*hclwrite.Body, *hclwrite.Body) {
+	resourceName, windowsVersion string) (*hclwrite.Body, *hclwrite.Body) {
 	nullResourceBlock := rootBody.AppendNewBlock(general.Resource, []string{general.NullResource, resourceName})
 	nullResourceBlockBody := nullResourceBlock.Body()
 
@@ -40,14 +38,11 @@ func CreateImportedWindowsNullResource(rootBody *hclwrite.Body, terraformConfig
 
 	connectionBlockBody.SetAttributeRaw(general.Host, host)
 
-	connectionBlockBody.SetAttributeValue(general.Type, cty.StringVal(general.WinRM))
-	connectionBlockBody.SetAttributeValue(general.User, cty.StringVal(terraformConfig.AWSConfig.WindowsAWSUser))
+	windowsUser, windowsPassword := customnodepools.WindowsCredentials(terraformConfig, windowsVersion)
 
-	if strings.Contains(terraformConfig.Module, modules.ImportedAWSRKE2Windows2019) {
-		connectionBlockBody.SetAttributeValue(general.Password, cty.StringVal(terraformConfig.AWSConfig.Windows2019Password))
-	} else if strings.Contains(terraformConfig.Module, modules.ImportedAWSRKE2Windows2022) {
-		connectionBlockBody.SetAttributeValue(general.Password, cty.StringVal(terraformConfig.AWSConfig.Windows2022Password))
-	}
+	connectionBlockBody.SetAttributeValue(general.Type, cty.StringVal(general.WinRM))
+	connectionBlockBody.SetAttributeValue(general.User, cty.StringVal(windowsUser))
+	connectionBlockBody.SetAttributeValue(general.Password, cty.StringVal(windowsPassword))
 
 	connectionBlockBody.SetAttributeValue(general.Insecure, cty.BoolVal(true))
 	connectionBlockBody.SetAttributeValue(general.UseNTLM, cty.BoolVal(true))
@@ -8,14 +8,23 @@ import (
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/framework/set/defaults/general"
 	awsDefaults "github.com/rancher/tfp-automation/framework/set/defaults/providers/aws"
+	azureDefaults "github.com/rancher/tfp-automation/framework/set/defaults/providers/azure"
+	googleDefaults "github.com/rancher/tfp-automation/framework/set/defaults/providers/google"
+	harvesterDefaults "github.com/rancher/tfp-automation/framework/set/defaults/providers/harvester"
+	linodeDefaults "github.com/rancher/tfp-automation/framework/set/defaults/providers/linode"
 	vsphereDefaults "github.com/rancher/tfp-automation/framework/set/defaults/providers/vsphere"
 	"github.com/rancher/tfp-automation/framework/set/resources/providers/aws"
+	"github.com/rancher/tfp-automation/framework/set/resources/providers/azure"
+	"github.com/rancher/tfp-automation/framework/set/resources/providers/google"
+	"github.com/rancher/tfp-automation/framework/set/resources/providers/harvester"
+	"github.com/rancher/tfp-automation/framework/set/resources/providers/linode"
 	"github.com/rancher/tfp-automation/framework/set/resources/providers/vsphere"
+	"github.com/rancher/tfp-automation/framework/set/resources/topology"
 )
 
 // getProviderIPAddresses is a helper function that returns the IP addresses of the nodes
 func getProviderIPAddresses(terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig, rootBody *hclwrite.Body,
-	linuxNodeNames []string) (map[string]string, map[string]string, map[string]string) {
+	linuxNodeNames []string) (map[string]string, map[string]string, map[string]string, error) {
 	nodePublicIPs := make(map[string]string, len(linuxNodeNames))
 	nodePrivateIPs := make(map[string]string, len(linuxNodeNames))
 	nodePublicIPv6s := make(map[string]string, len(linuxNodeNames))
@@ -43,7 +52,36 @@ func getProviderIPAddresses(terraformConfig *config.TerraformConfig, terratestCo
 		vsphere.CreateVsphereDatastore(rootBody, terraformConfig, dataCenterValue)
 		rootBody.AppendNewline()
 
-		vsphere.CreateVsphereVirtualMachineTemplate(rootBody, terraformConfig, dataCenterValue)
+		err := vsphere.CreateVsphereVirtualMachineTemplate(rootBody, terraformConfig, dataCenterValue)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		rootBody.AppendNewline()
+	}
+
+	switch terraformConfig.Provider {
+	case azureDefaults.Azure:
+		azure.CreateAzureResourceGroup(rootBody, terraformConfig)
+		rootBody.AppendNewline()
+
+		azure.CreateAzureVirtualNetwork(rootBody, terraformConfig)
+		rootBody.AppendNewline()
+
+		azure.CreateAzureSubnet(rootBody, terraformConfig)
+		rootBody.AppendNewline()
+
+		azure.CreateAzureNetworkSecurityGroup(rootBody, terraformConfig)
+		rootBody.AppendNewline()
+	case googleDefaults.Google:
+		google.CreateGoogleCloudFirewalls(rootBody, terraformConfig)
+		rootBody.AppendNewline()
+	case harvesterDefaults.Harvester:
+		err := harvester.CreateLocalBlock(rootBody, terraformConfig)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
 		rootBody.AppendNewline()
 	}
 
@@ -57,13 +95,62 @@ func getProviderIPAddresses(terraformConfig *config.TerraformConfig, terratestCo
 			nodePublicIPs[instance] = fmt.Sprintf("${%s.%s.public_ip}", awsDefaults.AwsInstance, instance)
 			nodePublicIPv6s[instance] = fmt.Sprintf("${%s.%s.ipv6_addresses[0]}", awsDefaults.AwsInstance, instance)
 		case vsphereDefaults.Vsphere:
-			vsphere.CreateVsphereVirtualMachine(rootBody, terraformConfig, terratestConfig, instance)
+			err := vsphere.CreateVsphereVirtualMachine(rootBody, terraformConfig, terratestConfig, instance)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+
 			rootBody.AppendNewline()
 
 			nodePrivateIPs[instance] = fmt.Sprintf("${%s.%s.default_ip_address}", vsphereDefaults.VsphereVirtualMachine, instance)
 			nodePublicIPs[instance] = fmt.Sprintf("${%s.%s.default_ip_address}", vsphereDefaults.VsphereVirtualMachine, instance)
+		case azureDefaults.Azure:
+			azure.CreateAzurePublicIP(rootBody, terraformConfig, instance)
+			rootBody.AppendNewline()
+
+			azure.CreateAzureNetworkInterface(rootBody, terraformConfig, instance)
+			rootBody.AppendNewline()
+
+			azure.CreateAzureNetworkInterfaceSecurityGroupAssociation(rootBody, terraformConfig, instance)
+			rootBody.AppendNewline()
+
+			azure.CreateAzureInstances(rootBody, terraformConfig, instance)
+			rootBody.AppendNewline()
+
+			nodePublicIPs[instance], nodePrivateIPs[instance] = addressInterpolations(terraformConfig.Provider, instance)
+		case googleDefaults.Google:
+			google.CreateGoogleCloudInstances(rootBody, terraformConfig, terratestConfig, instance)
+			rootBody.AppendNewline()
+
+			nodePublicIPs[instance], nodePrivateIPs[instance] = addressInterpolations(terraformConfig.Provider, instance)
+		case harvesterDefaults.Harvester:
+			err := harvester.CreateHarvesterInstances(rootBody, terraformConfig, terratestConfig, instance)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+
+			rootBody.AppendNewline()
+
+			nodePublicIPs[instance], nodePrivateIPs[instance] = addressInterpolations(terraformConfig.Provider, instance)
+		case linodeDefaults.Linode:
+			err := linode.CreateLinodeNodeInstances(rootBody, terraformConfig, terratestConfig, instance)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+
+			rootBody.AppendNewline()
+
+			nodePublicIPs[instance], nodePrivateIPs[instance] = addressInterpolations(terraformConfig.Provider, instance)
+		default:
+			return nil, nil, nil, fmt.Errorf("imported clusters are not supported on the %s provider", terraformConfig.Provider)
 		}
 	}
 
-	return nodePublicIPs, nodePublicIPv6s, nodePrivateIPs
+	return nodePublicIPs, nodePublicIPv6s, nodePrivateIPs, nil
+}
+
+// addressInterpolations is a helper function that returns the interpolated public and private IP of the given instance.
+func addressInterpolations(provider, instance string) (string, string) {
+	publicIP, privateIP := topology.AddressExpressions(provider, instance)
+	return "${" + publicIP + "}", "${" + privateIP + "}"
 }
@@ -43,17 +43,20 @@ func SetImportedRKE2K3s(terraformConfig *config.TerraformConfig, terratestConfig
 	serverOneName := serverNodeNames[0]
 	windowsNodeName := terraformConfig.ResourcePrefix + `-` + windows
 
-	nodePublicIPs, nodePublicIPv6s, nodePrivateIPs := getProviderIPAddresses(terraformConfig, terratestConfig, rootBody, linuxNodeNames)
+	nodePublicIPs, nodePublicIPv6s, nodePrivateIPs, err := getProviderIPAddresses(terraformConfig, terratestConfig, rootBody, linuxNodeNames)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	token := namegen.AppendRandomString(general.Import)
 
-	if terraformConfig.AWSConfig.ClusterCIDR != "" && !terraformConfig.AWSConfig.IPv6AddressOnly {
+	if terraformConfig.Networking.IPFamily == config.DualStack {
 		err = resources.CreateDualStackRKE2K3SImportedCluster(rootBody, terraformConfig, terratestConfig, linuxNodeNames, serverNodeNames, agentNodeNames, nodePublicIPs, nodePrivateIPs, token)
-	} else if terraformConfig.AWSConfig.ClusterCIDR != "" && terraformConfig.AWSConfig.IPv6AddressOnly {
+	} else if terraformConfig.Networking.IPFamily == config.IPv6 {
 		err = resources.CreateIPv6RKE2K3SImportedCluster(rootBody, terraformConfig, terratestConfig, linuxNodeNames, serverNodeNames, agentNodeNames, nodePublicIPs, nodePublicIPv6s, nodePrivateIPs, token)
 	} else if terraformConfig.Proxy != nil && terraformConfig.Proxy.ProxyBastion != "" {
 		err = resources.CreateProxyRKE2K3SImportedCluster(rootBody, terraformConfig, terratestConfig, linuxNodeNames, serverNodeNames, agentNodeNames, nodePublicIPs, nodePrivateIPs, token)
-	} else if terraformConfig.AWSConfig.ClusterCIDR == "" {
+	} else {
 		err = resources.CreateRKE2K3SImportedCluster(rootBody, terraformConfig, terratestConfig, linuxNodeNames, serverNodeNames, agentNodeNames, nodePublicIPs, nodePrivateIPs, token)
 	}
 	if err != nil {
@@ -63,11 +66,23 @@ func SetImportedRKE2K3s(terraformConfig *config.TerraformConfig, terratestConfig
 	rootBody.AppendNewline()
 
 	if strings.Contains(terraformConfig.Module, clustertypes.WINDOWS) {
-		aws.CreateWindowsAWSInstances(rootBody, terraformConfig, terratestConfig, terraformConfig.ResourcePrefix)
+		if terraformConfig.Provider != awsDefaults.Aws {
+			return nil, nil, fmt.Errorf("imported Windows nodes are not supported on the %s provider", terraformConfig.Provider)
+		}
+
+		windowsVersion, err := customnodepools.WindowsVersion(terraformConfig, terratestConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		aws.CreateWindowsAWSInstances(rootBody, terraformConfig, terratestConfig, terraformConfig.ResourcePrefix, windowsVersion)
 		rootBody.AppendNewline()
 
 		windowsNodePublicDNS := fmt.Sprintf("${%s.%s.public_dns}", awsDefaults.AwsInstance, windowsNodeName)
-		resources.AddWindowsNodeToImportedCluster(rootBody, terraformConfig, terratestConfig, nodePrivateIPs[serverOneName], windowsNodePublicDNS, token)
+		err = resources.AddWindowsNodeToImportedCluster(rootBody, terraformConfig, terratestConfig, nodePrivateIPs[serverOneName], windowsNodePublicDNS, token, windowsVersion)
+		if err != nil {
+			return nil, nil, err
+		}
 
 		// Add the sleep command to wait for the Windows node to be ready
 		rootBody.AppendNewline()
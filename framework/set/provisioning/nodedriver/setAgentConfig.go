@@ -0,0 +1,146 @@
+package nodedriver
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/defaults/general"
+	"github.com/rancher/tfp-automation/framework/set/defaults/rancher2/clusters"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	appendTolerations            = "append_tolerations"
+	overrideAffinity             = "override_affinity"
+	overrideResourceRequirements = "override_resource_requirements"
+	schedulingCustomization      = "scheduling_customization"
+	priorityClass                = "priority_class"
+	podDisruptionBudget          = "pod_disruption_budget"
+
+	tolerationEffect   = "effect"
+	tolerationKey      = "key"
+	tolerationOperator = "operator"
+	tolerationSeconds  = "seconds"
+	valueAttribute     = "value"
+	cpuLimit           = "cpu_limit"
+	cpuRequest         = "cpu_request"
+	memoryLimit        = "memory_limit"
+	memoryRequest      = "memory_request"
+	preemptionPolicy   = "preemption_policy"
+	minAvailable       = "min_available"
+	maxUnavailable     = "max_unavailable"
+)
+
+// SetAgentConfig is a function that will set the agent env vars, the cluster level cloud credential and the cluster
+// and fleet agent deployment customizations of the rancher2_cluster_v2 resource in the main.tf file.
+func SetAgentConfig(terraformConfig *config.TerraformConfig, clusterBlockBody *hclwrite.Body) error {
+	if terraformConfig.CloudCredentialSecretName != "" {
+		clusterBlockBody.SetAttributeValue(clusters.CloudCredentialSecretName, cty.StringVal(terraformConfig.CloudCredentialSecretName))
+	}
+
+	for _, envVar := range terraformConfig.AgentEnvVars {
+		envVarBlockBody := clusterBlockBody.AppendNewBlock(clusters.AgentEnvVars, nil).Body()
+
+		envVarBlockBody.SetAttributeValue(general.ResourceName, cty.StringVal(envVar.Name))
+		envVarBlockBody.SetAttributeValue(valueAttribute, cty.StringVal(envVar.Value))
+	}
+
+	if terraformConfig.ClusterAgentCustomization != nil {
+		err := setAgentDeploymentCustomization(clusterBlockBody, clusters.ClusterAgentCustomization, terraformConfig.ClusterAgentCustomization)
+		if err != nil {
+			return err
+		}
+	}
+
+	if terraformConfig.FleetAgentCustomization != nil {
+		if terraformConfig.FleetAgentCustomization.SchedulingCustomization != nil {
+			return errors.New("scheduling customization is only supported for the cluster agent")
+		}
+
+		err := setAgentDeploymentCustomization(clusterBlockBody, clusters.FleetAgentCustomization, terraformConfig.FleetAgentCustomization)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setAgentDeploymentCustomization is a helper function that will set the given agent deployment customization block.
+func setAgentDeploymentCustomization(clusterBlockBody *hclwrite.Body, blockName string, customization *config.AgentDeploymentCustomization) error {
+	customizationBlockBody := clusterBlockBody.AppendNewBlock(blockName, nil).Body()
+
+	for _, toleration := range customization.AppendTolerations {
+		tolerationBlockBody := customizationBlockBody.AppendNewBlock(appendTolerations, nil).Body()
+
+		tolerationBlockBody.SetAttributeValue(tolerationKey, cty.StringVal(toleration.Key))
+
+		if toleration.Operator != "" {
+			tolerationBlockBody.SetAttributeValue(tolerationOperator, cty.StringVal(toleration.Operator))
+		}
+
+		if toleration.Value != "" {
+			tolerationBlockBody.SetAttributeValue(valueAttribute, cty.StringVal(toleration.Value))
+		}
+
+		if toleration.Effect != "" {
+			tolerationBlockBody.SetAttributeValue(tolerationEffect, cty.StringVal(toleration.Effect))
+		}
+
+		if toleration.Seconds > 0 {
+			tolerationBlockBody.SetAttributeValue(tolerationSeconds, cty.NumberIntVal(toleration.Seconds))
+		}
+	}
+
+	// The provider expects the affinity as a JSON string, so the YAML given in the config is converted here.
+	if len(customization.OverrideAffinity) > 0 {
+		affinity, err := json.Marshal(customization.OverrideAffinity)
+		if err != nil {
+			return err
+		}
+
+		customizationBlockBody.SetAttributeValue(overrideAffinity, cty.StringVal(string(affinity)))
+	}
+
+	if resources := customization.OverrideResourceRequirements; resources != nil {
+		resourcesBlockBody := customizationBlockBody.AppendNewBlock(overrideResourceRequirements, nil).Body()
+
+		setOptionalString(resourcesBlockBody, cpuLimit, resources.CPULimit)
+		setOptionalString(resourcesBlockBody, cpuRequest, resources.CPURequest)
+		setOptionalString(resourcesBlockBody, memoryLimit, resources.MemoryLimit)
+		setOptionalString(resourcesBlockBody, memoryRequest, resources.MemoryRequest)
+	}
+
+	if scheduling := customization.SchedulingCustomization; scheduling != nil {
+		schedulingBlockBody := customizationBlockBody.AppendNewBlock(schedulingCustomization, nil).Body()
+
+		if scheduling.PriorityClass != nil {
+			priorityClassBlockBody := schedulingBlockBody.AppendNewBlock(priorityClass, nil).Body()
+
+			priorityClassBlockBody.SetAttributeValue(valueAttribute, cty.NumberIntVal(scheduling.PriorityClass.Value))
+			setOptionalString(priorityClassBlockBody, preemptionPolicy, scheduling.PriorityClass.PreemptionPolicy)
+		}
+
+		if pdb := scheduling.PodDisruptionBudget; pdb != nil {
+			if pdb.MinAvailable != "" && pdb.MaxUnavailable != "" {
+				return errors.New("only one of minAvailable or maxUnavailable can be set for the pod disruption budget")
+			}
+
+			pdbBlockBody := schedulingBlockBody.AppendNewBlock(podDisruptionBudget, nil).Body()
+
+			setOptionalString(pdbBlockBody, minAvailable, pdb.MinAvailable)
+			setOptionalString(pdbBlockBody, maxUnavailable, pdb.MaxUnavailable)
+		}
+	}
+
+	return nil
+}
+
+// setOptionalString is a helper function that will set the given attribute only when a value is provided.
+func setOptionalString(blockBody *hclwrite.Body, name, attributeValue string) {
+	if attributeValue != "" {
+		blockBody.SetAttributeValue(name, cty.StringVal(attributeValue))
+	}
+}
@@ -0,0 +1,364 @@
+package nodedriver
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/framework/set/defaults/rancher2/clusters"
+	"gopkg.in/yaml.v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	controlPlaneRoleLabel = "rke.cattle.io/control-plane-role"
+	cloudProviderExternal = "cloud-provider=external"
+	cloudProviderSecret   = "azure-cloud-provider"
+	kubeSystemNamespace   = "kube-system"
+
+	harvesterCloudConfigPath = "/var/lib/rancher/rke2/etc/config-files/cloud-provider-config"
+	harvesterCloudConfigKey  = "cloud-provider-config"
+
+	// The Azure charts are served from the GitHub repositories, so they are pinned to a release tag.
+	azureCloudProviderVersion = "v1.31.0"
+	azureDiskCSIDriverVersion = "v1.30.3"
+)
+
+// cloudProvider holds the configurations that enable the cloud provider of a node driver cluster. Out-of-tree cloud
+// providers are deployed through the additional manifest, with the kubelet and control plane components set to use
+// an external cloud provider.
+type cloudProvider struct {
+	globalConfig       map[string]any
+	selectorConfigs    []config.MachineSelectorConfig
+	chartValues        map[string]any
+	additionalManifest []map[string]any
+}
+
+// newCloudProvider is a function that will build the cloud provider configurations of the given node driver module,
+// using the credentials of the provider the cluster is provisioned on.
+func newCloudProvider(terraformConfig *config.TerraformConfig) (*cloudProvider, error) {
+	switch terraformConfig.Module {
+	case modules.NodeDriverAWSRKE2:
+		return awsCloudProvider(terraformConfig)
+	case modules.NodeDriverAzureRKE2:
+		return azureCloudProvider(terraformConfig)
+	case modules.NodeDriverHarvesterRKE2:
+		return harvesterCloudProvider(terraformConfig), nil
+	case modules.NodeDriverVsphereRKE2:
+		return vsphereCloudProvider(terraformConfig), nil
+	default:
+		return nil, fmt.Errorf("cloud provider is not supported for module %s", terraformConfig.Module)
+	}
+}
+
+// awsCloudProvider is a helper function that will build the out-of-tree AWS cloud controller manager and the EBS CSI
+// driver configurations.
+func awsCloudProvider(terraformConfig *config.TerraformConfig) (*cloudProvider, error) {
+	cloudControllerValues := map[string]any{
+		"hostNetworking": true,
+		"nodeSelector":   map[string]any{"node-role.kubernetes.io/control-plane": "true"},
+		"args": []string{
+			"--cloud-provider=aws",
+			"--configure-cloud-routes=false",
+			"--cluster-name=" + terraformConfig.ResourcePrefix,
+		},
+	}
+
+	storageClass := map[string]any{
+		"apiVersion": "storage.k8s.io/v1",
+		"kind":       "StorageClass",
+		"metadata": map[string]any{
+			"name":        "ebs-gp3",
+			"annotations": map[string]any{"storageclass.kubernetes.io/is-default-class": "true"},
+		},
+		"provisioner":       "ebs.csi.aws.com",
+		"volumeBindingMode": "WaitForFirstConsumer",
+		"parameters":        map[string]any{"type": "gp3"},
+	}
+
+	cloudControllerChart, err := helmChart("aws-cloud-controller-manager", "https://kubernetes.github.io/cloud-provider-aws", cloudControllerValues)
+	if err != nil {
+		return nil, err
+	}
+
+	csiDriverChart, err := helmChart("aws-ebs-csi-driver", "https://kubernetes-sigs.github.io/aws-ebs-csi-driver", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cloudProvider{
+		globalConfig:       map[string]any{"cloud-provider-name": "aws"},
+		selectorConfigs:    externalCloudProviderConfigs(),
+		additionalManifest: []map[string]any{cloudControllerChart, csiDriverChart, storageClass},
+	}, nil
+}
+
+// azureCloudProvider is a helper function that will build the out-of-tree Azure cloud controller manager and the Azure
+// Disk CSI driver configurations. Both read the cloud config from the same secret in the kube-system namespace.
+func azureCloudProvider(terraformConfig *config.TerraformConfig) (*cloudProvider, error) {
+	azureConfig := terraformConfig.AzureConfig
+	azureCredentials := terraformConfig.AzureCredentials
+
+	cloudConfig, err := json.Marshal(map[string]any{
+		"cloud":               azureCredentials.Environment,
+		"tenantId":            azureCredentials.TenantID,
+		"subscriptionId":      azureCredentials.SubscriptionID,
+		"aadClientId":         azureCredentials.ClientID,
+		"aadClientSecret":     azureCredentials.ClientSecret,
+		"resourceGroup":       azureConfig.ResourceGroup,
+		"location":            azureConfig.Location,
+		"vnetName":            azureConfig.Vnet,
+		"vnetResourceGroup":   azureConfig.ResourceGroup,
+		"subnetName":          azureConfig.Subnet,
+		"securityGroupName":   azureConfig.NSG,
+		"loadBalancerSku":     "standard",
+		"useInstanceMetadata": true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	secret := map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata":   map[string]any{"name": cloudProviderSecret, "namespace": kubeSystemNamespace},
+		"type":       "Opaque",
+		"stringData": map[string]any{"cloud-config": string(cloudConfig)},
+	}
+
+	cloudControllerValues := map[string]any{
+		"infra": map[string]any{"clusterName": terraformConfig.ResourcePrefix},
+		"cloudControllerManager": map[string]any{
+			"cloudConfigSecretName": cloudProviderSecret,
+			"configureCloudRoutes":  false,
+		},
+	}
+
+	diskValues := map[string]any{
+		"controller": map[string]any{"cloudConfigSecretName": cloudProviderSecret},
+		"node":       map[string]any{"cloudConfigSecretName": cloudProviderSecret},
+	}
+
+	storageClass := map[string]any{
+		"apiVersion": "storage.k8s.io/v1",
+		"kind":       "StorageClass",
+		"metadata": map[string]any{
+			"name":        "azuredisk",
+			"annotations": map[string]any{"storageclass.kubernetes.io/is-default-class": "true"},
+		},
+		"provisioner":       "disk.csi.azure.com",
+		"volumeBindingMode": "WaitForFirstConsumer",
+		"parameters":        map[string]any{"skuName": "StandardSSD_LRS"},
+	}
+
+	cloudControllerChart, err := helmChart("cloud-provider-azure",
+		fmt.Sprintf("https://raw.githubusercontent.com/kubernetes-sigs/cloud-provider-azure/%s/helm/repo", azureCloudProviderVersion),
+		cloudControllerValues)
+	if err != nil {
+		return nil, err
+	}
+
+	diskChart, err := helmChart("azuredisk-csi-driver",
+		fmt.Sprintf("https://raw.githubusercontent.com/kubernetes-sigs/azuredisk-csi-driver/%s/charts", azureDiskCSIDriverVersion), diskValues)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cloudProvider{
+		selectorConfigs:    externalCloudProviderConfigs(),
+		additionalManifest: []map[string]any{secret, cloudControllerChart, diskChart, storageClass},
+	}, nil
+}
+
+// harvesterCloudProvider is a helper function that will build the Harvester cloud provider and CSI driver configurations.
+// Both are shipped with RKE2, and authenticate against Harvester with the kubeconfig of the Harvester credentials, which
+// is written to the nodes through a machine selector config.
+func harvesterCloudProvider(terraformConfig *config.TerraformConfig) *cloudProvider {
+	return &cloudProvider{
+		globalConfig: map[string]any{"cloud-provider-name": "harvester"},
+		selectorConfigs: []config.MachineSelectorConfig{
+			{Config: map[string]any{harvesterCloudConfigKey: terraformConfig.HarvesterCredentials.KubeconfigContent}},
+		},
+		chartValues: map[string]any{
+			"harvester-cloud-provider": map[string]any{
+				"clusterName":     terraformConfig.ResourcePrefix,
+				"cloudConfigPath": harvesterCloudConfigPath,
+			},
+			"harvester-csi-driver": map[string]any{
+				"cloudConfig": map[string]any{"hostPath": path.Dir(harvesterCloudConfigPath)},
+			},
+		},
+	}
+}
+
+// vsphereCloudProvider is a helper function that will build the vSphere CPI and CSI chart values. Both charts are
+// shipped with RKE2, and generate their credential secrets from the vSphere credentials.
+func vsphereCloudProvider(terraformConfig *config.TerraformConfig) *cloudProvider {
+	vsphereCredentials := terraformConfig.VsphereCredentials
+
+	port := vsphereCredentials.VcenterPort
+	if port == "" {
+		port = "443"
+	}
+
+	vCenter := func() map[string]any {
+		return map[string]any{
+			"host":         vsphereCredentials.Vcenter,
+			"port":         port,
+			"insecureFlag": true,
+			"datacenters":  path.Base(terraformConfig.VsphereConfig.DataCenter),
+			"username":     vsphereCredentials.Username,
+			"password":     vsphereCredentials.Password,
+		}
+	}
+
+	cpiVCenter := vCenter()
+	cpiVCenter["credentialsSecret"] = map[string]any{"generate": true}
+
+	csiVCenter := vCenter()
+	csiVCenter["clusterId"] = terraformConfig.ResourcePrefix
+	csiVCenter["configSecret"] = map[string]any{"generate": true}
+
+	return &cloudProvider{
+		globalConfig: map[string]any{"cloud-provider-name": "rancher-vsphere"},
+		chartValues: map[string]any{
+			"rancher-vsphere-cpi": map[string]any{"vCenter": cpiVCenter},
+			"rancher-vsphere-csi": map[string]any{
+				"vCenter":      csiVCenter,
+				"storageClass": map[string]any{"enabled": true, "isDefault": true},
+			},
+		},
+	}
+}
+
+// externalCloudProviderConfigs is a helper function that will build the machine selector configs setting the kubelet of
+// all nodes, and the control plane components of the control plane nodes, to use an external cloud provider.
+func externalCloudProviderConfigs() []config.MachineSelectorConfig {
+	return []config.MachineSelectorConfig{
+		{
+			Config: map[string]any{"kubelet-arg": []string{cloudProviderExternal}},
+		},
+		{
+			Config: map[string]any{
+				"disable-cloud-controller":    true,
+				"kube-apiserver-arg":          []string{cloudProviderExternal},
+				"kube-controller-manager-arg": []string{cloudProviderExternal},
+				"kubelet-arg":                 []string{cloudProviderExternal},
+			},
+			MachineLabelSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: controlPlaneRoleLabel, Operator: metav1.LabelSelectorOpIn, Values: []string{"true"}},
+				},
+			},
+		},
+	}
+}
+
+// helmChart is a helper function that will build a HelmChart resource deployed by the RKE2 helm controller in the
+// kube-system namespace.
+func helmChart(name, repo string, values map[string]any) (map[string]any, error) {
+	spec := map[string]any{
+		"chart":           name,
+		"repo":            repo,
+		"targetNamespace": kubeSystemNamespace,
+		"bootstrap":       true,
+	}
+
+	if values != nil {
+		valuesContent, err := yaml.Marshal(values)
+		if err != nil {
+			return nil, err
+		}
+
+		spec["valuesContent"] = string(valuesContent)
+	}
+
+	return map[string]any{
+		"apiVersion": "helm.cattle.io/v1",
+		"kind":       "HelmChart",
+		"metadata":   map[string]any{"name": name, "namespace": kubeSystemNamespace},
+		"spec":       spec,
+	}, nil
+}
+
+// setCloudProvider is a function that will set the additional manifest of the cloud provider in the main.tf file. The
+// machine global and selector configs are set along with the rest of the RKE configurations. A manifest holding a Secret
+// carries the provider credentials, so it is marked sensitive.
+func setCloudProvider(rkeConfigBlockBody *hclwrite.Body, cloudProvider *cloudProvider) error {
+	if len(cloudProvider.additionalManifest) == 0 {
+		return nil
+	}
+
+	manifests := []string{}
+	hasSecret := false
+	for _, manifest := range cloudProvider.additionalManifest {
+		manifestYAML, err := yaml.Marshal(manifest)
+		if err != nil {
+			return err
+		}
+
+		manifests = append(manifests, strings.TrimSpace(string(manifestYAML)))
+		hasSecret = hasSecret || manifest["kind"] == "Secret"
+	}
+
+	additionalManifest := strings.Join(manifests, "\n---\n")
+	if hasSecret {
+		rkeConfigBlockBody.SetAttributeRaw(clusters.AdditionalManifest, sensitiveHeredoc(additionalManifest))
+	} else {
+		rkeConfigBlockBody.SetAttributeRaw(clusters.AdditionalManifest, heredoc(additionalManifest))
+	}
+
+	return nil
+}
+
+// mergeChartValues is a helper function that will merge the generated cloud provider chart values with the chart values
+// of the terraform config. Charts set in the terraform config take precedence over the generated ones.
+func mergeChartValues(chartValues map[string]any, userChartValues string) (string, error) {
+	if len(chartValues) == 0 {
+		return userChartValues, nil
+	}
+
+	merged := map[string]any{}
+	for chart, values := range chartValues {
+		merged[chart] = values
+	}
+
+	if userChartValues != "" {
+		userValues := map[string]any{}
+		err := yaml.Unmarshal([]byte(userChartValues), &userValues)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse chart values: %w", err)
+		}
+
+		for chart, values := range userValues {
+			merged[chart] = values
+		}
+	}
+
+	mergedYAML, err := yaml.Marshal(merged)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(mergedYAML)), nil
+}
+
+// heredoc is a helper function that will wrap the given content in a heredoc expression.
+func heredoc(content string) hclwrite.Tokens {
+	return hclwrite.TokensForTraversal(hcl.Traversal{
+		hcl.TraverseRoot{Name: "<<EOF\n" + content + "\nEOF"},
+	})
+}
+
+// sensitiveHeredoc is a helper function that will wrap the given content in a heredoc expression marked as sensitive, so
+// Terraform redacts it from the plan and apply output.
+func sensitiveHeredoc(content string) hclwrite.Tokens {
+	return hclwrite.TokensForTraversal(hcl.Traversal{
+		hcl.TraverseRoot{Name: "sensitive(<<EOF\n" + content + "\nEOF\n)"},
+	})
+}
@@ -30,5 +30,10 @@ func setClusterConfig(rootBody *hclwrite.Body, terraformConfig *config.Terraform
 
 	clusterBlockBody.SetAttributeValue(clusters.DefaultClusterRoleForProjectMembers, cty.StringVal(terraformConfig.DefaultClusterRoleForProjectMembers))
 
+	err := SetAgentConfig(terraformConfig, clusterBlockBody)
+	if err != nil {
+		return nil, err
+	}
+
 	return clusterBlockBody, nil
 }
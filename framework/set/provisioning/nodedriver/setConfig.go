@@ -121,11 +121,17 @@ func SetRKE2K3s(terraformConfig *config.TerraformConfig, terratestConfig *config
 	case modules.NodeDriverGoogleK3S, modules.NodeDriverGoogleRKE2:
 		google.SetGoogleRKE2K3SMachineConfig(machineConfigBlockBody, terraformConfig)
 	case modules.NodeDriverHarvesterRKE2, modules.NodeDriverHarvesterK3S:
-		harvester.SetHarvesterRKE2K3SMachineConfig(machineConfigBlockBody, terraformConfig)
+		err = harvester.SetHarvesterRKE2K3SMachineConfig(machineConfigBlockBody, terraformConfig)
+		if err != nil {
+			return nil, nil, err
+		}
 	case modules.NodeDriverLinodeRKE2, modules.NodeDriverLinodeK3S:
 		linode.SetLinodeRKE2K3SMachineConfig(machineConfigBlockBody, terraformConfig)
 	case modules.NodeDriverVsphereRKE2, modules.NodeDriverVsphereK3S:
-		vsphere.SetVsphereRKE2K3SMachineConfig(machineConfigBlockBody, terraformConfig)
+		err = vsphere.SetVsphereRKE2K3SMachineConfig(machineConfigBlockBody, terraformConfig)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	rootBody.AppendNewline()
@@ -168,12 +174,10 @@ func SetRKE2K3s(terraformConfig *config.TerraformConfig, terratestConfig *config
 		}
 	}
 
-	if terraformConfig.AWSConfig.Networking != nil {
-		if terraformConfig.AWSConfig.Networking.StackPreference != "" {
-			err = SetNetworkingConfig(rkeConfigBlockBody, terraformConfig)
-			if err != nil {
-				return nil, nil, err
-			}
+	if terraformConfig.Networking != nil && terraformConfig.Networking.StackPreference != "" {
+		err = SetNetworkingConfig(rkeConfigBlockBody, terraformConfig)
+		if err != nil {
+			return nil, nil, err
 		}
 	}
 
@@ -12,7 +12,7 @@ func SetNetworkingConfig(rkeConfigBlockBody *hclwrite.Body, terraformConfig *con
 	networkingConfigBlock := rkeConfigBlockBody.AppendNewBlock(clusters.Networking, nil)
 	networkingConfigBlockBody := networkingConfigBlock.Body()
 
-	networkingConfigBlockBody.SetAttributeValue(clusters.StackPreference, cty.StringVal(terraformConfig.AWSConfig.Networking.StackPreference))
+	networkingConfigBlockBody.SetAttributeValue(clusters.StackPreference, cty.StringVal(terraformConfig.Networking.StackPreference))
 
 	return nil
 }
@@ -1,13 +1,25 @@
 package nodedriver
 
 import (
+	"reflect"
 	"strings"
 
-	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/clustertypes"
 	"github.com/rancher/tfp-automation/framework/set/defaults/rancher2/clusters"
+	"github.com/zclconf/go-cty/cty"
+	"gopkg.in/yaml.v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	machineLabelSelector = "machine_label_selector"
+	matchLabels          = "match_labels"
+	matchExpressions     = "match_expressions"
+	selectorKey          = "key"
+	selectorOperator     = "operator"
+	selectorValues       = "values"
 )
 
 // setRKEConfig is a function that will set the RKE configurations in the main.tf file.
@@ -15,36 +27,219 @@ func setRKEConfig(clusterBlockBody *hclwrite.Body, terraformConfig *config.Terra
 	rkeConfigBlock := clusterBlockBody.AppendNewBlock(clusters.RkeConfig, nil)
 	rkeConfigBlockBody := rkeConfigBlock.Body()
 
-	if terraformConfig.ChartValues != "" {
-		chartValues := hclwrite.TokensForTraversal(hcl.Traversal{
-			hcl.TraverseRoot{Name: "<<EOF\n" + terraformConfig.ChartValues + "\nEOF"},
-		})
+	var generatedGlobalConfig map[string]any
+	var generatedSelectorConfigs []config.MachineSelectorConfig
+	chartValues := terraformConfig.ChartValues
+
+	if terraformConfig.EnableCloudProvider {
+		cloudProvider, err := newCloudProvider(terraformConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		generatedGlobalConfig = cloudProvider.globalConfig
+		generatedSelectorConfigs = cloudProvider.selectorConfigs
+
+		chartValues, err = mergeChartValues(cloudProvider.chartValues, chartValues)
+		if err != nil {
+			return nil, err
+		}
+
+		err = setCloudProvider(rkeConfigBlockBody, cloudProvider)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if chartValues != "" {
+		rkeConfigBlockBody.SetAttributeRaw(clusters.ChartValues, heredoc(chartValues))
+	}
+
+	globalConfig, err := machineGlobalConfig(terraformConfig, generatedGlobalConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	rkeConfigBlockBody.SetAttributeRaw(clusters.MachineGlobalConfig, globalConfig)
 
-		rkeConfigBlockBody.SetAttributeRaw(clusters.ChartValues, chartValues)
+	err = setMachineSelectorConfigs(rkeConfigBlockBody, terraformConfig, generatedSelectorConfigs)
+	if err != nil {
+		return nil, err
 	}
 
-	var cidrValues hclwrite.Tokens
+	return rkeConfigBlockBody, nil
+}
 
-	if terraformConfig.AWSConfig.EnablePrimaryIPv6 {
-		if strings.Contains(terraformConfig.Module, clustertypes.K3S) {
-			cidrValues = hclwrite.TokensForTraversal(hcl.Traversal{
-				hcl.TraverseRoot{Name: "<<EOF\ncluster-cidr: " + terraformConfig.AWSConfig.ClusterCIDR + "\nservice-cidr: " +
-					terraformConfig.AWSConfig.ServiceCIDR + "\nflannel-ipv6-masq: true" + "\ningress-controller: \"traefik\"\nEOF"},
-			})
+// MachineGlobalConfig is a function that returns the machine global config of the cluster. The generated defaults are
+// deep-merged with the machine global config of the terraform config, which takes precedence.
+func MachineGlobalConfig(terraformConfig *config.TerraformConfig) (hclwrite.Tokens, error) {
+	return machineGlobalConfig(terraformConfig, nil)
+}
+
+// SetMachineSelectorConfigs is a function that will set the machine selector configurations of the terraform config in
+// the main.tf file.
+func SetMachineSelectorConfigs(rkeConfigBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) error {
+	return setMachineSelectorConfigs(rkeConfigBlockBody, terraformConfig, nil)
+}
+
+// machineGlobalConfig is a helper function that deep-merges the default machine global config, the given generated
+// config and the machine global config of the terraform config, in that order of precedence.
+func machineGlobalConfig(terraformConfig *config.TerraformConfig, generated map[string]any) (hclwrite.Tokens, error) {
+	globalConfig := defaultMachineGlobalConfig(terraformConfig)
+
+	for _, override := range []map[string]any{generated, terraformConfig.MachineGlobalConfig} {
+		globalConfig = deepMerge(globalConfig, override)
+	}
+
+	globalConfigYAML, err := yaml.Marshal(globalConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return heredoc(strings.TrimSpace(string(globalConfigYAML))), nil
+}
+
+// defaultMachineGlobalConfig is a helper function that returns the generated machine global config of the cluster, holding
+// the CNI and kube-proxy settings, and the cluster and service CIDRs of the networking config whenever the cluster is not
+// IPv4 only.
+func defaultMachineGlobalConfig(terraformConfig *config.TerraformConfig) map[string]any {
+	globalConfig := map[string]any{"ingress-controller": "traefik"}
+
+	cni := terraformConfig.CNI
+	if cni == "" {
+		cni = terraformConfig.NetworkPlugin
+	}
+
+	// K3s does not take a CNI option, flannel is replaced through its own flags.
+	if cni != "" && !strings.Contains(terraformConfig.Module, clustertypes.K3S) {
+		globalConfig["cni"] = cni
+	}
+
+	if terraformConfig.DisableKubeProxy == "true" {
+		globalConfig["disable-kube-proxy"] = true
+	}
+
+	networking := terraformConfig.Networking
+	if networking == nil || networking.IPFamily == config.IPv4 || networking.ClusterCIDR == "" {
+		return globalConfig
+	}
+
+	globalConfig["cluster-cidr"] = networking.ClusterCIDR
+	globalConfig["service-cidr"] = networking.ServiceCIDR
+
+	if strings.Contains(terraformConfig.Module, clustertypes.K3S) {
+		globalConfig["flannel-ipv6-masq"] = true
+	} else if networking.IPFamily == config.IPv6 || (strings.Contains(terraformConfig.Module, clustertypes.RKE2) && isIPv6CIDRFirst(networking.ClusterCIDR)) {
+		for _, component := range []string{"kube-apiserver-arg", "kube-controller-manager-arg", "kube-scheduler-arg"} {
+			globalConfig[component] = []string{"bind-address=::"}
+		}
+	}
+
+	return globalConfig
+}
+
+// setMachineSelectorConfigs is a helper function that will set the given generated machine selector configs, along with the
+// machine selector configs of the terraform config, in the main.tf file. A config of the terraform config selecting the
+// same machines as a generated one is deep-merged into it, taking precedence.
+func setMachineSelectorConfigs(rkeConfigBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig, generated []config.MachineSelectorConfig) error {
+	selectorConfigs := append([]config.MachineSelectorConfig{}, generated...)
+
+	for _, override := range terraformConfig.MachineSelectorConfig {
+		merged := false
+		for i, selectorConfig := range selectorConfigs {
+			if !reflect.DeepEqual(selectorConfig.MachineLabelSelector, override.MachineLabelSelector) {
+				continue
+			}
+
+			selectorConfigs[i].Config = deepMerge(selectorConfig.Config, override.Config)
+			merged = true
+
+			break
+		}
+
+		if !merged {
+			selectorConfigs = append(selectorConfigs, override)
+		}
+	}
+
+	for _, selectorConfig := range selectorConfigs {
+		machineConfigYAML, err := yaml.Marshal(selectorConfig.Config)
+		if err != nil {
+			return err
+		}
+
+		machineSelectorBlockBody := rkeConfigBlockBody.AppendNewBlock(clusters.MachineSelectorConfig, nil).Body()
+
+		// The Harvester cloud provider config holds the kubeconfig of the Harvester credentials.
+		_, hasCloudConfig := selectorConfig.Config[harvesterCloudConfigKey]
+		if hasCloudConfig {
+			machineSelectorBlockBody.SetAttributeRaw(clusters.Config, sensitiveHeredoc(strings.TrimSpace(string(machineConfigYAML))))
 		} else {
-			cidrValues = hclwrite.TokensForTraversal(hcl.Traversal{
-				hcl.TraverseRoot{Name: "<<EOF\ncluster-cidr: " + terraformConfig.AWSConfig.ClusterCIDR + "\nservice-cidr: " +
-					terraformConfig.AWSConfig.ServiceCIDR + "\ningress-controller: \"traefik\"\nEOF"},
-			})
+			machineSelectorBlockBody.SetAttributeRaw(clusters.Config, heredoc(strings.TrimSpace(string(machineConfigYAML))))
 		}
 
-	} else {
-		cidrValues = hclwrite.TokensForTraversal(hcl.Traversal{
-			hcl.TraverseRoot{Name: "<<EOF\ningress-controller: \"traefik\"\nEOF"},
-		})
+		if selectorConfig.MachineLabelSelector != nil {
+			setMachineLabelSelector(machineSelectorBlockBody, selectorConfig.MachineLabelSelector)
+		}
 	}
 
-	rkeConfigBlockBody.SetAttributeRaw(clusters.MachineGlobalConfig, cidrValues)
+	return nil
+}
 
-	return rkeConfigBlockBody, nil
+// deepMerge is a helper function that will return a copy of dst with src merged into it. Nested maps are merged key by
+// key, while any other value of src replaces the one of dst, including empty values such as false, 0 or "".
+func deepMerge(dst, src map[string]any) map[string]any {
+	merged := make(map[string]any, len(dst)+len(src))
+	for key, value := range dst {
+		merged[key] = value
+	}
+
+	for key, value := range src {
+		srcMap, srcIsMap := value.(map[string]any)
+		dstMap, dstIsMap := merged[key].(map[string]any)
+
+		if srcIsMap && dstIsMap {
+			merged[key] = deepMerge(dstMap, srcMap)
+		} else {
+			merged[key] = value
+		}
+	}
+
+	return merged
+}
+
+// setMachineLabelSelector is a helper function that will set the machine label selector of a machine selector config in
+// the main.tf file.
+func setMachineLabelSelector(machineSelectorBlockBody *hclwrite.Body, labelSelector *metav1.LabelSelector) {
+	labelSelectorBlockBody := machineSelectorBlockBody.AppendNewBlock(machineLabelSelector, nil).Body()
+
+	if len(labelSelector.MatchLabels) > 0 {
+		labels := map[string]cty.Value{}
+		for key, value := range labelSelector.MatchLabels {
+			labels[key] = cty.StringVal(value)
+		}
+
+		labelSelectorBlockBody.SetAttributeValue(matchLabels, cty.MapVal(labels))
+	}
+
+	for _, expression := range labelSelector.MatchExpressions {
+		matchExpressionsBlockBody := labelSelectorBlockBody.AppendNewBlock(matchExpressions, nil).Body()
+		matchExpressionsBlockBody.SetAttributeValue(selectorKey, cty.StringVal(expression.Key))
+		matchExpressionsBlockBody.SetAttributeValue(selectorOperator, cty.StringVal(string(expression.Operator)))
+
+		if len(expression.Values) > 0 {
+			values := []cty.Value{}
+			for _, value := range expression.Values {
+				values = append(values, cty.StringVal(value))
+			}
+
+			matchExpressionsBlockBody.SetAttributeValue(selectorValues, cty.ListVal(values))
+		}
+	}
+}
+
+// isIPv6CIDRFirst is a helper function that reports whether the first of the given comma separated CIDRs is an IPv6 one.
+func isIPv6CIDRFirst(clusterCIDR string) bool {
+	firstCIDR := strings.TrimSpace(strings.Split(clusterCIDR, ",")[0])
+	return strings.Contains(firstCIDR, ":")
 }
@@ -31,6 +31,13 @@ func SetAWSRKE2K3SMachineConfig(machineConfigBlockBody *hclwrite.Body, terraform
 	awsConfigBlockBody.SetAttributeValue(amazon.VPCID, cty.StringVal(terraformConfig.AWSConfig.AWSVpcID))
 	awsConfigBlockBody.SetAttributeValue(amazon.Zone, cty.StringVal(terraformConfig.AWSConfig.AWSZoneLetter))
 
+	// The AWS cloud provider discovers the instances of the cluster through the cluster tag, and manages the load
+	// balancers and volumes with the permissions of the instance profile.
+	if terraformConfig.EnableCloudProvider {
+		awsConfigBlockBody.SetAttributeValue(amazon.IAMInstanceProfile, cty.StringVal(terraformConfig.AWSConfig.IAMInstanceProfile))
+		awsConfigBlockBody.SetAttributeValue(amazon.Tags, cty.StringVal("kubernetes.io/cluster/"+terraformConfig.ResourcePrefix+",owned"))
+	}
+
 	if terraformConfig.AWSConfig.EnablePrimaryIPv6 {
 		awsConfigBlockBody.SetAttributeValue(amazon.EnablePrimaryIPv6, cty.BoolVal(true))
 		awsConfigBlockBody.SetAttributeValue(amazon.HTTPProtocolIPv6, cty.StringVal(terraformConfig.AWSConfig.HTTPProtocolIPv6))
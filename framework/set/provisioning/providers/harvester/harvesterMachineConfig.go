@@ -1,6 +1,8 @@
 package harvester
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
@@ -9,21 +11,57 @@ import (
 )
 
 // SetHarvesterRKE2K3SMachineConfig is a helper function that will set the Harvester RKE2/K3S terraform machine configurations in the main.tf file.
-func SetHarvesterRKE2K3SMachineConfig(machineConfigBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+func SetHarvesterRKE2K3SMachineConfig(machineConfigBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) error {
+	// The node driver only takes the cloud-init content, and does not support PCI passthrough.
+	switch {
+	case terraformConfig.HarvesterConfig.UserDataSecretName != "" || terraformConfig.HarvesterConfig.NetworkDataSecretName != "":
+		return fmt.Errorf("harvesterConfig.userDataSecretName and networkDataSecretName are not supported by the Harvester node driver, use userData and networkData instead")
+	case len(terraformConfig.HarvesterConfig.PCIDevices) > 0:
+		return fmt.Errorf("harvesterConfig.pciDevices is not supported by the Harvester node driver")
+	}
+
 	harvesterConfigBlock := machineConfigBlockBody.AppendNewBlock(harvester.HarvesterConfig, nil)
 	harvesterConfigBlockBody := harvesterConfigBlock.Body()
 
-	harvesterConfigBlockBody.SetAttributeRaw(harvester.NetworkInfo, constructNetworkInfo(terraformConfig.HarvesterConfig.NetworkNames))
-	harvesterConfigBlockBody.SetAttributeRaw(harvester.DiskInfo, constructDiskInfo(terraformConfig.HarvesterConfig.ImageName, terraformConfig.HarvesterConfig.DiskSize))
+	networkInfo, err := constructNetworkInfo(terraformConfig.HarvesterConfig.NetworkNames)
+	if err != nil {
+		return err
+	}
+
+	harvesterConfigBlockBody.SetAttributeRaw(harvester.NetworkInfo, networkInfo)
+
+	diskInfo, err := constructDiskInfo(terraformConfig)
+	if err != nil {
+		return err
+	}
+
+	harvesterConfigBlockBody.SetAttributeRaw(harvester.DiskInfo, diskInfo)
 
 	if terraformConfig.HarvesterConfig.UserData == "" {
 		harvesterConfigBlockBody.SetAttributeRaw(harvester.UserData, hclwrite.TokensForTraversal(hcl.Traversal{
 			hcl.TraverseRoot{Name: "<<EOT\n#cloud-config\npackage_update: true\npackages:\n  - qemu-guest-agent\nruncmd:\n  - - systemctl\n    - enable\n    - '--now'\n    - qemu-guest-agent.service\nEOT"},
 		}))
+	} else {
+		harvesterConfigBlockBody.SetAttributeValue(harvester.UserData, cty.StringVal(terraformConfig.HarvesterConfig.UserData))
+	}
+
+	if terraformConfig.HarvesterConfig.NetworkData != "" {
+		harvesterConfigBlockBody.SetAttributeValue(harvester.NetworkData, cty.StringVal(terraformConfig.HarvesterConfig.NetworkData))
+	}
+
+	vmAffinity, err := constructVMAffinity(terraformConfig)
+	if err != nil {
+		return err
+	}
+
+	if vmAffinity != "" {
+		harvesterConfigBlockBody.SetAttributeValue(harvester.VMAffinity, cty.StringVal(vmAffinity))
 	}
 
 	harvesterConfigBlockBody.SetAttributeValue(harvester.CPUCount, cty.StringVal(terraformConfig.HarvesterConfig.CPUCount))
 	harvesterConfigBlockBody.SetAttributeValue(harvester.MemorySize, cty.StringVal(terraformConfig.HarvesterConfig.MemorySize))
 	harvesterConfigBlockBody.SetAttributeValue(harvester.SSHUser, cty.StringVal(terraformConfig.HarvesterConfig.SSHUser))
 	harvesterConfigBlockBody.SetAttributeValue(harvester.VMNamespace, cty.StringVal(terraformConfig.HarvesterConfig.VMNamespace))
+
+	return nil
 }
@@ -1,6 +1,12 @@
 package harvester
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
@@ -10,6 +16,14 @@ import (
 	"github.com/zclconf/go-cty/cty"
 )
 
+type diskInfo struct {
+	ImageName        string `json:"imageName,omitempty"`
+	StorageClassName string `json:"storageClassName,omitempty"`
+	Size             int    `json:"size"`
+	BootOrder        int    `json:"bootOrder,omitempty"`
+	Bus              string `json:"bus,omitempty"`
+}
+
 // SetHarvesterCredentialProvider is a helper function that will set the Harvester cloud provider in main.tf
 func SetHarvesterCredentialProvider(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
 	cloudCredBlock := rootBody.AppendNewBlock(general.Resource, []string{rancher2.CloudCredential, terraformConfig.ResourcePrefix})
@@ -25,7 +39,13 @@ func SetHarvesterCredentialProvider(rootBody *hclwrite.Body, terraformConfig *co
 	harvesterCredBlockBody.SetAttributeValue(harvester.KubeconfigContent, cty.StringVal(terraformConfig.HarvesterCredentials.KubeconfigContent))
 }
 
-func constructNetworkInfo(networkNames []string) hclwrite.Tokens {
+// constructNetworkInfo is a helper function that will build the network_info heredoc of the machine config, attaching
+// the VMs to every network of the config.
+func constructNetworkInfo(networkNames []string) (hclwrite.Tokens, error) {
+	if len(networkNames) == 0 {
+		return nil, fmt.Errorf("terraformConfig.HarvesterConfig.networkNames must be set")
+	}
+
 	var netString string
 	for _, net := range networkNames {
 		netString += "{\n\t\t\"networkName\": \"" + net + "\"\n\t},"
@@ -34,16 +54,94 @@ func constructNetworkInfo(networkNames []string) hclwrite.Tokens {
 
 	return hclwrite.TokensForTraversal(hcl.Traversal{
 		hcl.TraverseRoot{Name: "<<EOF\n{\n\t\"interfaces\": [" + netString + "]\n}" + "\nEOF"},
-	})
+	}), nil
 }
 
-func constructDiskInfo(imageName, diskSize string) hclwrite.Tokens {
-	var diskString = "{\n\t\t\"imageName\": \"" + imageName + "\",\n\t"
-	diskString += "\t\"size\": " + diskSize + ",\n\t"
-	diskString += "\t\"bootOrder\": 1 \n\t"
-	diskString += "}"
+// constructDiskInfo is a helper function that will build the disk_info heredoc of the machine config. The root disk is
+// created from the image of the config and booted first, followed by the additional disks of the config.
+func constructDiskInfo(terraformConfig *config.TerraformConfig) (hclwrite.Tokens, error) {
+	rootDiskSize, err := strconv.Atoi(terraformConfig.HarvesterConfig.DiskSize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid harvesterConfig.diskSize value: %s", terraformConfig.HarvesterConfig.DiskSize)
+	}
+
+	disks := []diskInfo{
+		{
+			ImageName: terraformConfig.HarvesterConfig.ImageName,
+			Size:      rootDiskSize,
+			BootOrder: 1,
+		},
+	}
+
+	for _, disk := range terraformConfig.HarvesterConfig.Disks {
+		size, err := strconv.Atoi(disk.Size)
+		if err != nil {
+			return nil, fmt.Errorf("invalid size value %s of the %s disk", disk.Size, disk.Name)
+		}
+
+		disks = append(disks, diskInfo{
+			StorageClassName: disk.StorageClassName,
+			Size:             size,
+			Bus:              disk.Bus,
+		})
+	}
+
+	diskJSON, err := json.MarshalIndent(map[string][]diskInfo{"disks": disks}, "", "\t")
+	if err != nil {
+		return nil, err
+	}
 
 	return hclwrite.TokensForTraversal(hcl.Traversal{
-		hcl.TraverseRoot{Name: "<<EOF\n{\n\t\"disks\": [" + diskString + "]\n}" + "\nEOF"},
-	})
+		hcl.TraverseRoot{Name: "<<EOF\n" + string(diskJSON) + "\nEOF"},
+	}), nil
+}
+
+// constructVMAffinity is a helper function that will build the base64 encoded vm_affinity of the machine config. The
+// vmAffinity of the config is used as is, otherwise the nodeSelector of the config is turned into a required node
+// affinity. An empty string is returned when neither is set.
+func constructVMAffinity(terraformConfig *config.TerraformConfig) (string, error) {
+	if terraformConfig.HarvesterConfig.VMAffinity != "" {
+		if !json.Valid([]byte(terraformConfig.HarvesterConfig.VMAffinity)) {
+			return "", fmt.Errorf("harvesterConfig.vmAffinity must be a JSON encoded Kubernetes affinity")
+		}
+
+		return base64.StdEncoding.EncodeToString([]byte(terraformConfig.HarvesterConfig.VMAffinity)), nil
+	}
+
+	if len(terraformConfig.HarvesterConfig.NodeSelector) == 0 {
+		return "", nil
+	}
+
+	keys := make([]string, 0, len(terraformConfig.HarvesterConfig.NodeSelector))
+	for key := range terraformConfig.HarvesterConfig.NodeSelector {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	matchExpressions := []map[string]any{}
+	for _, key := range keys {
+		matchExpressions = append(matchExpressions, map[string]any{
+			"key":      key,
+			"operator": "In",
+			"values":   []string{terraformConfig.HarvesterConfig.NodeSelector[key]},
+		})
+	}
+
+	affinity := map[string]any{
+		"nodeAffinity": map[string]any{
+			"requiredDuringSchedulingIgnoredDuringExecution": map[string]any{
+				"nodeSelectorTerms": []map[string]any{
+					{"matchExpressions": matchExpressions},
+				},
+			},
+		},
+	}
+
+	affinityJSON, err := json.Marshal(affinity)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(affinityJSON), nil
 }
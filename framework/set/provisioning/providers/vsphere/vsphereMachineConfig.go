@@ -1,6 +1,8 @@
 package vsphere
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/vsphere"
@@ -9,28 +11,20 @@ import (
 
 // SetVsphereRKE2K3SMachineConfig is a helper function that will set the Vsphere RKE2/K3S
 // Terraform machine configurations in the main.tf file.
-func SetVsphereRKE2K3SMachineConfig(machineConfigBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+func SetVsphereRKE2K3SMachineConfig(machineConfigBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) error {
 	vsphereConfigBlock := machineConfigBlockBody.AppendNewBlock(vsphere.VsphereConfig, nil)
 	vsphereConfigBlockBody := vsphereConfigBlock.Body()
 
-	cfgparams := make([]cty.Value, len(terraformConfig.VsphereConfig.Cfgparam))
-	for i, cfgparam := range terraformConfig.VsphereConfig.Cfgparam {
-		cfgparams[i] = cty.StringVal(cfgparam)
-	}
-
-	networks := make([]cty.Value, len(terraformConfig.VsphereConfig.Network))
-	for i, network := range terraformConfig.VsphereConfig.Network {
-		networks[i] = cty.StringVal(network)
+	err := setCreationType(vsphereConfigBlockBody, terraformConfig)
+	if err != nil {
+		return err
 	}
 
-	vsphereConfigBlockBody.SetAttributeValue(vsphere.DockerURL, cty.StringVal(terraformConfig.VsphereConfig.Boot2dockerURL))
-	vsphereConfigBlockBody.SetAttributeValue(vsphere.Cfgparam, cty.ListVal(cfgparams))
-	vsphereConfigBlockBody.SetAttributeValue(vsphere.CloneFrom, cty.StringVal(terraformConfig.VsphereConfig.CloneFrom))
+	vsphereConfigBlockBody.SetAttributeValue(vsphere.Cfgparam, listVal(terraformConfig.VsphereConfig.Cfgparam))
 	vsphereConfigBlockBody.SetAttributeValue(vsphere.CloudConfig, cty.StringVal(terraformConfig.VsphereConfig.CloudConfig))
 	vsphereConfigBlockBody.SetAttributeValue(vsphere.Cloudinit, cty.StringVal(terraformConfig.VsphereConfig.Cloudinit))
-	vsphereConfigBlockBody.SetAttributeValue(vsphere.ContentLibrary, cty.StringVal(terraformConfig.VsphereConfig.ContentLibrary))
 	vsphereConfigBlockBody.SetAttributeValue(vsphere.CPUCount, cty.StringVal(terraformConfig.VsphereConfig.CPUCount))
-	vsphereConfigBlockBody.SetAttributeValue(vsphere.CreationType, cty.StringVal(terraformConfig.VsphereConfig.CreationType))
+	vsphereConfigBlockBody.SetAttributeValue(vsphere.CustomAttributes, listVal(terraformConfig.VsphereConfig.CustomAttribute))
 	vsphereConfigBlockBody.SetAttributeValue(vsphere.DataCenter, cty.StringVal(terraformConfig.VsphereConfig.DataCenter))
 	vsphereConfigBlockBody.SetAttributeValue(vsphere.DataStore, cty.StringVal(terraformConfig.VsphereConfig.DataStore))
 	vsphereConfigBlockBody.SetAttributeValue(vsphere.DatastoreCluster, cty.StringVal(terraformConfig.VsphereConfig.DatastoreCluster))
@@ -38,10 +32,78 @@ func SetVsphereRKE2K3SMachineConfig(machineConfigBlockBody *hclwrite.Body, terra
 	vsphereConfigBlockBody.SetAttributeValue(vsphere.Folder, cty.StringVal(terraformConfig.VsphereConfig.Folder))
 	vsphereConfigBlockBody.SetAttributeValue(vsphere.HostSystem, cty.StringVal(terraformConfig.VsphereConfig.HostSystem))
 	vsphereConfigBlockBody.SetAttributeValue(vsphere.MemorySize, cty.StringVal(terraformConfig.VsphereConfig.MemorySize))
-	vsphereConfigBlockBody.SetAttributeValue(vsphere.Network, cty.ListVal(networks))
+	vsphereConfigBlockBody.SetAttributeValue(vsphere.Network, listVal(terraformConfig.VsphereConfig.Network))
+
+	if terraformConfig.VsphereConfig.OS != "" {
+		vsphereConfigBlockBody.SetAttributeValue(vsphere.OS, cty.StringVal(terraformConfig.VsphereConfig.OS))
+	}
+
 	vsphereConfigBlockBody.SetAttributeValue(vsphere.Pool, cty.StringVal(terraformConfig.VsphereConfig.Pool))
 	vsphereConfigBlockBody.SetAttributeValue(vsphere.SSHPassword, cty.StringVal(terraformConfig.VsphereConfig.SSHPassword))
 	vsphereConfigBlockBody.SetAttributeValue(vsphere.SSHPort, cty.StringVal(terraformConfig.VsphereConfig.SSHPort))
 	vsphereConfigBlockBody.SetAttributeValue(vsphere.SSHUser, cty.StringVal(terraformConfig.VsphereConfig.SSHUser))
 	vsphereConfigBlockBody.SetAttributeValue(vsphere.SSHUserGroup, cty.StringVal(terraformConfig.VsphereConfig.SSHUserGroup))
+	vsphereConfigBlockBody.SetAttributeValue(vsphere.Tags, listVal(terraformConfig.VsphereConfig.Tag))
+
+	if len(terraformConfig.VsphereConfig.VappProperty) > 0 {
+		vsphereConfigBlockBody.SetAttributeValue(vsphere.VappIPAllocationPolicy, cty.StringVal(terraformConfig.VsphereConfig.VappIpallocationpolicy))
+		vsphereConfigBlockBody.SetAttributeValue(vsphere.VappIPProtocol, cty.StringVal(terraformConfig.VsphereConfig.VappIpprotocol))
+		vsphereConfigBlockBody.SetAttributeValue(vsphere.VappProperty, listVal(terraformConfig.VsphereConfig.VappProperty))
+		vsphereConfigBlockBody.SetAttributeValue(vsphere.VappTransport, cty.StringVal(terraformConfig.VsphereConfig.VappTransport))
+	}
+
+	return nil
+}
+
+// setCreationType is a helper function that will set the creation type of the machine config, along with the source the
+// machines are created from. Only the source of the given creation type is set, as the node driver rejects the others.
+func setCreationType(vsphereConfigBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) error {
+	creationType := terraformConfig.VsphereConfig.CreationType
+	if creationType == "" {
+		creationType = vsphere.CreationTypeTemplate
+	}
+
+	switch creationType {
+	case vsphere.CreationTypeTemplate, vsphere.CreationTypeVM:
+		if terraformConfig.VsphereConfig.CloneFrom == "" {
+			return fmt.Errorf("vsphereConfig.cloneFrom must be set for the %s creation type", creationType)
+		}
+
+		vsphereConfigBlockBody.SetAttributeValue(vsphere.CloneFrom, cty.StringVal(terraformConfig.VsphereConfig.CloneFrom))
+	case vsphere.CreationTypeLibrary:
+		if terraformConfig.VsphereConfig.ContentLibrary == "" || terraformConfig.VsphereConfig.CloneFrom == "" {
+			return fmt.Errorf("vsphereConfig.contentLibrary and cloneFrom must be set for the %s creation type", creationType)
+		}
+
+		vsphereConfigBlockBody.SetAttributeValue(vsphere.ContentLibrary, cty.StringVal(terraformConfig.VsphereConfig.ContentLibrary))
+		vsphereConfigBlockBody.SetAttributeValue(vsphere.CloneFrom, cty.StringVal(terraformConfig.VsphereConfig.CloneFrom))
+	case vsphere.CreationTypeLegacy:
+		if terraformConfig.VsphereConfig.Boot2dockerURL == "" {
+			return fmt.Errorf("vsphereConfig.boot2dockerURL must be set for the %s creation type", creationType)
+		}
+
+		vsphereConfigBlockBody.SetAttributeValue(vsphere.DockerURL, cty.StringVal(terraformConfig.VsphereConfig.Boot2dockerURL))
+	default:
+		return fmt.Errorf("unsupported vsphereConfig.creationType %s, expected one of %s, %s, %s or %s", creationType,
+			vsphere.CreationTypeTemplate, vsphere.CreationTypeVM, vsphere.CreationTypeLibrary, vsphere.CreationTypeLegacy)
+	}
+
+	vsphereConfigBlockBody.SetAttributeValue(vsphere.CreationType, cty.StringVal(creationType))
+
+	return nil
+}
+
+// listVal is a helper function that will convert the given strings to a list value, which is empty when no strings are
+// given.
+func listVal(values []string) cty.Value {
+	if len(values) == 0 {
+		return cty.ListValEmpty(cty.String)
+	}
+
+	list := make([]cty.Value, len(values))
+	for i, value := range values {
+		list[i] = cty.StringVal(value)
+	}
+
+	return cty.ListVal(list)
 }
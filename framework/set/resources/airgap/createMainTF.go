@@ -1,6 +1,7 @@
 package airgap
 
 import (
+	"fmt"
 	"os"
 	"testing"
 
@@ -15,23 +16,19 @@ import (
 	"github.com/rancher/tfp-automation/framework/set/resources/providers"
 	registry "github.com/rancher/tfp-automation/framework/set/resources/registries/createRegistry"
 	"github.com/rancher/tfp-automation/framework/set/resources/sanity"
+	"github.com/rancher/tfp-automation/framework/set/resources/topology"
+	"github.com/rancher/tfp-automation/framework/timing"
 	"github.com/sirupsen/logrus"
 )
 
 const (
 	rancherRegistry = "registry"
 	bastion         = "bastion"
-	serverOne       = "server1"
-	serverTwo       = "server2"
-	serverThree     = "server3"
 
 	unauthRegistry = "unauth_registry"
 
-	registryPublicDNS    = "registry_public_dns"
-	bastionPublicDNS     = "bastion_public_dns"
-	serverOnePrivateIP   = "server1_private_ip"
-	serverTwoPrivateIP   = "server2_private_ip"
-	serverThreePrivateIP = "server3_private_ip"
+	registryPublicDNS = "registry_public_dns"
+	bastionPublicDNS  = "bastion_public_dns"
 
 	terraformConst = "terraform"
 )
@@ -49,15 +46,29 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 	tfBlock := rootBody.AppendNewBlock(terraformConst, nil)
 	tfBlockBody := tfBlock.Body()
 
+	nodes, err := topology.Nodes(terraformConfig)
+	if err != nil {
+		return "", "", err
+	}
+
 	instances := []string{bastion, rancherRegistry}
 
+	topology.CreateHostOutputs(rootBody, terraformConfig, instances...)
+	topology.CreateOutputs(rootBody, terraformConfig, nodes)
+
+	timing.AddInstances(terraformOptions, terraformConfig.Provider, timing.InstanceType(terraformConfig.Provider, terraformConfig), len(instances)+len(nodes))
+
 	providerTunnel := providers.TunnelToProvider(terraformConfig.Provider)
-	file, err := providerTunnel.CreateAirgap(file, newFile, tfBlockBody, rootBody, terraformConfig, terratestConfig, instances)
+	if providerTunnel.CreateAirgap == nil {
+		return "", "", fmt.Errorf("airgap setups are not supported on the %s provider", terraformConfig.Provider)
+	}
+
+	file, err = providerTunnel.CreateAirgap(file, newFile, tfBlockBody, rootBody, terraformConfig, terratestConfig, instances)
 	if err != nil {
 		return "", "", err
 	}
 
-	_, err = terraform.InitAndApplyE(t, terraformOptions)
+	_, err = timing.InitAndApplyE(t, terraformOptions, "resources")
 	if err != nil && *rancherConfig.Cleanup {
 		logrus.Infof("Error while creating resources. Cleaning up...")
 		cleanup.Cleanup(t, terraformOptions, keyPath)
@@ -66,9 +77,7 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 
 	registryPublicDNS := terraform.Output(t, terraformOptions, registryPublicDNS)
 	bastionPublicDNS := terraform.Output(t, terraformOptions, bastionPublicDNS)
-	serverOnePrivateIP := terraform.Output(t, terraformOptions, serverOnePrivateIP)
-	serverTwoPrivateIP := terraform.Output(t, terraformOptions, serverTwoPrivateIP)
-	serverThreePrivateIP := terraform.Output(t, terraformOptions, serverThreePrivateIP)
+	nodes = topology.LoadAddresses(t, terraformOptions, nodes)
 
 	// Needed for setting up an airgap Rancher server that is planned to be used for recurring runs.
 	terraformConfig.AirgapBastion = bastionPublicDNS
@@ -82,7 +91,7 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 		return "", "", err
 	}
 
-	_, err = terraform.InitAndApplyE(t, terraformOptions)
+	_, err = timing.InitAndApplyE(t, terraformOptions, "registry")
 	if err != nil && *rancherConfig.Cleanup {
 		logrus.Infof("Error while creating registry. Cleaning up...")
 		cleanup.Cleanup(t, terraformOptions, keyPath)
@@ -92,19 +101,19 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 	file = sanity.OpenFile(file, keyPath)
 	if terraformConfig.LocalCluster == "k3s" {
 		logrus.Infof("Creating K3S cluster...")
-		file, err = k3s.CreateAirgapK3SCluster(file, newFile, rootBody, terraformConfig, terratestConfig, bastionPublicDNS, registryPublicDNS, serverOnePrivateIP, serverTwoPrivateIP, serverThreePrivateIP)
+		file, err = k3s.CreateAirgapK3SCluster(file, newFile, rootBody, terraformConfig, terratestConfig, bastionPublicDNS, registryPublicDNS, nodes)
 		if err != nil {
 			return "", "", err
 		}
 	} else if terraformConfig.LocalCluster == "rke2" {
 		logrus.Infof("Creating RKE2 cluster...")
-		file, err = rke2.CreateAirgapRKE2Cluster(file, newFile, rootBody, terraformConfig, terratestConfig, bastionPublicDNS, registryPublicDNS, serverOnePrivateIP, serverTwoPrivateIP, serverThreePrivateIP)
+		file, err = rke2.CreateAirgapRKE2Cluster(file, newFile, rootBody, terraformConfig, terratestConfig, bastionPublicDNS, registryPublicDNS, nodes)
 		if err != nil {
 			return "", "", err
 		}
 	}
 
-	_, err = terraform.InitAndApplyE(t, terraformOptions)
+	_, err = timing.InitAndApplyE(t, terraformOptions, "local cluster")
 	if err != nil && *rancherConfig.Cleanup {
 		logrus.Infof("Error while creating local cluster. Cleaning up...")
 		cleanup.Cleanup(t, terraformOptions, keyPath)
@@ -118,7 +127,7 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 		return "", "", err
 	}
 
-	_, err = terraform.InitAndApplyE(t, terraformOptions)
+	_, err = timing.InitAndApplyE(t, terraformOptions, "rancher server")
 	if err != nil && *rancherConfig.Cleanup {
 		logrus.Infof("Error while creating Rancher server. Cleaning up...")
 		cleanup.Cleanup(t, terraformOptions, keyPath)
@@ -12,7 +12,8 @@ REGISTRY_USERNAME=$9
 REGISTRY_PASSWORD=${10}
 RANCHER_IMAGE=${11}
 RANCHER_TAG_VERSION=${12}
-RANCHER_AGENT_IMAGE=${13}
+NODE_ROLE=${13:-server}
+RANCHER_AGENT_IMAGE=${14}
 RANCHER_TAG_FILE=/home/${USER}/rancher-tag-version.txt
 PEM_FILE=/home/$USER/airgap.pem
 MAX_SSH_RETRIES=20
@@ -36,7 +37,8 @@ run_ssh() {
        export K3S_TOKEN=${K3S_TOKEN}; \
        export REGISTRY=${REGISTRY}; \
        export REGISTRY_USERNAME=${REGISTRY_USERNAME}; \
-       export REGISTRY_PASSWORD=${REGISTRY_PASSWORD}; $cmd"; then
+       export REGISTRY_PASSWORD=${REGISTRY_PASSWORD}; \
+       export NODE_ROLE=${NODE_ROLE}; $cmd"; then
       return 0
     else
       rc=$?
@@ -57,12 +59,30 @@ run_ssh() {
 
 setup_config() {
     sudo mkdir -p /etc/rancher/k3s
+
+    if [[ "${NODE_ROLE}" == "agent" ]]; then
+      sudo tee /etc/rancher/k3s/config.yaml > /dev/null << EOF
+token: ${K3S_TOKEN}
+EOF
+      return
+    fi
+
     sudo tee /etc/rancher/k3s/config.yaml > /dev/null << EOF
 token: ${K3S_TOKEN}
 system-default-registry: ${REGISTRY}
 tls-san:
   - ${K3S_SERVER_ONE_IP}
 EOF
+
+    if [[ "${NODE_ROLE}" == "etcd" ]]; then
+      sudo tee -a /etc/rancher/k3s/config.yaml > /dev/null << EOF
+disable-apiserver: true
+disable-controller-manager: true
+disable-scheduler: true
+EOF
+    elif [[ "${NODE_ROLE}" == "control-plane" ]]; then
+      echo "disable-etcd: true" | sudo tee -a /etc/rancher/k3s/config.yaml > /dev/null
+    fi
 }
 
 setup_registry() {
@@ -89,6 +109,10 @@ EOF
 }
 
 setup_networking() {
+  if [ -z "${VPC_IP}" ]; then
+    return
+  fi
+
   sudo systemctl disable systemd-resolved; sudo systemctl stop systemd-resolved
   sleep 5
   sudo sed -i.bak "s/^nameserver .*/nameserver ${VPC_IP}/" /etc/resolv.conf
@@ -109,7 +133,14 @@ run_ssh "${K3S_NEW_SERVER_IP}" "${configFunction}; setup_config"
 setupRegistryFunction=$(declare -f setup_registry)
 run_ssh "${K3S_NEW_SERVER_IP}" "${setupRegistryFunction}; setup_registry"
 
-run_ssh "${K3S_NEW_SERVER_IP}" "sudo INSTALL_K3S_VERSION=${K8S_VERSION} K3S_TOKEN=${K3S_TOKEN} INSTALL_K3S_EXEC=\"server --server https://${K3S_SERVER_ONE_IP}:6443\" INSTALL_K3S_SKIP_DOWNLOAD=true sh install.sh"
+K3S_SERVICE=k3s
+INSTALL_EXEC=server
+if [[ "${NODE_ROLE}" == "agent" ]]; then
+  K3S_SERVICE=k3s-agent
+  INSTALL_EXEC=agent
+fi
+
+run_ssh "${K3S_NEW_SERVER_IP}" "sudo INSTALL_K3S_VERSION=${K8S_VERSION} K3S_TOKEN=${K3S_TOKEN} INSTALL_K3S_EXEC=\"${INSTALL_EXEC} --server https://${K3S_SERVER_ONE_IP}:6443\" INSTALL_K3S_SKIP_DOWNLOAD=true sh install.sh"
 
 setupDaemonFunction=$(declare -f setup_docker_daemon)
 run_ssh "${K3S_NEW_SERVER_IP}" "${setupDaemonFunction}; setup_docker_daemon"
@@ -126,7 +157,7 @@ if [ -n "$RANCHER_AGENT_IMAGE" ]; then
 
   run_ssh "${K3S_NEW_SERVER_IP}" "sudo docker pull ${REGISTRY}/${RANCHER_IMAGE}:${RANCHER_TAG_VERSION}"
   run_ssh "${K3S_NEW_SERVER_IP}" "sudo docker pull ${REGISTRY}/${RANCHER_AGENT_IMAGE}:${RANCHER_TAG_VERSION}"
-  run_ssh "${K3S_NEW_SERVER_IP}" "sudo systemctl restart k3s"
+  run_ssh "${K3S_NEW_SERVER_IP}" "sudo systemctl restart ${K3S_SERVICE}"
 fi
 
 kubectl get nodes
\ No newline at end of file
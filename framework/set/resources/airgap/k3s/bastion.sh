@@ -2,13 +2,12 @@
 
 K8S_VERSION=$1
 K3S_SERVER_ONE_IP=$2
-K3S_SERVER_TWO_IP=$3
-K3S_SERVER_THREE_IP=$4
-USER=$5
-PEM_FILE=$6
-REPO=$7
-RANCHER_TAG_VERSION=$8
-RANCHER_CHART_REPO=$9
+K3S_NODE_IPS=$3
+USER=$4
+PEM_FILE=$5
+REPO=$6
+RANCHER_TAG_VERSION=$7
+RANCHER_CHART_REPO=$8
 
 set -e
 
@@ -95,26 +94,24 @@ sudo scp -i ${PEM} -o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null k
 sudo scp -i ${PEM} -o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null sha256sum-amd64.txt ${USER}@${K3S_SERVER_ONE_IP}:/home/${USER}/
 sudo scp -i ${PEM} -o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null sha256sum-arm64.txt ${USER}@${K3S_SERVER_ONE_IP}:/home/${USER}/
 
-echo "Copying files to K3S server two"
-sudo scp -i ${PEM} -o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null k3s ${USER}@${K3S_SERVER_TWO_IP}:/home/${USER}/
-sudo scp -i ${PEM} -o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null k3s-airgap-images-amd64.tar.gz ${USER}@${K3S_SERVER_TWO_IP}:/home/${USER}/
-sudo scp -i ${PEM} -o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null k3s-airgap-images-arm64.tar.gz ${USER}@${K3S_SERVER_TWO_IP}:/home/${USER}/
-sudo scp -i ${PEM} -o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null install.sh ${USER}@${K3S_SERVER_TWO_IP}:/home/${USER}/
-sudo scp -i ${PEM} -o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null sha256sum-amd64.txt ${USER}@${K3S_SERVER_TWO_IP}:/home/${USER}/
-sudo scp -i ${PEM} -o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null sha256sum-arm64.txt ${USER}@${K3S_SERVER_TWO_IP}:/home/${USER}/
-
-echo "Copying files to K3S server three"
-sudo scp -i ${PEM} -o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null k3s ${USER}@${K3S_SERVER_THREE_IP}:/home/${USER}/
-sudo scp -i ${PEM} -o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null k3s-airgap-images-amd64.tar.gz ${USER}@${K3S_SERVER_THREE_IP}:/home/${USER}/
-sudo scp -i ${PEM} -o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null k3s-airgap-images-arm64.tar.gz ${USER}@${K3S_SERVER_THREE_IP}:/home/${USER}/
-sudo scp -i ${PEM} -o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null install.sh ${USER}@${K3S_SERVER_THREE_IP}:/home/${USER}/
-sudo scp -i ${PEM} -o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null sha256sum-amd64.txt ${USER}@${K3S_SERVER_THREE_IP}:/home/${USER}/
-sudo scp -i ${PEM} -o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null sha256sum-arm64.txt ${USER}@${K3S_SERVER_THREE_IP}:/home/${USER}/
+IFS="," read -r -a NODE_IPS <<< "${K3S_NODE_IPS}"
+
+for NODE_IP in "${NODE_IPS[@]}"; do
+    echo "Copying files to K3S node ${NODE_IP}"
+    sudo scp -i ${PEM} -o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null k3s ${USER}@${NODE_IP}:/home/${USER}/
+    sudo scp -i ${PEM} -o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null k3s-airgap-images-amd64.tar.gz ${USER}@${NODE_IP}:/home/${USER}/
+    sudo scp -i ${PEM} -o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null k3s-airgap-images-arm64.tar.gz ${USER}@${NODE_IP}:/home/${USER}/
+    sudo scp -i ${PEM} -o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null install.sh ${USER}@${NODE_IP}:/home/${USER}/
+    sudo scp -i ${PEM} -o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null sha256sum-amd64.txt ${USER}@${NODE_IP}:/home/${USER}/
+    sudo scp -i ${PEM} -o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null sha256sum-arm64.txt ${USER}@${NODE_IP}:/home/${USER}/
+done
 
 if [[ $REPO == "prime-release" ]]; then
     sudo scp -i ${PEM} -o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null "/home/${USER}/rancher-tag-version.txt" ${USER}@${K3S_SERVER_ONE_IP}:/home/${USER}/rancher-tag-version.txt
-    sudo scp -i ${PEM} -o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null "/home/${USER}/rancher-tag-version.txt" ${USER}@${K3S_SERVER_TWO_IP}:/home/${USER}/rancher-tag-version.txt
-    sudo scp -i ${PEM} -o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null "/home/${USER}/rancher-tag-version.txt" ${USER}@${K3S_SERVER_THREE_IP}:/home/${USER}/rancher-tag-version.txt
+
+    for NODE_IP in "${NODE_IPS[@]}"; do
+        sudo scp -i ${PEM} -o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null "/home/${USER}/rancher-tag-version.txt" ${USER}@${NODE_IP}:/home/${USER}/rancher-tag-version.txt
+    done
 fi
 
 mkdir -p ~/.kube
@@ -3,49 +3,45 @@ package k3s
 import (
 	"encoding/base64"
 	"os"
-	"path/filepath"
+	"strings"
 
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	namegen "github.com/rancher/shepherd/pkg/namegenerator"
 	"github.com/rancher/tfp-automation/config"
-	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/framework/assets"
 	"github.com/rancher/tfp-automation/framework/set/defaults/general"
-	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/rancher/tfp-automation/framework/set/resources/providers"
 	"github.com/rancher/tfp-automation/framework/set/resources/rke2"
+	"github.com/rancher/tfp-automation/framework/set/resources/topology"
 	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
 )
 
 const (
-	k3sBastion     = "bastion"
-	k3sServerOne   = "server1"
-	k3sServerTwo   = "server2"
-	k3sServerThree = "server3"
-	token          = "token"
+	k3sBastion   = "bastion"
+	k3sServerOne = "server1"
+	token        = "token"
 )
 
 // CreateAirgapK3SCluster is a helper function that will create the K3S cluster.
 func CreateAirgapK3SCluster(file *os.File, newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
-	terratestConfig *config.TerratestConfig, k3sBastionPublicDNS, registryPublicDNS, k3sServerOnePrivateIP, k3sServerTwoPrivateIP,
-	k3sServerThreePrivateIP string) (*os.File, error) {
-	userDir, _ := rancher2.SetKeyPath(keypath.AirgapK3SKeyPath, terratestConfig.PathToRepo, terraformConfig.Provider)
+	terratestConfig *config.TerratestConfig, k3sBastionPublicDNS, registryPublicDNS string, nodes []topology.Node) (*os.File, error) {
+	bastionScriptPath := "framework/set/resources/airgap/k3s/bastion.sh"
+	serverScriptPath := "framework/set/resources/airgap/k3s/init-server.sh"
+	newServersScriptPath := "framework/set/resources/airgap/k3s/add-servers.sh"
 
-	bastionScriptPath := filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/airgap/k3s/bastion.sh")
-	serverScriptPath := filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/airgap/k3s/init-server.sh")
-	newServersScriptPath := filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/airgap/k3s/add-servers.sh")
-
-	bastionScriptContent, err := os.ReadFile(bastionScriptPath)
+	bastionScriptContent, err := assets.ReadFile(bastionScriptPath)
 	if err != nil {
 		return nil, err
 	}
 
-	serverOneScriptContent, err := os.ReadFile(serverScriptPath)
+	serverOneScriptContent, err := assets.ReadFile(serverScriptPath)
 	if err != nil {
 		return nil, err
 	}
 
-	newServersScriptContent, err := os.ReadFile(newServersScriptPath)
+	newServersScriptContent, err := assets.ReadFile(newServersScriptPath)
 	if err != nil {
 		return nil, err
 	}
@@ -59,8 +55,13 @@ func CreateAirgapK3SCluster(file *os.File, newFile *hclwrite.File, rootBody *hcl
 
 	_, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, k3sBastionPublicDNS, k3sBastion)
 
-	command := "/tmp/bastion.sh " + terraformConfig.Standalone.K3SVersion + " " + k3sServerOnePrivateIP + " " +
-		k3sServerTwoPrivateIP + " " + k3sServerThreePrivateIP + " " + terraformConfig.Standalone.OSUser + " " + encodedPEMFile + " " +
+	var nodeIPs []string
+	for _, node := range nodes[1:] {
+		nodeIPs = append(nodeIPs, node.PrivateIP)
+	}
+
+	command := "/tmp/bastion.sh " + terraformConfig.Standalone.K3SVersion + " " + nodes[0].PrivateIP + " \"" +
+		strings.Join(nodeIPs, ",") + "\" " + terraformConfig.Standalone.OSUser + " " + encodedPEMFile + " " +
 		terraformConfig.Standalone.Repo + " " + terraformConfig.Standalone.RancherTagVersion + " " +
 		terraformConfig.Standalone.RancherChartRepository
 
@@ -72,8 +73,8 @@ func CreateAirgapK3SCluster(file *os.File, newFile *hclwrite.File, rootBody *hcl
 
 	k3sToken := namegen.AppendRandomString(token)
 
-	createAirgappedK3SServer(rootBody, terraformConfig, k3sBastionPublicDNS, k3sServerOnePrivateIP, k3sToken, registryPublicDNS, serverOneScriptContent)
-	addAirgappedK3SServerNodes(rootBody, terraformConfig, k3sBastionPublicDNS, k3sServerOnePrivateIP, k3sServerTwoPrivateIP, k3sServerThreePrivateIP, k3sToken, registryPublicDNS, newServersScriptContent)
+	createAirgappedK3SServer(rootBody, terraformConfig, k3sBastionPublicDNS, nodes[0].PrivateIP, k3sToken, registryPublicDNS, serverOneScriptContent)
+	addAirgappedK3SServerNodes(rootBody, terraformConfig, k3sBastionPublicDNS, nodes[0].PrivateIP, nodes[1:], k3sToken, registryPublicDNS, newServersScriptContent)
 
 	_, err = file.Write(newFile.Bytes())
 	if err != nil {
@@ -90,7 +91,7 @@ func createAirgappedK3SServer(rootBody *hclwrite.Body, terraformConfig *config.T
 	nullResourceBlockBody, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, k3sBastionPublicDNS, k3sServerOne)
 
 	command := "/tmp/init-server.sh " + terraformConfig.Standalone.OSUser + " " + terraformConfig.Standalone.OSGroup + " " +
-		terraformConfig.AWSConfig.AWSVpcIP + " " + terraformConfig.Standalone.K3SVersion + " " + k3sServerOnePrivateIP + " " + k3sToken + " " +
+		"\"" + providers.AirgapNameserver(terraformConfig) + "\" " + terraformConfig.Standalone.K3SVersion + " " + k3sServerOnePrivateIP + " " + k3sToken + " " +
 		registryPublicDNS + " " + terraformConfig.Standalone.RegistryUsername + " " + terraformConfig.Standalone.RegistryPassword + " " +
 		terraformConfig.Standalone.RancherImage + " " + terraformConfig.Standalone.RancherTagVersion
 
@@ -112,21 +113,18 @@ func createAirgappedK3SServer(rootBody *hclwrite.Body, terraformConfig *config.T
 	nullResourceBlockBody.SetAttributeRaw(general.DependsOn, server)
 }
 
-// addAirgappedK3SServerNodes is a helper function that will add additional K3S server nodes to the initial K3S airgapped server.
-func addAirgappedK3SServerNodes(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, k3sBastionPublicDNS, k3sServerOnePrivateIP, k3sServerTwoPrivateIP,
-	k3sServerThreePrivateIP, k3sToken, registryPublicDNS string, script []byte) {
-	instances := []string{k3sServerTwoPrivateIP, k3sServerThreePrivateIP}
-	hosts := []string{k3sServerTwo, k3sServerThree}
-
-	for i, instance := range instances {
-		host := hosts[i]
-		nullResourceBlockBody, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, k3sBastionPublicDNS, host)
+// addAirgappedK3SServerNodes is a helper function that will add the remaining nodes of the topology to the initial K3S
+// airgapped server. Each node joins with its own role.
+func addAirgappedK3SServerNodes(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, k3sBastionPublicDNS, k3sServerOnePrivateIP string,
+	nodes []topology.Node, k3sToken, registryPublicDNS string, script []byte) {
+	for _, node := range nodes {
+		nullResourceBlockBody, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, k3sBastionPublicDNS, node.Name)
 
 		command := "/tmp/add-servers.sh " + terraformConfig.Standalone.OSUser + " " + terraformConfig.Standalone.OSGroup + " " +
-			terraformConfig.AWSConfig.AWSVpcIP + " " + terraformConfig.Standalone.K3SVersion + " " + k3sServerOnePrivateIP + " " +
-			instance + " " + k3sToken + " " + registryPublicDNS + " " + terraformConfig.Standalone.RegistryUsername + " " +
+			"\"" + providers.AirgapNameserver(terraformConfig) + "\" " + terraformConfig.Standalone.K3SVersion + " " + k3sServerOnePrivateIP + " " +
+			node.PrivateIP + " " + k3sToken + " " + registryPublicDNS + " " + terraformConfig.Standalone.RegistryUsername + " " +
 			terraformConfig.Standalone.RegistryPassword + " " + terraformConfig.Standalone.RancherImage + " " +
-			terraformConfig.Standalone.RancherTagVersion
+			terraformConfig.Standalone.RancherTagVersion + " " + node.Role
 
 		if terraformConfig.Standalone.RancherAgentImage != "" {
 			command += " " + terraformConfig.Standalone.RancherAgentImage
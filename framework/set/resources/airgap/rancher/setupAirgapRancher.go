@@ -3,13 +3,11 @@ package rancher
 import (
 	"encoding/base64"
 	"os"
-	"path/filepath"
 
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
-	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/framework/assets"
 	"github.com/rancher/tfp-automation/framework/set/defaults/general"
-	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 	"github.com/rancher/tfp-automation/framework/set/resources/rke2"
 	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
@@ -22,11 +20,9 @@ const (
 // CreateAirgapRancher is a function that will set the airgap Rancher configurations in the main.tf file.
 func CreateAirgapRancher(file *os.File, newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
 	terratestConfig *config.TerratestConfig, rke2BastionPublicDNS, registryPublicDNS string) (*os.File, error) {
-	userDir, _ := rancher2.SetKeyPath(keypath.AirgapKeyPath, terratestConfig.PathToRepo, terraformConfig.Provider)
+	scriptPath := "framework/set/resources/airgap/rancher/setup.sh"
 
-	scriptPath := filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/airgap/rancher/setup.sh")
-
-	scriptContent, err := os.ReadFile(scriptPath)
+	scriptContent, err := assets.ReadFile(scriptPath)
 	if err != nil {
 		return nil, err
 	}
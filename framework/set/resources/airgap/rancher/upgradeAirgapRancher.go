@@ -2,13 +2,11 @@ package rancher
 
 import (
 	"os"
-	"path/filepath"
 
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
-	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/framework/assets"
 	"github.com/rancher/tfp-automation/framework/set/defaults/general"
-	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 	"github.com/rancher/tfp-automation/framework/set/resources/rke2"
 	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
@@ -21,11 +19,9 @@ const (
 // UpgradeAirgapRancher is a function that will upgrade the Rancher configurations in the main.tf file.
 func UpgradeAirgapRancher(file *os.File, newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
 	terratestConfig *config.TerratestConfig, registryPublicDNS, bastionNode string) (*os.File, error) {
-	userDir, _ := rancher2.SetKeyPath(keypath.AirgapKeyPath, terratestConfig.PathToRepo, terraformConfig.Provider)
+	scriptPath := "framework/set/resources/airgap/rancher/upgrade.sh"
 
-	scriptPath := filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/airgap/rancher/upgrade.sh")
-
-	scriptContent, err := os.ReadFile(scriptPath)
+	scriptContent, err := assets.ReadFile(scriptPath)
 	if err != nil {
 		return nil, err
 	}
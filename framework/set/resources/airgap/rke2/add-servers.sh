@@ -11,7 +11,8 @@ REGISTRY_USERNAME=$8
 REGISTRY_PASSWORD=$9
 RANCHER_IMAGE=${10}
 RANCHER_TAG_VERSION=${11}
-RANCHER_AGENT_IMAGE=${12}
+NODE_ROLE=${12:-server}
+RANCHER_AGENT_IMAGE=${13}
 RANCHER_TAG_FILE=/home/${USER}/rancher-tag-version.txt
 PEM_FILE=/home/$USER/airgap.pem
 MAX_SSH_RETRIES=20
@@ -35,7 +36,8 @@ run_ssh() {
        export RKE2_TOKEN=${RKE2_TOKEN}; \
        export REGISTRY=${REGISTRY}; \
        export REGISTRY_USERNAME=${REGISTRY_USERNAME}; \
-       export REGISTRY_PASSWORD=${REGISTRY_PASSWORD}; $cmd"; then
+       export REGISTRY_PASSWORD=${REGISTRY_PASSWORD}; \
+       export NODE_ROLE=${NODE_ROLE}; $cmd"; then
       return 0
     else
       rc=$?
@@ -56,6 +58,15 @@ run_ssh() {
 
 setup_config() {
     sudo mkdir -p /etc/rancher/rke2
+
+    if [[ "${NODE_ROLE}" == "agent" ]]; then
+      sudo tee /etc/rancher/rke2/config.yaml > /dev/null << EOF
+server: https://${RKE2_SERVER_ONE_IP}:9345
+token: ${RKE2_TOKEN}
+EOF
+      return
+    fi
+
     sudo tee /etc/rancher/rke2/config.yaml > /dev/null << EOF
 server: https://${RKE2_SERVER_ONE_IP}:9345
 token: ${RKE2_TOKEN}
@@ -63,6 +74,16 @@ system-default-registry: ${REGISTRY}
 tls-san:
   - ${RKE2_SERVER_ONE_IP}
 EOF
+
+    if [[ "${NODE_ROLE}" == "etcd" ]]; then
+      sudo tee -a /etc/rancher/rke2/config.yaml > /dev/null << EOF
+disable-apiserver: true
+disable-controller-manager: true
+disable-scheduler: true
+EOF
+    elif [[ "${NODE_ROLE}" == "control-plane" ]]; then
+      echo "disable-etcd: true" | sudo tee -a /etc/rancher/rke2/config.yaml > /dev/null
+    fi
 }
 
 setup_registry() {
@@ -89,6 +110,10 @@ EOF
 }
 
 setup_networking() {
+  if [ -z "${VPC_IP}" ]; then
+    return
+  fi
+
   sudo systemctl disable systemd-resolved; sudo systemctl stop systemd-resolved
   sleep 5
   sudo sed -i.bak "s/^nameserver .*/nameserver ${VPC_IP}/" /etc/resolv.conf
@@ -107,9 +132,16 @@ run_ssh "${RKE2_NEW_SERVER_IP}" "${configFunction}; setup_config"
 setupRegistryFunction=$(declare -f setup_registry)
 run_ssh "${RKE2_NEW_SERVER_IP}" "${setupRegistryFunction}; setup_registry"
 
-run_ssh "${RKE2_NEW_SERVER_IP}" "sudo INSTALL_RKE2_ARTIFACT_PATH=/home/${USER} sh install.sh"
-run_ssh "${RKE2_NEW_SERVER_IP}" "sudo systemctl enable rke2-server"
-run_ssh "${RKE2_NEW_SERVER_IP}" "sudo systemctl start rke2-server"
+RKE2_SERVICE=rke2-server
+INSTALL_TYPE=server
+if [[ "${NODE_ROLE}" == "agent" ]]; then
+  RKE2_SERVICE=rke2-agent
+  INSTALL_TYPE=agent
+fi
+
+run_ssh "${RKE2_NEW_SERVER_IP}" "sudo INSTALL_RKE2_ARTIFACT_PATH=/home/${USER} INSTALL_RKE2_TYPE=${INSTALL_TYPE} sh install.sh"
+run_ssh "${RKE2_NEW_SERVER_IP}" "sudo systemctl enable ${RKE2_SERVICE}"
+run_ssh "${RKE2_NEW_SERVER_IP}" "sudo systemctl start ${RKE2_SERVICE}"
 
 setupDaemonFunction=$(declare -f setup_docker_daemon)
 run_ssh "${RKE2_NEW_SERVER_IP}" "${setupDaemonFunction}; setup_docker_daemon"
@@ -126,7 +158,7 @@ if [ -n "$RANCHER_AGENT_IMAGE" ]; then
 
   run_ssh "${RKE2_NEW_SERVER_IP}" "sudo docker pull ${REGISTRY}/${RANCHER_IMAGE}:${RANCHER_TAG_VERSION}"
   run_ssh "${RKE2_NEW_SERVER_IP}" "sudo docker pull ${REGISTRY}/${RANCHER_AGENT_IMAGE}:${RANCHER_TAG_VERSION}"
-  run_ssh "${RKE2_NEW_SERVER_IP}" "sudo systemctl restart rke2-server"
+  run_ssh "${RKE2_NEW_SERVER_IP}" "sudo systemctl restart ${RKE2_SERVICE}"
 fi
 
 kubectl get nodes
\ No newline at end of file
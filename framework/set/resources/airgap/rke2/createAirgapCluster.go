@@ -3,49 +3,45 @@ package rke2
 import (
 	"encoding/base64"
 	"os"
-	"path/filepath"
+	"strings"
 
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	namegen "github.com/rancher/shepherd/pkg/namegenerator"
 	"github.com/rancher/tfp-automation/config"
-	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/framework/assets"
 	"github.com/rancher/tfp-automation/framework/set/defaults/general"
-	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/rancher/tfp-automation/framework/set/resources/providers"
 	"github.com/rancher/tfp-automation/framework/set/resources/rke2"
+	"github.com/rancher/tfp-automation/framework/set/resources/topology"
 	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
 )
 
 const (
-	rke2Bastion     = "bastion"
-	rke2ServerOne   = "server1"
-	rke2ServerTwo   = "server2"
-	rke2ServerThree = "server3"
-	token           = "token"
+	rke2Bastion   = "bastion"
+	rke2ServerOne = "server1"
+	token         = "token"
 )
 
 // CreateAirgapRKE2Cluster is a helper function that will create the RKE2 cluster.
 func CreateAirgapRKE2Cluster(file *os.File, newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
-	terratestConfig *config.TerratestConfig, rke2BastionPublicDNS, registryPublicDNS, rke2ServerOnePrivateIP, rke2ServerTwoPrivateIP,
-	rke2ServerThreePrivateIP string) (*os.File, error) {
-	userDir, _ := rancher2.SetKeyPath(keypath.AirgapRKE2KeyPath, terratestConfig.PathToRepo, terraformConfig.Provider)
+	terratestConfig *config.TerratestConfig, rke2BastionPublicDNS, registryPublicDNS string, nodes []topology.Node) (*os.File, error) {
+	bastionScriptPath := "framework/set/resources/airgap/rke2/bastion.sh"
+	serverScriptPath := "framework/set/resources/airgap/rke2/init-server.sh"
+	newServersScriptPath := "framework/set/resources/airgap/rke2/add-servers.sh"
 
-	bastionScriptPath := filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/airgap/rke2/bastion.sh")
-	serverScriptPath := filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/airgap/rke2/init-server.sh")
-	newServersScriptPath := filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/airgap/rke2/add-servers.sh")
-
-	bastionScriptContent, err := os.ReadFile(bastionScriptPath)
+	bastionScriptContent, err := assets.ReadFile(bastionScriptPath)
 	if err != nil {
 		return nil, err
 	}
 
-	serverOneScriptContent, err := os.ReadFile(serverScriptPath)
+	serverOneScriptContent, err := assets.ReadFile(serverScriptPath)
 	if err != nil {
 		return nil, err
 	}
 
-	newServersScriptContent, err := os.ReadFile(newServersScriptPath)
+	newServersScriptContent, err := assets.ReadFile(newServersScriptPath)
 	if err != nil {
 		return nil, err
 	}
@@ -59,8 +55,13 @@ func CreateAirgapRKE2Cluster(file *os.File, newFile *hclwrite.File, rootBody *hc
 
 	_, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, rke2BastionPublicDNS, rke2Bastion)
 
-	command := "/tmp/bastion.sh " + terraformConfig.Standalone.RKE2Version + " " + rke2ServerOnePrivateIP + " " +
-		rke2ServerTwoPrivateIP + " " + rke2ServerThreePrivateIP + " " + terraformConfig.Standalone.OSUser + " " + encodedPEMFile + " " +
+	var nodeIPs []string
+	for _, node := range nodes[1:] {
+		nodeIPs = append(nodeIPs, node.PrivateIP)
+	}
+
+	command := "/tmp/bastion.sh " + terraformConfig.Standalone.RKE2Version + " " + nodes[0].PrivateIP + " \"" +
+		strings.Join(nodeIPs, ",") + "\" " + terraformConfig.Standalone.OSUser + " " + encodedPEMFile + " " +
 		terraformConfig.Standalone.Repo + " " + terraformConfig.Standalone.RancherTagVersion + " " +
 		terraformConfig.Standalone.RancherChartRepository
 
@@ -72,8 +73,8 @@ func CreateAirgapRKE2Cluster(file *os.File, newFile *hclwrite.File, rootBody *hc
 
 	rke2Token := namegen.AppendRandomString(token)
 
-	createAirgappedRKE2Server(rootBody, terraformConfig, rke2BastionPublicDNS, rke2ServerOnePrivateIP, rke2Token, registryPublicDNS, serverOneScriptContent)
-	addAirgappedRKE2ServerNodes(rootBody, terraformConfig, rke2BastionPublicDNS, rke2ServerOnePrivateIP, rke2ServerTwoPrivateIP, rke2ServerThreePrivateIP, rke2Token, registryPublicDNS, newServersScriptContent)
+	createAirgappedRKE2Server(rootBody, terraformConfig, rke2BastionPublicDNS, nodes[0].PrivateIP, rke2Token, registryPublicDNS, serverOneScriptContent)
+	addAirgappedRKE2ServerNodes(rootBody, terraformConfig, rke2BastionPublicDNS, nodes[0].PrivateIP, nodes[1:], rke2Token, registryPublicDNS, newServersScriptContent)
 
 	_, err = file.Write(newFile.Bytes())
 	if err != nil {
@@ -90,7 +91,7 @@ func createAirgappedRKE2Server(rootBody *hclwrite.Body, terraformConfig *config.
 	nullResourceBlockBody, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, rke2BastionPublicDNS, rke2ServerOne)
 
 	command := "/tmp/init-server.sh " + terraformConfig.Standalone.OSUser + " " + terraformConfig.Standalone.OSGroup + " " +
-		terraformConfig.AWSConfig.AWSVpcIP + " " + rke2ServerOnePrivateIP + " " + rke2Token + " " + registryPublicDNS + " " +
+		"\"" + providers.AirgapNameserver(terraformConfig) + "\" " + rke2ServerOnePrivateIP + " " + rke2Token + " " + registryPublicDNS + " " +
 		terraformConfig.Standalone.RegistryUsername + " " + terraformConfig.Standalone.RegistryPassword + " " + terraformConfig.Standalone.RancherImage + " " +
 		terraformConfig.Standalone.RancherTagVersion
 
@@ -112,20 +113,17 @@ func createAirgappedRKE2Server(rootBody *hclwrite.Body, terraformConfig *config.
 	nullResourceBlockBody.SetAttributeRaw(general.DependsOn, server)
 }
 
-// addAirgappedRKE2ServerNodes is a helper function that will add additional RKE2 server nodes to the initial RKE2 airgapped server.
-func addAirgappedRKE2ServerNodes(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, rke2BastionPublicDNS, rke2ServerOnePrivateIP, rke2ServerTwoPrivateIP,
-	rke2ServerThreePrivateIP, rke2Token, registryPublicDNS string, script []byte) {
-	instances := []string{rke2ServerTwoPrivateIP, rke2ServerThreePrivateIP}
-	hosts := []string{rke2ServerTwo, rke2ServerThree}
-
-	for i, instance := range instances {
-		host := hosts[i]
-		nullResourceBlockBody, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, rke2BastionPublicDNS, host)
+// addAirgappedRKE2ServerNodes is a helper function that will add the remaining nodes of the topology to the initial RKE2
+// airgapped server. Each node joins with its own role.
+func addAirgappedRKE2ServerNodes(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, rke2BastionPublicDNS, rke2ServerOnePrivateIP string,
+	nodes []topology.Node, rke2Token, registryPublicDNS string, script []byte) {
+	for _, node := range nodes {
+		nullResourceBlockBody, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, rke2BastionPublicDNS, node.Name)
 
 		command := "/tmp/add-servers.sh " + terraformConfig.Standalone.OSUser + " " + terraformConfig.Standalone.OSGroup + " " +
-			terraformConfig.AWSConfig.AWSVpcIP + " " + rke2ServerOnePrivateIP + " " + instance + " " + rke2Token + " " + registryPublicDNS + " " +
+			"\"" + providers.AirgapNameserver(terraformConfig) + "\" " + rke2ServerOnePrivateIP + " " + node.PrivateIP + " " + rke2Token + " " + registryPublicDNS + " " +
 			terraformConfig.Standalone.RegistryUsername + " " + terraformConfig.Standalone.RegistryPassword + " " + terraformConfig.Standalone.RancherImage + " " +
-			terraformConfig.Standalone.RancherTagVersion
+			terraformConfig.Standalone.RancherTagVersion + " " + node.Role
 
 		if terraformConfig.Standalone.RancherAgentImage != "" {
 			command += " " + terraformConfig.Standalone.RancherAgentImage
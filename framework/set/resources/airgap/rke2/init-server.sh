@@ -86,6 +86,10 @@ EOF
 }
 
 setup_networking() {
+  if [ -z "${VPC_IP}" ]; then
+    return
+  fi
+
   sudo systemctl disable systemd-resolved; sudo systemctl stop systemd-resolved
   sleep 5
   sudo sed -i.bak "s/^nameserver .*/nameserver ${VPC_IP}/" /etc/resolv.conf
@@ -0,0 +1,158 @@
+package backup
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/assets"
+	"github.com/rancher/tfp-automation/framework/set/defaults/general"
+	"github.com/rancher/tfp-automation/framework/set/resources/rke2"
+	"github.com/sirupsen/logrus"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	backupAction  = "backup"
+	restoreAction = "restore"
+
+	rancherBackup  = "rancher_backup"
+	rancherRestore = "rancher_restore"
+
+	defaultBucket          = "rancher-backup"
+	defaultMinIOCredential = "minioadmin"
+	defaultMinIORegion     = "us-east-1"
+	defaultResourceSetName = "rancher-resource-set-basic"
+	minioNodePort          = "30900"
+	restoreHostnameSuffix  = "-restore"
+
+	credentialsSuffix = "_credentials"
+	s3CredentialsPath = "/tmp/rancher-backup-s3.env"
+	sensitiveFunction = "sensitive"
+)
+
+// CreateRancherBackup is a function that will install the rancher-backup operator on the local cluster, running Rancher,
+// and create a backup of Rancher with the given name in the main.tf file. The backup is stored in the S3 bucket of the
+// backup config. When MinIO is enabled, a MinIO server is deployed on the local cluster as the S3 stand-in first.
+func CreateRancherBackup(file *os.File, newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
+	serverNode, backupName string) (*os.File, error) {
+	return createRancherBackupResource(file, newFile, rootBody, terraformConfig, serverNode, rancherBackup, backupAction, backupName, "")
+}
+
+// CreateRancherRestore is a function that will install the rancher-backup operator on the local cluster and restore the
+// given backup file into it in the main.tf file. The restore does not prune, so it can be used to migrate Rancher to a new
+// local cluster before Rancher is installed on it.
+func CreateRancherRestore(file *os.File, newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
+	serverNode, restoreName, backupFilename string) (*os.File, error) {
+	return createRancherBackupResource(file, newFile, rootBody, terraformConfig, serverNode, rancherRestore, restoreAction, restoreName, backupFilename)
+}
+
+// SetBackupDefaults is a function that will fill in the defaults of the backup config. With MinIO, the S3 endpoint is the
+// MinIO node port of the given node of the local cluster, which the new local cluster must be able to reach.
+func SetBackupDefaults(terraformConfig *config.TerraformConfig, minioNode string) {
+	if terraformConfig.Standalone.RancherBackup == nil {
+		terraformConfig.Standalone.RancherBackup = &config.RancherBackup{}
+	}
+
+	backupConfig := terraformConfig.Standalone.RancherBackup
+
+	if backupConfig.ResourceSetName == "" {
+		backupConfig.ResourceSetName = defaultResourceSetName
+	}
+
+	if backupConfig.S3Bucket == "" {
+		backupConfig.S3Bucket = defaultBucket
+	}
+
+	if backupConfig.RestoreRancherHostname == "" {
+		label, domain, _ := strings.Cut(terraformConfig.Standalone.RancherHostname, ".")
+		backupConfig.RestoreRancherHostname = label + restoreHostnameSuffix + "." + domain
+	}
+
+	if !backupConfig.MinIO {
+		return
+	}
+
+	if backupConfig.S3AccessKey == "" {
+		backupConfig.S3AccessKey = defaultMinIOCredential
+	}
+
+	if backupConfig.S3SecretKey == "" {
+		backupConfig.S3SecretKey = defaultMinIOCredential
+	}
+
+	if backupConfig.S3Region == "" {
+		backupConfig.S3Region = defaultMinIORegion
+	}
+
+	backupConfig.S3Endpoint = minioNode + ":" + minioNodePort
+}
+
+// createRancherBackupResource is a helper function that will create the null resource running the given action of the
+// rancher-backup script. The S3 credentials are written to an env file read by the script by a separate null resource,
+// so they are neither passed as arguments nor suppress the output of the script.
+func createRancherBackupResource(file *os.File, newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
+	serverNode, resourceName, action, name, backupFilename string) (*os.File, error) {
+	scriptPath := "framework/set/resources/backup/rancher-backup.sh"
+
+	scriptContent, err := assets.ReadFile(scriptPath)
+	if err != nil {
+		return nil, err
+	}
+
+	backupConfig := terraformConfig.Standalone.RancherBackup
+
+	setS3Credentials(rootBody, terraformConfig, serverNode, resourceName+credentialsSuffix)
+
+	rootBody.AppendNewline()
+
+	nullResourceBlockBody, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, serverNode, resourceName)
+
+	dependsOnCredentials := `[` + general.NullResource + `.` + resourceName + credentialsSuffix + `]`
+	credentials := hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(dependsOnCredentials)},
+	}
+
+	nullResourceBlockBody.SetAttributeRaw(general.DependsOn, credentials)
+
+	args := []string{action, name, backupConfig.ChartVersion, backupConfig.ResourceSetName, backupConfig.S3Bucket, backupConfig.S3Folder,
+		backupConfig.S3Region, backupConfig.S3Endpoint, strconv.FormatBool(backupConfig.MinIO), backupFilename}
+
+	command := "/tmp/rancher-backup.sh"
+	for _, arg := range args {
+		command += " \"" + arg + "\""
+	}
+
+	provisionerBlockBody.SetAttributeValue(general.Inline, cty.ListVal([]cty.Value{
+		cty.StringVal("cat <<'EOF' > /tmp/rancher-backup.sh\n" + string(scriptContent) + "\nEOF"),
+		cty.StringVal("chmod +x /tmp/rancher-backup.sh"),
+		cty.StringVal(command),
+	}))
+
+	_, err = file.Write(newFile.Bytes())
+	if err != nil {
+		logrus.Infof("Failed to append configurations to main.tf file. Error: %v", err)
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// setS3Credentials is a helper function that will create the null resource writing the S3 credentials of the backup
+// config to the env file read by the rancher-backup script. The command is marked as sensitive, so Terraform redacts
+// the credentials from the plan and apply output.
+func setS3Credentials(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, serverNode, resourceName string) {
+	backupConfig := terraformConfig.Standalone.RancherBackup
+
+	_, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, serverNode, resourceName)
+
+	command := "umask 077 && cat <<'EOF' > " + s3CredentialsPath + "\naccessKey=" + backupConfig.S3AccessKey + "\nsecretKey=" +
+		backupConfig.S3SecretKey + "\nEOF"
+
+	provisionerBlockBody.SetAttributeRaw(general.Inline, hclwrite.TokensForTuple([]hclwrite.Tokens{
+		hclwrite.TokensForFunctionCall(sensitiveFunction, hclwrite.TokensForValue(cty.StringVal(command))),
+	}))
+}
@@ -0,0 +1,161 @@
+package backup
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	shepherdConfig "github.com/rancher/shepherd/clients/rancher"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/clustertypes"
+	"github.com/rancher/tfp-automation/defaults/providers"
+	"github.com/rancher/tfp-automation/framework/cleanup"
+	"github.com/rancher/tfp-automation/framework/set/resources/k3s"
+	tunnel "github.com/rancher/tfp-automation/framework/set/resources/providers"
+	"github.com/rancher/tfp-automation/framework/set/resources/rke2"
+	"github.com/rancher/tfp-automation/framework/set/resources/sanity"
+	"github.com/rancher/tfp-automation/framework/set/resources/sanity/rancher"
+	"github.com/rancher/tfp-automation/framework/set/resources/topology"
+	"github.com/rancher/tfp-automation/framework/timing"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	googleLoadBalancerAddress = "google_load_balancer_ip_address"
+	linodeBalancerHostname    = "linode_node_balancer_hostname"
+
+	sslipioSuffix  = ".sslip.io"
+	terraformConst = "terraform"
+)
+
+// CreateBackupMainTF is a helper function that will create the main.tf file for backing up the Rancher server running on the
+// local cluster of the given server node.
+func CreateBackupMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath string, rancherConfig *shepherdConfig.Config,
+	terraformConfig *config.TerraformConfig, serverNode, backupName string) error {
+	var file *os.File
+	file = sanity.OpenFile(file, keyPath)
+	defer file.Close()
+
+	newFile := hclwrite.NewEmptyFile()
+	rootBody := newFile.Body()
+
+	logrus.Infof("Creating Rancher backup %s...", backupName)
+	_, err := CreateRancherBackup(file, newFile, rootBody, terraformConfig, serverNode, backupName)
+	if err != nil {
+		return err
+	}
+
+	_, err = timing.InitAndApplyE(t, terraformOptions, "rancher backup")
+	if err != nil && *rancherConfig.Cleanup {
+		logrus.Infof("Error while creating Rancher backup. Cleaning up...")
+		cleanup.Cleanup(t, terraformOptions, keyPath)
+		return err
+	}
+
+	return err
+}
+
+// CreateRestoreMainTF is a helper function that will create the main.tf file for migrating Rancher to a new local cluster. The
+// infrastructure and the local cluster are created the same way as a Rancher server, then the given backup file is
+// restored into the local cluster before Rancher is installed on it, as documented for migrations. The Rancher hostname
+// of the given Terraform config is updated to the one of the new load balancer, when the provider creates it.
+func CreateRestoreMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath string, rancherConfig *shepherdConfig.Config,
+	terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig, restoreName, backupFilename string) (string, error) {
+	var file *os.File
+	file = sanity.OpenFile(file, keyPath)
+	defer file.Close()
+
+	newFile := hclwrite.NewEmptyFile()
+	rootBody := newFile.Body()
+
+	tfBlock := rootBody.AppendNewBlock(terraformConst, nil)
+	tfBlockBody := tfBlock.Body()
+
+	nodes, err := topology.Nodes(terraformConfig)
+	if err != nil {
+		return "", err
+	}
+
+	topology.CreateOutputs(rootBody, terraformConfig, nodes)
+
+	instances := topology.Names(nodes)
+	timing.AddInstances(terraformOptions, terraformConfig.Provider, timing.InstanceType(terraformConfig.Provider, terraformConfig), len(instances))
+
+	providerTunnel := tunnel.TunnelToProvider(terraformConfig.Provider)
+	file, err = providerTunnel.CreateNonAirgap(file, newFile, tfBlockBody, rootBody, terraformConfig, terratestConfig, instances)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = timing.InitAndApplyE(t, terraformOptions, "resources")
+	if err != nil && *rancherConfig.Cleanup {
+		logrus.Infof("Error while creating resources. Cleaning up...")
+		cleanup.Cleanup(t, terraformOptions, keyPath)
+		return "", err
+	}
+
+	switch terraformConfig.Provider {
+	case providers.Google:
+		terraformConfig.Standalone.RancherHostname = terraform.Output(t, terraformOptions, googleLoadBalancerAddress) + sslipioSuffix
+	case providers.Linode:
+		terraformConfig.Standalone.RancherHostname = terraform.Output(t, terraformOptions, linodeBalancerHostname)
+	case providers.Harvester, providers.Vsphere:
+		terraformConfig.Standalone.RancherHostname = terraform.Output(t, terraformOptions, topology.PublicIPOutput(nodes[0].Name)) + sslipioSuffix
+	}
+
+	nodes = topology.LoadAddresses(t, terraformOptions, nodes)
+	serverOnePublicIP := nodes[0].PublicIP
+
+	file = sanity.OpenFile(file, keyPath)
+	if terraformConfig.LocalCluster == clustertypes.K3S {
+		logrus.Infof("Creating K3S cluster...")
+		file, err = k3s.CreateK3SCluster(file, newFile, rootBody, terraformConfig, terratestConfig, nodes)
+		if err != nil {
+			return "", err
+		}
+	} else if terraformConfig.LocalCluster == clustertypes.RKE2 {
+		logrus.Infof("Creating RKE2 cluster...")
+		file, err = rke2.CreateRKE2Cluster(file, newFile, rootBody, terraformConfig, terratestConfig, nodes)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	_, err = timing.InitAndApplyE(t, terraformOptions, "local cluster")
+	if err != nil && *rancherConfig.Cleanup {
+		logrus.Infof("Error while creating local cluster. Cleaning up...")
+		cleanup.Cleanup(t, terraformOptions, keyPath)
+		return "", err
+	}
+
+	file = sanity.OpenFile(file, keyPath)
+	logrus.Infof("Restoring Rancher backup %s...", backupFilename)
+	file, err = CreateRancherRestore(file, newFile, rootBody, terraformConfig, serverOnePublicIP, restoreName, backupFilename)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = timing.InitAndApplyE(t, terraformOptions, "rancher restore")
+	if err != nil && *rancherConfig.Cleanup {
+		logrus.Infof("Error while restoring Rancher backup. Cleaning up...")
+		cleanup.Cleanup(t, terraformOptions, keyPath)
+		return "", err
+	}
+
+	file = sanity.OpenFile(file, keyPath)
+	logrus.Infof("Creating Rancher server...")
+	file, err = rancher.CreateRancher(file, newFile, rootBody, terraformConfig, terratestConfig, serverOnePublicIP)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = timing.InitAndApplyE(t, terraformOptions, "rancher server")
+	if err != nil && *rancherConfig.Cleanup {
+		logrus.Infof("Error while creating Rancher server. Cleaning up...")
+		cleanup.Cleanup(t, terraformOptions, keyPath)
+		return "", err
+	}
+
+	return serverOnePublicIP, nil
+}
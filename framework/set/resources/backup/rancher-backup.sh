@@ -0,0 +1,262 @@
+#!/bin/bash
+
+ACTION=$1
+NAME=$2
+CHART_VERSION=$3
+RESOURCE_SET=$4
+S3_BUCKET=$5
+S3_FOLDER=$6
+S3_REGION=$7
+S3_ENDPOINT=$8
+MINIO=$9
+BACKUP_FILENAME=${10}
+
+# The S3 credentials are kept out of the arguments and the trace, the env file holds the accessKey and secretKey.
+S3_CREDENTIALS="/tmp/rancher-backup-s3.env"
+
+NAMESPACE="cattle-resources-system"
+CREDENTIAL_SECRET="s3-creds"
+MINIO_CREDENTIAL_SECRET="minio-creds"
+MINIO_NAMESPACE="minio"
+MINIO_NODE_PORT=30900
+
+set -ex
+
+trap 'rm -f ${S3_CREDENTIALS}' EXIT
+
+install_kubectl() {
+    if command -v kubectl > /dev/null; then
+        return 0
+    fi
+
+    ARCH=$(uname -m)
+    if [[ $ARCH == "x86_64" ]]; then
+        ARCH="amd64"
+    elif [[ $ARCH == "arm64" || $ARCH == "aarch64" ]]; then
+        ARCH="arm64"
+    fi
+
+    echo "Installing kubectl"
+    KUBECTL_VERSION="v1.36.0"
+    curl -fsSL --max-time 30 -o kubectl https://dl.k8s.io/release/${KUBECTL_VERSION}/bin/linux/${ARCH}/kubectl
+    curl -fsSL --max-time 30 -o kubectl.sha256 https://dl.k8s.io/release/${KUBECTL_VERSION}/bin/linux/${ARCH}/kubectl.sha256
+    echo "$(cat kubectl.sha256) kubectl" | sha256sum -c
+    sudo install -o root -g root -m 0755 kubectl /usr/local/bin/kubectl
+    rm kubectl kubectl.sha256
+}
+
+install_helm() {
+    if command -v helm > /dev/null; then
+        return 0
+    fi
+
+    HELM_VERSION="v4.1.4"
+    ARCH=$(uname -m)
+    if [[ $ARCH == "x86_64" ]]; then
+        ARCH="amd64"
+    elif [[ $ARCH == "arm64" || $ARCH == "aarch64" ]]; then
+        ARCH="arm64"
+    fi
+
+    echo "Installing Helm"
+    curl -fsSL --max-time 30 -o helm-${HELM_VERSION}-linux-${ARCH}.tar.gz https://get.helm.sh/helm-${HELM_VERSION}-linux-${ARCH}.tar.gz
+    curl -fsSL --max-time 30 -o helm-${HELM_VERSION}-linux-${ARCH}.tar.gz.sha256 https://get.helm.sh/helm-${HELM_VERSION}-linux-${ARCH}.tar.gz.sha256
+
+    echo "$(cat helm-${HELM_VERSION}-linux-${ARCH}.tar.gz.sha256) helm-${HELM_VERSION}-linux-${ARCH}.tar.gz" | sha256sum -c
+    tar -xf helm-${HELM_VERSION}-linux-${ARCH}.tar.gz
+    sudo mv linux-${ARCH}/helm /usr/local/bin/helm
+    rm -rf linux-${ARCH} helm-${HELM_VERSION}-linux-${ARCH}.tar.gz
+    rm helm-${HELM_VERSION}-linux-${ARCH}.tar.gz.sha256
+}
+
+install_rancher_backup() {
+    echo "Installing the rancher-backup operator"
+    helm repo add rancher-charts https://charts.rancher.io
+    helm repo update
+
+    VERSION=""
+    if [ -n "$CHART_VERSION" ]; then
+        VERSION="--version ${CHART_VERSION}"
+    fi
+
+    helm upgrade --install rancher-backup-crd rancher-charts/rancher-backup-crd -n ${NAMESPACE} --create-namespace ${VERSION} --wait
+    helm upgrade --install rancher-backup rancher-charts/rancher-backup -n ${NAMESPACE} ${VERSION} --wait
+
+    kubectl -n ${NAMESPACE} create secret generic ${CREDENTIAL_SECRET} --from-env-file=${S3_CREDENTIALS} \
+        --dry-run=client -o yaml | kubectl apply -f -
+}
+
+install_minio() {
+    echo "Installing MinIO as the S3 stand-in"
+    openssl req -x509 -newkey rsa:2048 -nodes -days 7 -subj "/CN=minio" -keyout /tmp/minio.key -out /tmp/minio.crt
+
+    kubectl create ns ${MINIO_NAMESPACE} --dry-run=client -o yaml | kubectl apply -f -
+    kubectl -n ${MINIO_NAMESPACE} create secret generic minio-certs --from-file=public.crt=/tmp/minio.crt \
+        --from-file=private.key=/tmp/minio.key --dry-run=client -o yaml | kubectl apply -f -
+    kubectl -n ${MINIO_NAMESPACE} create secret generic ${MINIO_CREDENTIAL_SECRET} --from-env-file=${S3_CREDENTIALS} \
+        --dry-run=client -o yaml | kubectl apply -f -
+
+    cat <<MINIO | kubectl apply -f -
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: minio
+  namespace: ${MINIO_NAMESPACE}
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: minio
+  template:
+    metadata:
+      labels:
+        app: minio
+    spec:
+      containers:
+        - name: minio
+          image: minio/minio
+          args: ["server", "/data", "--certs-dir", "/certs"]
+          env:
+            - name: MINIO_ROOT_USER
+              valueFrom:
+                secretKeyRef:
+                  name: ${MINIO_CREDENTIAL_SECRET}
+                  key: accessKey
+            - name: MINIO_ROOT_PASSWORD
+              valueFrom:
+                secretKeyRef:
+                  name: ${MINIO_CREDENTIAL_SECRET}
+                  key: secretKey
+          ports:
+            - containerPort: 9000
+          volumeMounts:
+            - name: certs
+              mountPath: /certs
+            - name: data
+              mountPath: /data
+      volumes:
+        - name: certs
+          secret:
+            secretName: minio-certs
+        - name: data
+          emptyDir: {}
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: minio
+  namespace: ${MINIO_NAMESPACE}
+spec:
+  type: NodePort
+  selector:
+    app: minio
+  ports:
+    - port: 9000
+      targetPort: 9000
+      nodePort: ${MINIO_NODE_PORT}
+MINIO
+
+    kubectl -n ${MINIO_NAMESPACE} rollout status deploy/minio --timeout=300s
+
+    for i in $(seq 1 30); do
+        if kubectl -n ${MINIO_NAMESPACE} exec deploy/minio -- sh -c 'mc alias set --insecure local https://localhost:9000 "$MINIO_ROOT_USER" "$MINIO_ROOT_PASSWORD" && mc mb --insecure --ignore-existing "local/$1"' sh ${S3_BUCKET}; then
+            return 0
+        fi
+
+        sleep 10
+    done
+
+    echo "The ${S3_BUCKET} bucket was not created in MinIO"
+    exit 1
+}
+
+storage_location() {
+    cat <<LOCATION
+  storageLocation:
+    s3:
+      credentialSecretName: ${CREDENTIAL_SECRET}
+      credentialSecretNamespace: ${NAMESPACE}
+      bucketName: ${S3_BUCKET}
+      folder: "${S3_FOLDER}"
+      region: "${S3_REGION}"
+      endpoint: ${S3_ENDPOINT}
+      insecureTLSSkipVerify: ${MINIO:-false}
+LOCATION
+}
+
+create_backup() {
+    echo "Creating the ${NAME} backup"
+    cat <<BACKUP | kubectl apply -f -
+apiVersion: resources.cattle.io/v1
+kind: Backup
+metadata:
+  name: ${NAME}
+spec:
+  resourceSetName: ${RESOURCE_SET}
+$(storage_location)
+BACKUP
+
+    for i in $(seq 1 60); do
+        FILENAME=$(kubectl get backups.resources.cattle.io ${NAME} -o jsonpath='{.status.filename}')
+        if [ -n "$FILENAME" ]; then
+            echo "The ${NAME} backup was stored as ${FILENAME}"
+            return 0
+        fi
+
+        sleep 10
+    done
+
+    echo "The ${NAME} backup did not complete"
+    kubectl get backups.resources.cattle.io ${NAME} -o yaml || true
+    exit 1
+}
+
+create_restore() {
+    echo "Restoring the ${BACKUP_FILENAME} backup"
+    cat <<RESTORE | kubectl apply -f -
+apiVersion: resources.cattle.io/v1
+kind: Restore
+metadata:
+  name: ${NAME}
+spec:
+  backupFilename: ${BACKUP_FILENAME}
+  prune: false
+$(storage_location)
+RESTORE
+
+    for i in $(seq 1 60); do
+        COMPLETED=$(kubectl get restores.resources.cattle.io ${NAME} -o jsonpath='{.status.restoreCompletionTs}')
+        if [ -n "$COMPLETED" ]; then
+            echo "The ${BACKUP_FILENAME} backup was restored"
+            return 0
+        fi
+
+        sleep 10
+    done
+
+    echo "The ${BACKUP_FILENAME} backup was not restored"
+    kubectl get restores.resources.cattle.io ${NAME} -o yaml || true
+    exit 1
+}
+
+install_kubectl
+install_helm
+
+case "${ACTION}" in
+  backup)
+    if [[ "${MINIO}" == "true" ]]; then
+        install_minio
+    fi
+
+    install_rancher_backup
+    create_backup
+    ;;
+  restore)
+    install_rancher_backup
+    create_restore
+    ;;
+  *)
+    echo "Unknown action ${ACTION}, expected backup or restore"
+    exit 1
+    ;;
+esac
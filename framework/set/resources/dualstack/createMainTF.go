@@ -14,13 +14,12 @@ import (
 	tunnel "github.com/rancher/tfp-automation/framework/set/resources/providers"
 	"github.com/rancher/tfp-automation/framework/set/resources/sanity"
 	"github.com/rancher/tfp-automation/framework/set/resources/sanity/rancher"
+	"github.com/rancher/tfp-automation/framework/set/resources/topology"
+	"github.com/rancher/tfp-automation/framework/timing"
 	"github.com/sirupsen/logrus"
 )
 
 const (
-	serverOne           = "server1"
-	serverTwo           = "server2"
-	serverThree         = "server3"
 	serverOnePublicIP   = "server1_public_ip"
 	serverOnePrivateIP  = "server1_private_ip"
 	serverTwoPublicIP   = "server2_public_ip"
@@ -42,9 +41,16 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 	tfBlock := rootBody.AppendNewBlock(terraformConst, nil)
 	tfBlockBody := tfBlock.Body()
 
-	var err error
+	nodes, err := topology.FixedNodes(terraformConfig, "dual-stack")
+	if err != nil {
+		return "", err
+	}
+
+	instances := topology.Names(nodes)
+
+	topology.CreateOutputs(rootBody, terraformConfig, nodes)
 
-	instances := []string{serverOne, serverTwo, serverThree}
+	timing.AddInstances(terraformOptions, terraformConfig.Provider, timing.InstanceType(terraformConfig.Provider, terraformConfig), len(instances))
 
 	providerTunnel := tunnel.TunnelToProvider(terraformConfig.Provider)
 	file, err = providerTunnel.CreateNonAirgap(file, newFile, tfBlockBody, rootBody, terraformConfig, terratestConfig, instances)
@@ -52,7 +58,7 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 		return "", err
 	}
 
-	_, err = terraform.InitAndApplyE(t, terraformOptions)
+	_, err = timing.InitAndApplyE(t, terraformOptions, "resources")
 	if err != nil && *rancherConfig.Cleanup {
 		logrus.Infof("Error while creating resources. Cleaning up...")
 		cleanup.Cleanup(t, terraformOptions, keyPath)
@@ -79,7 +85,7 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 		}
 	}
 
-	_, err = terraform.InitAndApplyE(t, terraformOptions)
+	_, err = timing.InitAndApplyE(t, terraformOptions, "local cluster")
 	if err != nil && *rancherConfig.Cleanup {
 		logrus.Infof("Error while creating local cluster. Cleaning up...")
 		cleanup.Cleanup(t, terraformOptions, keyPath)
@@ -93,7 +99,7 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 		return "", err
 	}
 
-	_, err = terraform.InitAndApplyE(t, terraformOptions)
+	_, err = timing.InitAndApplyE(t, terraformOptions, "rancher server")
 	if err != nil && *rancherConfig.Cleanup {
 		logrus.Infof("Error while creating Rancher server. Cleaning up...")
 		cleanup.Cleanup(t, terraformOptions, keyPath)
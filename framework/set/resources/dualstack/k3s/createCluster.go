@@ -2,15 +2,13 @@ package k3s
 
 import (
 	"os"
-	"path/filepath"
 
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	namegen "github.com/rancher/shepherd/pkg/namegenerator"
 	"github.com/rancher/tfp-automation/config"
-	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/framework/assets"
 	"github.com/rancher/tfp-automation/framework/set/defaults/general"
-	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 	"github.com/rancher/tfp-automation/framework/set/resources/rke2"
 	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
@@ -26,17 +24,15 @@ const (
 // CreateK3SCluster is a helper function that will create the K3S cluster.
 func CreateK3SCluster(file *os.File, newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
 	terratestConfig *config.TerratestConfig, k3sServerOnePublicDNS, k3sServerOnePrivateIP, k3sServerTwoPublicDNS, k3sServerThreePublicDNS string) (*os.File, error) {
-	userDir, _ := rancher2.SetKeyPath(keypath.K3sKeyPath, terratestConfig.PathToRepo, terraformConfig.Provider)
+	serverScriptPath := "framework/set/resources/dualstack/k3s/init-server.sh"
+	newServersScriptPath := "framework/set/resources/dualstack/k3s/add-servers.sh"
 
-	serverScriptPath := filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/dualstack/k3s/init-server.sh")
-	newServersScriptPath := filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/dualstack/k3s/add-servers.sh")
-
-	serverOneScriptContent, err := os.ReadFile(serverScriptPath)
+	serverOneScriptContent, err := assets.ReadFile(serverScriptPath)
 	if err != nil {
 		return nil, err
 	}
 
-	newServersScriptContent, err := os.ReadFile(newServersScriptPath)
+	newServersScriptContent, err := assets.ReadFile(newServersScriptPath)
 	if err != nil {
 		return nil, err
 	}
@@ -63,7 +59,7 @@ func CreateK3SServer(rootBody *hclwrite.Body, terraformConfig *config.TerraformC
 	command := "/tmp/init-server.sh " + terraformConfig.Standalone.OSUser + " " + terraformConfig.Standalone.OSGroup + " " +
 		terraformConfig.Standalone.K3SVersion + " " + k3sServerOnePrivateIP + " " + k3sToken + " " +
 		terraformConfig.Standalone.RegistryUsername + " " + terraformConfig.Standalone.RegistryPassword + " " +
-		terraformConfig.AWSConfig.ClusterCIDR + " " + terraformConfig.AWSConfig.ServiceCIDR
+		terraformConfig.Networking.ClusterCIDR + " " + terraformConfig.Networking.ServiceCIDR
 
 	provisionerBlockBody.SetAttributeValue(general.Inline, cty.ListVal([]cty.Value{
 		cty.StringVal("printf '" + string(script) + "' > /tmp/init-server.sh"),
@@ -85,7 +81,7 @@ func AddK3SServerNodes(rootBody *hclwrite.Body, terraformConfig *config.Terrafor
 		command := "/tmp/add-servers.sh " + terraformConfig.Standalone.OSUser + " " + terraformConfig.Standalone.OSGroup + " " +
 			terraformConfig.Standalone.K3SVersion + " " + k3sServerOnePrivateIP + " " + instance + " " + k3sToken + " " +
 			terraformConfig.Standalone.RegistryUsername + " " + terraformConfig.Standalone.RegistryPassword + " " +
-			terraformConfig.AWSConfig.ClusterCIDR + " " + terraformConfig.AWSConfig.ServiceCIDR
+			terraformConfig.Networking.ClusterCIDR + " " + terraformConfig.Networking.ServiceCIDR
 
 		provisionerBlockBody.SetAttributeValue(general.Inline, cty.ListVal([]cty.Value{
 			cty.StringVal("printf '" + string(script) + "' > /tmp/add-servers.sh"),
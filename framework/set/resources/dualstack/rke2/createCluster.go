@@ -2,15 +2,13 @@ package rke2
 
 import (
 	"os"
-	"path/filepath"
 
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	namegen "github.com/rancher/shepherd/pkg/namegenerator"
 	"github.com/rancher/tfp-automation/config"
-	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/framework/assets"
 	"github.com/rancher/tfp-automation/framework/set/defaults/general"
-	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 	"github.com/rancher/tfp-automation/framework/set/resources/rke2"
 	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
@@ -26,17 +24,15 @@ const (
 // CreateRKE2Cluster is a helper function that will create the RKE2 cluster.
 func CreateRKE2Cluster(file *os.File, newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
 	terratestConfig *config.TerratestConfig, rke2ServerOnePublicIP, rke2ServerOnePrivateIP, rke2ServerTwoPublicIP, rke2ServerThreePublicIP string) (*os.File, error) {
-	userDir, _ := rancher2.SetKeyPath(keypath.RKE2KeyPath, terratestConfig.PathToRepo, terraformConfig.Provider)
+	serverScriptPath := "framework/set/resources/dualstack/rke2/init-server.sh"
+	newServersScriptPath := "framework/set/resources/dualstack/rke2/add-servers.sh"
 
-	serverScriptPath := filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/dualstack/rke2/init-server.sh")
-	newServersScriptPath := filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/dualstack/rke2/add-servers.sh")
-
-	serverOneScriptContent, err := os.ReadFile(serverScriptPath)
+	serverOneScriptContent, err := assets.ReadFile(serverScriptPath)
 	if err != nil {
 		return nil, err
 	}
 
-	newServersScriptContent, err := os.ReadFile(newServersScriptPath)
+	newServersScriptContent, err := assets.ReadFile(newServersScriptPath)
 	if err != nil {
 		return nil, err
 	}
@@ -63,7 +59,7 @@ func createRKE2Server(rootBody *hclwrite.Body, terraformConfig *config.Terraform
 	command := "/tmp/init-server.sh " + terraformConfig.Standalone.OSUser + " " + terraformConfig.Standalone.OSGroup + " " +
 		terraformConfig.Standalone.RKE2Version + " " + rke2ServerOnePrivateIP + " " + rke2Token + " " + terraformConfig.CNI + " " +
 		terraformConfig.Standalone.RegistryUsername + " " + terraformConfig.Standalone.RegistryPassword + " " +
-		terraformConfig.AWSConfig.ClusterCIDR + " " + terraformConfig.AWSConfig.ServiceCIDR
+		terraformConfig.Networking.ClusterCIDR + " " + terraformConfig.Networking.ServiceCIDR
 
 	provisionerBlockBody.SetAttributeValue(general.Inline, cty.ListVal([]cty.Value{
 		cty.StringVal("printf '" + string(script) + "' > /tmp/init-server.sh"),
@@ -84,7 +80,7 @@ func addRKE2ServerNodes(rootBody *hclwrite.Body, terraformConfig *config.Terrafo
 
 		command := "/tmp/add-servers.sh " + terraformConfig.Standalone.OSUser + " " + terraformConfig.Standalone.RKE2Version + " " +
 			rke2ServerOnePrivateIP + " " + instance + " " + rke2Token + " " + terraformConfig.CNI + " " + terraformConfig.Standalone.RegistryUsername + " " +
-			terraformConfig.Standalone.RegistryPassword + " " + terraformConfig.AWSConfig.ClusterCIDR + " " + terraformConfig.AWSConfig.ServiceCIDR
+			terraformConfig.Standalone.RegistryPassword + " " + terraformConfig.Networking.ClusterCIDR + " " + terraformConfig.Networking.ServiceCIDR
 
 		provisionerBlockBody.SetAttributeValue(general.Inline, cty.ListVal([]cty.Value{
 			cty.StringVal("printf '" + string(script) + "' > /tmp/add-servers.sh"),
@@ -7,15 +7,15 @@ NODE_SIZE=$4
 AZURE_CLIENT_ID=$5
 AZURE_CLIENT_SECRET=$6
 AZURE_TENANT_ID=$7
-USER=$8
-PUB_FILE=${9}
+PUB_FILE=$8
+CREATE_RESOURCE_GROUP=$9
 
 RESOURCE_GROUP_NAME="${RESOURCE_PREFIX}-rg"
 
 set -e
 
-base64 -d <<< $PUB_FILE > /home/$USER/cloud.pub
-PUB=/home/$USER/cloud.pub
+base64 -d <<< $PUB_FILE > $HOME/cloud.pub
+PUB=$HOME/cloud.pub
 chmod 600 $PUB
 
 . /etc/os-release
@@ -36,6 +36,11 @@ Signed-by: /etc/apt/keyrings/microsoft.gpg" | sudo tee /etc/apt/sources.list.d/a
 
 az login --service-principal -u "$AZURE_CLIENT_ID" -p "$AZURE_CLIENT_SECRET" --tenant "$AZURE_TENANT_ID" > /dev/null 2>&1
 
+if [[ "${CREATE_RESOURCE_GROUP}" == "true" ]]; then
+    echo "Creating resource group..."
+    az group create --name "$RESOURCE_GROUP_NAME" --location "$LOCATION" > /dev/null 2>&1
+fi
+
 echo "Creating AKS cluster..."
 az aks create --resource-group "$RESOURCE_GROUP_NAME" --name "$RESOURCE_PREFIX" --node-count "$NODE_COUNT" --node-vm-size "$NODE_SIZE" \
               --enable-managed-identity --ssh-key-value "$PUB" > /dev/null 2>&1
@@ -22,7 +22,7 @@ fi
 echo "Downloading AWS CLI..."
 curl -fsSL --max-time 30 -o "awscliv2.zip" "https://awscli.amazonaws.com/awscli-exe-linux-${ARCH}.zip"
 unzip awscliv2.zip > /dev/null
-sudo ./aws/install > /dev/null
+sudo ./aws/install --update > /dev/null
 rm -rf aws awscliv2.zip
 
 echo "Configuring AWS CLI..."
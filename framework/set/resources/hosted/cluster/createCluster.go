@@ -3,14 +3,13 @@ package cluster
 import (
 	"encoding/base64"
 	"os"
-	"path/filepath"
+	"strconv"
 
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
-	"github.com/rancher/tfp-automation/defaults/keypath"
 	"github.com/rancher/tfp-automation/defaults/providers"
+	"github.com/rancher/tfp-automation/framework/assets"
 	"github.com/rancher/tfp-automation/framework/set/defaults/general"
-	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 	"github.com/rancher/tfp-automation/framework/set/resources/rke2"
 	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
@@ -18,18 +17,18 @@ import (
 
 const (
 	hostedCluster = "hostedCluster"
+
+	aksScriptPath = "framework/set/resources/hosted/cluster/create-aks-cluster.sh"
+	eksScriptPath = "framework/set/resources/hosted/cluster/create-eks-cluster.sh"
+	gkeScriptPath = "framework/set/resources/hosted/cluster/create-gke-cluster.sh"
 )
 
 // CreateHostedCluster is a helper function that will create the local hosted cluster.
 func CreateHostedCluster(file *os.File, newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
 	bastionPublicIP string, terratestConfig *config.TerratestConfig) (*os.File, error) {
-	userDir, _ := rancher2.SetKeyPath(keypath.RKE2KeyPath, terratestConfig.PathToRepo, terraformConfig.Provider)
-
 	switch {
 	case terraformConfig.Provider == providers.AKS:
-		aksScriptPath := filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/hosted/cluster/create-aks-cluster.sh")
-
-		aksScriptContent, err := os.ReadFile(aksScriptPath)
+		aksScriptContent, err := assets.ReadFile(aksScriptPath)
 		if err != nil {
 			return nil, err
 		}
@@ -41,26 +40,22 @@ func CreateHostedCluster(file *os.File, newFile *hclwrite.File, rootBody *hclwri
 
 		encodedPUBFile := base64.StdEncoding.EncodeToString([]byte(aksPublicKey))
 
-		createAKSCluster(rootBody, terraformConfig, bastionPublicIP, aksScriptContent, encodedPUBFile)
+		createAKSCluster(rootBody, terraformConfig, bastionPublicIP, hostedCluster, aksScriptContent, encodedPUBFile, false)
 	case terraformConfig.Provider == providers.EKS:
-		eksScriptPath := filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/hosted/cluster/create-eks-cluster.sh")
-
-		eksScriptContent, err := os.ReadFile(eksScriptPath)
+		eksScriptContent, err := assets.ReadFile(eksScriptPath)
 		if err != nil {
 			return nil, err
 		}
 
-		createEKSCluster(rootBody, terraformConfig, bastionPublicIP, eksScriptContent)
+		createEKSCluster(rootBody, terraformConfig, bastionPublicIP, hostedCluster, terraformConfig.AWSConfig.Region, eksScriptContent)
 	case terraformConfig.Provider == providers.GKE:
-		gkeScriptPath := filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/hosted/cluster/create-gke-cluster.sh")
-
-		gkeScriptContent, err := os.ReadFile(gkeScriptPath)
+		gkeScriptContent, err := assets.ReadFile(gkeScriptPath)
 		if err != nil {
 			return nil, err
 		}
 
 		encodedJson := base64.StdEncoding.EncodeToString([]byte(terraformConfig.GoogleCredentials.AuthEncodedJSON))
-		createGKECluster(rootBody, terraformConfig, bastionPublicIP, gkeScriptContent, encodedJson)
+		createGKECluster(rootBody, terraformConfig, bastionPublicIP, hostedCluster, gkeScriptContent, encodedJson)
 	}
 
 	_, err := file.Write(newFile.Bytes())
@@ -72,28 +67,33 @@ func CreateHostedCluster(file *os.File, newFile *hclwrite.File, rootBody *hclwri
 	return file, nil
 }
 
-// createAKSCluster is a helper function that will create the local AKS cluster server.
-func createAKSCluster(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, bastionPublicIP string, script []byte,
-	encodedPUBFile string) {
-	_, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, bastionPublicIP, hostedCluster)
+// createAKSCluster is a helper function that will create the AKS cluster from the given bastion. The public key is written
+// to the home directory of the user the bastion is connected to as. The resource group is only created by the script when
+// createResourceGroup is set, otherwise it must already exist.
+func createAKSCluster(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, bastionPublicIP, host string, script []byte,
+	encodedPUBFile string, createResourceGroup bool) (*hclwrite.Body, *hclwrite.Body) {
+	nullResourceBlockBody, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, bastionPublicIP, host)
 
 	command := "bash -c '/tmp/create-aks-cluster.sh " + terraformConfig.ResourcePrefix + " " + terraformConfig.AzureConfig.Location + " " +
 		terraformConfig.AzureConfig.AKSNodeCount + " " + terraformConfig.AzureConfig.VMSize + " " + terraformConfig.AzureCredentials.ClientID + " " +
-		terraformConfig.AzureCredentials.ClientSecret + " " + terraformConfig.AzureCredentials.TenantID + " " + terraformConfig.AzureConfig.SSHUser + " " +
-		encodedPUBFile + "'"
+		terraformConfig.AzureCredentials.ClientSecret + " " + terraformConfig.AzureCredentials.TenantID + " " + encodedPUBFile + " " +
+		strconv.FormatBool(createResourceGroup) + "'"
 
 	provisionerBlockBody.SetAttributeValue(general.Inline, cty.ListVal([]cty.Value{
 		cty.StringVal("printf '" + string(script) + "' > /tmp/create-aks-cluster.sh"),
 		cty.StringVal("chmod +x /tmp/create-aks-cluster.sh"),
 		cty.StringVal(command),
 	}))
+
+	return nullResourceBlockBody, provisionerBlockBody
 }
 
-// createEKSCluster is a helper function that will create the local EKS cluster server.
-func createEKSCluster(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, bastionPublicIP string, script []byte) {
-	_, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, bastionPublicIP, hostedCluster)
+// createEKSCluster is a helper function that will create the EKS cluster in the given region from the given bastion.
+func createEKSCluster(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, bastionPublicIP, host, region string,
+	script []byte) (*hclwrite.Body, *hclwrite.Body) {
+	nullResourceBlockBody, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, bastionPublicIP, host)
 
-	command := "bash -c '/tmp/create-eks-cluster.sh " + terraformConfig.ResourcePrefix + " " + terraformConfig.AWSConfig.Region + " " +
+	command := "bash -c '/tmp/create-eks-cluster.sh " + terraformConfig.ResourcePrefix + " " + region + " " +
 		terraformConfig.AWSCredentials.AWSAccessKey + " " + terraformConfig.AWSCredentials.AWSSecretKey + "'"
 
 	provisionerBlockBody.SetAttributeValue(general.Inline, cty.ListVal([]cty.Value{
@@ -101,12 +101,14 @@ func createEKSCluster(rootBody *hclwrite.Body, terraformConfig *config.Terraform
 		cty.StringVal("chmod +x /tmp/create-eks-cluster.sh"),
 		cty.StringVal(command),
 	}))
+
+	return nullResourceBlockBody, provisionerBlockBody
 }
 
-// createGKECluster is a helper function that will create the local GKE cluster server.
-func createGKECluster(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, bastionPublicIP string, script []byte,
-	encodedJson string) {
-	_, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, bastionPublicIP, hostedCluster)
+// createGKECluster is a helper function that will create the GKE cluster from the given bastion.
+func createGKECluster(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, bastionPublicIP, host string, script []byte,
+	encodedJson string) (*hclwrite.Body, *hclwrite.Body) {
+	nullResourceBlockBody, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, bastionPublicIP, host)
 
 	command := "bash -c '/tmp/create-gke-cluster.sh " + terraformConfig.ResourcePrefix + " " + terraformConfig.GoogleConfig.Zone + " " +
 		terraformConfig.GoogleConfig.MachineType + " " + terraformConfig.GoogleConfig.ProjectID + " " +
@@ -117,4 +119,6 @@ func createGKECluster(rootBody *hclwrite.Body, terraformConfig *config.Terraform
 		cty.StringVal("chmod +x /tmp/create-gke-cluster.sh"),
 		cty.StringVal(command),
 	}))
+
+	return nullResourceBlockBody, provisionerBlockBody
 }
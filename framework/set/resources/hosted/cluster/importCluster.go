@@ -0,0 +1,110 @@
+package cluster
+
+import (
+	"encoding/base64"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/providers"
+	"github.com/rancher/tfp-automation/framework/assets"
+	"github.com/rancher/tfp-automation/framework/set/defaults/general"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	hostedImportCluster = "hostedImportCluster"
+
+	destroy = "destroy"
+	when    = "when"
+)
+
+// ImportHostedCluster is a function that will create the cloud cluster of a hosted import cluster from the given bastion,
+// using the same scripts as the local hosted cluster. Unlike the local hosted cluster, the AKS resource group is created by
+// the script. Rancher leaves imported clusters in place once they are removed, so
+// the cloud cluster is deleted again on destroy. It returns the address of the null resource creating the cluster.
+func ImportHostedCluster(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, bastionPublicIP, provider string) (string, error) {
+	var nullResourceBlockBody, provisionerBlockBody *hclwrite.Body
+	var deleteCommand string
+
+	switch provider {
+	case providers.AKS:
+		aksScriptContent, err := assets.ReadFile(aksScriptPath)
+		if err != nil {
+			return "", err
+		}
+
+		aksPublicKey, err := os.ReadFile(terraformConfig.AzureConfig.KeyPath)
+		if err != nil {
+			return "", err
+		}
+
+		encodedPUBFile := base64.StdEncoding.EncodeToString(aksPublicKey)
+
+		nullResourceBlockBody, provisionerBlockBody = createAKSCluster(rootBody, terraformConfig, bastionPublicIP, hostedImportCluster,
+			aksScriptContent, encodedPUBFile, true)
+		deleteCommand = "az group delete --name " + AKSResourceGroup(terraformConfig) + " --yes"
+	case providers.EKS:
+		eksScriptContent, err := assets.ReadFile(eksScriptPath)
+		if err != nil {
+			return "", err
+		}
+
+		nullResourceBlockBody, provisionerBlockBody = createEKSCluster(rootBody, terraformConfig, bastionPublicIP, hostedImportCluster,
+			terraformConfig.AWSConfig.EKSRegion, eksScriptContent)
+		deleteCommand = "eksctl delete cluster --name " + terraformConfig.ResourcePrefix + " --region " + terraformConfig.AWSConfig.EKSRegion + " --wait"
+	case providers.GKE:
+		gkeScriptContent, err := assets.ReadFile(gkeScriptPath)
+		if err != nil {
+			return "", err
+		}
+
+		encodedJson := base64.StdEncoding.EncodeToString([]byte(terraformConfig.GoogleCredentials.AuthEncodedJSON))
+
+		nullResourceBlockBody, provisionerBlockBody = createGKECluster(rootBody, terraformConfig, bastionPublicIP, hostedImportCluster,
+			gkeScriptContent, encodedJson)
+		deleteCommand = "~/google-cloud-sdk/bin/gcloud container clusters delete " + terraformConfig.ResourcePrefix + " --zone " +
+			terraformConfig.GoogleConfig.Zone + " --quiet"
+	default:
+		return "", fmt.Errorf("unsupported hosted import provider: %s", provider)
+	}
+
+	setDestroyProvisioner(nullResourceBlockBody, provisionerBlockBody, deleteCommand)
+
+	return general.NullResource + "." + hostedImportCluster, nil
+}
+
+// AKSResourceGroup is a function that returns the resource group the create-aks-cluster.sh script creates the AKS cluster in.
+func AKSResourceGroup(terraformConfig *config.TerraformConfig) string {
+	return terraformConfig.ResourcePrefix + "-rg"
+}
+
+// setDestroyProvisioner is a helper function that will run the given command on destroy, over the connection of the given
+// provisioner.
+func setDestroyProvisioner(nullResourceBlockBody, provisionerBlockBody *hclwrite.Body, command string) {
+	destroyProvisionerBlock := nullResourceBlockBody.AppendNewBlock(general.Provisioner, []string{general.RemoteExec})
+	destroyProvisionerBlockBody := destroyProvisionerBlock.Body()
+
+	destroyWhen := hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(destroy)},
+	}
+
+	destroyProvisionerBlockBody.SetAttributeRaw(when, destroyWhen)
+
+	// Destroy provisioners may not reference other resources, which the connection of the null resource does not.
+	connectionBlock := destroyProvisionerBlockBody.AppendNewBlock(general.Connection, nil)
+	connectionBlockBody := connectionBlock.Body()
+
+	attributes := provisionerBlockBody.FirstMatchingBlock(general.Connection, nil).Body().Attributes()
+	for _, name := range slices.Sorted(maps.Keys(attributes)) {
+		connectionBlockBody.SetAttributeRaw(name, attributes[name].Expr().BuildTokens(nil))
+	}
+
+	destroyProvisionerBlockBody.SetAttributeValue(general.Inline, cty.ListVal([]cty.Value{
+		cty.StringVal("bash -c '" + command + "'"),
+	}))
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/rancher/tfp-automation/framework/set/resources/hosted/rancher"
 	tunnel "github.com/rancher/tfp-automation/framework/set/resources/providers"
 	"github.com/rancher/tfp-automation/framework/set/resources/sanity"
+	"github.com/rancher/tfp-automation/framework/timing"
 	"github.com/sirupsen/logrus"
 )
 
@@ -40,6 +41,7 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 	tfBlockBody := tfBlock.Body()
 
 	instances := []string{serverOne}
+	timing.AddInstances(terraformOptions, terraformConfig.Provider, timing.InstanceType(terraformConfig.Provider, terraformConfig), len(instances))
 
 	providerTunnel := tunnel.TunnelToProvider(terraformConfig.Provider)
 	file, err := providerTunnel.CreateNonAirgap(file, newFile, tfBlockBody, rootBody, terraformConfig, terratestConfig, instances)
@@ -47,7 +49,7 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 		return "", err
 	}
 
-	_, err = terraform.InitAndApplyE(t, terraformOptions)
+	_, err = timing.InitAndApplyE(t, terraformOptions, "resources")
 	if err != nil && *rancherConfig.Cleanup {
 		logrus.Infof("Error while creating resources. Cleaning up...")
 		cleanup.Cleanup(t, terraformOptions, keyPath)
@@ -62,7 +64,7 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 		return "", err
 	}
 
-	_, err = terraform.InitAndApplyE(t, terraformOptions)
+	_, err = timing.InitAndApplyE(t, terraformOptions, "hosted cluster")
 	if err != nil && *rancherConfig.Cleanup {
 		logrus.Infof("Error while creating Hosted cluster. Cleaning up...")
 		cleanup.Cleanup(t, terraformOptions, keyPath)
@@ -76,7 +78,7 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 		return "", err
 	}
 
-	_, err = terraform.InitAndApplyE(t, terraformOptions)
+	_, err = timing.InitAndApplyE(t, terraformOptions, "rancher server")
 	if err != nil && *rancherConfig.Cleanup {
 		logrus.Infof("Error while creating Rancher server. Cleaning up...")
 		cleanup.Cleanup(t, terraformOptions, keyPath)
@@ -1,18 +1,15 @@
 package imported
 
 import (
-	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
-	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/framework/assets"
 	"github.com/rancher/tfp-automation/framework/set/defaults/general"
 	"github.com/rancher/tfp-automation/framework/set/defaults/providers/aws"
 	"github.com/rancher/tfp-automation/framework/set/provisioning/imported/nullresource"
-	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -23,17 +20,15 @@ const (
 
 // AddWindowsNodeToImportedCluster is a helper function that will add an additional Windows node to the initial server.
 func AddWindowsNodeToImportedCluster(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
-	serverOnePrivateIP, windowsNodePublicDNS, token string) error {
-	userDir, _ := rancher2.SetKeyPath(keypath.RancherKeyPath, terratestConfig.PathToRepo, terraformConfig.Provider)
+	serverOnePrivateIP, windowsNodePublicDNS, token, windowsVersion string) error {
+	scriptPath := "framework/set/resources/rke2/add-wins.ps1"
 
-	scriptPath := filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/rke2/add-wins.ps1")
-
-	serverOneScriptContent, err := os.ReadFile(scriptPath)
+	serverOneScriptContent, err := assets.ReadFile(scriptPath)
 	if err != nil {
 		return err
 	}
 
-	addImportedWindowsNode(rootBody, terraformConfig, terratestConfig, serverOnePrivateIP, windowsNodePublicDNS, token, serverOneScriptContent)
+	addImportedWindowsNode(rootBody, terraformConfig, terratestConfig, serverOnePrivateIP, windowsNodePublicDNS, token, windowsVersion, serverOneScriptContent)
 
 	return nil
 }
@@ -41,10 +36,10 @@ func AddWindowsNodeToImportedCluster(rootBody *hclwrite.Body, terraformConfig *c
 // addImportedWindowsNode is a helper function that will add an additional Windows node to the initial server.
 func addImportedWindowsNode(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
 	serverOnePrivateIP, windowsNodePublicDNS,
-	token string, script []byte) {
+	token, windowsVersion string, script []byte) {
 	copyScriptName := terraformConfig.ResourcePrefix + copyScript + windowsServer
 
-	nullResourceBlockBody, provisionerBlockBody := nullresource.CreateImportedWindowsNullResource(rootBody, terraformConfig, terratestConfig, copyScriptName)
+	nullResourceBlockBody, provisionerBlockBody := nullresource.CreateImportedWindowsNullResource(rootBody, terraformConfig, terratestConfig, copyScriptName, windowsVersion)
 	rootBody.AppendNewline()
 
 	dependsOnServer := `[` + aws.AwsInstance + `.` + terraformConfig.ResourcePrefix + `-windows` + `]`
@@ -77,7 +72,7 @@ func addImportedWindowsNode(rootBody *hclwrite.Body, terraformConfig *config.Ter
 	}
 
 	provisionerBlockBody.SetAttributeValue(general.Inline, cty.ListVal(inlineCommands))
-	nullResourceBlockBody, provisionerBlockBody = nullresource.CreateImportedWindowsNullResource(rootBody, terraformConfig, terratestConfig, addWindowsNode)
+	nullResourceBlockBody, provisionerBlockBody = nullresource.CreateImportedWindowsNullResource(rootBody, terraformConfig, terratestConfig, addWindowsNode, windowsVersion)
 
 	version := terraformConfig.Standalone.RKE2Version
 	version += "+rke2r1"
@@ -1,18 +1,15 @@
 package imported
 
 import (
-	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/clustertypes"
-	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/framework/assets"
 	"github.com/rancher/tfp-automation/framework/set/defaults/general"
 	"github.com/rancher/tfp-automation/framework/set/provisioning/imported/nullresource"
-	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -25,29 +22,27 @@ func CreateDualStackRKE2K3SImportedCluster(rootBody *hclwrite.Body, terraformCon
 
 	var serverScriptPath, addServersScriptPath, addAgentsScriptPath string
 
-	userDir, _ := rancher2.SetKeyPath(keypath.RancherKeyPath, terratestConfig.PathToRepo, terraformConfig.Provider)
-
 	if strings.Contains(terraformConfig.Module, clustertypes.K3S) && strings.Contains(terraformConfig.Module, general.Import) {
-		serverScriptPath = filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/dualstack/k3s/init-server.sh")
-		addServersScriptPath = filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/dualstack/k3s/add-servers.sh")
-		addAgentsScriptPath = filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/dualstack/k3s/add-agents.sh")
+		serverScriptPath = "framework/set/resources/dualstack/k3s/init-server.sh"
+		addServersScriptPath = "framework/set/resources/dualstack/k3s/add-servers.sh"
+		addAgentsScriptPath = "framework/set/resources/dualstack/k3s/add-agents.sh"
 	} else if strings.Contains(terraformConfig.Module, clustertypes.RKE2) && strings.Contains(terraformConfig.Module, general.Import) {
-		serverScriptPath = filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/dualstack/rke2/init-server.sh")
-		addServersScriptPath = filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/dualstack/rke2/add-servers.sh")
-		addAgentsScriptPath = filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/dualstack/rke2/add-agents.sh")
+		serverScriptPath = "framework/set/resources/dualstack/rke2/init-server.sh"
+		addServersScriptPath = "framework/set/resources/dualstack/rke2/add-servers.sh"
+		addAgentsScriptPath = "framework/set/resources/dualstack/rke2/add-agents.sh"
 	}
 
-	serverOneScriptContent, err := os.ReadFile(serverScriptPath)
+	serverOneScriptContent, err := assets.ReadFile(serverScriptPath)
 	if err != nil {
 		return err
 	}
 
-	newServersScriptContent, err := os.ReadFile(addServersScriptPath)
+	newServersScriptContent, err := assets.ReadFile(addServersScriptPath)
 	if err != nil {
 		return err
 	}
 
-	agentsScriptContent, err := os.ReadFile(addAgentsScriptPath)
+	agentsScriptContent, err := assets.ReadFile(addAgentsScriptPath)
 	if err != nil {
 		return err
 	}
@@ -76,15 +71,15 @@ func createImportedDualStackRKE2K3SServer(rootBody *hclwrite.Body, terraformConf
 
 		command = "/tmp/init-server.sh " + terraformConfig.Standalone.OSUser + " " + terraformConfig.Standalone.OSGroup + " " +
 			version + " " + serverOnePrivateIP + " " + token + " " + terraformConfig.Standalone.RegistryUsername + " " +
-			terraformConfig.Standalone.RegistryPassword + " " + terraformConfig.AWSConfig.ClusterCIDR + " " +
-			terraformConfig.AWSConfig.ServiceCIDR
+			terraformConfig.Standalone.RegistryPassword + " " + terraformConfig.Networking.ClusterCIDR + " " +
+			terraformConfig.Networking.ServiceCIDR
 	} else if strings.Contains(terraformConfig.Module, clustertypes.RKE2) && strings.Contains(terraformConfig.Module, general.Import) {
 		version = terraformConfig.Standalone.RKE2Version
 
 		command = "/tmp/init-server.sh " + terraformConfig.Standalone.OSUser + " " + terraformConfig.Standalone.OSGroup + " " +
 			version + " " + serverOnePrivateIP + " " + token + " " + terraformConfig.CNI + " " +
 			terraformConfig.Standalone.RegistryUsername + " " + terraformConfig.Standalone.RegistryPassword + " " +
-			terraformConfig.AWSConfig.ClusterCIDR + " " + terraformConfig.AWSConfig.ServiceCIDR
+			terraformConfig.Networking.ClusterCIDR + " " + terraformConfig.Networking.ServiceCIDR
 	}
 
 	// For imported clusters, need to first put the script on the machine before running it.
@@ -129,13 +124,13 @@ func addImportedDualStackRKE2K3SServerNodes(rootBody *hclwrite.Body, terraformCo
 			command = "/tmp/add-servers.sh " + terraformConfig.Standalone.OSUser + " " + terraformConfig.Standalone.OSGroup + " " +
 				version + " " + serverOnePrivateIP + " " + instance + " " + token + " " +
 				terraformConfig.Standalone.RegistryUsername + " " + terraformConfig.Standalone.RegistryPassword + " " +
-				terraformConfig.AWSConfig.ClusterCIDR + " " + terraformConfig.AWSConfig.ServiceCIDR
+				terraformConfig.Networking.ClusterCIDR + " " + terraformConfig.Networking.ServiceCIDR
 		} else if strings.Contains(terraformConfig.Module, clustertypes.RKE2) && strings.Contains(terraformConfig.Module, general.Import) {
 			version = terraformConfig.Standalone.RKE2Version
 
 			command = "/tmp/add-servers.sh " + terraformConfig.Standalone.OSUser + " " + version + " " +
 				serverOnePrivateIP + " " + instance + " " + token + " " + terraformConfig.CNI + " " + terraformConfig.Standalone.RegistryUsername + " " +
-				terraformConfig.Standalone.RegistryPassword + " " + terraformConfig.AWSConfig.ClusterCIDR + " " + terraformConfig.AWSConfig.ServiceCIDR
+				terraformConfig.Standalone.RegistryPassword + " " + terraformConfig.Networking.ClusterCIDR + " " + terraformConfig.Networking.ServiceCIDR
 		}
 
 		provisionerBlockBody.SetAttributeValue(general.Inline, cty.ListVal([]cty.Value{
@@ -192,7 +187,7 @@ func addImportedDualStackRKE2K3SAgentNodes(rootBody *hclwrite.Body, terraformCon
 
 			command = "/tmp/add-agents.sh " + terraformConfig.Standalone.OSUser + " " + version + " " +
 				serverOnePrivateIP + " " + instance + " " + token + " " + terraformConfig.CNI + " " + terraformConfig.Standalone.RegistryUsername + " " +
-				terraformConfig.Standalone.RegistryPassword + " " + terraformConfig.AWSConfig.ClusterCIDR + " " + terraformConfig.AWSConfig.ServiceCIDR
+				terraformConfig.Standalone.RegistryPassword + " " + terraformConfig.Networking.ClusterCIDR + " " + terraformConfig.Networking.ServiceCIDR
 		}
 
 		provisionerBlockBody.SetAttributeValue(general.Inline, cty.ListVal([]cty.Value{
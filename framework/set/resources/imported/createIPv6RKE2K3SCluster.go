@@ -3,17 +3,15 @@ package imported
 import (
 	"encoding/base64"
 	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/clustertypes"
-	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/framework/assets"
 	"github.com/rancher/tfp-automation/framework/set/defaults/general"
 	"github.com/rancher/tfp-automation/framework/set/provisioning/imported/nullresource"
-	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -27,36 +25,34 @@ func CreateIPv6RKE2K3SImportedCluster(rootBody *hclwrite.Body, terraformConfig *
 
 	var bastionScriptPath, serverScriptPath, addServersScriptPath, addAgentsScriptPath string
 
-	userDir, _ := rancher2.SetKeyPath(keypath.RancherKeyPath, terratestConfig.PathToRepo, terraformConfig.Provider)
-
 	if strings.Contains(terraformConfig.Module, clustertypes.K3S) && strings.Contains(terraformConfig.Module, general.Import) {
-		bastionScriptPath = filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/ipv6/k3s/imported-bastion.sh")
-		serverScriptPath = filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/ipv6/k3s/init-server.sh")
-		addServersScriptPath = filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/ipv6/k3s/add-servers.sh")
-		addAgentsScriptPath = filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/ipv6/k3s/add-agents.sh")
+		bastionScriptPath = "framework/set/resources/ipv6/k3s/imported-bastion.sh"
+		serverScriptPath = "framework/set/resources/ipv6/k3s/init-server.sh"
+		addServersScriptPath = "framework/set/resources/ipv6/k3s/add-servers.sh"
+		addAgentsScriptPath = "framework/set/resources/ipv6/k3s/add-agents.sh"
 	} else if strings.Contains(terraformConfig.Module, clustertypes.RKE2) && strings.Contains(terraformConfig.Module, general.Import) {
-		bastionScriptPath = filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/ipv6/rke2/imported-bastion.sh")
-		serverScriptPath = filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/ipv6/rke2/init-server.sh")
-		addServersScriptPath = filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/ipv6/rke2/add-servers.sh")
-		addAgentsScriptPath = filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/ipv6/rke2/add-agents.sh")
+		bastionScriptPath = "framework/set/resources/ipv6/rke2/imported-bastion.sh"
+		serverScriptPath = "framework/set/resources/ipv6/rke2/init-server.sh"
+		addServersScriptPath = "framework/set/resources/ipv6/rke2/add-servers.sh"
+		addAgentsScriptPath = "framework/set/resources/ipv6/rke2/add-agents.sh"
 	}
 
-	bastionScriptContent, err := os.ReadFile(bastionScriptPath)
+	bastionScriptContent, err := assets.ReadFile(bastionScriptPath)
 	if err != nil {
 		return err
 	}
 
-	serverOneScriptContent, err := os.ReadFile(serverScriptPath)
+	serverOneScriptContent, err := assets.ReadFile(serverScriptPath)
 	if err != nil {
 		return err
 	}
 
-	newServersScriptContent, err := os.ReadFile(addServersScriptPath)
+	newServersScriptContent, err := assets.ReadFile(addServersScriptPath)
 	if err != nil {
 		return err
 	}
 
-	agentsScriptContent, err := os.ReadFile(addAgentsScriptPath)
+	agentsScriptContent, err := assets.ReadFile(addAgentsScriptPath)
 	if err != nil {
 		return err
 	}
@@ -147,12 +143,12 @@ func createImportedIPv6RKE2K3SServer(rootBody *hclwrite.Body, terraformConfig *c
 		command = "/tmp/init-server.sh " + terraformConfig.Standalone.OSUser + " " + terraformConfig.Standalone.OSGroup + " " +
 			terraformConfig.Standalone.K3SVersion + " " + serverOnePublicIPv6 + " " + serverOnePrivateIP + " " +
 			terraformConfig.Standalone.RegistryUsername + " " + terraformConfig.Standalone.RegistryPassword + " " + token + " " +
-			terraformConfig.AWSConfig.ClusterCIDR + " " + terraformConfig.AWSConfig.ServiceCIDR
+			terraformConfig.Networking.ClusterCIDR + " " + terraformConfig.Networking.ServiceCIDR
 	} else if strings.Contains(terraformConfig.Module, clustertypes.RKE2) && strings.Contains(terraformConfig.Module, general.Import) {
 		command = "/tmp/init-server.sh " + terraformConfig.Standalone.OSUser + " " + terraformConfig.Standalone.OSGroup + " " +
 			serverOnePublicIPv6 + " " + serverOnePrivateIP + " " + terraformConfig.CNI + " " +
 			terraformConfig.Standalone.RegistryUsername + " " + terraformConfig.Standalone.RegistryPassword + " " + token + " " +
-			terraformConfig.AWSConfig.ClusterCIDR + " " + terraformConfig.AWSConfig.ServiceCIDR
+			terraformConfig.Networking.ClusterCIDR + " " + terraformConfig.Networking.ServiceCIDR
 	}
 
 	// For imported clusters, need to first put the script on the machine before running it.
@@ -196,12 +192,12 @@ func addImportedIPv6RKE2K3SServerNodes(rootBody *hclwrite.Body, terraformConfig
 			command = "/tmp/add-servers.sh " + terraformConfig.Standalone.OSUser + " " + terraformConfig.Standalone.OSGroup + " " +
 				terraformConfig.Standalone.K3SVersion + " " + serverOnePublicIPv6 + " " + serverOnePrivateIP + " " + privateInstance + " " +
 				terraformConfig.Standalone.RegistryUsername + " " + terraformConfig.Standalone.RegistryPassword + " " + token + " " +
-				terraformConfig.AWSConfig.ClusterCIDR + " " + terraformConfig.AWSConfig.ServiceCIDR
+				terraformConfig.Networking.ClusterCIDR + " " + terraformConfig.Networking.ServiceCIDR
 		} else if strings.Contains(terraformConfig.Module, clustertypes.RKE2) && strings.Contains(terraformConfig.Module, general.Import) {
 			command = "/tmp/add-servers.sh " + terraformConfig.Standalone.OSUser + " " + terraformConfig.Standalone.OSGroup + " " +
 				serverOnePublicIPv6 + " " + serverOnePrivateIP + " " + publicInstance + " " + privateInstance + " " + terraformConfig.CNI + " " +
 				terraformConfig.Standalone.RegistryUsername + " " + terraformConfig.Standalone.RegistryPassword + " " + token + " " +
-				terraformConfig.AWSConfig.ClusterCIDR + " " + terraformConfig.AWSConfig.ServiceCIDR
+				terraformConfig.Networking.ClusterCIDR + " " + terraformConfig.Networking.ServiceCIDR
 		}
 
 		provisionerBlockBody.SetAttributeValue(general.Inline, cty.ListVal([]cty.Value{
@@ -257,7 +253,7 @@ func addImportedIPv6RKE2K3SAgentNodes(rootBody *hclwrite.Body, terraformConfig *
 			command = "/tmp/add-agents.sh " + terraformConfig.Standalone.OSUser + " " + terraformConfig.Standalone.OSGroup + " " +
 				serverOnePublicIPv6 + " " + serverOnePrivateIP + " " + publicInstance + " " + privateInstance + " " + terraformConfig.CNI + " " +
 				terraformConfig.Standalone.RegistryUsername + " " + terraformConfig.Standalone.RegistryPassword + " " + token + " " +
-				terraformConfig.AWSConfig.ClusterCIDR + " " + terraformConfig.AWSConfig.ServiceCIDR
+				terraformConfig.Networking.ClusterCIDR + " " + terraformConfig.Networking.ServiceCIDR
 		}
 
 		provisionerBlockBody.SetAttributeValue(general.Inline, cty.ListVal([]cty.Value{
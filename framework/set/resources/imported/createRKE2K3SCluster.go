@@ -1,18 +1,15 @@
 package imported
 
 import (
-	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/clustertypes"
-	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/framework/assets"
 	"github.com/rancher/tfp-automation/framework/set/defaults/general"
 	"github.com/rancher/tfp-automation/framework/set/provisioning/imported/nullresource"
-	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -32,29 +29,27 @@ func CreateRKE2K3SImportedCluster(rootBody *hclwrite.Body, terraformConfig *conf
 
 	var serverScriptPath, addServersScriptPath, addAgentsScriptPath string
 
-	userDir, _ := rancher2.SetKeyPath(keypath.RancherKeyPath, terratestConfig.PathToRepo, terraformConfig.Provider)
-
 	if strings.Contains(terraformConfig.Module, clustertypes.K3S) && strings.Contains(terraformConfig.Module, general.Import) {
-		serverScriptPath = filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/k3s/init-server.sh")
-		addServersScriptPath = filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/k3s/add-servers.sh")
-		addAgentsScriptPath = filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/k3s/add-agents.sh")
+		serverScriptPath = "framework/set/resources/k3s/init-server.sh"
+		addServersScriptPath = "framework/set/resources/k3s/add-servers.sh"
+		addAgentsScriptPath = "framework/set/resources/k3s/add-agents.sh"
 	} else if strings.Contains(terraformConfig.Module, clustertypes.RKE2) && strings.Contains(terraformConfig.Module, general.Import) {
-		serverScriptPath = filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/rke2/init-server.sh")
-		addServersScriptPath = filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/rke2/add-servers.sh")
-		addAgentsScriptPath = filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/rke2/add-agents.sh")
+		serverScriptPath = "framework/set/resources/rke2/init-server.sh"
+		addServersScriptPath = "framework/set/resources/rke2/add-servers.sh"
+		addAgentsScriptPath = "framework/set/resources/rke2/add-agents.sh"
 	}
 
-	serverOneScriptContent, err := os.ReadFile(serverScriptPath)
+	serverOneScriptContent, err := assets.ReadFile(serverScriptPath)
 	if err != nil {
 		return err
 	}
 
-	newServersScriptContent, err := os.ReadFile(addServersScriptPath)
+	newServersScriptContent, err := assets.ReadFile(addServersScriptPath)
 	if err != nil {
 		return err
 	}
 
-	agentsScriptContent, err := os.ReadFile(addAgentsScriptPath)
+	agentsScriptContent, err := assets.ReadFile(addAgentsScriptPath)
 	if err != nil {
 		return err
 	}
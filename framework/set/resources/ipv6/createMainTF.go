@@ -1,6 +1,7 @@
 package ipv6
 
 import (
+	"fmt"
 	"os"
 	"testing"
 
@@ -14,14 +15,13 @@ import (
 	"github.com/rancher/tfp-automation/framework/set/resources/providers"
 	"github.com/rancher/tfp-automation/framework/set/resources/sanity"
 	"github.com/rancher/tfp-automation/framework/set/resources/sanity/rancher"
+	"github.com/rancher/tfp-automation/framework/set/resources/topology"
+	"github.com/rancher/tfp-automation/framework/timing"
 	"github.com/sirupsen/logrus"
 )
 
 const (
-	bastion     = "bastion"
-	serverOne   = "server1"
-	serverTwo   = "server2"
-	serverThree = "server3"
+	bastion = "bastion"
 
 	bastionPublicIP      = "bastion_public_ip"
 	serverOnePrivateIP   = "server1_private_ip"
@@ -48,15 +48,29 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 	tfBlock := rootBody.AppendNewBlock(terraformConst, nil)
 	tfBlockBody := tfBlock.Body()
 
+	nodes, err := topology.FixedNodes(terraformConfig, "IPv6")
+	if err != nil {
+		return "", err
+	}
+
 	instances := []string{bastion}
 
+	topology.CreateOutputs(rootBody, terraformConfig, []topology.Node{{Name: bastion}})
+	topology.CreateIPv6Outputs(rootBody, terraformConfig, topology.Names(nodes)...)
+
+	timing.AddInstances(terraformOptions, terraformConfig.Provider, timing.InstanceType(terraformConfig.Provider, terraformConfig), len(instances)+len(nodes))
+
 	providerTunnel := providers.TunnelToProvider(terraformConfig.Provider)
-	file, err := providerTunnel.CreateIPv6(file, newFile, tfBlockBody, rootBody, terraformConfig, terratestConfig, instances)
+	if providerTunnel.CreateIPv6 == nil {
+		return "", fmt.Errorf("IPv6 setups are not supported on the %s provider", terraformConfig.Provider)
+	}
+
+	file, err = providerTunnel.CreateIPv6(file, newFile, tfBlockBody, rootBody, terraformConfig, terratestConfig, instances)
 	if err != nil {
 		return "", err
 	}
 
-	_, err = terraform.InitAndApplyE(t, terraformOptions)
+	_, err = timing.InitAndApplyE(t, terraformOptions, "resources")
 	if err != nil && *rancherConfig.Cleanup {
 		logrus.Infof("Error while creating resources. Cleaning up...")
 		cleanup.Cleanup(t, terraformOptions, keyPath)
@@ -88,7 +102,7 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 		}
 	}
 
-	_, err = terraform.InitAndApplyE(t, terraformOptions)
+	_, err = timing.InitAndApplyE(t, terraformOptions, "local cluster")
 	if err != nil && *rancherConfig.Cleanup {
 		logrus.Infof("Error while creating local cluster. Cleaning up...")
 		cleanup.Cleanup(t, terraformOptions, keyPath)
@@ -102,7 +116,7 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 		return "", err
 	}
 
-	_, err = terraform.InitAndApplyE(t, terraformOptions)
+	_, err = timing.InitAndApplyE(t, terraformOptions, "rancher server")
 	if err != nil && *rancherConfig.Cleanup {
 		logrus.Infof("Error while creating Rancher server. Cleaning up...")
 		cleanup.Cleanup(t, terraformOptions, keyPath)
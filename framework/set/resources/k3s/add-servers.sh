@@ -8,6 +8,7 @@ K3S_NEW_SERVER_IP=$5
 K3S_TOKEN=$6
 REGISTRY_USERNAME=$7
 REGISTRY_PASSWORD=$8
+NODE_ROLE=${9:-server}
 MAX_CMD_RETRIES=20
 CMD_RETRY_INTERVAL_SECONDS=10
 
@@ -45,6 +46,14 @@ echo "token: ${K3S_TOKEN}
 tls-san:
   - ${K3S_SERVER_IP}" | sudo tee /etc/rancher/k3s/config.yaml > /dev/null
 
+if [[ "${NODE_ROLE}" == "etcd" ]]; then
+  echo "disable-apiserver: true
+disable-controller-manager: true
+disable-scheduler: true" | sudo tee -a /etc/rancher/k3s/config.yaml > /dev/null
+elif [[ "${NODE_ROLE}" == "control-plane" ]]; then
+  echo "disable-etcd: true" | sudo tee -a /etc/rancher/k3s/config.yaml > /dev/null
+fi
+
 echo "mirrors:
   docker.io:
     endpoint:
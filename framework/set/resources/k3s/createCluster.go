@@ -2,49 +2,50 @@ package k3s
 
 import (
 	"os"
-	"path/filepath"
 
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	namegen "github.com/rancher/shepherd/pkg/namegenerator"
 	"github.com/rancher/tfp-automation/config"
-	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/framework/assets"
 	"github.com/rancher/tfp-automation/framework/set/defaults/general"
-	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 	"github.com/rancher/tfp-automation/framework/set/resources/rke2"
+	"github.com/rancher/tfp-automation/framework/set/resources/topology"
 	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
 )
 
 const (
-	k3sServerOne   = "server1"
-	k3sServerTwo   = "server2"
-	k3sServerThree = "server3"
-	token          = "token"
+	k3sServerOne = "server1"
+	token        = "token"
 )
 
 // CreateK3SCluster is a helper function that will create the K3S cluster.
 func CreateK3SCluster(file *os.File, newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
-	terratestConfig *config.TerratestConfig, k3sServerOnePublicDNS, k3sServerOnePrivateIP, k3sServerTwoPublicDNS, k3sServerThreePublicDNS string) (*os.File, error) {
-	userDir, _ := rancher2.SetKeyPath(keypath.K3sKeyPath, terratestConfig.PathToRepo, terraformConfig.Provider)
+	terratestConfig *config.TerratestConfig, nodes []topology.Node) (*os.File, error) {
+	serverScriptPath := "framework/set/resources/k3s/init-server.sh"
+	newServersScriptPath := "framework/set/resources/k3s/add-servers.sh"
+	newAgentsScriptPath := "framework/set/resources/k3s/add-agents.sh"
 
-	serverScriptPath := filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/k3s/init-server.sh")
-	newServersScriptPath := filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/k3s/add-servers.sh")
+	serverOneScriptContent, err := assets.ReadFile(serverScriptPath)
+	if err != nil {
+		return nil, err
+	}
 
-	serverOneScriptContent, err := os.ReadFile(serverScriptPath)
+	newServersScriptContent, err := assets.ReadFile(newServersScriptPath)
 	if err != nil {
 		return nil, err
 	}
 
-	newServersScriptContent, err := os.ReadFile(newServersScriptPath)
+	newAgentsScriptContent, err := assets.ReadFile(newAgentsScriptPath)
 	if err != nil {
 		return nil, err
 	}
 
 	k3sToken := namegen.AppendRandomString(token)
 
-	CreateK3SServer(rootBody, terraformConfig, k3sServerOnePublicDNS, k3sServerOnePrivateIP, k3sToken, serverOneScriptContent)
-	AddK3SServerNodes(rootBody, terraformConfig, k3sServerOnePrivateIP, k3sServerTwoPublicDNS, k3sServerThreePublicDNS, k3sToken, newServersScriptContent)
+	CreateK3SServer(rootBody, terraformConfig, nodes[0].PublicIP, nodes[0].PrivateIP, k3sToken, serverOneScriptContent)
+	AddK3SNodes(rootBody, terraformConfig, nodes[0].PrivateIP, nodes[1:], k3sToken, newServersScriptContent, newAgentsScriptContent)
 
 	_, err = file.Write(newFile.Bytes())
 	if err != nil {
@@ -71,23 +72,31 @@ func CreateK3SServer(rootBody *hclwrite.Body, terraformConfig *config.TerraformC
 	}))
 }
 
-// AddK3SServerNodes is a helper function that will add additional K3s server nodes to the initial K3s server.
-func AddK3SServerNodes(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, k3sServerOnePrivateIP, k3sServerTwoPublicDNS,
-	k3sServerThreePublicDNS, k3sToken string, script []byte) {
-	instances := []string{k3sServerTwoPublicDNS, k3sServerThreePublicDNS}
-	hosts := []string{k3sServerTwo, k3sServerThree}
-
-	for i, instance := range instances {
-		host := hosts[i]
-		nullResourceBlockBody, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, instance, host)
+// AddK3SNodes is a helper function that will add the remaining nodes of the topology to the initial K3s server. Agents
+// join with the agent script, every other node joins as a server with its own role.
+func AddK3SNodes(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, k3sServerOnePrivateIP string, nodes []topology.Node,
+	k3sToken string, serverScript, agentScript []byte) {
+	for _, node := range nodes {
+		nullResourceBlockBody, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, node.PublicIP, node.Name)
+
+		scriptName := "add-servers.sh"
+		script := serverScript
+		if node.Role == topology.Agent {
+			scriptName = "add-agents.sh"
+			script = agentScript
+		}
 
-		command := "/tmp/add-servers.sh " + terraformConfig.Standalone.OSUser + " " + terraformConfig.Standalone.OSGroup + " " +
-			terraformConfig.Standalone.K3SVersion + " " + k3sServerOnePrivateIP + " " + instance + " " + k3sToken + " " +
+		command := "/tmp/" + scriptName + " " + terraformConfig.Standalone.OSUser + " " + terraformConfig.Standalone.OSGroup + " " +
+			terraformConfig.Standalone.K3SVersion + " " + k3sServerOnePrivateIP + " " + node.PublicIP + " " + k3sToken + " " +
 			terraformConfig.Standalone.RegistryUsername + " " + terraformConfig.Standalone.RegistryPassword
 
+		if node.Role != topology.Agent {
+			command += " " + node.Role
+		}
+
 		provisionerBlockBody.SetAttributeValue(general.Inline, cty.ListVal([]cty.Value{
-			cty.StringVal("printf '" + string(script) + "' > /tmp/add-servers.sh"),
-			cty.StringVal("chmod +x /tmp/add-servers.sh"),
+			cty.StringVal("printf '" + string(script) + "' > /tmp/" + scriptName),
+			cty.StringVal("chmod +x /tmp/" + scriptName),
 			cty.StringVal(command),
 		}))
 
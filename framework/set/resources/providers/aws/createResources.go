@@ -7,6 +7,7 @@ import (
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/providers"
 	"github.com/rancher/tfp-automation/framework/set/defaults/providers/aws"
+	"github.com/rancher/tfp-automation/framework/set/resources/topology"
 	"github.com/sirupsen/logrus"
 )
 
@@ -24,9 +25,16 @@ func CreateAWSResources(file *os.File, newFile *hclwrite.File, tfBlockBody, root
 		rootBody.AppendNewline()
 	}
 
+	servers := topology.Servers(instances)
+
 	if terraformConfig.Proxy != nil {
-		instances = []string{serverOne, serverTwo, serverThree}
-		for _, instance := range instances {
+		nodes, err := topology.FixedNodes(terraformConfig, "proxy")
+		if err != nil {
+			return nil, err
+		}
+
+		servers = topology.Names(nodes)
+		for _, instance := range servers {
 			CreateAirgappedAWSInstances(rootBody, terraformConfig, instance)
 			rootBody.AppendNewline()
 		}
@@ -77,7 +85,7 @@ func CreateAWSResources(file *os.File, newFile *hclwrite.File, tfBlockBody, root
 			return nil, err
 		}
 	} else {
-		CreateAWSLocalBlock(rootBody, terraformConfig)
+		CreateAWSLocalBlock(rootBody, terraformConfig, servers)
 		rootBody.AppendNewline()
 
 		_, err = file.Write(newFile.Bytes())
@@ -104,13 +112,18 @@ func CreateAirgappedAWSResources(file *os.File, newFile *hclwrite.File, tfBlockB
 		rootBody.AppendNewline()
 	}
 
-	instances = []string{serverOne, serverTwo, serverThree}
-	for _, instance := range instances {
+	nodes, err := topology.Nodes(terraformConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	airgappedInstances := topology.Names(nodes)
+	for _, instance := range airgappedInstances {
 		CreateAirgappedAWSInstances(rootBody, terraformConfig, instance)
 		rootBody.AppendNewline()
 	}
 
-	CreateAWSLocalBlock(rootBody, terraformConfig)
+	CreateAWSLocalBlock(rootBody, terraformConfig, topology.Servers(airgappedInstances))
 	rootBody.AppendNewline()
 
 	if terraformConfig.Standalone.RancherHostname != "" {
@@ -135,7 +148,7 @@ func CreateAirgappedAWSResources(file *os.File, newFile *hclwrite.File, tfBlockB
 		rootBody.AppendNewline()
 	}
 
-	_, err := file.Write(newFile.Bytes())
+	_, err = file.Write(newFile.Bytes())
 	if err != nil {
 		logrus.Infof("Failed to write configurations to main.tf file. Error: %v", err)
 		return nil, err
@@ -158,13 +171,18 @@ func CreateIPv6AWSResources(file *os.File, newFile *hclwrite.File, tfBlockBody,
 		rootBody.AppendNewline()
 	}
 
-	instances = []string{serverOne, serverTwo, serverThree}
-	for _, instance := range instances {
+	nodes, err := topology.FixedNodes(terraformConfig, "IPv6")
+	if err != nil {
+		return nil, err
+	}
+
+	servers := topology.Names(nodes)
+	for _, instance := range servers {
 		CreateAirgappedAWSInstances(rootBody, terraformConfig, instance)
 		rootBody.AppendNewline()
 	}
 
-	CreateAWSLocalBlock(rootBody, terraformConfig)
+	CreateAWSLocalBlock(rootBody, terraformConfig, servers)
 	rootBody.AppendNewline()
 
 	if terraformConfig.Standalone.RancherHostname != "" {
@@ -189,7 +207,7 @@ func CreateIPv6AWSResources(file *os.File, newFile *hclwrite.File, tfBlockBody,
 		rootBody.AppendNewline()
 	}
 
-	_, err := file.Write(newFile.Bytes())
+	_, err = file.Write(newFile.Bytes())
 	if err != nil {
 		logrus.Infof("Failed to write configurations to main.tf file. Error: %v", err)
 		return nil, err
@@ -16,8 +16,6 @@ const (
 	locals            = "locals"
 	requiredProviders = "required_providers"
 	serverOne         = "server1"
-	serverTwo         = "server2"
-	serverThree       = "server3"
 )
 
 // CreateAWSTerraformProviderBlock will up the terraform block with the required aws provider.
@@ -43,28 +41,18 @@ func CreateAWSProviderBlock(rootBody *hclwrite.Body, terraformConfig *config.Ter
 	awsProvBlockBody.SetAttributeValue(aws.SecretKey, cty.StringVal(terraformConfig.AWSCredentials.AWSSecretKey))
 }
 
-// CreateAWSLocalBlock will set up the local block. Returns the local block.
-func CreateAWSLocalBlock(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+// CreateAWSLocalBlock will set up the local block with the given servers. Returns the local block.
+func CreateAWSLocalBlock(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, servers []string) {
 	localBlock := rootBody.AppendNewBlock(locals, nil)
 	localBlockBody := localBlock.Body()
 
-	var instanceIds map[string]any
+	instanceIds := map[string]any{}
 
-	if terraformConfig.Provider == providers.EKS {
-		instanceIds = map[string]any{
-			serverOne: aws.AwsInstance + "." + serverOne + ".id",
-		}
-	} else if terraformConfig.AWSConfig.IPAddressType != aws.IPv6 {
-		instanceIds = map[string]any{
-			serverOne:   aws.AwsInstance + "." + serverOne + ".id",
-			serverTwo:   aws.AwsInstance + "." + serverTwo + ".id",
-			serverThree: aws.AwsInstance + "." + serverThree + ".id",
-		}
-	} else if terraformConfig.AWSConfig.IPAddressType == aws.IPv6 {
-		instanceIds = map[string]any{
-			serverOne:   aws.AwsInstance + "." + serverOne + ".ipv6_addresses[0]",
-			serverTwo:   aws.AwsInstance + "." + serverTwo + ".ipv6_addresses[0]",
-			serverThree: aws.AwsInstance + "." + serverThree + ".ipv6_addresses[0]",
+	for _, server := range servers {
+		if terraformConfig.AWSConfig.IPAddressType == aws.IPv6 && terraformConfig.Provider != providers.EKS {
+			instanceIds[server] = aws.AwsInstance + "." + server + ".ipv6_addresses[0]"
+		} else {
+			instanceIds[server] = aws.AwsInstance + "." + server + ".id"
 		}
 	}
 
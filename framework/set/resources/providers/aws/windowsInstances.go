@@ -8,7 +8,6 @@ import (
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/clustertypes"
-	"github.com/rancher/tfp-automation/defaults/modules"
 	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults/general"
 	"github.com/rancher/tfp-automation/framework/set/defaults/providers/aws"
@@ -29,17 +28,21 @@ const (
               EOF`
 )
 
-// CreateWindowsAWSInstances is a function that will set the Windows AWS instances configurations in the main.tf file.
+// CreateWindowsAWSInstances is a function that will set the Windows AWS instances configurations in the main.tf file,
+// using the AMI of the given Windows Server version.
 func CreateWindowsAWSInstances(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
-	hostnamePrefix string) {
+	hostnamePrefix, windowsVersion string) {
 	configBlock := rootBody.AppendNewBlock(general.Resource, []string{aws.AwsInstance, hostnamePrefix + "-windows"})
 	configBlockBody := configBlock.Body()
 
 	configBlockBody.SetAttributeValue(general.Count, cty.NumberIntVal(customnodepools.WindowsNodeCount(terratestConfig)))
 
-	if strings.Contains(terraformConfig.Module, clustertypes.WINDOWS) && strings.Contains(terraformConfig.Module, "2019") {
+	switch windowsVersion {
+	case config.Windows2019:
 		configBlockBody.SetAttributeValue(aws.Ami, cty.StringVal(terraformConfig.AWSConfig.Windows2019AMI))
-	} else if strings.Contains(terraformConfig.Module, clustertypes.WINDOWS) && strings.Contains(terraformConfig.Module, "2022") {
+	case config.Windows2025:
+		configBlockBody.SetAttributeValue(aws.Ami, cty.StringVal(terraformConfig.AWSConfig.Windows2025AMI))
+	default:
 		configBlockBody.SetAttributeValue(aws.Ami, cty.StringVal(terraformConfig.AWSConfig.Windows2022AMI))
 	}
 
@@ -77,14 +80,11 @@ func CreateWindowsAWSInstances(rootBody *hclwrite.Body, terraformConfig *config.
 	connectionBlock := configBlockBody.AppendNewBlock(general.Connection, nil)
 	connectionBlockBody := connectionBlock.Body()
 
-	connectionBlockBody.SetAttributeValue(general.Type, cty.StringVal(general.WinRM))
-	connectionBlockBody.SetAttributeValue(general.User, cty.StringVal(terraformConfig.AWSConfig.WindowsAWSUser))
+	windowsUser, windowsPassword := customnodepools.WindowsCredentials(terraformConfig, windowsVersion)
 
-	if strings.Contains(terraformConfig.Module, clustertypes.WINDOWS) && strings.Contains(terraformConfig.Module, "2019") {
-		connectionBlockBody.SetAttributeValue(general.Password, cty.StringVal(terraformConfig.AWSConfig.Windows2019Password))
-	} else if strings.Contains(terraformConfig.Module, clustertypes.WINDOWS) && strings.Contains(terraformConfig.Module, "2022") {
-		connectionBlockBody.SetAttributeValue(general.Password, cty.StringVal(terraformConfig.AWSConfig.Windows2022Password))
-	}
+	connectionBlockBody.SetAttributeValue(general.Type, cty.StringVal(general.WinRM))
+	connectionBlockBody.SetAttributeValue(general.User, cty.StringVal(windowsUser))
+	connectionBlockBody.SetAttributeValue(general.Password, cty.StringVal(windowsPassword))
 
 	connectionBlockBody.SetAttributeValue(general.Insecure, cty.BoolVal(true))
 	connectionBlockBody.SetAttributeValue(general.UseNTLM, cty.BoolVal(true))
@@ -99,7 +99,7 @@ func CreateWindowsAWSInstances(rootBody *hclwrite.Body, terraformConfig *config.
 	connectionBlockBody.SetAttributeValue(aws.Timeout, cty.StringVal(terraformConfig.AWSConfig.Timeout))
 	configBlockBody.AppendNewline()
 
-	if terraformConfig.Module == modules.ImportedAWSRKE2Windows2019 || terraformConfig.Module == modules.ImportedAWSRKE2Windows2022 {
+	if strings.Contains(terraformConfig.Module, clustertypes.IMPORT) {
 		nonWindowsNodeCount := customnodepools.TotalNodeCount(terratestConfig)
 		dependsOnResources := make([]string, 0, nonWindowsNodeCount)
 
@@ -5,6 +5,7 @@ import (
 
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/resources/topology"
 	"github.com/sirupsen/logrus"
 )
 
@@ -63,7 +64,7 @@ func CreateAzureResources(file *os.File, newFile *hclwrite.File, tfBlockBody, ro
 		rootBody.AppendNewline()
 	}
 
-	CreateAzureLocalBlock(rootBody, terraformConfig)
+	CreateAzureLocalBlock(rootBody, terraformConfig, topology.Servers(instances))
 	rootBody.AppendNewline()
 
 	_, err := file.Write(newFile.Bytes())
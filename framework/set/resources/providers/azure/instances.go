@@ -2,6 +2,7 @@ package azure
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
@@ -16,7 +17,8 @@ func CreateAzureInstances(rootBody *hclwrite.Body, terraformConfig *config.Terra
 	vmBlock := rootBody.AppendNewBlock(general.Resource, []string{azure.AzureLinuxVirtualMachine, hostnamePrefix})
 	vmBlockBody := vmBlock.Body()
 
-	vmBlockBody.SetAttributeValue(general.ResourceName, cty.StringVal(terraformConfig.ResourcePrefix+"-"+hostnamePrefix))
+	// Linux virtual machine names are also used as the computer name, which does not allow underscores.
+	vmBlockBody.SetAttributeValue(general.ResourceName, cty.StringVal(strings.ReplaceAll(terraformConfig.ResourcePrefix+"-"+hostnamePrefix, "_", "-")))
 
 	expression := azure.AzureResourceGroup + "." + azure.AzureResourceGroup + ".name"
 	values := hclwrite.Tokens{
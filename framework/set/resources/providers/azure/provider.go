@@ -6,7 +6,6 @@ import (
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
-	"github.com/rancher/tfp-automation/defaults/providers"
 	"github.com/rancher/tfp-automation/framework/set/defaults/general"
 	"github.com/rancher/tfp-automation/framework/set/defaults/providers/azure"
 	"github.com/zclconf/go-cty/cty"
@@ -40,9 +39,6 @@ const (
 	resourceGroupName          = "resource_group_name"
 	requiredProviders          = "required_providers"
 	securityRule               = "security_rule"
-	serverOne                  = "server1"
-	serverTwo                  = "server2"
-	serverThree                = "server3"
 	sku                        = "sku"
 	sourceImageReference       = "source_image_reference"
 	storageAccountType         = "storage_account_type"
@@ -76,23 +72,14 @@ func CreateAzureProviderBlock(rootBody *hclwrite.Body, terraformConfig *config.T
 	_ = featuresBlock.Body()
 }
 
-// CreateAzureLocalBlock will set up the local block. Returns the local block.
-func CreateAzureLocalBlock(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+// CreateAzureLocalBlock will set up the local block with the given servers. Returns the local block.
+func CreateAzureLocalBlock(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, servers []string) {
 	localBlock := rootBody.AppendNewBlock(locals, nil)
 	localBlockBody := localBlock.Body()
 
-	var instanceIds map[string]any
-
-	if terraformConfig.Provider == providers.AKS {
-		instanceIds = map[string]any{
-			serverOne: azure.AzureLinuxVirtualMachine + "." + serverOne + ".id",
-		}
-	} else {
-		instanceIds = map[string]any{
-			serverOne:   azure.AzureLinuxVirtualMachine + "." + serverOne + ".id",
-			serverTwo:   azure.AzureLinuxVirtualMachine + "." + serverTwo + ".id",
-			serverThree: azure.AzureLinuxVirtualMachine + "." + serverThree + ".id",
-		}
+	instanceIds := map[string]any{}
+	for _, server := range servers {
+		instanceIds[server] = azure.AzureLinuxVirtualMachine + "." + server + ".id"
 	}
 
 	instanceIdsBlock := localBlockBody.AppendNewBlock(instanceIDs+" =", nil)
@@ -0,0 +1,139 @@
+package azure
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/defaults/general"
+	"github.com/rancher/tfp-automation/framework/set/defaults/providers/azure"
+	customnodepools "github.com/rancher/tfp-automation/framework/set/provisioning/custom/nodepools"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	adminPassword         = "admin_password"
+	computerName          = "computer_name"
+	customScriptExtension = "CustomScriptExtension"
+	microsoftCompute      = "Microsoft.Compute"
+	settings              = "settings"
+	typeHandlerVersion    = "type_handler_version"
+	virtualMachineID      = "virtual_machine_id"
+	winRMListener         = "winrm_listener"
+	customScriptVersion   = "1.10"
+
+	enableWinRMCommand = `Enable-PSRemoting -Force; ` +
+		`Set-Item WSMan:\\localhost\\Service\\AllowUnencrypted -Value $true; ` +
+		`Set-Item WSMan:\\localhost\\Service\\Auth\\Basic -Value $true; ` +
+		`New-NetFirewallRule -DisplayName 'WinRM HTTP' -Direction Inbound -Action Allow -Protocol TCP -LocalPort 5985`
+)
+
+// CreateAzureWindowsInstances is a function that will set the Azure Windows VM instance configurations in the main.tf
+// file, using the Windows Server image of the given version. WinRM is enabled through a custom script extension.
+func CreateAzureWindowsInstances(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, hostnamePrefix string,
+	index int, windowsVersion string) {
+	vmBlock := rootBody.AppendNewBlock(general.Resource, []string{azure.AzureWindowsVirtualMachine, hostnamePrefix})
+	vmBlockBody := vmBlock.Body()
+
+	vmBlockBody.SetAttributeValue(general.ResourceName, cty.StringVal(strings.ReplaceAll(hostnamePrefix, "_", "-")))
+	vmBlockBody.SetAttributeValue(computerName, cty.StringVal(fmt.Sprintf("%s-%d", customnodepools.WindowsComputerNamePrefix(terraformConfig), index)))
+
+	expression := azure.AzureResourceGroup + "." + azure.AzureResourceGroup + ".name"
+	values := hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(expression)},
+	}
+
+	vmBlockBody.SetAttributeRaw(resourceGroupName, values)
+
+	expression = azure.AzureResourceGroup + "." + azure.AzureResourceGroup + ".location"
+	values = hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(expression)},
+	}
+
+	vmBlockBody.SetAttributeRaw(location, values)
+
+	size := terraformConfig.AzureConfig.WindowsSize
+	if size == "" {
+		size = terraformConfig.AzureConfig.Size
+	}
+
+	vmBlockBody.SetAttributeValue(general.Size, cty.StringVal(size))
+	vmBlockBody.SetAttributeValue(adminUsername, cty.StringVal(terraformConfig.AzureConfig.WindowsUser))
+	vmBlockBody.SetAttributeValue(adminPassword, cty.StringVal(terraformConfig.AzureConfig.WindowsPassword))
+
+	expression = "[" + azure.AzureNetworkInterface + "." + azure.AzureNetworkInterface + "-" + hostnamePrefix + ".id" + "]"
+	values = hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(expression)},
+	}
+
+	vmBlockBody.SetAttributeRaw(networkInterfaceIDs, values)
+
+	osDiskBlock := vmBlockBody.AppendNewBlock(azure.OSDisk, nil)
+	osDiskBlockBody := osDiskBlock.Body()
+
+	osDiskBlockBody.SetAttributeValue(caching, cty.StringVal("ReadWrite"))
+	osDiskBlockBody.SetAttributeValue(storageAccountType, cty.StringVal("Standard_LRS"))
+	osDiskBlockBody.SetAttributeValue(diskSizeGB, cty.StringVal(terraformConfig.AzureConfig.DiskSize))
+
+	sourceImageRefBlock := vmBlockBody.AppendNewBlock(sourceImageReference, nil)
+	sourceImageRefBlockBody := sourceImageRefBlock.Body()
+
+	sourceImageRefBlockBody.SetAttributeValue(publisher, cty.StringVal(azure.WindowsPublisher))
+	sourceImageRefBlockBody.SetAttributeValue(offer, cty.StringVal(azure.WindowsOffer))
+	sourceImageRefBlockBody.SetAttributeValue(sku, cty.StringVal(windowsImageSKU(terraformConfig, windowsVersion)))
+	sourceImageRefBlockBody.SetAttributeValue(version, cty.StringVal("latest"))
+
+	winRMListenerBlock := vmBlockBody.AppendNewBlock(winRMListener, nil)
+	winRMListenerBlock.Body().SetAttributeValue(protocol, cty.StringVal("Http"))
+
+	rootBody.AppendNewline()
+
+	extensionBlock := rootBody.AppendNewBlock(general.Resource, []string{azure.AzureVirtualMachineExtension, hostnamePrefix})
+	extensionBlockBody := extensionBlock.Body()
+
+	extensionBlockBody.SetAttributeValue(general.ResourceName, cty.StringVal(strings.ReplaceAll(hostnamePrefix, "_", "-")+"-winrm"))
+
+	expression = azure.AzureWindowsVirtualMachine + "." + hostnamePrefix + ".id"
+	values = hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(expression)},
+	}
+
+	extensionBlockBody.SetAttributeRaw(virtualMachineID, values)
+	extensionBlockBody.SetAttributeValue(publisher, cty.StringVal(microsoftCompute))
+	extensionBlockBody.SetAttributeValue(general.Type, cty.StringVal(customScriptExtension))
+	extensionBlockBody.SetAttributeValue(typeHandlerVersion, cty.StringVal(customScriptVersion))
+
+	expression = fmt.Sprintf(`jsonencode({ commandToExecute = "powershell -ExecutionPolicy Unrestricted -Command \"%s\"" })`, enableWinRMCommand)
+	values = hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(expression)},
+	}
+
+	extensionBlockBody.SetAttributeRaw(settings, values)
+}
+
+// windowsImageSKU is a helper function that returns the Windows Server image SKU of the given version, allowing the
+// default SKUs to be overridden in the Azure config.
+func windowsImageSKU(terraformConfig *config.TerraformConfig, windowsVersion string) string {
+	switch windowsVersion {
+	case config.Windows2019:
+		if terraformConfig.AzureConfig.Windows2019SKU != "" {
+			return terraformConfig.AzureConfig.Windows2019SKU
+		}
+
+		return azure.Windows2019SKU
+	case config.Windows2025:
+		if terraformConfig.AzureConfig.Windows2025SKU != "" {
+			return terraformConfig.AzureConfig.Windows2025SKU
+		}
+
+		return azure.Windows2025SKU
+	default:
+		if terraformConfig.AzureConfig.Windows2022SKU != "" {
+			return terraformConfig.AzureConfig.Windows2022SKU
+		}
+
+		return azure.Windows2022SKU
+	}
+}
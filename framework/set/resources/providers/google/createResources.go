@@ -6,6 +6,7 @@ import (
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/providers"
+	"github.com/rancher/tfp-automation/framework/set/resources/topology"
 	"github.com/sirupsen/logrus"
 )
 
@@ -33,7 +34,7 @@ func CreateGoogleCloudResources(file *os.File, newFile *hclwrite.File, tfBlockBo
 		if terraformConfig.Standalone.CertManagerVersion != "" {
 			ports := []int64{80, 443, 6443, 9345}
 			for _, port := range ports {
-				CreateGoogleCloudInstanceGroups(rootBody, terraformConfig, port)
+				CreateGoogleCloudInstanceGroups(rootBody, terraformConfig, topology.Servers(instances), port)
 				rootBody.AppendNewline()
 
 				CreateGoogleBackendService(rootBody, terraformConfig, port)
@@ -48,7 +49,7 @@ func CreateGoogleCloudResources(file *os.File, newFile *hclwrite.File, tfBlockBo
 		}
 	}
 
-	CreateGoogleCloudLocalBlock(rootBody, terraformConfig)
+	CreateGoogleCloudLocalBlock(rootBody, terraformConfig, topology.Servers(instances))
 	rootBody.AppendNewline()
 
 	_, err := file.Write(newFile.Bytes())
@@ -29,9 +29,6 @@ const (
 	namedPort            = "named_port"
 	network              = "network"
 	protocol             = "protocol"
-	server1              = "server1"
-	server2              = "server2"
-	server3              = "server3"
 	sshAccess            = "allow-ssh"
 	sshKeys              = "ssh-keys"
 	sourceRanges         = "source_ranges"
@@ -1,8 +1,8 @@
 package google
 
 import (
-	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
@@ -12,17 +12,20 @@ import (
 	"github.com/zclconf/go-cty/cty"
 )
 
-// CreateGoogleCloudInstanceGroups will set up the Google Cloud instance groups.
-func CreateGoogleCloudInstanceGroups(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, port int64) {
+// CreateGoogleCloudInstanceGroups will set up the Google Cloud instance groups with the given servers.
+func CreateGoogleCloudInstanceGroups(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, servers []string, port int64) {
 	instanceGroupBlock := rootBody.AppendNewBlock(general.Resource, []string{googleDefaults.GoogleComputeInstanceGroup, googleDefaults.GoogleComputeInstanceGroup + "_" + strconv.FormatInt(port, 10)})
 	instanceGroupBlockBody := instanceGroupBlock.Body()
 
 	instanceGroupBlockBody.SetAttributeValue(general.ResourceName, cty.StringVal(terraformConfig.ResourcePrefix+"-group-"+strconv.FormatInt(port, 10)))
 	instanceGroupBlockBody.SetAttributeValue(googleDefaults.GoogleZone, cty.StringVal(terraformConfig.GoogleConfig.Zone))
 
-	expression := fmt.Sprintf("[" + googleDefaults.GoogleComputeInstance + `.` + server1 + `.self_link, ` +
-		googleDefaults.GoogleComputeInstance + `.` + server2 + `.self_link, ` +
-		googleDefaults.GoogleComputeInstance + `.` + server3 + `.self_link]`)
+	var selfLinks []string
+	for _, server := range servers {
+		selfLinks = append(selfLinks, googleDefaults.GoogleComputeInstance+`.`+server+`.self_link`)
+	}
+
+	expression := "[" + strings.Join(selfLinks, ", ") + "]"
 
 	value := hclwrite.Tokens{
 		{Type: hclsyntax.TokenIdent, Bytes: []byte(expression)},
@@ -19,12 +19,20 @@ func CreateGoogleCloudInstances(rootBody *hclwrite.Body, terraformConfig *config
 	configBlock := rootBody.AppendNewBlock(general.Resource, []string{googleDefaults.GoogleComputeInstance, hostnamePrefix})
 	configBlockBody := configBlock.Body()
 
+	// Instance names only allow lowercase letters, digits and hyphens.
+	instanceName := strings.ReplaceAll(terraformConfig.ResourcePrefix+"-"+hostnamePrefix, "_", "-")
+
 	if strings.Contains(terraformConfig.Module, general.Custom) {
 		totalNodeCount := customnodepools.TotalNodeCount(terratestConfig)
 		configBlockBody.SetAttributeValue(general.Count, cty.NumberIntVal(totalNodeCount))
+
+		configBlockBody.SetAttributeRaw(general.ResourceName, hclwrite.Tokens{
+			{Type: hclsyntax.TokenIdent, Bytes: []byte(`"` + instanceName + `-${count.index}"`)},
+		})
+	} else {
+		configBlockBody.SetAttributeValue(general.ResourceName, cty.StringVal(instanceName))
 	}
 
-	configBlockBody.SetAttributeValue(general.ResourceName, cty.StringVal(terraformConfig.ResourcePrefix+"-"+hostnamePrefix))
 	configBlockBody.SetAttributeValue(machineType, cty.StringVal(terraformConfig.GoogleConfig.MachineType))
 
 	bootDiskBlock := configBlockBody.AppendNewBlock(googleDefaults.GoogleBootDisk, nil)
@@ -6,7 +6,6 @@ import (
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
-	"github.com/rancher/tfp-automation/defaults/providers"
 	"github.com/rancher/tfp-automation/framework/set/defaults/general"
 	googleDefaults "github.com/rancher/tfp-automation/framework/set/defaults/providers/google"
 	"github.com/zclconf/go-cty/cty"
@@ -16,9 +15,6 @@ const (
 	locals            = "locals"
 	requiredProviders = "required_providers"
 	instanceIDs       = "instance_ids"
-	serverOne         = "server1"
-	serverTwo         = "server2"
-	serverThree       = "server3"
 )
 
 // CreateGoogleCloudTerraformProviderBlock will up the terraform block with the required Google Cloud provider.
@@ -44,23 +40,14 @@ func CreateGoogleCloudProviderBlock(rootBody *hclwrite.Body, terraformConfig *co
 	googleProvBlockBody.SetAttributeValue(googleDefaults.GoogleZone, cty.StringVal(terraformConfig.GoogleConfig.Zone))
 }
 
-// CreateGoogleCloudLocalBlock will set up the local block. Returns the local block.
-func CreateGoogleCloudLocalBlock(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+// CreateGoogleCloudLocalBlock will set up the local block with the given servers. Returns the local block.
+func CreateGoogleCloudLocalBlock(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, servers []string) {
 	localBlock := rootBody.AppendNewBlock(locals, nil)
 	localBlockBody := localBlock.Body()
 
-	var instanceIds map[string]any
-
-	if terraformConfig.Provider == providers.GKE {
-		instanceIds = map[string]any{
-			serverOne: googleDefaults.GoogleComputeInstance + "." + serverOne + ".id",
-		}
-	} else {
-		instanceIds = map[string]any{
-			serverOne:   googleDefaults.GoogleComputeInstance + "." + serverOne + ".id",
-			serverTwo:   googleDefaults.GoogleComputeInstance + "." + serverTwo + ".id",
-			serverThree: googleDefaults.GoogleComputeInstance + "." + serverThree + ".id",
-		}
+	instanceIds := map[string]any{}
+	for _, server := range servers {
+		instanceIds[server] = googleDefaults.GoogleComputeInstance + "." + server + ".id"
 	}
 
 	instanceIdsBlock := localBlockBody.AppendNewBlock(instanceIDs+" =", nil)
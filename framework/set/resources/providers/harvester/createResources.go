@@ -1,43 +1,172 @@
 package harvester
 
 import (
+	"fmt"
 	"os"
-	"strings"
+	"path/filepath"
 
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/resources/topology"
 	"github.com/sirupsen/logrus"
 )
 
 // CreateHarvesterResources is a helper function that will create the Harvester resources needed for the RKE2 cluster.
 func CreateHarvesterResources(file *os.File, newFile *hclwrite.File, tfBlockBody, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
 	terratestConfig *config.TerratestConfig, instances []string) (*os.File, error) {
+	if terraformConfig.Proxy != nil && terraformConfig.HarvesterConfig.AirgapNetworkName == "" {
+		return nil, fmt.Errorf("harvesterConfig.airgapNetworkName must be set to create the proxied nodes")
+	}
+
 	CreateTerraformProviderBlock(tfBlockBody)
 	rootBody.AppendNewline()
 
 	CreateHarvesterProviderBlock(rootBody, terraformConfig)
 	rootBody.AppendNewline()
 
-	CreateLocalBlock(rootBody, terraformConfig)
+	err := CreateLocalBlock(rootBody, terraformConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	rootBody.AppendNewline()
 
 	for _, instance := range instances {
-		CreateHarvesterInstances(rootBody, terraformConfig, terratestConfig, instance)
+		err = CreateHarvesterInstances(rootBody, terraformConfig, terratestConfig, instance)
+		if err != nil {
+			return nil, err
+		}
+
 		rootBody.AppendNewline()
 	}
 
+	if terraformConfig.Proxy != nil {
+		nodes, err := topology.FixedNodes(terraformConfig, "proxy")
+		if err != nil {
+			return nil, err
+		}
+
+		for _, instance := range topology.Names(nodes) {
+			err = CreateAirgappedHarvesterInstances(rootBody, terraformConfig, terratestConfig, instance)
+			if err != nil {
+				return nil, err
+			}
+
+			rootBody.AppendNewline()
+		}
+	}
+
+	return writeHarvesterFiles(file, newFile, terraformConfig)
+}
+
+// CreateAirgappedHarvesterResources is a helper function that will create the Harvester resources needed for the
+// airgapped RKE2 cluster. The given instances, e.g. the bastion and the registry, are attached to the first network of
+// the config while the nodes of the local cluster are only attached to the airgap network, so both networks must route
+// to each other.
+func CreateAirgappedHarvesterResources(file *os.File, newFile *hclwrite.File, tfBlockBody, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
+	terratestConfig *config.TerratestConfig, instances []string) (*os.File, error) {
+	if terraformConfig.HarvesterConfig.AirgapNetworkName == "" {
+		return nil, fmt.Errorf("harvesterConfig.airgapNetworkName must be set to create the airgapped nodes")
+	}
+
+	CreateTerraformProviderBlock(tfBlockBody)
 	rootBody.AppendNewline()
-	dirList := file.Name()
 
-	localFile, err := os.Create(strings.Join(strings.Split(dirList, "main.tf"), "/") + "/local.yaml")
+	CreateHarvesterProviderBlock(rootBody, terraformConfig)
+	rootBody.AppendNewline()
+
+	err := CreateLocalBlock(rootBody, terraformConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	rootBody.AppendNewline()
+
+	for _, instance := range instances {
+		err = CreateHarvesterInstances(rootBody, terraformConfig, terratestConfig, instance)
+		if err != nil {
+			return nil, err
+		}
+
+		rootBody.AppendNewline()
+	}
+
+	nodes, err := topology.Nodes(terraformConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, instance := range topology.Names(nodes) {
+		err = CreateAirgappedHarvesterInstances(rootBody, terraformConfig, terratestConfig, instance)
+		if err != nil {
+			return nil, err
+		}
+
+		rootBody.AppendNewline()
+	}
+
+	return writeHarvesterFiles(file, newFile, terraformConfig)
+}
+
+// CreateIPv6HarvesterResources is a helper function that will create the Harvester resources needed for the IPv6 RKE2
+// cluster. The nodes of the local cluster are attached to the first network of the config, which must hand out IPv6
+// addresses.
+func CreateIPv6HarvesterResources(file *os.File, newFile *hclwrite.File, tfBlockBody, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
+	terratestConfig *config.TerratestConfig, instances []string) (*os.File, error) {
+	CreateTerraformProviderBlock(tfBlockBody)
+	rootBody.AppendNewline()
+
+	CreateHarvesterProviderBlock(rootBody, terraformConfig)
+	rootBody.AppendNewline()
+
+	err := CreateLocalBlock(rootBody, terraformConfig)
 	if err != nil {
-		logrus.Infof("Failed create local.yaml kubeconfig. Error: %v", err)
 		return nil, err
 	}
 
+	rootBody.AppendNewline()
+
+	nodes, err := topology.FixedNodes(terraformConfig, "IPv6")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, instance := range append(instances, topology.Names(nodes)...) {
+		err = CreateHarvesterInstances(rootBody, terraformConfig, terratestConfig, instance)
+		if err != nil {
+			return nil, err
+		}
+
+		rootBody.AppendNewline()
+	}
+
+	return writeHarvesterFiles(file, newFile, terraformConfig)
+}
+
+// WriteKubeconfig is a function that will write the Harvester kubeconfig, read by the Harvester provider, to the
+// local.yaml file of the given module directory.
+func WriteKubeconfig(moduleDir string, terraformConfig *config.TerraformConfig) error {
+	localFile, err := os.Create(filepath.Join(moduleDir, "local.yaml"))
+	if err != nil {
+		logrus.Infof("Failed create local.yaml kubeconfig. Error: %v", err)
+		return err
+	}
+	defer localFile.Close()
+
 	_, err = localFile.Write([]byte(terraformConfig.HarvesterCredentials.KubeconfigContent))
 	if err != nil {
 		logrus.Infof("Failed write to local.yaml. Error: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// writeHarvesterFiles is a helper function that will write the Harvester kubeconfig next to the main.tf file, followed
+// by the main.tf file itself.
+func writeHarvesterFiles(file *os.File, newFile *hclwrite.File, terraformConfig *config.TerraformConfig) (*os.File, error) {
+	err := WriteKubeconfig(filepath.Dir(file.Name()), terraformConfig)
+	if err != nil {
 		return nil, err
 	}
 
@@ -1,46 +1,46 @@
 package harvester
 
 import (
+	"encoding/json"
 	"fmt"
-	"log"
-	"os"
 	"strings"
 
+	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/shepherd/pkg/namegenerator"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults/general"
 	"github.com/rancher/tfp-automation/framework/set/defaults/providers/harvester"
 	customnodepools "github.com/rancher/tfp-automation/framework/set/provisioning/custom/nodepools"
 	"github.com/zclconf/go-cty/cty"
-	"golang.org/x/crypto/ssh"
 )
 
-// getPublicSSHKey gets the public key from a private SSH key and returns it as a string
-func getPublicSSHKey(privateKeyPath string) string {
-	sshBytes, err := os.ReadFile(privateKeyPath)
-	if err != nil {
-		log.Fatalf("Failed to read private key file: %v", err)
-	}
-
-	signer, err := ssh.ParsePrivateKey(sshBytes)
-	if err != nil {
-		log.Fatalf("Failed to parse OpenSSH private key: %v", err)
-		return ""
+// CreateHarvesterInstances is a function that will set the Harvester instances configurations in the main.tf file.
+func CreateHarvesterInstances(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
+	hostnamePrefix string) error {
+	if len(terraformConfig.HarvesterConfig.NetworkNames) == 0 {
+		return fmt.Errorf("harvesterConfig.networkNames must be set")
 	}
 
-	publicKey := signer.PublicKey()
-
-	// Convert the public key to authorized_keys format
-	pubKeyString := string(ssh.MarshalAuthorizedKey(publicKey))
+	return createHarvesterInstance(rootBody, terraformConfig, terratestConfig, hostnamePrefix, terraformConfig.HarvesterConfig.NetworkNames[0], true)
+}
 
-	return pubKeyString
+// CreateAirgappedHarvesterInstances is a function that will set the Harvester instance configuration of an airgapped node
+// in the main.tf file. The instance is only attached to the airgap network, so it is not waited on over SSH.
+func CreateAirgappedHarvesterInstances(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
+	hostnamePrefix string) error {
+	return createHarvesterInstance(rootBody, terraformConfig, terratestConfig, hostnamePrefix, terraformConfig.HarvesterConfig.AirgapNetworkName, false)
 }
 
-// CreateHarvesterInstances is a function that will set the Harvester instances configurations in the main.tf file.
-func CreateHarvesterInstances(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
-	hostnamePrefix string) {
+// createHarvesterInstance is a helper function that will set a Harvester instance attached to the given network in the
+// main.tf file. When reachable is set, the instance is only considered created once it accepts SSH connections.
+func createHarvesterInstance(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
+	hostnamePrefix, networkName string, reachable bool) error {
+	if terraformConfig.HarvesterConfig.VMAffinity != "" {
+		return fmt.Errorf("harvesterConfig.vmAffinity is only supported by the Harvester node driver, use nodeSelector instead")
+	}
 
 	configBlockSSHKey := rootBody.AppendNewBlock(general.Resource, []string{harvester.HarvesterSSHKey, hostnamePrefix + "ssh_key"})
 	configBlockSSHKeyBody := configBlockSSHKey.Body()
@@ -48,50 +48,68 @@ func CreateHarvesterInstances(rootBody *hclwrite.Body, terraformConfig *config.T
 	configBlockSSHKeyBody.SetAttributeValue(harvester.LowerCaseName, cty.StringVal(namegenerator.AppendRandomString("tfpsshkey")))
 	configBlockSSHKeyBody.SetAttributeValue(general.Namespace, cty.StringVal(terraformConfig.HarvesterConfig.VMNamespace))
 
-	publicKey := getPublicSSHKey(terraformConfig.PrivateKeyPath)
+	publicKey, err := format.PublicSSHKey(terraformConfig.PrivateKeyPath)
+	if err != nil {
+		return err
+	}
+
 	configBlockSSHKeyBody.SetAttributeValue(harvester.PublicKey, cty.StringVal(publicKey))
-	configBlockSecret := rootBody.AppendNewBlock(general.Resource, []string{harvester.KubernetesSecret, hostnamePrefix + "secret"})
-	configBlockSecretBody := configBlockSecret.Body()
 
-	secretBlockMeta := configBlockSecretBody.AppendNewBlock("metadata", []string{})
-	secretBlockMetaBody := secretBlockMeta.Body()
+	// An existing cloud-init secret replaces the generated one, and must authorize the public key of privateKeyPath.
+	secretName := terraformConfig.HarvesterConfig.UserDataSecretName
+	if secretName == "" {
+		configBlockSecret := rootBody.AppendNewBlock(general.Resource, []string{harvester.KubernetesSecret, hostnamePrefix + "secret"})
+		configBlockSecretBody := configBlockSecret.Body()
 
-	secretName := namegenerator.AppendRandomString("tfpsecret")
+		secretBlockMeta := configBlockSecretBody.AppendNewBlock("metadata", []string{})
+		secretBlockMetaBody := secretBlockMeta.Body()
 
-	secretBlockMetaBody.SetAttributeValue(harvester.LowerCaseName, cty.StringVal(secretName))
-	secretBlockMetaBody.SetAttributeValue(general.Namespace, cty.StringVal(terraformConfig.HarvesterConfig.VMNamespace))
+		secretName = namegenerator.AppendRandomString("tfpsecret")
 
-	secretBlockMetaBody.SetAttributeValue(harvester.Labels, cty.ObjectVal(map[string]cty.Value{
-		"sensitive": cty.StringVal("false"),
-	}))
+		secretBlockMetaBody.SetAttributeValue(harvester.LowerCaseName, cty.StringVal(secretName))
+		secretBlockMetaBody.SetAttributeValue(general.Namespace, cty.StringVal(terraformConfig.HarvesterConfig.VMNamespace))
 
-	hclLocalValue := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte("{\"userdata\" = local." + harvester.CloudInit + "}")},
-	}
+		secretBlockMetaBody.SetAttributeValue(harvester.Labels, cty.ObjectVal(map[string]cty.Value{
+			"sensitive": cty.StringVal("false"),
+		}))
+
+		hclLocalValue := hclwrite.Tokens{
+			{Type: hclsyntax.TokenIdent, Bytes: []byte("{\"userdata\" = local." + harvester.CloudInit + "}")},
+		}
 
-	configBlockSecretBody.SetAttributeRaw("data", hclLocalValue)
+		configBlockSecretBody.SetAttributeRaw("data", hclLocalValue)
+	}
 
 	configBlock := rootBody.AppendNewBlock(general.Resource, []string{harvester.HarvesterVirtualMachine, hostnamePrefix})
 	configBlockBody := configBlock.Body()
 
+	randName := namegenerator.AppendRandomString("tfp-vm")
+
 	if strings.Contains(terraformConfig.Module, general.Custom) {
 		totalNodeCount := customnodepools.TotalNodeCount(terratestConfig)
 		configBlockBody.SetAttributeValue(general.Count, cty.NumberIntVal(totalNodeCount))
+
+		randName += "-${count.index}"
 	}
 
 	configBlockBody.AppendNewline()
 
-	formattedList := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(`[` + fmt.Sprintf("kubernetes_secret.%s", hostnamePrefix+"secret") + `]`)},
+	if terraformConfig.HarvesterConfig.UserDataSecretName == "" {
+		formattedList := hclwrite.Tokens{
+			{Type: hclsyntax.TokenIdent, Bytes: []byte(`[` + fmt.Sprintf("kubernetes_secret.%s", hostnamePrefix+"secret") + `]`)},
+		}
+
+		configBlockBody.SetAttributeRaw(general.DependsOn, formattedList)
 	}
 
-	configBlockBody.SetAttributeRaw(general.DependsOn, formattedList)
+	vmName := hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(`"` + randName + `"`)},
+	}
 
-	randName := namegenerator.AppendRandomString("tfp-vm")
-	configBlockBody.SetAttributeValue(harvester.LowerCaseName, cty.StringVal(randName))
+	configBlockBody.SetAttributeRaw(harvester.LowerCaseName, vmName)
 	configBlockBody.SetAttributeValue(general.Namespace, cty.StringVal(terraformConfig.HarvesterConfig.VMNamespace))
 	configBlockBody.SetAttributeValue(harvester.RestartAfterUpdate, cty.BoolVal(true))
-	configBlockBody.SetAttributeValue(general.Description, cty.StringVal(randName))
+	configBlockBody.SetAttributeRaw(general.Description, vmName)
 
 	tagMap := hclwrite.Tokens{
 		{Type: hclsyntax.TokenIdent, Bytes: []byte(fmt.Sprintf("{%s = \"%s\"}", harvester.SshUser, terraformConfig.HarvesterConfig.SSHUser))},
@@ -107,8 +125,18 @@ func CreateHarvesterInstances(rootBody *hclwrite.Body, terraformConfig *config.T
 	configBlockBody.SetAttributeValue(harvester.SecureBoot, cty.BoolVal(false))
 
 	configBlockBody.SetAttributeValue(harvester.RunStrategy, cty.StringVal(harvester.RerunOnFailure))
-	configBlockBody.SetAttributeValue(harvester.Hostname, cty.StringVal(randName))
+	configBlockBody.SetAttributeRaw(harvester.Hostname, vmName)
 	configBlockBody.SetAttributeValue(harvester.MachineType, cty.StringVal(harvester.Q35))
+
+	if len(terraformConfig.HarvesterConfig.NodeSelector) > 0 {
+		nodeSelector := map[string]cty.Value{}
+		for key, value := range terraformConfig.HarvesterConfig.NodeSelector {
+			nodeSelector[key] = cty.StringVal(value)
+		}
+
+		configBlockBody.SetAttributeValue(harvester.NodeSelector, cty.MapVal(nodeSelector))
+	}
+
 	networkBlock := configBlockBody.AppendNewBlock(harvester.NetworkInterface, nil)
 	networkBlockBody := networkBlock.Body()
 
@@ -116,7 +144,7 @@ func CreateHarvesterInstances(rootBody *hclwrite.Body, terraformConfig *config.T
 	networkBlockBody.SetAttributeValue(harvester.WaitForLease, cty.BoolVal(true))
 	networkBlockBody.SetAttributeValue(harvester.Model, cty.StringVal(harvester.Virtio))
 	networkBlockBody.SetAttributeValue(general.Type, cty.StringVal(harvester.Bridge))
-	networkBlockBody.SetAttributeValue(harvester.NetworkName, cty.StringVal(terraformConfig.HarvesterConfig.NetworkNames[0]))
+	networkBlockBody.SetAttributeValue(harvester.NetworkName, cty.StringVal(networkName))
 
 	diskBlock := configBlockBody.AppendNewBlock(harvester.Disk, nil)
 	diskBlockBody := diskBlock.Body()
@@ -129,11 +157,42 @@ func CreateHarvesterInstances(rootBody *hclwrite.Body, terraformConfig *config.T
 	diskBlockBody.SetAttributeValue(harvester.Image, cty.StringVal(terraformConfig.HarvesterConfig.ImageName))
 	diskBlockBody.SetAttributeValue(harvester.AutoDelete, cty.BoolVal(true))
 
+	err = setAdditionalDisks(configBlockBody, terraformConfig)
+	if err != nil {
+		return err
+	}
+
 	cloudInitBlock := configBlockBody.AppendNewBlock(harvester.CloudInit, nil)
 	cloudInitBlockBody := cloudInitBlock.Body()
 
 	cloudInitBlockBody.SetAttributeValue(harvester.UserDataSecretName, cty.StringVal(secretName))
-	cloudInitBlockBody.SetAttributeValue(harvester.NetworkData, cty.StringVal(""))
+
+	if terraformConfig.HarvesterConfig.NetworkDataSecretName != "" {
+		cloudInitBlockBody.SetAttributeValue(harvester.NetworkDataSecretName, cty.StringVal(terraformConfig.HarvesterConfig.NetworkDataSecretName))
+	} else {
+		cloudInitBlockBody.SetAttributeValue(harvester.NetworkData, cty.StringVal(terraformConfig.HarvesterConfig.NetworkData))
+	}
+
+	hostDevices, err := instancePCIDevices(terraformConfig, hostnamePrefix)
+	if err != nil {
+		return err
+	}
+
+	if len(hostDevices) > 0 {
+		if strings.Contains(terraformConfig.Module, general.Custom) && customnodepools.TotalNodeCount(terratestConfig) > 1 {
+			return fmt.Errorf("harvesterConfig.pciDevices can not be attached to %s, it creates more than one node", hostnamePrefix)
+		}
+
+		err = setPCIDevices(configBlockBody, terraformConfig, hostDevices)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !reachable {
+		return nil
+	}
+
 	configBlockBody.AppendNewline()
 
 	connectionBlock := configBlockBody.AppendNewBlock(general.Connection, nil)
@@ -165,4 +224,101 @@ func CreateHarvesterInstances(rootBody *hclwrite.Body, terraformConfig *config.T
 	provisionerBlockBody.SetAttributeValue(general.Inline, cty.ListVal([]cty.Value{
 		cty.StringVal("echo Connected!!!"),
 	}))
+
+	return nil
+}
+
+// setAdditionalDisks is a helper function that will attach the additional disks of the config to the Harvester instance,
+// after the root disk. The disks use the default storage class of the Harvester cluster unless one is set.
+func setAdditionalDisks(configBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) error {
+	for i, disk := range terraformConfig.HarvesterConfig.Disks {
+		if disk.Size == "" {
+			return fmt.Errorf("harvesterConfig.disks[%d].size must be set", i)
+		}
+
+		name := disk.Name
+		if name == "" {
+			name = fmt.Sprintf("%s-%d", harvester.Disk, i+1)
+		}
+
+		bus := disk.Bus
+		if bus == "" {
+			bus = harvester.Virtio
+		}
+
+		diskBlock := configBlockBody.AppendNewBlock(harvester.Disk, nil)
+		diskBlockBody := diskBlock.Body()
+
+		diskBlockBody.SetAttributeValue(harvester.LowerCaseName, cty.StringVal(name))
+		diskBlockBody.SetAttributeValue(general.Type, cty.StringVal(harvester.Disk))
+		diskBlockBody.SetAttributeValue(harvester.Size, cty.StringVal(disk.Size+harvester.Gi))
+		diskBlockBody.SetAttributeValue(harvester.Bus, cty.StringVal(bus))
+
+		if disk.StorageClassName != "" {
+			diskBlockBody.SetAttributeValue(harvester.StorageClassName, cty.StringVal(disk.StorageClassName))
+		}
+
+		diskBlockBody.SetAttributeValue(harvester.AutoDelete, cty.BoolVal(true))
+	}
+
+	return nil
+}
+
+// instancePCIDevices is a helper function that will return the PCI devices of the config scoped to the given Harvester
+// instance. A device can only be attached to one virtual machine, so every device names its instance and is listed once.
+func instancePCIDevices(terraformConfig *config.TerraformConfig, hostnamePrefix string) ([]map[string]string, error) {
+	hostDevices := []map[string]string{}
+	deviceNames := map[string]bool{}
+
+	for i, device := range terraformConfig.HarvesterConfig.PCIDevices {
+		if device.Name == "" || device.DeviceName == "" || device.Instance == "" {
+			return nil, fmt.Errorf("harvesterConfig.pciDevices[%d] must set name, deviceName and instance", i)
+		}
+
+		if deviceNames[device.Name] {
+			return nil, fmt.Errorf("harvesterConfig.pciDevices[%d] lists %s more than once", i, device.Name)
+		}
+
+		deviceNames[device.Name] = true
+
+		if device.Instance == hostnamePrefix {
+			hostDevices = append(hostDevices, map[string]string{"name": device.Name, "deviceName": device.DeviceName})
+		}
+	}
+
+	return hostDevices, nil
+}
+
+// setPCIDevices is a helper function that will pass the given PCI devices through to the Harvester instance. The
+// Harvester provider does not manage host devices, so the virtual machine is patched and restarted with kubectl, run
+// from the runner, once created. The devices must already be enabled for passthrough.
+func setPCIDevices(configBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig, hostDevices []map[string]string) error {
+	hostDevicesJSON, err := json.Marshal(hostDevices)
+	if err != nil {
+		return err
+	}
+
+	kubectl := fmt.Sprintf("kubectl --kubeconfig ${local.%s}/local.yaml -n ${self.namespace}", harvester.CodebaseRootPath)
+	virtualMachineInstance := "virtualmachineinstance/${self.name}"
+
+	commands := []string{
+		"set -e",
+		fmt.Sprintf(`%s patch virtualmachine ${self.name} --type merge -p '{"spec":{"runStrategy":"Halted","template":{"spec":{"domain":{"devices":{"hostDevices":%s}}}}}}'`,
+			kubectl, string(hostDevicesJSON)),
+		fmt.Sprintf("%s wait --for=delete %s --timeout=10m", kubectl, virtualMachineInstance),
+		fmt.Sprintf(`%s patch virtualmachine ${self.name} --type merge -p '{"spec":{"runStrategy":"%s"}}'`, kubectl, harvester.RerunOnFailure),
+		fmt.Sprintf("until %s get %s; do sleep 5; done", kubectl, virtualMachineInstance),
+		fmt.Sprintf("%s wait --for=condition=Ready %s --timeout=10m", kubectl, virtualMachineInstance),
+	}
+
+	configBlockBody.AppendNewline()
+
+	provisionerBlock := configBlockBody.AppendNewBlock(general.Provisioner, []string{general.LocalExec})
+	provisionerBlockBody := provisionerBlock.Body()
+
+	provisionerBlockBody.SetAttributeRaw(general.Command, hclwrite.TokensForTraversal(hcl.Traversal{
+		hcl.TraverseRoot{Name: "<<-EOT\n" + strings.Join(commands, "\n") + "\nEOT"},
+	}))
+
+	return nil
 }
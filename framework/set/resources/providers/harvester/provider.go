@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults/general"
 	"github.com/rancher/tfp-automation/framework/set/defaults/providers/harvester"
 	"github.com/zclconf/go-cty/cty"
@@ -61,11 +62,15 @@ func CreateHarvesterProviderBlock(rootBody *hclwrite.Body, terraformConfig *conf
 
 }
 
-// CreateLocalBlock will set up the local block. Returns the local block.
-func CreateLocalBlock(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+// CreateLocalBlock will set up the local block.
+func CreateLocalBlock(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) error {
 	localBlock := rootBody.AppendNewBlock(locals, nil)
-	localBlockBody := localBlock.Body()
+	return SetLocalAttributes(localBlock.Body(), terraformConfig)
+}
 
+// SetLocalAttributes will set the module paths and the cloud-init config used by the Harvester instances in the given
+// local block.
+func SetLocalAttributes(localBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) error {
 	pathModuleVar := fmt.Sprint(`abspath("${path.module}")`)
 	hclPathModule := hclwrite.Tokens{
 		{Type: hclsyntax.TokenIdent, Bytes: []byte(pathModuleVar)},
@@ -84,10 +89,16 @@ func CreateLocalBlock(rootBody *hclwrite.Body, terraformConfig *config.Terraform
 	}
 	localBlockBody.SetAttributeRaw(harvester.ModuleRelPath, relPathModule)
 
-	publicKey := getPublicSSHKey(terraformConfig.PrivateKeyPath)
+	publicKey, err := format.PublicSSHKey(terraformConfig.PrivateKeyPath)
+	if err != nil {
+		return err
+	}
+
 	localBlockBody.SetAttributeRaw(harvester.CloudInit, hclwrite.TokensForTraversal(hcl.Traversal{
 		hcl.TraverseRoot{
 			Name: fmt.Sprintf("<<-EOT\n#cloud-config\npackage_update: true\npackages:\n  - qemu-guest-agent\nruncmd:\n  - - systemctl\n    - enable\n    - --now\n    - qemu-guest-agent.service\nssh_authorized_keys:\n  - %s\nEOT", publicKey),
 		},
 	}))
+
+	return nil
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/resources/topology"
 	"github.com/sirupsen/logrus"
 )
 
@@ -26,7 +27,7 @@ func CreateLinodeResources(file *os.File, newFile *hclwrite.File, tfBlockBody, r
 			CreateNodeBalancerConfig(rootBody, terraformConfig, port)
 			rootBody.AppendNewline()
 
-			CreateNodeBalancerNode(rootBody, terraformConfig, port)
+			CreateNodeBalancerNode(rootBody, terraformConfig, topology.Servers(instances), port)
 			rootBody.AppendNewline()
 		}
 	}
@@ -36,7 +37,7 @@ func CreateLinodeResources(file *os.File, newFile *hclwrite.File, tfBlockBody, r
 		rootBody.AppendNewline()
 	}
 
-	CreateLinodeLocalBlock(rootBody, terraformConfig)
+	CreateLinodeLocalBlock(rootBody, terraformConfig, topology.Servers(instances))
 	rootBody.AppendNewline()
 
 	_, err := file.Write(newFile.Bytes())
@@ -1,6 +1,8 @@
 package linode
 
 import (
+	"encoding/base64"
+	"fmt"
 	"strings"
 
 	"github.com/hashicorp/hcl/v2/hclsyntax"
@@ -14,15 +16,54 @@ import (
 	"github.com/zclconf/go-cty/cty"
 )
 
+const (
+	metadata = "metadata"
+	userData = "user_data"
+)
+
 // CreateLinodeInstances is a function that will set the Linode instances configurations in the main.tf file.
 func CreateLinodeInstances(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
 	hostnamePrefix string) {
+	createLinodeInstance(rootBody, terraformConfig, terratestConfig, hostnamePrefix, "")
+}
+
+// CreateLinodeNodeInstances is a function that will set the Linode instance configuration of a custom or imported cluster
+// node in the main.tf file. Linode images only ship the root user, so cloud-init creates the standalone OS user that the
+// nodes are connected to as. The image needs to support cloud-init.
+func CreateLinodeNodeInstances(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
+	hostnamePrefix string) error {
+	if terraformConfig.Standalone == nil || terraformConfig.Standalone.OSUser == "" || terraformConfig.Standalone.OSGroup == "" {
+		return fmt.Errorf("standalone.osUser and standalone.osGroup must be set to create Linode cluster nodes")
+	}
+
+	publicKey, err := format.PublicSSHKey(terraformConfig.PrivateKeyPath)
+	if err != nil {
+		return err
+	}
+
+	cloudConfig := fmt.Sprintf("#cloud-config\nusers:\n  - default\n  - name: %s\n    primary_group: %s\n    groups: sudo\n"+
+		"    sudo: ALL=(ALL) NOPASSWD:ALL\n    shell: /bin/bash\n    ssh_authorized_keys:\n      - %s\n",
+		terraformConfig.Standalone.OSUser, terraformConfig.Standalone.OSGroup, strings.TrimSpace(publicKey))
+
+	createLinodeInstance(rootBody, terraformConfig, terratestConfig, hostnamePrefix, cloudConfig)
+
+	return nil
+}
+
+// createLinodeInstance is a helper function that will set a Linode instance in the main.tf file. When a cloud config is
+// given, it is passed as user data and the instance is connected to as the standalone OS user instead of root.
+func createLinodeInstance(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
+	hostnamePrefix, cloudConfig string) {
 	configBlock := rootBody.AppendNewBlock(general.Resource, []string{linodeDefaults.LinodeInstance, hostnamePrefix})
 	configBlockBody := configBlock.Body()
 
+	label := terraformConfig.ResourcePrefix + "-" + hostnamePrefix
+
 	if strings.Contains(terraformConfig.Module, general.Custom) {
 		totalNodeCount := customnodepools.TotalNodeCount(terratestConfig)
 		configBlockBody.SetAttributeValue(general.Count, cty.NumberIntVal(totalNodeCount))
+
+		label += "-${count.index}"
 	}
 
 	configBlockBody.SetAttributeValue(linode.Image, cty.StringVal(terraformConfig.LinodeConfig.LinodeImage))
@@ -31,19 +72,40 @@ func CreateLinodeInstances(rootBody *hclwrite.Body, terraformConfig *config.Terr
 	configBlockBody.SetAttributeValue(linode.RootPass, cty.StringVal(terraformConfig.LinodeConfig.LinodeRootPass))
 	configBlockBody.SetAttributeValue(linode.SwapSize, cty.NumberIntVal(terraformConfig.LinodeConfig.SwapSize))
 	configBlockBody.SetAttributeValue(linode.PrivateIP, cty.BoolVal(terraformConfig.LinodeConfig.PrivateIP))
-	configBlockBody.SetAttributeValue(linode.Label, cty.StringVal(terraformConfig.ResourcePrefix+"-"+hostnamePrefix))
+	configBlockBody.SetAttributeRaw(linode.Label, hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(`"` + label + `"`)},
+	})
 
 	tags := format.ListOfStrings(terraformConfig.LinodeConfig.Tags)
 	configBlockBody.SetAttributeRaw(linode.Tags, tags)
 
+	if cloudConfig != "" {
+		metadataBlock := configBlockBody.AppendNewBlock(metadata, nil)
+		metadataBlockBody := metadataBlock.Body()
+
+		metadataBlockBody.SetAttributeValue(userData, cty.StringVal(base64.StdEncoding.EncodeToString([]byte(cloudConfig))))
+	}
+
 	configBlockBody.AppendNewline()
 
 	connectionBlock := configBlockBody.AppendNewBlock(general.Connection, nil)
 	connectionBlockBody := connectionBlock.Body()
 
 	connectionBlockBody.SetAttributeValue(general.Type, cty.StringVal(general.Ssh))
-	connectionBlockBody.SetAttributeValue(general.User, cty.StringVal(linode.RootUser))
-	connectionBlockBody.SetAttributeValue(general.Password, cty.StringVal(terraformConfig.LinodeConfig.LinodeRootPass))
+
+	if cloudConfig != "" {
+		connectionBlockBody.SetAttributeValue(general.User, cty.StringVal(terraformConfig.Standalone.OSUser))
+
+		keyPathExpression := general.File + `("` + terraformConfig.PrivateKeyPath + `")`
+		keyPath := hclwrite.Tokens{
+			{Type: hclsyntax.TokenIdent, Bytes: []byte(keyPathExpression)},
+		}
+
+		connectionBlockBody.SetAttributeRaw(general.PrivateKey, keyPath)
+	} else {
+		connectionBlockBody.SetAttributeValue(general.User, cty.StringVal(linode.RootUser))
+		connectionBlockBody.SetAttributeValue(general.Password, cty.StringVal(terraformConfig.LinodeConfig.LinodeRootPass))
+	}
 
 	hostExpression := general.Self + "." + general.IPAddress
 	host := hclwrite.Tokens{
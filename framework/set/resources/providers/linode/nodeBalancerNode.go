@@ -2,6 +2,7 @@ package linode
 
 import (
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
@@ -12,15 +13,18 @@ import (
 	"github.com/zclconf/go-cty/cty"
 )
 
-// CreateNodeBalancerNode is a function that will set the node balancer configurations in the main.tf file.
-func CreateNodeBalancerNode(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, port int64) {
+// CreateNodeBalancerNode is a function that will set the node balancer configurations for the given servers in the main.tf file.
+func CreateNodeBalancerNode(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, servers []string, port int64) {
 	nodeBalancerNodeBlock := rootBody.AppendNewBlock(general.Resource, []string{linodeDefalts.LinodeNodeBalancerNode, linodeDefalts.LinodeNodeBalancerNode + "_" + strconv.FormatInt(port, 10)})
 	nodeBalancerNodeBlockBody := nodeBalancerNodeBlock.Body()
 
+	var instances []string
+	for _, server := range servers {
+		instances = append(instances, linodeDefalts.LinodeInstance+`.`+server)
+	}
+
 	expression := `{
-        for instance in [` + linodeDefalts.LinodeInstance + `.` + serverOne + `, ` +
-		linodeDefalts.LinodeInstance + `.` + serverTwo + `, ` +
-		linodeDefalts.LinodeInstance + `.` + serverThree + `] : instance.label => instance
+        for instance in [` + strings.Join(instances, `, `) + `] : instance.label => instance
 	}`
 
 	values := hclwrite.Tokens{
@@ -16,9 +16,6 @@ const (
 	locals            = "locals"
 	requiredProviders = "required_providers"
 	instanceIDs       = "instance_ids"
-	serverOne         = "server1"
-	serverTwo         = "server2"
-	serverThree       = "server3"
 )
 
 // CreateLinodeTerraformProviderBlock will up the terraform block with the required linode provider.
@@ -42,15 +39,14 @@ func CreateLinodeProviderBlock(rootBody *hclwrite.Body, terraformConfig *config.
 	linodeProvBlockBody.SetAttributeValue(linode.Token, cty.StringVal(terraformConfig.LinodeCredentials.LinodeToken))
 }
 
-// CreateLinodeLocalBlock will set up the local block. Returns the local block.
-func CreateLinodeLocalBlock(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+// CreateLinodeLocalBlock will set up the local block with the given servers. Returns the local block.
+func CreateLinodeLocalBlock(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, servers []string) {
 	localBlock := rootBody.AppendNewBlock(locals, nil)
 	localBlockBody := localBlock.Body()
 
-	instanceIds := map[string]any{
-		serverOne:   linodeDefaults.LinodeInstance + "." + serverOne + ".id",
-		serverTwo:   linodeDefaults.LinodeInstance + "." + serverTwo + ".id",
-		serverThree: linodeDefaults.LinodeInstance + "." + serverThree + ".id",
+	instanceIds := map[string]any{}
+	for _, server := range servers {
+		instanceIds[server] = linodeDefaults.LinodeInstance + "." + server + ".id"
 	}
 
 	instanceIdsBlock := localBlockBody.AppendNewBlock(instanceIDs+" =", nil)
@@ -53,14 +53,31 @@ func TunnelToProvider(provider string) ProviderResources {
 	case providers.Harvester:
 		logrus.Infof("Creating Harvester resources...")
 		return ProviderResources{
+			CreateAirgap:    harvester.CreateAirgappedHarvesterResources,
 			CreateNonAirgap: harvester.CreateHarvesterResources,
+			CreateIPv6:      harvester.CreateIPv6HarvesterResources,
 		}
 	case providers.Vsphere:
 		logrus.Infof("Creating vSphere resources...")
 		return ProviderResources{
+			CreateAirgap:    vsphere.CreateAirgappedVsphereResources,
 			CreateNonAirgap: vsphere.CreateVsphereResources,
+			CreateIPv6:      vsphere.CreateIPv6VsphereResources,
 		}
 	default:
 		panic(fmt.Sprintf("Unsupported provider: %s", provider))
 	}
 }
+
+// AirgapNameserver returns the nameserver that the airgapped nodes resolve through. An empty value keeps the
+// nameserver handed out by the network of the nodes.
+func AirgapNameserver(terraformConfig *config.TerraformConfig) string {
+	switch terraformConfig.Provider {
+	case providers.Harvester:
+		return terraformConfig.HarvesterConfig.AirgapNameserver
+	case providers.Vsphere:
+		return terraformConfig.VsphereConfig.AirgapNameserver
+	default:
+		return terraformConfig.AWSConfig.AWSVpcIP
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/framework/set/defaults/general"
 	"github.com/rancher/tfp-automation/framework/set/defaults/providers/vsphere"
+	"github.com/rancher/tfp-automation/framework/set/resources/topology"
 	"github.com/sirupsen/logrus"
 )
 
@@ -19,8 +20,8 @@ const (
 	cluster               = "cluster"
 	datacenterID          = "datacenter_id"
 	datastoreID           = "datastore_id"
-	diskEnableUUID        = "disk_enable_uuid"
 	domain                = "domain"
+	enableDiskUUID        = "enable_disk_uuid"
 	guestID               = "guest_id"
 	firmware              = "firmware"
 	hostName              = "host_name"
@@ -35,6 +36,153 @@ const (
 // CreateVsphereResources is a helper function that will create the vSphere resources needed for the RKE2 cluster.
 func CreateVsphereResources(file *os.File, newFile *hclwrite.File, tfBlockBody, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
 	terratestConfig *config.TerratestConfig, instances []string) (*os.File, error) {
+	if terraformConfig.Proxy != nil && terraformConfig.VsphereConfig.AirgapNetwork == "" {
+		return nil, fmt.Errorf("vsphereConfig.airgapNetwork must be set to create the proxied nodes")
+	}
+
+	err := createVsphereDataSources(tfBlockBody, rootBody, terraformConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, instance := range instances {
+		err = CreateVsphereVirtualMachine(rootBody, terraformConfig, terratestConfig, instance)
+		if err != nil {
+			return nil, err
+		}
+
+		rootBody.AppendNewline()
+	}
+
+	servers := topology.Servers(instances)
+
+	if terraformConfig.Proxy != nil {
+		nodes, err := topology.FixedNodes(terraformConfig, "proxy")
+		if err != nil {
+			return nil, err
+		}
+
+		servers = topology.Names(nodes)
+		for _, instance := range servers {
+			err = CreateAirgappedVsphereVirtualMachine(rootBody, terraformConfig, terratestConfig, instance)
+			if err != nil {
+				return nil, err
+			}
+
+			rootBody.AppendNewline()
+		}
+	}
+
+	CreateVsphereLocalBlock(rootBody, terraformConfig, servers)
+	rootBody.AppendNewline()
+
+	_, err = file.Write(newFile.Bytes())
+	if err != nil {
+		logrus.Infof("Failed to write configurations to main.tf file. Error: %v", err)
+		return nil, err
+	}
+
+	return file, err
+}
+
+// CreateAirgappedVsphereResources is a helper function that will create the vSphere resources needed for the airgapped
+// RKE2 cluster. The given instances, e.g. the bastion and the registry, are attached to the standalone network while the
+// nodes of the local cluster are only attached to the airgap network, so both networks must route to each other.
+func CreateAirgappedVsphereResources(file *os.File, newFile *hclwrite.File, tfBlockBody, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
+	terratestConfig *config.TerratestConfig, instances []string) (*os.File, error) {
+	if terraformConfig.VsphereConfig.AirgapNetwork == "" {
+		return nil, fmt.Errorf("vsphereConfig.airgapNetwork must be set to create the airgapped nodes")
+	}
+
+	err := createVsphereDataSources(tfBlockBody, rootBody, terraformConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, instance := range instances {
+		err = CreateVsphereVirtualMachine(rootBody, terraformConfig, terratestConfig, instance)
+		if err != nil {
+			return nil, err
+		}
+
+		rootBody.AppendNewline()
+	}
+
+	nodes, err := topology.Nodes(terraformConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	airgappedInstances := topology.Names(nodes)
+	for _, instance := range airgappedInstances {
+		err = CreateAirgappedVsphereVirtualMachine(rootBody, terraformConfig, terratestConfig, instance)
+		if err != nil {
+			return nil, err
+		}
+
+		rootBody.AppendNewline()
+	}
+
+	CreateVsphereLocalBlock(rootBody, terraformConfig, topology.Servers(airgappedInstances))
+	rootBody.AppendNewline()
+
+	_, err = file.Write(newFile.Bytes())
+	if err != nil {
+		logrus.Infof("Failed to write configurations to main.tf file. Error: %v", err)
+		return nil, err
+	}
+
+	return file, err
+}
+
+// CreateIPv6VsphereResources is a helper function that will create the vSphere resources needed for the IPv6 RKE2
+// cluster. The nodes of the local cluster are attached to the standalone network, which must hand out IPv6 addresses.
+func CreateIPv6VsphereResources(file *os.File, newFile *hclwrite.File, tfBlockBody, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
+	terratestConfig *config.TerratestConfig, instances []string) (*os.File, error) {
+	err := createVsphereDataSources(tfBlockBody, rootBody, terraformConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, instance := range instances {
+		err = CreateVsphereVirtualMachine(rootBody, terraformConfig, terratestConfig, instance)
+		if err != nil {
+			return nil, err
+		}
+
+		rootBody.AppendNewline()
+	}
+
+	nodes, err := topology.FixedNodes(terraformConfig, "IPv6")
+	if err != nil {
+		return nil, err
+	}
+
+	servers := topology.Names(nodes)
+	for _, instance := range servers {
+		err = CreateVsphereVirtualMachine(rootBody, terraformConfig, terratestConfig, instance)
+		if err != nil {
+			return nil, err
+		}
+
+		rootBody.AppendNewline()
+	}
+
+	CreateVsphereLocalBlock(rootBody, terraformConfig, servers)
+	rootBody.AppendNewline()
+
+	_, err = file.Write(newFile.Bytes())
+	if err != nil {
+		logrus.Infof("Failed to write configurations to main.tf file. Error: %v", err)
+		return nil, err
+	}
+
+	return file, err
+}
+
+// createVsphereDataSources is a helper function that will set the vSphere providers and the data sources shared by
+// every virtual machine in the main.tf file.
+func createVsphereDataSources(tfBlockBody, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) error {
 	CreateVsphereTerraformProviderBlock(tfBlockBody)
 	rootBody.AppendNewline()
 
@@ -63,22 +211,17 @@ func CreateVsphereResources(file *os.File, newFile *hclwrite.File, tfBlockBody,
 	CreateVsphereNetwork(rootBody, terraformConfig, dataCenterValue)
 	rootBody.AppendNewline()
 
-	CreateVsphereVirtualMachineTemplate(rootBody, terraformConfig, dataCenterValue)
-	rootBody.AppendNewline()
-
-	for _, instance := range instances {
-		CreateVsphereVirtualMachine(rootBody, terraformConfig, terratestConfig, instance)
+	if terraformConfig.VsphereConfig.AirgapNetwork != "" {
+		CreateVsphereAirgapNetwork(rootBody, terraformConfig, dataCenterValue)
 		rootBody.AppendNewline()
 	}
 
-	CreateVsphereLocalBlock(rootBody, terraformConfig)
-	rootBody.AppendNewline()
-
-	_, err := file.Write(newFile.Bytes())
+	err := CreateVsphereVirtualMachineTemplate(rootBody, terraformConfig, dataCenterValue)
 	if err != nil {
-		logrus.Infof("Failed to write configurations to main.tf file. Error: %v", err)
-		return nil, err
+		return err
 	}
 
-	return file, err
+	rootBody.AppendNewline()
+
+	return nil
 }
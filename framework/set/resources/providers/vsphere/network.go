@@ -16,3 +16,13 @@ func CreateVsphereNetwork(rootBody *hclwrite.Body, terraformConfig *config.Terra
 	networkBlockBody.SetAttributeValue(general.ResourceName, cty.StringVal(terraformConfig.VsphereConfig.StandaloneNetwork))
 	networkBlockBody.SetAttributeRaw(datacenterID, dataCenterValue)
 }
+
+// CreateVsphereAirgapNetwork is a function that will set the vSphere network configuration of the isolated network in
+// the main.tf file. The airgapped nodes are only attached to this network.
+func CreateVsphereAirgapNetwork(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, dataCenterValue hclwrite.Tokens) {
+	networkBlock := rootBody.AppendNewBlock(general.Data, []string{vsphere.VsphereNetwork, vsphere.VsphereAirgapNetwork})
+	networkBlockBody := networkBlock.Body()
+
+	networkBlockBody.SetAttributeValue(general.ResourceName, cty.StringVal(terraformConfig.VsphereConfig.AirgapNetwork))
+	networkBlockBody.SetAttributeRaw(datacenterID, dataCenterValue)
+}
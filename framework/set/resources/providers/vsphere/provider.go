@@ -14,9 +14,6 @@ import (
 const (
 	locals             = "locals"
 	requiredProviders  = "required_providers"
-	serverOne          = "server1"
-	serverTwo          = "server2"
-	serverThree        = "server3"
 	instanceIDs        = "instance_ids"
 	allowUnverifiedSSL = "allow_unverified_ssl"
 )
@@ -44,15 +41,14 @@ func CreateVsphereProviderBlock(rootBody *hclwrite.Body, terraformConfig *config
 	vsphereProvBlockBody.SetAttributeValue(allowUnverifiedSSL, cty.BoolVal(true))
 }
 
-// CreateVsphereLocalBlock will set up the local block. Returns the local block.
-func CreateVsphereLocalBlock(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
+// CreateVsphereLocalBlock will set up the local block with the given servers. Returns the local block.
+func CreateVsphereLocalBlock(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, servers []string) {
 	localBlock := rootBody.AppendNewBlock(locals, nil)
 	localBlockBody := localBlock.Body()
 
-	instanceIds := map[string]any{
-		serverOne:   vsphere.VsphereVirtualMachine + "." + serverOne + ".id",
-		serverTwo:   vsphere.VsphereVirtualMachine + "." + serverTwo + ".id",
-		serverThree: vsphere.VsphereVirtualMachine + "." + serverThree + ".id",
+	instanceIds := map[string]any{}
+	for _, server := range servers {
+		instanceIds[server] = vsphere.VsphereVirtualMachine + "." + server + ".id"
 	}
 
 	instanceIdsBlock := localBlockBody.AppendNewBlock(instanceIDs+" =", nil)
@@ -16,7 +16,22 @@ import (
 
 // CreateVsphereVirtualMachine is a function that will set the vSphere virtual machine configuration in the main.tf file.
 func CreateVsphereVirtualMachine(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
-	hostnamePrefix string) {
+	hostnamePrefix string) error {
+	return createVirtualMachine(rootBody, terraformConfig, terratestConfig, hostnamePrefix, vsphere.VsphereNetwork)
+}
+
+// CreateAirgappedVsphereVirtualMachine is a function that will set the vSphere virtual machine configuration of an
+// airgapped node in the main.tf file. The virtual machine is only attached to the isolated network.
+func CreateAirgappedVsphereVirtualMachine(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
+	hostnamePrefix string) error {
+	return createVirtualMachine(rootBody, terraformConfig, terratestConfig, hostnamePrefix, vsphere.VsphereAirgapNetwork)
+}
+
+// createVirtualMachine is a helper function that will set the vSphere virtual machine configuration in the main.tf file,
+// attached to the given network data source. The tags, custom attributes, cfgparams and vApp properties of the config are
+// applied to the virtual machine.
+func createVirtualMachine(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
+	hostnamePrefix, networkName string) error {
 	vmBlock := rootBody.AppendNewBlock(general.Resource, []string{vsphere.VsphereVirtualMachine, hostnamePrefix})
 	vmBlockBody := vmBlock.Body()
 
@@ -60,19 +75,47 @@ func CreateVsphereVirtualMachine(rootBody *hclwrite.Body, terraformConfig *confi
 
 	cpuCount, err := strconv.ParseInt(terraformConfig.VsphereConfig.CPUCount, 10, 64)
 	if err != nil {
-		panic(fmt.Sprintf("Invalid CPU count value: %s", terraformConfig.VsphereConfig.CPUCount))
+		return fmt.Errorf("invalid CPU count value: %s", terraformConfig.VsphereConfig.CPUCount)
 	}
 
 	vmBlockBody.SetAttributeValue(vsphere.NumCPUs, cty.NumberIntVal(cpuCount))
 
 	memory, err := strconv.ParseInt(terraformConfig.VsphereConfig.MemorySize, 10, 64)
 	if err != nil {
-		panic(fmt.Sprintf("Invalid memory size value: %s", terraformConfig.VsphereConfig.MemorySize))
+		return fmt.Errorf("invalid memory size value: %s", terraformConfig.VsphereConfig.MemorySize)
 	}
 
 	vmBlockBody.SetAttributeValue(vsphere.Memory, cty.NumberIntVal(memory))
 	vmBlockBody.SetAttributeValue(guestID, cty.StringVal(terraformConfig.VsphereConfig.GuestID))
 	vmBlockBody.SetAttributeValue(firmware, cty.StringVal(terraformConfig.VsphereConfig.Firmware))
+	vmBlockBody.SetAttributeValue(enableDiskUUID, cty.BoolVal(true))
+
+	if len(terraformConfig.VsphereConfig.Tag) > 0 {
+		tags := make([]cty.Value, len(terraformConfig.VsphereConfig.Tag))
+		for i, tag := range terraformConfig.VsphereConfig.Tag {
+			tags[i] = cty.StringVal(tag)
+		}
+
+		vmBlockBody.SetAttributeValue(vsphere.Tags, cty.ListVal(tags))
+	}
+
+	if len(terraformConfig.VsphereConfig.CustomAttribute) > 0 {
+		customAttributes, err := keyValues(terraformConfig.VsphereConfig.CustomAttribute, "customAttribute")
+		if err != nil {
+			return err
+		}
+
+		vmBlockBody.SetAttributeValue(vsphere.CustomAttributes, cty.MapVal(customAttributes))
+	}
+
+	if len(terraformConfig.VsphereConfig.Cfgparam) > 0 {
+		cfgparams, err := keyValues(terraformConfig.VsphereConfig.Cfgparam, "cfgparam")
+		if err != nil {
+			return err
+		}
+
+		vmBlockBody.SetAttributeValue(vsphere.ExtraConfig, cty.MapVal(cfgparams))
+	}
 
 	vmBlockBody.AppendNewline()
 
@@ -85,7 +128,7 @@ func CreateVsphereVirtualMachine(rootBody *hclwrite.Body, terraformConfig *confi
 	networkBlock := vmBlockBody.AppendNewBlock(vsphere.NetworkInterface, nil)
 	networkBlockBody := networkBlock.Body()
 
-	networkExpression := fmt.Sprintf(general.Data + `.` + vsphere.VsphereNetwork + `.` + vsphere.VsphereNetwork + `.id`)
+	networkExpression := fmt.Sprintf(general.Data + `.` + vsphere.VsphereNetwork + `.` + networkName + `.id`)
 	networkValue := hclwrite.Tokens{
 		{Type: hclsyntax.TokenIdent, Bytes: []byte(networkExpression)},
 	}
@@ -109,7 +152,7 @@ func CreateVsphereVirtualMachine(rootBody *hclwrite.Body, terraformConfig *confi
 
 	diskSize, err := strconv.ParseInt(terraformConfig.VsphereConfig.DiskSize, 10, 64)
 	if err != nil {
-		panic(fmt.Sprintf("Invalid disk size value: %s", terraformConfig.VsphereConfig.DiskSize))
+		return fmt.Errorf("invalid disk size value: %s", terraformConfig.VsphereConfig.DiskSize)
 	}
 
 	diskBlockBody.SetAttributeValue(vsphere.Size, cty.NumberIntVal(diskSize))
@@ -118,16 +161,38 @@ func CreateVsphereVirtualMachine(rootBody *hclwrite.Body, terraformConfig *confi
 	cloneBlock := vmBlockBody.AppendNewBlock(clone, nil)
 	cloneBlockBody := cloneBlock.Body()
 
-	templateUUIDExpression := fmt.Sprintf(general.Data + `.` + vsphere.VsphereVirtualMachine + `.` + vsphere.VsphereVirtualMachineTemplate + `.id`)
 	templateUUIDValue := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(templateUUIDExpression)},
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(templateUUIDExpression(terraformConfig))},
 	}
 
 	cloneBlockBody.SetAttributeRaw(templateUUID, templateUUIDValue)
-	vmBlockBody.AppendNewline()
 
-	extraConfigBlock := vmBlockBody.AppendNewBlock(vsphere.ExtraConfig+" =", nil)
-	extraConfigBlockBody := extraConfigBlock.Body()
+	if len(terraformConfig.VsphereConfig.VappProperty) > 0 {
+		vappProperties, err := keyValues(terraformConfig.VsphereConfig.VappProperty, "vappProperty")
+		if err != nil {
+			return err
+		}
+
+		vmBlockBody.AppendNewline()
+
+		vappBlock := vmBlockBody.AppendNewBlock(vsphere.Vapp, nil)
+		vappBlock.Body().SetAttributeValue(vsphere.VappProperties, cty.MapVal(vappProperties))
+	}
+
+	return nil
+}
+
+// keyValues is a helper function that will split the given key=value pairs of the config field into a map value.
+func keyValues(pairs []string, field string) (map[string]cty.Value, error) {
+	values := map[string]cty.Value{}
+	for _, pair := range pairs {
+		key, value, found := strings.Cut(pair, "=")
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid vsphereConfig.%s %s, expected key=value", field, pair)
+		}
+
+		values[key] = cty.StringVal(value)
+	}
 
-	extraConfigBlockBody.SetAttributeValue(diskEnableUUID, cty.BoolVal(true))
+	return values, nil
 }
@@ -1,18 +1,67 @@
 package vsphere
 
 import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
+	vsphereBlocks "github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/vsphere"
 	"github.com/rancher/tfp-automation/framework/set/defaults/general"
 	"github.com/rancher/tfp-automation/framework/set/defaults/providers/vsphere"
 	"github.com/zclconf/go-cty/cty"
 )
 
+const (
+	libraryID = "library_id"
+	ovf       = "ovf"
+)
+
 // CreateVsphereVirtualMachineTemplate is a function that will set the vSphere virtual machine template configuration in the main.tf file.
-func CreateVsphereVirtualMachineTemplate(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, dataCenterValue hclwrite.Tokens) {
-	vmTemplateBlock := rootBody.AppendNewBlock(general.Data, []string{vsphere.VsphereVirtualMachine, vsphere.VsphereVirtualMachineTemplate})
-	vmTemplateBlockBody := vmTemplateBlock.Body()
+// The virtual machines are cloned from a template or a virtual machine, or deployed from a content library item depending on the
+// creation type of the config.
+func CreateVsphereVirtualMachineTemplate(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, dataCenterValue hclwrite.Tokens) error {
+	switch terraformConfig.VsphereConfig.CreationType {
+	case "", vsphereBlocks.CreationTypeTemplate, vsphereBlocks.CreationTypeVM:
+		vmTemplateBlock := rootBody.AppendNewBlock(general.Data, []string{vsphere.VsphereVirtualMachine, vsphere.VsphereVirtualMachineTemplate})
+		vmTemplateBlockBody := vmTemplateBlock.Body()
+
+		vmTemplateBlockBody.SetAttributeValue(general.ResourceName, cty.StringVal(terraformConfig.VsphereConfig.CloneFrom))
+		vmTemplateBlockBody.SetAttributeRaw(datacenterID, dataCenterValue)
+	case vsphereBlocks.CreationTypeLibrary:
+		if terraformConfig.VsphereConfig.ContentLibrary == "" {
+			return fmt.Errorf("vsphereConfig.contentLibrary must be set for the %s creation type", vsphereBlocks.CreationTypeLibrary)
+		}
+
+		libraryBlock := rootBody.AppendNewBlock(general.Data, []string{vsphere.VsphereContentLibrary, vsphere.VsphereContentLibrary})
+		libraryBlock.Body().SetAttributeValue(general.ResourceName, cty.StringVal(terraformConfig.VsphereConfig.ContentLibrary))
+		rootBody.AppendNewline()
+
+		libraryItemBlock := rootBody.AppendNewBlock(general.Data, []string{vsphere.VsphereContentLibraryItem, vsphere.VsphereVirtualMachineTemplate})
+		libraryItemBlockBody := libraryItemBlock.Body()
+
+		libraryItemBlockBody.SetAttributeValue(general.ResourceName, cty.StringVal(terraformConfig.VsphereConfig.CloneFrom))
+		libraryItemBlockBody.SetAttributeValue(general.Type, cty.StringVal(ovf))
+
+		libraryIDExpression := general.Data + `.` + vsphere.VsphereContentLibrary + `.` + vsphere.VsphereContentLibrary + `.id`
+		libraryItemBlockBody.SetAttributeRaw(libraryID, hclwrite.Tokens{
+			{Type: hclsyntax.TokenIdent, Bytes: []byte(libraryIDExpression)},
+		})
+	case vsphereBlocks.CreationTypeLegacy:
+		return fmt.Errorf("the %s creation type is only supported by the vSphere node driver", vsphereBlocks.CreationTypeLegacy)
+	default:
+		return fmt.Errorf("unsupported vsphereConfig.creationType %s", terraformConfig.VsphereConfig.CreationType)
+	}
+
+	return nil
+}
+
+// templateUUIDExpression is a helper function that returns the expression of the template the virtual machines are cloned
+// from, matching the data source created by CreateVsphereVirtualMachineTemplate.
+func templateUUIDExpression(terraformConfig *config.TerraformConfig) string {
+	if terraformConfig.VsphereConfig.CreationType == vsphereBlocks.CreationTypeLibrary {
+		return general.Data + `.` + vsphere.VsphereContentLibraryItem + `.` + vsphere.VsphereVirtualMachineTemplate + `.id`
+	}
 
-	vmTemplateBlockBody.SetAttributeValue(general.ResourceName, cty.StringVal(terraformConfig.VsphereConfig.CloneFrom))
-	vmTemplateBlockBody.SetAttributeRaw(datacenterID, dataCenterValue)
+	return general.Data + `.` + vsphere.VsphereVirtualMachine + `.` + vsphere.VsphereVirtualMachineTemplate + `.id`
 }
@@ -0,0 +1,152 @@
+package vsphere
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/defaults/general"
+	"github.com/rancher/tfp-automation/framework/set/defaults/providers/vsphere"
+	customnodepools "github.com/rancher/tfp-automation/framework/set/provisioning/custom/nodepools"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	adminPassword = "admin_password"
+	computerName  = "computer_name"
+)
+
+// CreateWindowsVsphereVirtualMachine is a function that will set the vSphere Windows virtual machines configuration in
+// the main.tf file. The virtual machines are cloned from the Windows template of the given Windows Server version, which
+// must have WinRM enabled.
+func CreateWindowsVsphereVirtualMachine(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
+	dataCenterValue hclwrite.Tokens, version string) error {
+	var templateName string
+
+	switch version {
+	case config.Windows2019:
+		templateName = terraformConfig.VsphereConfig.Windows2019Template
+	case config.Windows2022:
+		templateName = terraformConfig.VsphereConfig.Windows2022Template
+	case config.Windows2025:
+		templateName = terraformConfig.VsphereConfig.Windows2025Template
+	}
+
+	if templateName == "" {
+		return fmt.Errorf("vsphereConfig.windows%sTemplate must be set to create the Windows nodes", version)
+	}
+
+	vmTemplateBlock := rootBody.AppendNewBlock(general.Data, []string{vsphere.VsphereVirtualMachine, vsphere.VsphereWindowsTemplate})
+	vmTemplateBlockBody := vmTemplateBlock.Body()
+
+	vmTemplateBlockBody.SetAttributeValue(general.ResourceName, cty.StringVal(templateName))
+	vmTemplateBlockBody.SetAttributeRaw(datacenterID, dataCenterValue)
+	rootBody.AppendNewline()
+
+	windowsTemplate := general.Data + `.` + vsphere.VsphereVirtualMachine + `.` + vsphere.VsphereWindowsTemplate
+	hostnamePrefix := terraformConfig.ResourcePrefix + "-windows"
+
+	vmBlock := rootBody.AppendNewBlock(general.Resource, []string{vsphere.VsphereVirtualMachine, hostnamePrefix})
+	vmBlockBody := vmBlock.Body()
+
+	vmBlockBody.SetAttributeValue(general.Count, cty.NumberIntVal(customnodepools.WindowsNodeCount(terratestConfig)))
+
+	vmNameExpression := fmt.Sprintf(` "%s-${%s.%s}"`, hostnamePrefix, general.Count, general.Index)
+	vmNameValue := hclwrite.Tokens{
+		{Type: hclsyntax.TokenStringLit, Bytes: []byte(vmNameExpression)},
+	}
+
+	vmBlockBody.SetAttributeRaw(general.ResourceName, vmNameValue)
+
+	resourcePoolExpression := general.Data + `.` + vsphere.VsphereComputeCluster + `.` + vsphere.VsphereComputeCluster + `.` + resourcePoolID
+	if terraformConfig.VsphereConfig.Pool != "" {
+		resourcePoolExpression = general.Data + `.` + vsphere.VsphereResourcePool + `.` + vsphere.VsphereResourcePool + `.id`
+	}
+
+	vmBlockBody.SetAttributeRaw(resourcePoolID, hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(resourcePoolExpression)},
+	})
+
+	dataStoreExpression := general.Data + `.` + vsphere.VsphereDatastore + `.` + vsphere.VsphereDatastore + `.id`
+	vmBlockBody.SetAttributeRaw(datastoreID, hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(dataStoreExpression)},
+	})
+
+	vmBlockBody.SetAttributeValue(vsphere.Folder, cty.StringVal(terraformConfig.VsphereConfig.Folder))
+
+	cpuCount, err := strconv.ParseInt(terraformConfig.VsphereConfig.CPUCount, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid CPU count value: %s", terraformConfig.VsphereConfig.CPUCount)
+	}
+
+	vmBlockBody.SetAttributeValue(vsphere.NumCPUs, cty.NumberIntVal(cpuCount))
+
+	memory, err := strconv.ParseInt(terraformConfig.VsphereConfig.MemorySize, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid memory size value: %s", terraformConfig.VsphereConfig.MemorySize)
+	}
+
+	vmBlockBody.SetAttributeValue(vsphere.Memory, cty.NumberIntVal(memory))
+
+	// The guest ID and firmware must match the Windows template, which differ from the Linux nodes.
+	vmBlockBody.SetAttributeRaw(guestID, hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(windowsTemplate + `.` + guestID)},
+	})
+	vmBlockBody.SetAttributeRaw(firmware, hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(windowsTemplate + `.` + firmware)},
+	})
+	vmBlockBody.SetAttributeValue(enableDiskUUID, cty.BoolVal(true))
+
+	vmBlockBody.AppendNewline()
+
+	networkBlock := vmBlockBody.AppendNewBlock(vsphere.NetworkInterface, nil)
+	networkBlockBody := networkBlock.Body()
+
+	networkExpression := general.Data + `.` + vsphere.VsphereNetwork + `.` + vsphere.VsphereNetwork + `.id`
+	networkBlockBody.SetAttributeRaw(vsphere.NetworkID, hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(networkExpression)},
+	})
+	networkBlockBody.SetAttributeRaw(adapterType, hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(windowsTemplate + `.` + networkInterfaceTypes + `[0]`)},
+	})
+
+	vmBlockBody.AppendNewline()
+
+	diskBlock := vmBlockBody.AppendNewBlock(vsphere.Disk, nil)
+	diskBlockBody := diskBlock.Body()
+
+	diskBlockBody.SetAttributeRaw(general.Label, vmNameValue)
+
+	diskSize, err := strconv.ParseInt(terraformConfig.VsphereConfig.DiskSize, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid disk size value: %s", terraformConfig.VsphereConfig.DiskSize)
+	}
+
+	diskBlockBody.SetAttributeValue(vsphere.Size, cty.NumberIntVal(diskSize))
+	vmBlockBody.AppendNewline()
+
+	cloneBlock := vmBlockBody.AppendNewBlock(clone, nil)
+	cloneBlockBody := cloneBlock.Body()
+
+	cloneBlockBody.SetAttributeRaw(templateUUID, hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(windowsTemplate + `.id`)},
+	})
+
+	customizeBlock := cloneBlockBody.AppendNewBlock(vsphere.Customize, nil)
+	customizeBlockBody := customizeBlock.Body()
+
+	windowsOptionsBlock := customizeBlockBody.AppendNewBlock(vsphere.WindowsOptions, nil)
+	windowsOptionsBlockBody := windowsOptionsBlock.Body()
+
+	computerNameExpression := fmt.Sprintf(` "%s-${%s.%s}"`, customnodepools.WindowsComputerNamePrefix(terraformConfig), general.Count, general.Index)
+	windowsOptionsBlockBody.SetAttributeRaw(computerName, hclwrite.Tokens{
+		{Type: hclsyntax.TokenStringLit, Bytes: []byte(computerNameExpression)},
+	})
+	windowsOptionsBlockBody.SetAttributeValue(adminPassword, cty.StringVal(terraformConfig.VsphereConfig.WindowsPassword))
+
+	customizeBlockBody.AppendNewBlock(vsphere.NetworkInterface, nil)
+
+	return nil
+}
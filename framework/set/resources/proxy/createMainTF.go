@@ -16,16 +16,15 @@ import (
 	"github.com/rancher/tfp-automation/framework/set/resources/proxy/rke2"
 	"github.com/rancher/tfp-automation/framework/set/resources/proxy/rke2/squid"
 	"github.com/rancher/tfp-automation/framework/set/resources/sanity"
+	"github.com/rancher/tfp-automation/framework/set/resources/topology"
+	"github.com/rancher/tfp-automation/framework/timing"
 	"github.com/sirupsen/logrus"
 )
 
 const (
 	nodeBalancerHostname = "linode_node_balancer_hostname"
 
-	bastion     = "bastion"
-	serverOne   = "server1"
-	serverTwo   = "server2"
-	serverThree = "server3"
+	bastion = "bastion"
 
 	bastionPublicDNS     = "bastion_public_dns"
 	bastionPrivateIP     = "bastion_private_ip"
@@ -49,9 +48,15 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 	tfBlock := rootBody.AppendNewBlock(terraformConst, nil)
 	tfBlockBody := tfBlock.Body()
 
-	var err error
+	nodes, err := topology.FixedNodes(terraformConfig, "proxy")
+	if err != nil {
+		return "", "", err
+	}
 
 	instances := []string{bastion}
+	topology.CreateHostOutputs(rootBody, terraformConfig, append(instances, topology.Names(nodes)...)...)
+
+	timing.AddInstances(terraformOptions, terraformConfig.Provider, timing.InstanceType(terraformConfig.Provider, terraformConfig), len(instances)+len(nodes))
 
 	providerTunnel := tunnel.TunnelToProvider(terraformConfig.Provider)
 	file, err = providerTunnel.CreateNonAirgap(file, newFile, tfBlockBody, rootBody, terraformConfig, terratestConfig, instances)
@@ -59,7 +64,7 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 		return "", "", err
 	}
 
-	_, err = terraform.InitAndApplyE(t, terraformOptions)
+	_, err = timing.InitAndApplyE(t, terraformOptions, "resources")
 	if err != nil && *rancherConfig.Cleanup {
 		logrus.Infof("Error while creating resources. Cleaning up...")
 		cleanup.Cleanup(t, terraformOptions, keyPath)
@@ -86,7 +91,7 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 		}
 	}
 
-	_, err = terraform.InitAndApplyE(t, terraformOptions)
+	_, err = timing.InitAndApplyE(t, terraformOptions, "squid proxy")
 	if err != nil && *rancherConfig.Cleanup {
 		logrus.Infof("Error while creating squid proxy. Cleaning up...")
 		cleanup.Cleanup(t, terraformOptions, keyPath)
@@ -108,7 +113,7 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 		}
 	}
 
-	_, err = terraform.InitAndApplyE(t, terraformOptions)
+	_, err = timing.InitAndApplyE(t, terraformOptions, "local cluster")
 	if err != nil && *rancherConfig.Cleanup {
 		logrus.Infof("Error while creating local cluster. Cleaning up...")
 		cleanup.Cleanup(t, terraformOptions, keyPath)
@@ -122,7 +127,7 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 		return "", "", err
 	}
 
-	_, err = terraform.InitAndApplyE(t, terraformOptions)
+	_, err = timing.InitAndApplyE(t, terraformOptions, "rancher server")
 	if err != nil && *rancherConfig.Cleanup {
 		logrus.Infof("Error while creating Rancher server. Cleaning up...")
 		cleanup.Cleanup(t, terraformOptions, keyPath)
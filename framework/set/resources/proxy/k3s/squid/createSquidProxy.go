@@ -2,13 +2,11 @@ package squid
 
 import (
 	"os"
-	"path/filepath"
 
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
-	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/framework/assets"
 	"github.com/rancher/tfp-automation/framework/set/defaults/general"
-	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 	"github.com/rancher/tfp-automation/framework/set/resources/rke2"
 	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
@@ -21,12 +19,10 @@ const (
 // CreateSquidProxy is a function that will set the squid proxy configurations in the main.tf file.
 func CreateSquidProxy(file *os.File, newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
 	terratestConfig *config.TerratestConfig, k3sBastionPublicDNS, k3sServerOnePrivateIP, k3sServerTwoPrivateIP, k3sServerThreePrivateIP string) (*os.File, error) {
-	userDir, _ := rancher2.SetKeyPath(keypath.ProxyKeyPath, terratestConfig.PathToRepo, terraformConfig.Provider)
+	scriptPath := "framework/set/resources/proxy/k3s/squid/setup.sh"
+	squidConf := "framework/set/resources/proxy/k3s/squid/squid.conf"
 
-	scriptPath := filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/proxy/k3s/squid/setup.sh")
-	squidConf := filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/proxy/k3s/squid/squid.conf")
-
-	scriptContent, err := os.ReadFile(scriptPath)
+	scriptContent, err := assets.ReadFile(scriptPath)
 	if err != nil {
 		return nil, err
 	}
@@ -36,7 +32,7 @@ func CreateSquidProxy(file *os.File, newFile *hclwrite.File, rootBody *hclwrite.
 		return nil, err
 	}
 
-	squidConfContent, err := os.ReadFile(squidConf)
+	squidConfContent, err := assets.ReadFile(squidConf)
 	if err != nil {
 		return nil, err
 	}
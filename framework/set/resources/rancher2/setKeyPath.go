@@ -3,9 +3,13 @@ package rancher2
 import (
 	"os"
 	"path/filepath"
+
+	"github.com/rancher/tfp-automation/framework/assets"
+	"github.com/sirupsen/logrus"
 )
 
-// SetKeyPath is a function that will set the path to the key file.
+// SetKeyPath is a function that will set the path to the key file. The skeleton of the module is written to the
+// returned path when it is not already there.
 func SetKeyPath(keyPath, pathToRepo, provider string) (string, string) {
 	var err error
 	userDir := os.Getenv("GOPATH")
@@ -16,11 +20,17 @@ func SetKeyPath(keyPath, pathToRepo, provider string) (string, string) {
 		}
 	}
 
+	module := filepath.Join(keyPath, provider)
 	keyPath = filepath.Join(userDir, pathToRepo, keyPath)
 
 	if provider != "" {
 		keyPath = filepath.Join(keyPath, "/", provider)
 	}
 
+	err = assets.MaterializeModule(module, keyPath)
+	if err != nil {
+		logrus.Warnf("Failed to write the %s module skeleton to %s. Error: %v", module, keyPath, err)
+	}
+
 	return userDir, keyPath
 }
@@ -4,6 +4,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/shepherd/clients/rancher"
 	management "github.com/rancher/shepherd/clients/rancher/generated/management/v3"
@@ -12,6 +13,9 @@ import (
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/framework/set/defaults/general"
 	"github.com/rancher/tfp-automation/framework/set/defaults/providers/aws"
+	"github.com/rancher/tfp-automation/framework/set/defaults/providers/azure"
+	"github.com/rancher/tfp-automation/framework/set/defaults/providers/google"
+	"github.com/rancher/tfp-automation/framework/set/defaults/providers/harvester"
 	"github.com/rancher/tfp-automation/framework/set/defaults/providers/linode"
 	vsphereDefaults "github.com/rancher/tfp-automation/framework/set/defaults/providers/vsphere"
 	"github.com/rancher/tfp-automation/framework/set/defaults/rke"
@@ -21,36 +25,41 @@ import (
 )
 
 const (
-	admin                   = "admin"
-	apiURL                  = "api_url"
-	alias                   = "alias"
-	allowUnverifiedSSL      = "allow_unverified_ssl"
-	ec2                     = "ec2"
-	globalRoleBinding       = "rancher2_global_role_binding"
-	globalRoleID            = "global_role_id"
-	insecure                = "insecure"
-	name                    = "name"
-	password                = "password"
-	provider                = "provider"
-	rancher2Const           = "rancher2"
-	rancher2CustomUserToken = "rancher2_custom_user_token"
-	rancherRKE              = "rancher/rke"
-	rancherSource           = "source"
-	rancherUser             = "rancher2_user"
-	rc                      = "-rc"
-	requiredProviders       = "required_providers"
-	terraform               = "terraform"
-	tokenKey                = "token_key"
-	ttl                     = "ttl"
-	version                 = "version"
-	vsphere                 = "vsphere"
-	user                    = "user"
-	userID                  = "user_id"
-	username                = "username"
-	providerEnvVar          = "RANCHER2_PROVIDER_VERSION"
-	cloudProviderEnvVar     = "CLOUD_PROVIDER_VERSION"
-	localProviderEnvVar     = "LOCALS_PROVIDER_VERSION"
-	rkeEnvVar               = "RKE_PROVIDER_VERSION"
+	admin                    = "admin"
+	apiURL                   = "api_url"
+	alias                    = "alias"
+	allowUnverifiedSSL       = "allow_unverified_ssl"
+	ec2                      = "ec2"
+	globalRoleBinding        = "rancher2_global_role_binding"
+	globalRoleID             = "global_role_id"
+	insecure                 = "insecure"
+	name                     = "name"
+	password                 = "password"
+	provider                 = "provider"
+	rancher2Const            = "rancher2"
+	rancher2CustomUserToken  = "rancher2_custom_user_token"
+	rancherRKE               = "rancher/rke"
+	rancherSource            = "source"
+	rancherUser              = "rancher2_user"
+	rc                       = "-rc"
+	requiredProviders        = "required_providers"
+	terraform                = "terraform"
+	tokenKey                 = "token_key"
+	ttl                      = "ttl"
+	version                  = "version"
+	vsphere                  = "vsphere"
+	configPath               = "config_path"
+	kubeconfig               = "kubeconfig"
+	kubeconfigPath           = `"${local.codebase_root_path}/local.yaml"`
+	none                     = "none"
+	user                     = "user"
+	userID                   = "user_id"
+	username                 = "username"
+	providerEnvVar           = "RANCHER2_PROVIDER_VERSION"
+	cloudProviderEnvVar      = "CLOUD_PROVIDER_VERSION"
+	kubernetesProviderEnvVar = "KUBERNETES_PROVIDER_VERSION"
+	localProviderEnvVar      = "LOCALS_PROVIDER_VERSION"
+	rkeEnvVar                = "RKE_PROVIDER_VERSION"
 )
 
 // SetProvidersAndUsersTF is a helper function that will set the general Terraform configurations in the main.tf file.
@@ -91,6 +100,32 @@ func createRequiredProviders(rootBody *hclwrite.Body, terraformConfig *config.Te
 		}))
 	}
 
+	if cloudProviderVersion != "" && terraformConfig.Provider == azure.Azure && customModule {
+		reqProvsBlockBody.SetAttributeValue(azure.AzureRM, cty.ObjectVal(map[string]cty.Value{
+			general.Source:  cty.StringVal(azure.AzureSource),
+			general.Version: cty.StringVal("=" + cloudProviderVersion),
+		}))
+	}
+
+	if cloudProviderVersion != "" && terraformConfig.Provider == google.Google && customModule {
+		reqProvsBlockBody.SetAttributeValue(google.Google, cty.ObjectVal(map[string]cty.Value{
+			general.Source:  cty.StringVal(google.GoogleSource),
+			general.Version: cty.StringVal(cloudProviderVersion),
+		}))
+	}
+
+	if cloudProviderVersion != "" && terraformConfig.Provider == harvester.Harvester && customModule {
+		reqProvsBlockBody.SetAttributeValue(harvester.Harvester, cty.ObjectVal(map[string]cty.Value{
+			general.Source:  cty.StringVal(harvester.HarvesterSource),
+			general.Version: cty.StringVal(cloudProviderVersion),
+		}))
+
+		reqProvsBlockBody.SetAttributeValue(general.Kubernetes, cty.ObjectVal(map[string]cty.Value{
+			general.Source:  cty.StringVal(general.KubernetesSource),
+			general.Version: cty.StringVal(os.Getenv(kubernetesProviderEnvVar)),
+		}))
+	}
+
 	if cloudProviderVersion != "" && terraformConfig.Provider == linode.Linode && customModule {
 		reqProvsBlockBody.SetAttributeValue(linode.Linode, cty.ObjectVal(map[string]cty.Value{
 			general.Source:  cty.StringVal(linode.LinodeSource),
@@ -143,6 +178,49 @@ func createProvider(rancherConfig *rancher.Config, rootBody *hclwrite.Body, terr
 		rootBody.AppendNewline()
 	}
 
+	if cloudProviderVersion != "" && terraformConfig.Provider == azure.Azure && customModule {
+		azureProvBlock := rootBody.AppendNewBlock(general.Provider, []string{azure.AzureRM})
+		azureProvBlockBody := azureProvBlock.Body()
+
+		azureProvBlockBody.SetAttributeValue(azure.SubscriptionID, cty.StringVal(terraformConfig.AzureCredentials.SubscriptionID))
+		azureProvBlockBody.SetAttributeValue(azure.ResourceProviderRegistrations, cty.StringVal(none))
+		azureProvBlockBody.AppendNewBlock(azure.Features, nil)
+
+		rootBody.AppendNewline()
+		rootBody.AppendNewBlock(general.Provider, []string{general.Local})
+		rootBody.AppendNewline()
+	}
+
+	if cloudProviderVersion != "" && terraformConfig.Provider == google.Google && customModule {
+		googleProvBlock := rootBody.AppendNewBlock(general.Provider, []string{google.Google})
+		googleProvBlockBody := googleProvBlock.Body()
+
+		googleProvBlockBody.SetAttributeValue(google.GoogleProject, cty.StringVal(terraformConfig.GoogleConfig.ProjectID))
+		googleProvBlockBody.SetAttributeValue(google.GoogleRegion, cty.StringVal(terraformConfig.GoogleConfig.Region))
+		googleProvBlockBody.SetAttributeValue(google.GoogleZone, cty.StringVal(terraformConfig.GoogleConfig.Zone))
+
+		rootBody.AppendNewline()
+		rootBody.AppendNewBlock(general.Provider, []string{general.Local})
+		rootBody.AppendNewline()
+	}
+
+	if cloudProviderVersion != "" && terraformConfig.Provider == harvester.Harvester && customModule {
+		kubeconfigExpression := hclwrite.Tokens{
+			{Type: hclsyntax.TokenIdent, Bytes: []byte(kubeconfigPath)},
+		}
+
+		harvesterProvBlock := rootBody.AppendNewBlock(general.Provider, []string{harvester.Harvester})
+		harvesterProvBlock.Body().SetAttributeRaw(kubeconfig, kubeconfigExpression)
+
+		rootBody.AppendNewline()
+		kubernetesProvBlock := rootBody.AppendNewBlock(general.Provider, []string{general.Kubernetes})
+		kubernetesProvBlock.Body().SetAttributeRaw(configPath, kubeconfigExpression)
+
+		rootBody.AppendNewline()
+		rootBody.AppendNewBlock(general.Provider, []string{general.Local})
+		rootBody.AppendNewline()
+	}
+
 	if cloudProviderVersion != "" && terraformConfig.Provider == linode.Linode && customModule {
 		linodeProvBlock := rootBody.AppendNewBlock(general.Provider, []string{linode.Linode})
 		linodeProvBlockBody := linodeProvBlock.Body()
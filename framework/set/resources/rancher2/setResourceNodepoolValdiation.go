@@ -19,12 +19,24 @@ func SetResourceNodepoolValidation(terraformConfig *config.TerraformConfig, pool
 			return false, fmt.Errorf(`Invalid quantity specified for pool %v. Quantity must be greater than 0.`, poolNum)
 		}
 
+		if module == modules.HostedAzureAKS && pool.Spot {
+			return false, fmt.Errorf(`Spot instances are not supported for AKS pool %v.`, poolNum)
+		}
+
+		if pool.EnableAutoscaling && (pool.MaxSize <= 0 || pool.MinSize > pool.MaxSize) {
+			return false, fmt.Errorf(`Invalid autoscaling bounds specified for pool %v. Max size must be greater than 0 and not less than min size.`, poolNum)
+		}
+
 		return true, nil
 	case module == modules.HostedAWSEKS:
 		if pool.DesiredSize <= 0 {
 			return false, fmt.Errorf(`Invalid desired size specified for pool %v. Desired size must be greater than 0.`, poolNum)
 		}
 
+		if len(pool.Taints) > 0 {
+			return false, fmt.Errorf(`Taints are not supported for EKS pool %v.`, poolNum)
+		}
+
 		return true, nil
 	case strings.Contains(module, clustertypes.RKE2) || strings.Contains(module, clustertypes.K3S):
 		if !pool.Etcd && !pool.Controlplane && !pool.Worker {
@@ -8,44 +8,31 @@ import (
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	shepherdConfig "github.com/rancher/shepherd/clients/rancher"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/providers"
 	"github.com/rancher/tfp-automation/framework/cleanup"
-	"github.com/rancher/tfp-automation/framework/set/resources/providers"
+	tunnel "github.com/rancher/tfp-automation/framework/set/resources/providers"
 	registry "github.com/rancher/tfp-automation/framework/set/resources/registries/createRegistry"
 	"github.com/rancher/tfp-automation/framework/set/resources/registries/rancher"
 	"github.com/rancher/tfp-automation/framework/set/resources/registries/rke2"
 	"github.com/rancher/tfp-automation/framework/set/resources/sanity"
+	"github.com/rancher/tfp-automation/framework/set/resources/topology"
+	"github.com/rancher/tfp-automation/framework/timing"
 	"github.com/sirupsen/logrus"
 )
 
 const (
-	authRegistryPublicDNS         = "auth_registry_public_dns"
-	unauthRegistryPublicDNS       = "unauth_registry_public_dns"
-	authGlobalRegistryPublicDNS   = "auth_global_registry_public_dns"
-	unauthGlobalRegistryPublicDNS = "unauth_global_registry_public_dns"
-	ecrRegistryPublicDNS          = "ecr_registry_public_dns"
-
-	authRegistryRoute53FQDN         = "auth_registry_route_53_fqdn"
-	authGlobalRegistryRoute53FQDN   = "auth_global_registry_route_53_fqdn"
-	unauthGlobalRegistryRoute53FQDN = "unauth_global_registry_route_53_fqdn"
-
 	authRegistry         = "auth"
 	unauthRegistry       = "unauth"
 	authGlobalRegistry   = "auth-global"
 	unauthGlobalRegistry = "unauth-global"
 	ecrRegistry          = "ecr"
 
-	serverOne            = "server1"
-	serverTwo            = "server2"
-	serverThree          = "server3"
-	serverOnePublicDNS   = "server1_public_dns"
-	serverOnePrivateIP   = "server1_private_ip"
-	serverTwoPublicDNS   = "server2_public_dns"
-	serverThreePublicDNS = "server3_public_dns"
-
+	sslipioSuffix  = ".sslip.io"
 	terraformConst = "terraform"
 )
 
-// CreateMainTF is a helper function that will create the main.tf file for creating an Airgapped-Rancher server.
+// CreateMainTF is a helper function that will create the main.tf file for creating an Airgapped-Rancher server. The ECR
+// registry is only created on AWS, other providers serve the registries under the sslip.io name of their address.
 func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath string, rancherConfig *shepherdConfig.Config,
 	terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig) (string, string, string, error) {
 	var file *os.File
@@ -58,48 +45,79 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 	tfBlock := rootBody.AppendNewBlock(terraformConst, nil)
 	tfBlockBody := tfBlock.Body()
 
-	instances := []string{serverOne, serverTwo, serverThree, authRegistry, unauthRegistry, authGlobalRegistry, unauthGlobalRegistry, ecrRegistry}
+	nodes, err := topology.FixedNodes(terraformConfig, "registry")
+	if err != nil {
+		return "", "", "", err
+	}
+
+	registries := []string{authRegistry, unauthRegistry, authGlobalRegistry, unauthGlobalRegistry}
+	if terraformConfig.Provider == providers.AWS {
+		registries = append(registries, ecrRegistry)
+	}
+
+	var globalRegistry string
+	if terraformConfig.StandaloneRegistry.CreateAuthGlobalRegistry {
+		globalRegistry = authGlobalRegistry
+	} else if terraformConfig.StandaloneRegistry.CreateUnauthGlobalRegistry {
+		globalRegistry = unauthGlobalRegistry
+	}
+
+	instances := append(topology.Names(nodes), registries...)
+
+	topology.CreateHostOutputs(rootBody, terraformConfig, instances...)
+	topology.CreateFQDNOutputs(rootBody, terraformConfig, authRegistry)
+
+	if globalRegistry != "" {
+		topology.CreateFQDNOutputs(rootBody, terraformConfig, globalRegistry)
+	}
+
+	timing.AddInstances(terraformOptions, terraformConfig.Provider, timing.InstanceType(terraformConfig.Provider, terraformConfig), len(instances))
 
-	providerTunnel := providers.TunnelToProvider(terraformConfig.Provider)
-	file, err := providerTunnel.CreateNonAirgap(file, newFile, tfBlockBody, rootBody, terraformConfig, terratestConfig, instances)
+	providerTunnel := tunnel.TunnelToProvider(terraformConfig.Provider)
+	file, err = providerTunnel.CreateNonAirgap(file, newFile, tfBlockBody, rootBody, terraformConfig, terratestConfig, instances)
 	if err != nil {
 		return "", "", "", err
 	}
 
-	_, err = terraform.InitAndApplyE(t, terraformOptions)
+	_, err = timing.InitAndApplyE(t, terraformOptions, "resources")
 	if err != nil && *rancherConfig.Cleanup {
 		logrus.Infof("Error while creating resources. Cleaning up...")
 		cleanup.Cleanup(t, terraformOptions, keyPath)
 		return "", "", "", err
 	}
 
-	var authGlobalRegistryPublicDNS, authGlobalRegistryRoute53FQDN, unauthGlobalRegistryPublicDNS, unauthGlobalRegistryRoute53FQDN string
+	var authGlobalRegistryPublicDNS, authGlobalRegistryFQDN, unauthGlobalRegistryPublicDNS, unauthGlobalRegistryFQDN string
 
 	if terraformConfig.StandaloneRegistry.CreateAuthGlobalRegistry {
-		authGlobalRegistryPublicDNS = terraform.Output(t, terraformOptions, authGlobalRegistryPublicDNS)
-		authGlobalRegistryRoute53FQDN = terraform.Output(t, terraformOptions, authGlobalRegistryRoute53FQDN)
+		authGlobalRegistryPublicDNS = terraform.Output(t, terraformOptions, topology.PublicDNSOutput(authGlobalRegistry))
+		authGlobalRegistryFQDN = terraform.Output(t, terraformOptions, topology.FQDNOutput(authGlobalRegistry))
 	} else if terraformConfig.StandaloneRegistry.CreateUnauthGlobalRegistry {
-		unauthGlobalRegistryPublicDNS = terraform.Output(t, terraformOptions, unauthGlobalRegistryPublicDNS)
-		unauthGlobalRegistryRoute53FQDN = terraform.Output(t, terraformOptions, unauthGlobalRegistryRoute53FQDN)
+		unauthGlobalRegistryPublicDNS = terraform.Output(t, terraformOptions, topology.PublicDNSOutput(unauthGlobalRegistry))
+		unauthGlobalRegistryFQDN = terraform.Output(t, terraformOptions, topology.FQDNOutput(unauthGlobalRegistry))
 	}
 
-	authRegistryPublicDNS := terraform.Output(t, terraformOptions, authRegistryPublicDNS)
-	unauthRegistryPublicDNS := terraform.Output(t, terraformOptions, unauthRegistryPublicDNS)
-	authRegistryRoute53FQDN := terraform.Output(t, terraformOptions, authRegistryRoute53FQDN)
-	ecrRegistryPublicDNS := terraform.Output(t, terraformOptions, ecrRegistryPublicDNS)
-	serverOnePublicDNS := terraform.Output(t, terraformOptions, serverOnePublicDNS)
-	serverOnePrivateIP := terraform.Output(t, terraformOptions, serverOnePrivateIP)
-	serverTwoPublicDNS := terraform.Output(t, terraformOptions, serverTwoPublicDNS)
-	serverThreePublicDNS := terraform.Output(t, terraformOptions, serverThreePublicDNS)
+	authRegistryPublicDNS := terraform.Output(t, terraformOptions, topology.PublicDNSOutput(authRegistry))
+	unauthRegistryPublicDNS := terraform.Output(t, terraformOptions, topology.PublicDNSOutput(unauthRegistry))
+	authRegistryFQDN := terraform.Output(t, terraformOptions, topology.FQDNOutput(authRegistry))
+	serverOnePublicDNS := terraform.Output(t, terraformOptions, topology.PublicDNSOutput(nodes[0].Name))
+	serverOnePrivateIP := terraform.Output(t, terraformOptions, topology.PrivateIPOutput(nodes[0].Name))
+	serverTwoPublicDNS := terraform.Output(t, terraformOptions, topology.PublicDNSOutput(nodes[1].Name))
+	serverThreePublicDNS := terraform.Output(t, terraformOptions, topology.PublicDNSOutput(nodes[2].Name))
+
+	// Without a load balancer in front of the servers, Rancher is served under the sslip.io name of the first server.
+	switch terraformConfig.Provider {
+	case providers.Harvester, providers.Vsphere:
+		terraformConfig.Standalone.RancherHostname = serverOnePublicDNS + sslipioSuffix
+	}
 
 	file = sanity.OpenFile(file, keyPath)
 	logrus.Infof("Creating unauthenticated registry...")
-	file, err = registry.CreateUnauthenticatedRegistry(file, newFile, rootBody, terraformConfig, terratestConfig, unauthRegistryPublicDNS, unauthRegistry, unauthGlobalRegistryRoute53FQDN, false)
+	file, err = registry.CreateUnauthenticatedRegistry(file, newFile, rootBody, terraformConfig, terratestConfig, unauthRegistryPublicDNS, unauthRegistry, unauthGlobalRegistryFQDN, false)
 	if err != nil {
 		logrus.Fatalf("Error creating unauthenticated registry: %v", err)
 	}
 
-	_, err = terraform.InitAndApplyE(t, terraformOptions)
+	_, err = timing.InitAndApplyE(t, terraformOptions, "unauthenticated registry")
 	if err != nil && *rancherConfig.Cleanup {
 		logrus.Infof("Error while creating registries. Cleaning up...")
 		cleanup.Cleanup(t, terraformOptions, keyPath)
@@ -109,18 +127,18 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 	file = sanity.OpenFile(file, keyPath)
 	logrus.Infof("Creating global registry...")
 	if !terraformConfig.StandaloneRegistry.UseAuthGlobalRegistry {
-		file, err = registry.CreateUnauthenticatedRegistry(file, newFile, rootBody, terraformConfig, terratestConfig, unauthGlobalRegistryPublicDNS, unauthGlobalRegistry, unauthGlobalRegistryRoute53FQDN, true)
+		file, err = registry.CreateUnauthenticatedRegistry(file, newFile, rootBody, terraformConfig, terratestConfig, unauthGlobalRegistryPublicDNS, unauthGlobalRegistry, unauthGlobalRegistryFQDN, true)
 		if err != nil {
 			logrus.Fatalf("Error creating global registry: %v", err)
 		}
 	} else {
-		file, err = registry.CreateAuthenticatedRegistry(file, newFile, rootBody, terraformConfig, terratestConfig, authGlobalRegistryPublicDNS, authGlobalRegistry, authGlobalRegistryRoute53FQDN, true)
+		file, err = registry.CreateAuthenticatedRegistry(file, newFile, rootBody, terraformConfig, terratestConfig, authGlobalRegistryPublicDNS, authGlobalRegistry, authGlobalRegistryFQDN, true)
 		if err != nil {
 			logrus.Fatalf("Error creating global registry: %v", err)
 		}
 	}
 
-	_, err = terraform.InitAndApplyE(t, terraformOptions)
+	_, err = timing.InitAndApplyE(t, terraformOptions, "global registry")
 	if err != nil && *rancherConfig.Cleanup {
 		logrus.Infof("Error while creating registries. Cleaning up...")
 		cleanup.Cleanup(t, terraformOptions, keyPath)
@@ -129,39 +147,43 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 
 	file = sanity.OpenFile(file, keyPath)
 	logrus.Infof("Creating authenticated registry...")
-	file, err = registry.CreateAuthenticatedRegistry(file, newFile, rootBody, terraformConfig, terratestConfig, authRegistryPublicDNS, authRegistry, authRegistryRoute53FQDN, true)
+	file, err = registry.CreateAuthenticatedRegistry(file, newFile, rootBody, terraformConfig, terratestConfig, authRegistryPublicDNS, authRegistry, authRegistryFQDN, true)
 	if err != nil {
 		logrus.Fatalf("Error creating authenticated registry: %v", err)
 	}
 
-	_, err = terraform.InitAndApplyE(t, terraformOptions)
+	_, err = timing.InitAndApplyE(t, terraformOptions, "authenticated registry")
 	if err != nil && *rancherConfig.Cleanup {
 		logrus.Infof("Error while creating registries. Cleaning up...")
 		cleanup.Cleanup(t, terraformOptions, keyPath)
 		return "", "", "", err
 	}
 
-	file = sanity.OpenFile(file, keyPath)
-	logrus.Infof("Creating ecr registry...")
-	file, err = registry.CreateECRRegistry(file, newFile, rootBody, terraformConfig, terratestConfig, ecrRegistryPublicDNS)
-	if err != nil {
-		logrus.Fatalf("Error creating ecr registry: %v", err)
-	}
+	if terraformConfig.Provider == providers.AWS {
+		ecrRegistryPublicDNS := terraform.Output(t, terraformOptions, topology.PublicDNSOutput(ecrRegistry))
 
-	_, err = terraform.InitAndApplyE(t, terraformOptions)
-	if err != nil && *rancherConfig.Cleanup {
-		logrus.Infof("Error while creating registries. Cleaning up...")
-		cleanup.Cleanup(t, terraformOptions, keyPath)
-		return "", "", "", err
+		file = sanity.OpenFile(file, keyPath)
+		logrus.Infof("Creating ecr registry...")
+		file, err = registry.CreateECRRegistry(file, newFile, rootBody, terraformConfig, terratestConfig, ecrRegistryPublicDNS)
+		if err != nil {
+			logrus.Fatalf("Error creating ecr registry: %v", err)
+		}
+
+		_, err = timing.InitAndApplyE(t, terraformOptions, "ecr registry")
+		if err != nil && *rancherConfig.Cleanup {
+			logrus.Infof("Error while creating registries. Cleaning up...")
+			cleanup.Cleanup(t, terraformOptions, keyPath)
+			return "", "", "", err
+		}
 	}
 
 	// Needed so that when building the local cluster and setting up Rancher, we use the correct registry images based on
 	// whether the global registry is authenticated or not.
 	var globalRegistryPublicDNS string
 	if !terraformConfig.StandaloneRegistry.UseAuthGlobalRegistry {
-		globalRegistryPublicDNS = unauthGlobalRegistryRoute53FQDN
+		globalRegistryPublicDNS = unauthGlobalRegistryFQDN
 	} else {
-		globalRegistryPublicDNS = authGlobalRegistryRoute53FQDN
+		globalRegistryPublicDNS = authGlobalRegistryFQDN
 	}
 
 	file = sanity.OpenFile(file, keyPath)
@@ -172,7 +194,7 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 		return "", "", "", err
 	}
 
-	_, err = terraform.InitAndApplyE(t, terraformOptions)
+	_, err = timing.InitAndApplyE(t, terraformOptions, "rke2 cluster")
 	if err != nil && *rancherConfig.Cleanup {
 		logrus.Infof("Error while creating RKE2 cluster. Cleaning up...")
 		cleanup.Cleanup(t, terraformOptions, keyPath)
@@ -186,12 +208,12 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 		return "", "", "", err
 	}
 
-	_, err = terraform.InitAndApplyE(t, terraformOptions)
+	_, err = timing.InitAndApplyE(t, terraformOptions, "rancher server")
 	if err != nil && *rancherConfig.Cleanup {
 		logrus.Infof("Error while creating Rancher server. Cleaning up...")
 		cleanup.Cleanup(t, terraformOptions, keyPath)
 		return "", "", "", err
 	}
 
-	return authRegistryRoute53FQDN, unauthRegistryPublicDNS, globalRegistryPublicDNS, nil
+	return authRegistryFQDN, unauthRegistryPublicDNS, globalRegistryPublicDNS, nil
 }
@@ -3,13 +3,11 @@ package createRegistry
 import (
 	"encoding/base64"
 	"os"
-	"path/filepath"
 
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
-	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/framework/assets"
 	"github.com/rancher/tfp-automation/framework/set/defaults/general"
-	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 	"github.com/rancher/tfp-automation/framework/set/resources/rke2"
 	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
@@ -26,11 +24,9 @@ const (
 func CreateAuthenticatedRegistry(file *os.File, newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
 	terratestConfig *config.TerratestConfig, rke2AuthRegistryPublicDNS, registryType, rke2AuthRegistryRoute53FQDN string,
 	useSecureFQDN bool) (*os.File, error) {
-	userDir, _ := rancher2.SetKeyPath(keypath.RegistryKeyPath, terratestConfig.PathToRepo, terraformConfig.Provider)
+	scriptPath := "framework/set/resources/registries/createRegistry/auth-registry.sh"
 
-	scriptPath := filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/registries/createRegistry/auth-registry.sh")
-
-	registryScriptContent, err := os.ReadFile(scriptPath)
+	registryScriptContent, err := assets.ReadFile(scriptPath)
 	if err != nil {
 		return nil, err
 	}
@@ -87,11 +83,9 @@ func CreateAuthenticatedRegistry(file *os.File, newFile *hclwrite.File, rootBody
 func CreateUnauthenticatedRegistry(file *os.File, newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
 	terratestConfig *config.TerratestConfig, rke2UnauthRegistryPublicDNS, registryType, rke2UnauthRegistryRoute53FQDN string,
 	useSecureFQDN bool) (*os.File, error) {
-	userDir, _ := rancher2.SetKeyPath(keypath.RegistryKeyPath, terratestConfig.PathToRepo, terraformConfig.Provider)
-
-	scriptPath := filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/registries/createRegistry/unauth-registry.sh")
+	scriptPath := "framework/set/resources/registries/createRegistry/unauth-registry.sh"
 
-	registryScriptContent, err := os.ReadFile(scriptPath)
+	registryScriptContent, err := assets.ReadFile(scriptPath)
 	if err != nil {
 		return nil, err
 	}
@@ -169,11 +163,9 @@ func CreateUnauthenticatedRegistry(file *os.File, newFile *hclwrite.File, rootBo
 // CreateECRRegistry is a helper function that will create an authenticated ECR registry.
 func CreateECRRegistry(file *os.File, newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
 	terratestConfig *config.TerratestConfig, rke2EcrRegistryPublicDNS string) (*os.File, error) {
-	userDir, _ := rancher2.SetKeyPath(keypath.RegistryKeyPath, terratestConfig.PathToRepo, terraformConfig.Provider)
-
-	scriptPath := filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/registries/createRegistry/ecr-registry.sh")
+	scriptPath := "framework/set/resources/registries/createRegistry/ecr-registry.sh"
 
-	registryScriptContent, err := os.ReadFile(scriptPath)
+	registryScriptContent, err := assets.ReadFile(scriptPath)
 	if err != nil {
 		return nil, err
 	}
@@ -8,6 +8,7 @@ RKE2_TOKEN=$5
 CNI=$6
 REGISTRY_USERNAME=$7
 REGISTRY_PASSWORD=$8
+NODE_ROLE=${9:-server}
 MAX_CMD_RETRIES=20
 CMD_RETRY_INTERVAL_SECONDS=10
 
@@ -78,6 +79,14 @@ token: ${RKE2_TOKEN}
 tls-san:
   - ${RKE2_SERVER_ONE_IP}" | sudo tee /etc/rancher/rke2/config.yaml > /dev/null
 
+if [[ "${NODE_ROLE}" == "etcd" ]]; then
+  echo "disable-apiserver: true
+disable-controller-manager: true
+disable-scheduler: true" | sudo tee -a /etc/rancher/rke2/config.yaml > /dev/null
+elif [[ "${NODE_ROLE}" == "control-plane" ]]; then
+  echo "disable-etcd: true" | sudo tee -a /etc/rancher/rke2/config.yaml > /dev/null
+fi
+
 echo "mirrors:
   docker.io:
     endpoint:
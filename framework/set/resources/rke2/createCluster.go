@@ -2,15 +2,14 @@ package rke2
 
 import (
 	"os"
-	"path/filepath"
 
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	namegen "github.com/rancher/shepherd/pkg/namegenerator"
 	"github.com/rancher/tfp-automation/config"
-	"github.com/rancher/tfp-automation/defaults/keypath"
 	"github.com/rancher/tfp-automation/defaults/providers"
 	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/linode"
+	"github.com/rancher/tfp-automation/framework/assets"
 	"github.com/rancher/tfp-automation/framework/set/defaults/general"
 	"github.com/rancher/tfp-automation/framework/set/defaults/providers/aws"
 	"github.com/rancher/tfp-automation/framework/set/defaults/providers/azure"
@@ -18,40 +17,42 @@ import (
 	"github.com/rancher/tfp-automation/framework/set/defaults/providers/harvester"
 	linodeDefaults "github.com/rancher/tfp-automation/framework/set/defaults/providers/linode"
 	"github.com/rancher/tfp-automation/framework/set/defaults/providers/vsphere"
-	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/rancher/tfp-automation/framework/set/resources/topology"
 	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
 )
 
 const (
-	rke2ServerOne   = "server1"
-	rke2ServerTwo   = "server2"
-	rke2ServerThree = "server3"
-	token           = "token"
+	rke2ServerOne = "server1"
+	token         = "token"
 )
 
 // CreateRKE2Cluster is a helper function that will create the RKE2 cluster.
 func CreateRKE2Cluster(file *os.File, newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
-	terratestConfig *config.TerratestConfig, rke2ServerOnePublicIP, rke2ServerOnePrivateIP, rke2ServerTwoPublicIP, rke2ServerThreePublicIP string) (*os.File, error) {
-	userDir, _ := rancher2.SetKeyPath(keypath.RKE2KeyPath, terratestConfig.PathToRepo, terraformConfig.Provider)
+	terratestConfig *config.TerratestConfig, nodes []topology.Node) (*os.File, error) {
+	serverScriptPath := "framework/set/resources/rke2/init-server.sh"
+	newServersScriptPath := "framework/set/resources/rke2/add-servers.sh"
+	newAgentsScriptPath := "framework/set/resources/rke2/add-agents.sh"
 
-	serverScriptPath := filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/rke2/init-server.sh")
-	newServersScriptPath := filepath.Join(userDir, terratestConfig.PathToRepo, "/framework/set/resources/rke2/add-servers.sh")
+	serverOneScriptContent, err := assets.ReadFile(serverScriptPath)
+	if err != nil {
+		return nil, err
+	}
 
-	serverOneScriptContent, err := os.ReadFile(serverScriptPath)
+	newServersScriptContent, err := assets.ReadFile(newServersScriptPath)
 	if err != nil {
 		return nil, err
 	}
 
-	newServersScriptContent, err := os.ReadFile(newServersScriptPath)
+	newAgentsScriptContent, err := assets.ReadFile(newAgentsScriptPath)
 	if err != nil {
 		return nil, err
 	}
 
 	rke2Token := namegen.AppendRandomString(token)
 
-	createRKE2Server(rootBody, terraformConfig, rke2ServerOnePublicIP, rke2ServerOnePrivateIP, rke2Token, serverOneScriptContent)
-	addRKE2ServerNodes(rootBody, terraformConfig, rke2ServerOnePrivateIP, rke2ServerTwoPublicIP, rke2ServerThreePublicIP, rke2Token, newServersScriptContent)
+	createRKE2Server(rootBody, terraformConfig, nodes[0].PublicIP, nodes[0].PrivateIP, rke2Token, serverOneScriptContent)
+	addRKE2Nodes(rootBody, terraformConfig, nodes[0].PrivateIP, nodes[1:], rke2Token, newServersScriptContent, newAgentsScriptContent)
 
 	_, err = file.Write(newFile.Bytes())
 	if err != nil {
@@ -147,23 +148,31 @@ func createRKE2Server(rootBody *hclwrite.Body, terraformConfig *config.Terraform
 	}))
 }
 
-// addRKE2ServerNodes is a helper function that will add additional RKE2 server nodes to the initial RKE2 server.
-func addRKE2ServerNodes(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, rke2ServerOnePrivateIP, rke2ServerTwoPublicIP,
-	rke2ServerThreePublicIP, rke2Token string, script []byte) {
-	instances := []string{rke2ServerTwoPublicIP, rke2ServerThreePublicIP}
-	hosts := []string{rke2ServerTwo, rke2ServerThree}
-
-	for i, instance := range instances {
-		host := hosts[i]
-		nullResourceBlockBody, provisionerBlockBody := SSHNullResource(rootBody, terraformConfig, instance, host)
+// addRKE2Nodes is a helper function that will add the remaining nodes of the topology to the initial RKE2 server. Agents
+// join with the agent script, every other node joins as a server with its own role.
+func addRKE2Nodes(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, rke2ServerOnePrivateIP string, nodes []topology.Node,
+	rke2Token string, serverScript, agentScript []byte) {
+	for _, node := range nodes {
+		nullResourceBlockBody, provisionerBlockBody := SSHNullResource(rootBody, terraformConfig, node.PublicIP, node.Name)
+
+		scriptName := "add-servers.sh"
+		script := serverScript
+		if node.Role == topology.Agent {
+			scriptName = "add-agents.sh"
+			script = agentScript
+		}
 
-		command := "/tmp/add-servers.sh " + terraformConfig.Standalone.OSUser + " " + terraformConfig.Standalone.RKE2Version + " " +
-			rke2ServerOnePrivateIP + " " + instance + " " + rke2Token + " " + terraformConfig.CNI + " " + terraformConfig.Standalone.RegistryUsername + " " +
+		command := "/tmp/" + scriptName + " " + terraformConfig.Standalone.OSUser + " " + terraformConfig.Standalone.RKE2Version + " " +
+			rke2ServerOnePrivateIP + " " + node.PublicIP + " " + rke2Token + " " + terraformConfig.CNI + " " + terraformConfig.Standalone.RegistryUsername + " " +
 			terraformConfig.Standalone.RegistryPassword
 
+		if node.Role != topology.Agent {
+			command += " " + node.Role
+		}
+
 		provisionerBlockBody.SetAttributeValue(general.Inline, cty.ListVal([]cty.Value{
-			cty.StringVal("printf '" + string(script) + "' > /tmp/add-servers.sh"),
-			cty.StringVal("chmod +x /tmp/add-servers.sh"),
+			cty.StringVal("printf '" + string(script) + "' > /tmp/" + scriptName),
+			cty.StringVal("chmod +x /tmp/" + scriptName),
 			cty.StringVal(command),
 		}))
 
@@ -15,6 +15,8 @@ import (
 	tunnel "github.com/rancher/tfp-automation/framework/set/resources/providers"
 	"github.com/rancher/tfp-automation/framework/set/resources/rke2"
 	"github.com/rancher/tfp-automation/framework/set/resources/sanity/rancher"
+	"github.com/rancher/tfp-automation/framework/set/resources/topology"
+	"github.com/rancher/tfp-automation/framework/timing"
 	"github.com/sirupsen/logrus"
 )
 
@@ -22,14 +24,6 @@ const (
 	googleLoadBalancerAddress = "google_load_balancer_ip_address"
 	linodeBalancerHostname    = "linode_node_balancer_hostname"
 
-	serverOne           = "server1"
-	serverTwo           = "server2"
-	serverThree         = "server3"
-	serverOnePublicIP   = "server1_public_ip"
-	serverOnePrivateIP  = "server1_private_ip"
-	serverTwoPublicIP   = "server2_public_ip"
-	serverThreePublicIP = "server3_public_ip"
-
 	sslipioSuffix  = ".sslip.io"
 	terraformConst = "terraform"
 )
@@ -47,10 +41,17 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 	tfBlock := rootBody.AppendNewBlock(terraformConst, nil)
 	tfBlockBody := tfBlock.Body()
 
-	var err error
 	var loadBalancerHostname string
 
-	instances := []string{serverOne, serverTwo, serverThree}
+	nodes, err := topology.Nodes(terraformConfig)
+	if err != nil {
+		return "", err
+	}
+
+	topology.CreateOutputs(rootBody, terraformConfig, nodes)
+
+	instances := topology.Names(nodes)
+	timing.AddInstances(terraformOptions, terraformConfig.Provider, timing.InstanceType(terraformConfig.Provider, terraformConfig), len(instances))
 
 	providerTunnel := tunnel.TunnelToProvider(terraformConfig.Provider)
 	file, err = providerTunnel.CreateNonAirgap(file, newFile, tfBlockBody, rootBody, terraformConfig, terratestConfig, instances)
@@ -58,7 +59,7 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 		return "", err
 	}
 
-	_, err = terraform.InitAndApplyE(t, terraformOptions)
+	_, err = timing.InitAndApplyE(t, terraformOptions, "resources")
 	if err != nil && *rancherConfig.Cleanup {
 		logrus.Infof("Error while creating resources. Cleaning up...")
 		cleanup.Cleanup(t, terraformOptions, keyPath)
@@ -73,31 +74,29 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 		loadBalancerHostname = terraform.Output(t, terraformOptions, linodeBalancerHostname)
 		terraformConfig.Standalone.RancherHostname = loadBalancerHostname
 	case providers.Harvester, providers.Vsphere:
-		loadBalancerHostname = terraform.Output(t, terraformOptions, serverOnePublicIP) + sslipioSuffix
+		loadBalancerHostname = terraform.Output(t, terraformOptions, topology.PublicIPOutput(nodes[0].Name)) + sslipioSuffix
 		terraformConfig.Standalone.RancherHostname = loadBalancerHostname
 	}
 
-	serverOnePublicIP := terraform.Output(t, terraformOptions, serverOnePublicIP)
-	serverOnePrivateIP := terraform.Output(t, terraformOptions, serverOnePrivateIP)
-	serverTwoPublicIP := terraform.Output(t, terraformOptions, serverTwoPublicIP)
-	serverThreePublicIP := terraform.Output(t, terraformOptions, serverThreePublicIP)
+	nodes = topology.LoadAddresses(t, terraformOptions, nodes)
+	serverOnePublicIP := nodes[0].PublicIP
 
 	file = OpenFile(file, keyPath)
 	if terraformConfig.LocalCluster == "k3s" {
 		logrus.Infof("Creating K3S cluster...")
-		file, err = k3s.CreateK3SCluster(file, newFile, rootBody, terraformConfig, terratestConfig, serverOnePublicIP, serverOnePrivateIP, serverTwoPublicIP, serverThreePublicIP)
+		file, err = k3s.CreateK3SCluster(file, newFile, rootBody, terraformConfig, terratestConfig, nodes)
 		if err != nil {
 			return "", err
 		}
 	} else if terraformConfig.LocalCluster == "rke2" {
 		logrus.Infof("Creating RKE2 cluster...")
-		file, err = rke2.CreateRKE2Cluster(file, newFile, rootBody, terraformConfig, terratestConfig, serverOnePublicIP, serverOnePrivateIP, serverTwoPublicIP, serverThreePublicIP)
+		file, err = rke2.CreateRKE2Cluster(file, newFile, rootBody, terraformConfig, terratestConfig, nodes)
 		if err != nil {
 			return "", err
 		}
 	}
 
-	_, err = terraform.InitAndApplyE(t, terraformOptions)
+	_, err = timing.InitAndApplyE(t, terraformOptions, "local cluster")
 	if err != nil && *rancherConfig.Cleanup {
 		logrus.Infof("Error while creating local cluster. Cleaning up...")
 		cleanup.Cleanup(t, terraformOptions, keyPath)
@@ -111,7 +110,7 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 		return "", err
 	}
 
-	_, err = terraform.InitAndApplyE(t, terraformOptions)
+	_, err = timing.InitAndApplyE(t, terraformOptions, "rancher server")
 	if err != nil && *rancherConfig.Cleanup {
 		logrus.Infof("Error while creating Rancher server. Cleaning up...")
 		cleanup.Cleanup(t, terraformOptions, keyPath)
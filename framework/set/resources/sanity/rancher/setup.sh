@@ -129,7 +129,7 @@ setup_helm_repo() {
 
 install_cert_manager() {
     echo "Installing cert manager"
-    kubectl create ns cattle-system
+    kubectl create ns cattle-system --dry-run=client -o yaml | kubectl apply -f -
     kubectl apply -f https://github.com/jetstack/cert-manager/releases/download/${CERT_MANAGER_VERSION}/cert-manager.crds.yaml
     helm repo add jetstack https://charts.jetstack.io
     helm repo update
@@ -0,0 +1,132 @@
+package topology
+
+import (
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/providers"
+	"github.com/rancher/tfp-automation/framework/set/defaults/general"
+	"github.com/rancher/tfp-automation/framework/set/defaults/providers/aws"
+	"github.com/rancher/tfp-automation/framework/set/defaults/providers/azure"
+	"github.com/rancher/tfp-automation/framework/set/defaults/providers/google"
+	"github.com/rancher/tfp-automation/framework/set/defaults/providers/harvester"
+	"github.com/rancher/tfp-automation/framework/set/defaults/providers/linode"
+	"github.com/rancher/tfp-automation/framework/set/defaults/providers/vsphere"
+)
+
+// CreateOutputs is a function that will set the public and private IP outputs of every node in the main.tf file.
+func CreateOutputs(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, nodes []Node) {
+	for _, node := range nodes {
+		publicIP, privateIP := AddressExpressions(terraformConfig.Provider, node.Name)
+
+		createOutput(rootBody, PublicIPOutput(node.Name), publicIP)
+		createOutput(rootBody, PrivateIPOutput(node.Name), privateIP)
+	}
+}
+
+// CreateHostOutputs is a function that will set the public DNS and private IP outputs of the given helper instances,
+// e.g. the bastion or the registry, in the main.tf file.
+func CreateHostOutputs(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, names ...string) {
+	for _, name := range names {
+		_, privateIP := AddressExpressions(terraformConfig.Provider, name)
+
+		createOutput(rootBody, PublicDNSOutput(name), publicDNSExpression(terraformConfig.Provider, name))
+		createOutput(rootBody, PrivateIPOutput(name), privateIP)
+	}
+}
+
+// CreateIPv6Outputs is a function that will set the IPv6 address of the given nodes as their public IP output, next to
+// their private IP output, in the main.tf file.
+func CreateIPv6Outputs(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, names ...string) {
+	for _, name := range names {
+		_, privateIP := AddressExpressions(terraformConfig.Provider, name)
+
+		createOutput(rootBody, PublicIPOutput(name), ipv6Expression(terraformConfig.Provider, name))
+		createOutput(rootBody, PrivateIPOutput(name), privateIP)
+	}
+}
+
+// CreateFQDNOutputs is a function that will set the output of the fully qualified domain name the given registries are
+// served under in the main.tf file. On AWS this is the Route53 record of the registry, while providers without managed
+// DNS fall back to the sslip.io name of the registry address.
+func CreateFQDNOutputs(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, names ...string) {
+	for _, name := range names {
+		createOutput(rootBody, FQDNOutput(name), fqdnExpression(terraformConfig.Provider, name))
+	}
+}
+
+// createOutput is a helper function that will set a single output in the main.tf file.
+func createOutput(rootBody *hclwrite.Body, name, expression string) {
+	outputBlock := rootBody.AppendNewBlock(general.Output, []string{name})
+	outputBlockBody := outputBlock.Body()
+
+	value := hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte(expression)},
+	}
+
+	outputBlockBody.SetAttributeRaw(general.Value, value)
+	rootBody.AppendNewline()
+}
+
+// AddressExpressions is a function that returns the expressions of the public and private IP of an instance created by
+// the given provider.
+func AddressExpressions(provider, name string) (string, string) {
+	switch provider {
+	case providers.Azure, providers.AKS:
+		return azure.AzurePublicIP + "." + azure.AzurePublicIP + "-" + name + "." + general.IPAddress,
+			azure.AzureNetworkInterface + "." + azure.AzureNetworkInterface + "-" + name + "." + general.PrivateIPAddress
+	case providers.Google, providers.GKE:
+		return google.GoogleComputeInstance + "." + name + ".network_interface[0].access_config[0].nat_ip",
+			google.GoogleComputeInstance + "." + name + ".network_interface[0].network_ip"
+	case providers.Linode:
+		return linode.LinodeInstance + "." + name + "." + general.IPAddress,
+			linode.LinodeInstance + "." + name + "." + general.PrivateIPAddress
+	case providers.Harvester:
+		address := harvester.HarvesterVirtualMachine + "." + name + ".network_interface[0]." + general.IPAddress
+		return address, address
+	case providers.Vsphere:
+		address := vsphere.VsphereVirtualMachine + "." + name + "." + general.DefaultIPAddress
+		return address, address
+	default:
+		return aws.AwsInstance + "." + name + "." + general.PublicIp, aws.AwsInstance + "." + name + "." + general.PrivateIp
+	}
+}
+
+// publicDNSExpression is a helper function that returns the expression of the public DNS name of an instance created by
+// the given provider. Providers without public DNS names fall back to the public IP.
+func publicDNSExpression(provider, name string) string {
+	switch provider {
+	case providers.AWS, providers.EKS:
+		return aws.AwsInstance + "." + name + "." + general.PublicDNS
+	default:
+		publicIP, _ := AddressExpressions(provider, name)
+		return publicIP
+	}
+}
+
+// fqdnExpression is a helper function that returns the expression of the fully qualified domain name of a registry created
+// by the given provider.
+func fqdnExpression(provider, name string) string {
+	switch provider {
+	case providers.AWS:
+		return aws.Route53Record + "." + strings.ReplaceAll(name, "-", "_") + ".fqdn"
+	default:
+		publicIP, _ := AddressExpressions(provider, name)
+		return `"${` + publicIP + `}` + sslipioSuffix + `"`
+	}
+}
+
+// ipv6Expression is a helper function that returns the expression of the first global IPv6 address of an instance
+// created by the given provider.
+func ipv6Expression(provider, name string) string {
+	switch provider {
+	case providers.Harvester:
+		return `[for nic in ` + harvester.HarvesterVirtualMachine + "." + name + `.network_interface : nic.ip_address if length(split(":", nic.ip_address)) > 1 && !startswith(nic.ip_address, "fe80")][0]`
+	case providers.Vsphere:
+		return `[for ip in ` + vsphere.VsphereVirtualMachine + "." + name + `.guest_ip_addresses : ip if length(split(":", ip)) > 1 && !startswith(ip, "fe80")][0]`
+	default:
+		return aws.AwsInstance + "." + name + ".ipv6_addresses[0]"
+	}
+}
@@ -0,0 +1,158 @@
+package topology
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/rancher/tfp-automation/config"
+)
+
+const (
+	// Server is the role of the node the local cluster is initialized on, and of the servers that join it when no
+	// dedicated etcd nodes are requested.
+	Server = "server"
+	// ControlPlane is the role of the servers that join the local cluster when dedicated etcd nodes are requested.
+	ControlPlane = "control-plane"
+	// Etcd is the role of the dedicated etcd nodes.
+	Etcd = "etcd"
+	// Agent is the role of the agent nodes.
+	Agent = "agent"
+
+	DefaultServers = 3
+
+	serverPrefix = "server"
+	etcdPrefix   = "etcd"
+	agentPrefix  = "agent"
+
+	publicIPSuffix  = "_public_ip"
+	publicDNSSuffix = "_public_dns"
+	privateIPSuffix = "_private_ip"
+	fqdnSuffix      = "_fqdn"
+	sslipioSuffix   = ".sslip.io"
+)
+
+// Node is a node of the local cluster, along with the addresses read from the Terraform outputs once it is created.
+type Node struct {
+	Name      string
+	Role      string
+	PublicIP  string
+	PrivateIP string
+}
+
+// Nodes is a function that returns the nodes of the local cluster described by the topology in the Terraform config.
+// The first node is always the server the cluster is initialized on. Without a topology, three servers are returned.
+func Nodes(terraformConfig *config.TerraformConfig) ([]Node, error) {
+	servers, agents, etcd := int64(DefaultServers), int64(0), int64(0)
+
+	if terraformConfig.Topology != nil {
+		if terraformConfig.Topology.Servers < 0 || terraformConfig.Topology.Agents < 0 || terraformConfig.Topology.DedicatedEtcd < 0 {
+			return nil, fmt.Errorf("topology node counts cannot be negative: %+v", *terraformConfig.Topology)
+		}
+
+		if terraformConfig.Topology.Servers > 0 {
+			servers = terraformConfig.Topology.Servers
+		}
+
+		agents = terraformConfig.Topology.Agents
+		etcd = terraformConfig.Topology.DedicatedEtcd
+	}
+
+	var nodes []Node
+
+	for i := int64(1); i <= servers; i++ {
+		role := Server
+		if i > 1 && etcd > 0 {
+			role = ControlPlane
+		}
+
+		nodes = append(nodes, Node{Name: fmt.Sprintf("%s%d", serverPrefix, i), Role: role})
+	}
+
+	for i := int64(1); i <= etcd; i++ {
+		nodes = append(nodes, Node{Name: fmt.Sprintf("%s%d", etcdPrefix, i), Role: Etcd})
+	}
+
+	for i := int64(1); i <= agents; i++ {
+		nodes = append(nodes, Node{Name: fmt.Sprintf("%s%d", agentPrefix, i), Role: Agent})
+	}
+
+	return nodes, nil
+}
+
+// FixedNodes is a function that returns the nodes of the local cluster for the setups that always stand up the
+// default three servers, such as the proxy, IPv6, dual-stack and registry setups. Any other topology is rejected.
+func FixedNodes(terraformConfig *config.TerraformConfig, setup string) ([]Node, error) {
+	nodes, err := Nodes(terraformConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, node := range nodes {
+		if node.Role != Server {
+			return nil, fmt.Errorf("the %s setup only supports the default topology of %d servers", setup, DefaultServers)
+		}
+	}
+
+	if len(nodes) != DefaultServers {
+		return nil, fmt.Errorf("the %s setup only supports the default topology of %d servers", setup, DefaultServers)
+	}
+
+	return nodes, nil
+}
+
+// Names is a function that returns the instance names of the given nodes.
+func Names(nodes []Node) []string {
+	var names []string
+	for _, node := range nodes {
+		names = append(names, node.Name)
+	}
+
+	return names
+}
+
+// Servers is a function that returns the server instances out of the given instance names. Only servers are placed
+// behind the Rancher load balancer.
+func Servers(instances []string) []string {
+	var servers []string
+	for _, instance := range instances {
+		if strings.HasPrefix(instance, serverPrefix) {
+			servers = append(servers, instance)
+		}
+	}
+
+	return servers
+}
+
+// PublicIPOutput returns the name of the Terraform output holding the public IP of the given node.
+func PublicIPOutput(name string) string {
+	return name + publicIPSuffix
+}
+
+// PublicDNSOutput returns the name of the Terraform output holding the address used to reach the given instance from the
+// outside. Providers without public DNS names expose the public IP instead.
+func PublicDNSOutput(name string) string {
+	return name + publicDNSSuffix
+}
+
+// FQDNOutput returns the name of the Terraform output holding the fully qualified domain name the given registry is
+// served under.
+func FQDNOutput(name string) string {
+	return name + fqdnSuffix
+}
+
+// PrivateIPOutput returns the name of the Terraform output holding the private IP of the given node.
+func PrivateIPOutput(name string) string {
+	return name + privateIPSuffix
+}
+
+// LoadAddresses is a function that fills the public and private IPs of the given nodes from the Terraform outputs.
+func LoadAddresses(t *testing.T, terraformOptions *terraform.Options, nodes []Node) []Node {
+	for i := range nodes {
+		nodes[i].PublicIP = terraform.Output(t, terraformOptions, PublicIPOutput(nodes[i].Name))
+		nodes[i].PrivateIP = terraform.Output(t, terraformOptions, PrivateIPOutput(nodes[i].Name))
+	}
+
+	return nodes
+}
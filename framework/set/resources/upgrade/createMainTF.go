@@ -1,6 +1,7 @@
 package upgrade
 
 import (
+	"fmt"
 	"os"
 	"testing"
 
@@ -15,6 +16,8 @@ import (
 	registry "github.com/rancher/tfp-automation/framework/set/resources/registries/createRegistry"
 	"github.com/rancher/tfp-automation/framework/set/resources/sanity"
 	sanityRancher "github.com/rancher/tfp-automation/framework/set/resources/sanity/rancher"
+	"github.com/rancher/tfp-automation/framework/set/resources/topology"
+	"github.com/rancher/tfp-automation/framework/timing"
 	"github.com/sirupsen/logrus"
 )
 
@@ -26,7 +29,7 @@ const (
 // CreateMainTF is a helper function that will create the main.tf file for creating a Rancher server behind a proxy.
 func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath string, rancherConfig *rancher.Config,
 	terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig, serverNode, proxyNode, bastionNode,
-	registryNode string) error {
+	registryNode string, nodes []topology.Node) error {
 	var file *os.File
 	file = sanity.OpenFile(file, keyPath)
 	defer file.Close()
@@ -52,7 +55,7 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 			return err
 		}
 
-		_, err = terraform.InitAndApplyE(t, terraformOptions)
+		_, err = timing.InitAndApplyE(t, terraformOptions, "private registry")
 		if err != nil && *rancherConfig.Cleanup {
 			logrus.Infof("Error while updating private registry. Cleaning up...")
 			cleanup.Cleanup(t, terraformOptions, keyPath)
@@ -66,7 +69,7 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 			return err
 		}
 
-		_, err = terraform.InitAndApplyE(t, terraformOptions)
+		_, err = timing.InitAndApplyE(t, terraformOptions, "airgap rancher upgrade")
 		if err != nil && *rancherConfig.Cleanup {
 			logrus.Infof("Error while upgrading Airgap Rancher. Cleaning up...")
 			cleanup.Cleanup(t, terraformOptions, keyPath)
@@ -79,7 +82,7 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 			return err
 		}
 
-		_, err = terraform.InitAndApplyE(t, terraformOptions)
+		_, err = timing.InitAndApplyE(t, terraformOptions, "proxy rancher upgrade")
 		if err != nil && *rancherConfig.Cleanup {
 			logrus.Infof("Error while upgrading Proxy Rancher. Cleaning up...")
 			cleanup.Cleanup(t, terraformOptions, keyPath)
@@ -92,14 +95,65 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 			return err
 		}
 
-		_, err = terraform.InitAndApplyE(t, terraformOptions)
+		_, err = timing.InitAndApplyE(t, terraformOptions, "rancher upgrade")
 		if err != nil && *rancherConfig.Cleanup {
 			logrus.Infof("Error while upgrading Rancher. Cleaning up...")
 			cleanup.Cleanup(t, terraformOptions, keyPath)
 			return err
 		}
 	default:
-		logrus.Errorf("Unsupported Rancher environment. Please check the configuration file.")
+		if !terraformConfig.Standalone.UpgradeLocalCluster {
+			logrus.Errorf("Unsupported Rancher environment. Please check the configuration file.")
+		}
+	}
+
+	if terraformConfig.Standalone.UpgradeLocalCluster {
+		// The local cluster is upgraded from the host the Rancher upgrade runs on, which can reach its API in every network mode.
+		host, registry := serverNode, ""
+		switch {
+		case bastionNode != "":
+			host, registry = bastionNode, registryNode
+		case proxyNode != "":
+			host = proxyNode
+		}
+
+		file = sanity.OpenFile(file, keyPath)
+		logrus.Infof("Upgrading local cluster...")
+
+		var err error
+		switch terraformConfig.Standalone.LocalClusterUpgradeStrategy {
+		case "", PlansStrategy:
+			_, err = UpgradeLocalCluster(file, newFile, rootBody, terraformConfig, host, registry)
+		case ScriptStrategy:
+			if bastionNode != "" || proxyNode != "" {
+				return fmt.Errorf("the %s strategy requires direct access to the local cluster nodes, use the %s strategy instead", ScriptStrategy, PlansStrategy)
+			}
+
+			_, err = RollingUpgradeLocalCluster(file, newFile, rootBody, terraformConfig, nodes)
+		default:
+			err = fmt.Errorf("unsupported local cluster upgrade strategy %s, expected %s or %s", terraformConfig.Standalone.LocalClusterUpgradeStrategy,
+				PlansStrategy, ScriptStrategy)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		// Rancher is only reachable from the runner when it is not behind a bastion or a proxy.
+		if bastionNode != "" || proxyNode != "" {
+			logrus.Infof("Rancher is not reachable from the runner, skipping the downtime measurement of the local cluster upgrade")
+			_, err = timing.InitAndApplyE(t, terraformOptions, "local cluster upgrade")
+		} else {
+			stopMonitor := timing.MonitorRancher(terraformOptions, "local cluster upgrade", terraformConfig.Standalone.RancherHostname)
+			_, err = timing.InitAndApplyE(t, terraformOptions, "local cluster upgrade")
+			stopMonitor()
+		}
+
+		if err != nil && *rancherConfig.Cleanup {
+			logrus.Infof("Error while upgrading local cluster. Cleaning up...")
+			cleanup.Cleanup(t, terraformOptions, keyPath)
+			return err
+		}
 	}
 
 	return nil
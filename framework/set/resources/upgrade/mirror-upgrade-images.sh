@@ -0,0 +1,25 @@
+#!/bin/bash
+
+REGISTRY=$1
+SUC_VERSION=$2
+UPGRADE_IMAGE=$3
+
+SUC_RELEASE="https://github.com/rancher/system-upgrade-controller/releases/download/${SUC_VERSION}"
+
+set -e
+
+mirror_image() {
+    echo "Mirroring ${1} to ${REGISTRY}"
+    sudo docker pull ${1}
+    sudo docker tag ${1} ${REGISTRY}/${1}
+    sudo docker push ${REGISTRY}/${1}
+}
+
+echo "Fetching the system-upgrade-controller images"
+curl -fsSL ${SUC_RELEASE}/system-upgrade-controller.yaml -o /tmp/system-upgrade-controller.yaml
+
+for IMAGE in $(grep -oE "rancher/[a-z0-9-]+:[A-Za-z0-9._-]+" /tmp/system-upgrade-controller.yaml | sort -u); do
+    mirror_image ${IMAGE}
+done
+
+mirror_image ${UPGRADE_IMAGE}
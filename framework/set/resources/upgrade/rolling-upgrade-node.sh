@@ -0,0 +1,119 @@
+#!/bin/bash
+
+ACTION=$1
+LOCAL_CLUSTER=$2
+VERSION=$3
+NODE_IP=$4
+NODE_PRIVATE_IP=$5
+NODE_ROLE=${6:-server}
+MAX_CMD_RETRIES=30
+CMD_RETRY_INTERVAL_SECONDS=10
+
+set -e
+
+retryCmd() {
+  local attempt=1
+  local rc=0
+
+  while [ "$attempt" -le "$MAX_CMD_RETRIES" ]; do
+    if "$@"; then
+      return 0
+    else
+      rc=$?
+    fi
+
+    if [ "$attempt" -eq "$MAX_CMD_RETRIES" ]; then
+      echo "Command failed after ${MAX_CMD_RETRIES} attempts (exit ${rc}): $*" >&2
+      return "$rc"
+    fi
+
+    echo "Command failed on attempt ${attempt}/${MAX_CMD_RETRIES} (exit ${rc}), retrying in ${CMD_RETRY_INTERVAL_SECONDS}s: $*" >&2
+    sleep "$CMD_RETRY_INTERVAL_SECONDS"
+    attempt=$((attempt + 1))
+  done
+
+  return "$rc"
+}
+
+node_name() {
+  # The nodes are named after one of their addresses, so both the name and the addresses of each node are matched.
+  kubectl get nodes -o jsonpath='{range .items[*]}{.metadata.name}{" "}{.status.addresses[*].address}{"\n"}{end}' | \
+    awk -v public="${NODE_IP}" -v private="${NODE_PRIVATE_IP}" '{ for (i = 1; i <= NF; i++) if ($i == public || $i == private) { print $1; exit } }'
+}
+
+find_node() {
+  NODE_NAME=$(node_name)
+  [ -n "$NODE_NAME" ]
+}
+
+drain_node() {
+  retryCmd find_node
+  echo "Draining node ${NODE_NAME}"
+  kubectl drain ${NODE_NAME} --ignore-daemonsets --delete-emptydir-data --force --timeout=600s
+}
+
+upgrade_node() {
+  SERVICE_TYPE="server"
+  if [[ "${NODE_ROLE}" == "agent" ]]; then
+    SERVICE_TYPE="agent"
+  fi
+
+  echo "Upgrading ${LOCAL_CLUSTER} to ${VERSION}"
+  if [[ "${LOCAL_CLUSTER}" == "rke2" ]]; then
+    retryCmd curl -fsSL --max-time 120 -o install.sh https://get.rke2.io
+    chmod +x install.sh
+
+    retryCmd sudo INSTALL_RKE2_VERSION=${VERSION} INSTALL_RKE2_TYPE=${SERVICE_TYPE} sh install.sh
+    sudo systemctl restart rke2-${SERVICE_TYPE}
+  else
+    ARCH=$(uname -m)
+    BINARY="k3s"
+    if [[ $ARCH == "arm64" || $ARCH == "aarch64" ]]; then
+      BINARY="k3s-arm64"
+    fi
+
+    # The binary is replaced in place, so the flags the node was installed with are kept.
+    retryCmd curl -fsSL --max-time 120 -o k3s https://github.com/k3s-io/k3s/releases/download/${VERSION}/${BINARY}
+    sudo install -o root -g root -m 0755 k3s /usr/local/bin/k3s
+
+    if [[ "${SERVICE_TYPE}" == "agent" ]]; then
+      sudo systemctl restart k3s-agent
+    else
+      sudo systemctl restart k3s
+    fi
+  fi
+}
+
+node_upgraded() {
+  find_node || return 1
+
+  KUBELET_VERSION=$(kubectl get node ${NODE_NAME} -o jsonpath='{.status.nodeInfo.kubeletVersion}')
+  READY=$(kubectl get node ${NODE_NAME} -o jsonpath='{.status.conditions[?(@.type=="Ready")].status}')
+
+  [[ "${KUBELET_VERSION}" == "${VERSION}" && "${READY}" == "True" ]]
+}
+
+uncordon_node() {
+  echo "Waiting for node ${NODE_IP} to be ready with ${VERSION}"
+  MAX_CMD_RETRIES=60 retryCmd node_upgraded
+
+  echo "Uncordoning node ${NODE_NAME}"
+  retryCmd kubectl uncordon ${NODE_NAME}
+  kubectl get nodes -o wide
+}
+
+case "${ACTION}" in
+  drain)
+    drain_node
+    ;;
+  upgrade)
+    upgrade_node
+    ;;
+  uncordon)
+    uncordon_node
+    ;;
+  *)
+    echo "Unknown action ${ACTION}, expected drain, upgrade or uncordon"
+    exit 1
+    ;;
+esac
@@ -0,0 +1,128 @@
+package upgrade
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/assets"
+	"github.com/rancher/tfp-automation/framework/set/defaults/general"
+	"github.com/rancher/tfp-automation/framework/set/resources/rke2"
+	"github.com/rancher/tfp-automation/framework/set/resources/topology"
+	"github.com/sirupsen/logrus"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	drainAction    = "drain"
+	upgradeAction  = "upgrade"
+	uncordonAction = "uncordon"
+)
+
+// RollingUpgradeLocalCluster is a function that will upgrade the Kubernetes version of the local cluster, running Rancher,
+// one node at a time in the main.tf file. Each node is drained from the first server, reinstalled with the install script
+// of the upgraded version, then uncordoned once it is ready again. The servers are upgraded before the other nodes, and
+// every step waits for the previous one, so a single node is unavailable at any time.
+func RollingUpgradeLocalCluster(file *os.File, newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
+	nodes []topology.Node) (*os.File, error) {
+	scriptPath := "framework/set/resources/upgrade/rolling-upgrade-node.sh"
+
+	scriptContent, err := assets.ReadFile(scriptPath)
+	if err != nil {
+		return nil, err
+	}
+
+	upgradedVersion, err := UpgradedLocalClusterVersion(terraformConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("the %s strategy requires the nodes of the local cluster, which are only loaded by the standard and dual-stack setups", ScriptStrategy)
+	}
+
+	// The first server holds the kubeconfig of the local cluster, so the nodes are drained and uncordoned from it.
+	kubectlNode := nodes[0]
+
+	var previousStep string
+	for _, node := range orderedNodes(nodes) {
+		steps := []struct {
+			action string
+			host   string
+		}{
+			{drainAction, kubectlNode.PublicIP},
+			{upgradeAction, node.PublicIP},
+			{uncordonAction, kubectlNode.PublicIP},
+		}
+
+		for _, step := range steps {
+			name := node.Name + "_" + step.action
+
+			nullResourceBlockBody, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, step.host, name)
+
+			command := "bash -c '/tmp/rolling-upgrade-node.sh " + step.action + " " + terraformConfig.LocalCluster + " " + upgradedVersion + " " +
+				node.PublicIP + " " + node.PrivateIP + " " + node.Role + "'"
+
+			provisionerBlockBody.SetAttributeValue(general.Inline, cty.ListVal([]cty.Value{
+				cty.StringVal("cat <<'EOF' > /tmp/rolling-upgrade-node.sh\n" + string(scriptContent) + "\nEOF"),
+				cty.StringVal("chmod +x /tmp/rolling-upgrade-node.sh"),
+				cty.StringVal(command),
+			}))
+
+			if previousStep != "" {
+				dependsOnStep := `[` + general.NullResource + `.` + previousStep + `]`
+				dependsOn := hclwrite.Tokens{
+					{Type: hclsyntax.TokenIdent, Bytes: []byte(dependsOnStep)},
+				}
+
+				nullResourceBlockBody.SetAttributeRaw(general.DependsOn, dependsOn)
+			}
+
+			previousStep = name
+		}
+	}
+
+	_, err = file.Write(newFile.Bytes())
+	if err != nil {
+		logrus.Infof("Failed to append configurations to main.tf file. Error: %v", err)
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// LocalClusterNodes is a function that returns the nodes of the local cluster, along with their addresses read from the
+// given Terraform options of the Rancher setup. The nodes are only needed by the script strategy of the local cluster
+// upgrade, so nil is returned otherwise.
+func LocalClusterNodes(t *testing.T, terraformConfig *config.TerraformConfig, terraformOptions *terraform.Options) ([]topology.Node, error) {
+	if !terraformConfig.Standalone.UpgradeLocalCluster || terraformConfig.Standalone.LocalClusterUpgradeStrategy != ScriptStrategy {
+		return nil, nil
+	}
+
+	nodes, err := topology.Nodes(terraformConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return topology.LoadAddresses(t, terraformOptions, nodes), nil
+}
+
+// orderedNodes is a helper function that returns the given nodes with the servers and etcd nodes first, in the order of
+// the topology, followed by the agents.
+func orderedNodes(nodes []topology.Node) []topology.Node {
+	var servers, agents []topology.Node
+	for _, node := range nodes {
+		if node.Role == topology.Agent {
+			agents = append(agents, node)
+			continue
+		}
+
+		servers = append(servers, node)
+	}
+
+	return append(servers, agents...)
+}
@@ -0,0 +1,91 @@
+#!/bin/bash
+
+LOCAL_CLUSTER=$1
+VERSION=$2
+SUC_VERSION=$3
+REGISTRY=${4}
+
+SUC_RELEASE="https://github.com/rancher/system-upgrade-controller/releases/download/${SUC_VERSION}"
+UPGRADE_IMAGE="rancher/${LOCAL_CLUSTER}-upgrade"
+
+set -ex
+
+install_system_upgrade_controller() {
+    echo "Installing system-upgrade-controller ${SUC_VERSION}"
+    curl -fsSL ${SUC_RELEASE}/crd.yaml -o /tmp/system-upgrade-controller-crd.yaml
+    curl -fsSL ${SUC_RELEASE}/system-upgrade-controller.yaml -o /tmp/system-upgrade-controller.yaml
+
+    if [ -n "$REGISTRY" ]; then
+        sed -i "s# rancher/# ${REGISTRY}/rancher/#g" /tmp/system-upgrade-controller.yaml
+        UPGRADE_IMAGE="${REGISTRY}/${UPGRADE_IMAGE}"
+    fi
+
+    kubectl apply -f /tmp/system-upgrade-controller-crd.yaml
+    kubectl apply -f /tmp/system-upgrade-controller.yaml
+    kubectl -n system-upgrade rollout status deploy/system-upgrade-controller
+}
+
+create_plans() {
+    echo "Creating the upgrade plans of the local cluster"
+    cat <<PLANS | kubectl apply -f -
+apiVersion: upgrade.cattle.io/v1
+kind: Plan
+metadata:
+  name: server-plan
+  namespace: system-upgrade
+spec:
+  concurrency: 1
+  cordon: true
+  nodeSelector:
+    matchExpressions:
+      - key: node-role.kubernetes.io/control-plane
+        operator: In
+        values: ["true"]
+  serviceAccountName: system-upgrade
+  upgrade:
+    image: ${UPGRADE_IMAGE}
+  version: ${VERSION}
+---
+apiVersion: upgrade.cattle.io/v1
+kind: Plan
+metadata:
+  name: agent-plan
+  namespace: system-upgrade
+spec:
+  concurrency: 1
+  cordon: true
+  nodeSelector:
+    matchExpressions:
+      - key: node-role.kubernetes.io/control-plane
+        operator: DoesNotExist
+  prepare:
+    args: ["prepare", "server-plan"]
+    image: ${UPGRADE_IMAGE}
+  serviceAccountName: system-upgrade
+  upgrade:
+    image: ${UPGRADE_IMAGE}
+  version: ${VERSION}
+PLANS
+}
+
+wait_for_upgrade() {
+    echo "Waiting for every node of the local cluster to run ${VERSION}"
+    for i in $(seq 1 120); do
+        KUBELET_VERSIONS=$(kubectl get nodes -o jsonpath='{range .items[*]}{.status.nodeInfo.kubeletVersion}{"\n"}{end}' 2>/dev/null || true)
+
+        if [ -n "$KUBELET_VERSIONS" ] && ! echo "$KUBELET_VERSIONS" | grep -qv "^${VERSION}$"; then
+            kubectl get nodes -o wide
+            return 0
+        fi
+
+        sleep 30
+    done
+
+    echo "The local cluster was not upgraded to ${VERSION}"
+    kubectl get nodes -o wide || true
+    exit 1
+}
+
+install_system_upgrade_controller
+create_plans
+wait_for_upgrade
@@ -0,0 +1,145 @@
+package upgrade
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/clustertypes"
+	"github.com/rancher/tfp-automation/framework/assets"
+	"github.com/rancher/tfp-automation/framework/set/defaults/general"
+	"github.com/rancher/tfp-automation/framework/set/resources/rke2"
+	"github.com/sirupsen/logrus"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	// PlansStrategy upgrades the local cluster through system-upgrade-controller plans. It is the default strategy.
+	PlansStrategy = "plans"
+	// ScriptStrategy upgrades the local cluster by draining and reinstalling each node in turn with the install script.
+	ScriptStrategy = "script"
+
+	// systemUpgradeControllerVersion pins the system-upgrade-controller release the upgrade plans are run with, so the
+	// images mirrored to the private registry of airgap setups match the ones it deploys.
+	systemUpgradeControllerVersion = "v0.13.4"
+
+	upgradeLocalCluster = "upgrade_local_cluster"
+	mirrorUpgradeImages = "mirror_upgrade_images"
+)
+
+// UpgradeLocalCluster is a function that will upgrade the Kubernetes version of the local cluster, running Rancher, in
+// the main.tf file. The upgrade is driven from the given host through system-upgrade-controller plans, upgrading one
+// node at a time, so it works the same in every network mode. In airgap setups, the system-upgrade-controller and
+// upgrade images are first mirrored to the given registry from the registry node, and then pulled from it.
+func UpgradeLocalCluster(file *os.File, newFile *hclwrite.File, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
+	host, registry string) (*os.File, error) {
+	scriptPath := "framework/set/resources/upgrade/upgrade-local-cluster.sh"
+
+	scriptContent, err := assets.ReadFile(scriptPath)
+	if err != nil {
+		return nil, err
+	}
+
+	upgradedVersion, err := UpgradedLocalClusterVersion(terraformConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if registry != "" {
+		err = mirrorUpgradeImagesToRegistry(rootBody, terraformConfig, registry, upgradedVersion)
+		if err != nil {
+			return nil, err
+		}
+
+		rootBody.AppendNewline()
+	}
+
+	nullResourceBlockBody, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, host, upgradeLocalCluster)
+
+	command := "bash -c '/tmp/upgrade-local-cluster.sh " + terraformConfig.LocalCluster + " " + upgradedVersion + " " +
+		systemUpgradeControllerVersion
+
+	if registry != "" {
+		command += " " + registry
+	}
+
+	command += "'"
+
+	provisionerBlockBody.SetAttributeValue(general.Inline, cty.ListVal([]cty.Value{
+		cty.StringVal("cat <<'EOF' > /tmp/upgrade-local-cluster.sh\n" + string(scriptContent) + "\nEOF"),
+		cty.StringVal("chmod +x /tmp/upgrade-local-cluster.sh"),
+		cty.StringVal(command),
+	}))
+
+	if registry != "" {
+		dependsOnMirror := hclwrite.Tokens{
+			{Type: hclsyntax.TokenIdent, Bytes: []byte(`[` + general.NullResource + `.` + mirrorUpgradeImages + `]`)},
+		}
+
+		nullResourceBlockBody.SetAttributeRaw(general.DependsOn, dependsOnMirror)
+	}
+
+	_, err = file.Write(newFile.Bytes())
+	if err != nil {
+		logrus.Infof("Failed to append configurations to main.tf file. Error: %v", err)
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// mirrorUpgradeImagesToRegistry is a helper function that will mirror the system-upgrade-controller images, and the
+// upgrade image of the given version, to the private registry from the registry node in the main.tf file.
+func mirrorUpgradeImagesToRegistry(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, registry, upgradedVersion string) error {
+	scriptPath := "framework/set/resources/upgrade/mirror-upgrade-images.sh"
+
+	scriptContent, err := assets.ReadFile(scriptPath)
+	if err != nil {
+		return err
+	}
+
+	// system-upgrade-controller tags the upgrade image with the version, replacing the + of the release suffix.
+	upgradeImage := fmt.Sprintf("rancher/%s-upgrade:%s", terraformConfig.LocalCluster, strings.ReplaceAll(upgradedVersion, "+", "-"))
+
+	_, provisionerBlockBody := rke2.SSHNullResource(rootBody, terraformConfig, registry, mirrorUpgradeImages)
+
+	command := "bash -c '/tmp/mirror-upgrade-images.sh " + registry + " " + systemUpgradeControllerVersion + " " + upgradeImage + "'"
+
+	provisionerBlockBody.SetAttributeValue(general.Inline, cty.ListVal([]cty.Value{
+		cty.StringVal("cat <<'EOF' > /tmp/mirror-upgrade-images.sh\n" + string(scriptContent) + "\nEOF"),
+		cty.StringVal("chmod +x /tmp/mirror-upgrade-images.sh"),
+		cty.StringVal(command),
+	}))
+
+	return nil
+}
+
+// UpgradedLocalClusterVersion is a function that returns the Kubernetes version the local cluster is upgraded to, based
+// on its distribution, in the format reported by its nodes.
+func UpgradedLocalClusterVersion(terraformConfig *config.TerraformConfig) (string, error) {
+	var upgradedVersion string
+
+	switch terraformConfig.LocalCluster {
+	case clustertypes.K3S:
+		upgradedVersion = terraformConfig.Standalone.UpgradedK3SVersion
+	case clustertypes.RKE2:
+		upgradedVersion = terraformConfig.Standalone.UpgradedRKE2Version
+	default:
+		return "", fmt.Errorf("unsupported local cluster %s, expected %s or %s", terraformConfig.LocalCluster, clustertypes.RKE2, clustertypes.K3S)
+	}
+
+	if upgradedVersion == "" {
+		return "", fmt.Errorf("the upgraded %s version of the local cluster is not set", terraformConfig.LocalCluster)
+	}
+
+	// The nodes report the full version, so it is required to tell when the upgrade is done.
+	if !strings.Contains(upgradedVersion, "+") {
+		return "", fmt.Errorf("the upgraded %s version %s of the local cluster must include its release suffix, i.e. v1.32.5+%sr1",
+			terraformConfig.LocalCluster, upgradedVersion, terraformConfig.LocalCluster)
+	}
+
+	return upgradedVersion, nil
+}
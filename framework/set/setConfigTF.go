@@ -11,7 +11,9 @@ import (
 	"github.com/rancher/tfp-automation/defaults/configs"
 	"github.com/rancher/tfp-automation/defaults/modules"
 	"github.com/rancher/tfp-automation/framework/set/defaults/general"
+	harvesterDefaults "github.com/rancher/tfp-automation/framework/set/defaults/providers/harvester"
 	"github.com/rancher/tfp-automation/framework/set/provisioning/custom/locals"
+	"github.com/rancher/tfp-automation/framework/set/resources/providers/harvester"
 	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 	"github.com/sirupsen/logrus"
 )
@@ -96,5 +98,13 @@ func ConfigTF(client *rancher.Client, rancherConfig *rancher.Config, terratestCo
 		return clusterNames, customClusterName, err
 	}
 
+	if terraformConfig.Provider == harvesterDefaults.Harvester && (strings.Contains(terraformConfig.Module, general.Custom) ||
+		strings.Contains(terraformConfig.Module, general.Import)) {
+		err = harvester.WriteKubeconfig(nestedRancherModuleDir, terraformConfig)
+		if err != nil {
+			return clusterNames, customClusterName, err
+		}
+	}
+
 	return clusterNames, customClusterName, nil
 }
@@ -0,0 +1,101 @@
+package timing
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sync"
+
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/providers"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultRate  = "default"
+	rateTableEnv = "COST_RATE_TABLE"
+)
+
+// RateTable maps a provider to the hourly rate, in USD, of each instance type. A "default" entry is used for instance
+// types that are not listed.
+type RateTable map[string]map[string]float64
+
+//go:embed rates.json
+var defaultRateTable []byte
+
+var (
+	rateTableOnce sync.Once
+	rateTable     RateTable
+)
+
+// LoadRateTable loads the rate table from the file referenced by COST_RATE_TABLE, falling back to the bundled rates.
+func LoadRateTable() RateTable {
+	rateTableOnce.Do(func() {
+		data := defaultRateTable
+
+		if path := os.Getenv(rateTableEnv); path != "" {
+			custom, err := os.ReadFile(path)
+			if err != nil {
+				logrus.Warningf("Unable to read rate table %s, using bundled rates: %v", path, err)
+			} else {
+				data = custom
+			}
+		}
+
+		err := json.Unmarshal(data, &rateTable)
+		if err != nil {
+			logrus.Warningf("Unable to parse rate table, cost estimates are disabled: %v", err)
+		}
+	})
+
+	return rateTable
+}
+
+// EstimateCost returns the approximate cost of running the given instances for the given duration.
+func EstimateCost(instances []Instances, durationSeconds float64, rates RateTable) float64 {
+	var total float64
+
+	hours := durationSeconds / 3600
+	for _, instance := range instances {
+		providerRates, ok := rates[instance.Provider]
+		if !ok {
+			continue
+		}
+
+		rate, ok := providerRates[instance.InstanceType]
+		if !ok {
+			rate = providerRates[defaultRate]
+		}
+
+		total += rate * hours * float64(instance.Count)
+	}
+
+	return math.Round(total*100) / 100
+}
+
+// InstanceType returns the instance type configured for the given provider. Providers without named instance types
+// are described by their CPU and memory sizing.
+func InstanceType(provider string, terraformConfig *config.TerraformConfig) string {
+	switch provider {
+	case providers.AWS:
+		if terraformConfig.ARMAchitecture {
+			return terraformConfig.AWSConfig.ARMInstanceType
+		}
+
+		return terraformConfig.AWSConfig.AWSInstanceType
+	case providers.Azure:
+		return terraformConfig.AzureConfig.VMSize
+	case providers.Google:
+		return terraformConfig.GoogleConfig.MachineType
+	case providers.Linode:
+		return terraformConfig.LinodeConfig.Type
+	case providers.Harvester:
+		return fmt.Sprintf("%scpu-%sgb", terraformConfig.HarvesterConfig.CPUCount, terraformConfig.HarvesterConfig.MemorySize)
+	case providers.Vsphere:
+		return fmt.Sprintf("%scpu-%smb", terraformConfig.VsphereConfig.CPUCount, terraformConfig.VsphereConfig.MemorySize)
+	default:
+		return ""
+	}
+}
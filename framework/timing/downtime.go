@@ -0,0 +1,143 @@
+package timing
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	pingPath      = "/ping"
+	pingResponse  = "pong"
+	probeInterval = time.Second
+	probeTimeout  = 5 * time.Second
+)
+
+// Downtime is the availability of an endpoint measured while a stage was running. An outage spans from the first failed
+// probe to the next successful one, or to the end of the stage.
+type Downtime struct {
+	Stage                  string    `json:"stage"`
+	URL                    string    `json:"url"`
+	StartedAt              time.Time `json:"started_at"`
+	Probes                 int       `json:"probes"`
+	FailedProbes           int       `json:"failed_probes"`
+	Outages                int       `json:"outages"`
+	DowntimeSeconds        float64   `json:"downtime_seconds"`
+	LongestOutageSeconds   float64   `json:"longest_outage_seconds"`
+	MonitoredSeconds       float64   `json:"monitored_seconds"`
+	AvailabilityPercentage float64   `json:"availability_percentage"`
+}
+
+// MonitorRancher starts probing the /ping endpoint of the given Rancher hostname every second, until the returned function
+// is called. The function stops the probes, records the measured downtime in the timing report of the given stage and
+// returns it.
+func MonitorRancher(terraformOptions *terraform.Options, stage, rancherHostname string) func() Downtime {
+	url := "https://" + rancherHostname + pingPath
+
+	client := &http.Client{
+		Timeout: probeTimeout,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan Downtime)
+
+	go func() {
+		downtime := Downtime{Stage: stage, URL: url, StartedAt: time.Now().UTC()}
+
+		var outageStart time.Time
+		endOutage := func(end time.Time) {
+			outage := end.Sub(outageStart).Seconds()
+			downtime.DowntimeSeconds += outage
+			downtime.LongestOutageSeconds = max(downtime.LongestOutageSeconds, outage)
+			outageStart = time.Time{}
+		}
+
+		ticker := time.NewTicker(probeInterval)
+		defer ticker.Stop()
+
+	probes:
+		for {
+			select {
+			case <-ctx.Done():
+				break probes
+			case probeStart := <-ticker.C:
+				available := probe(ctx, client, url)
+				if ctx.Err() != nil {
+					break probes
+				}
+
+				downtime.Probes++
+				if !available {
+					downtime.FailedProbes++
+				}
+
+				switch {
+				case !available && outageStart.IsZero():
+					logrus.Warningf("Rancher is unavailable at %s", url)
+					downtime.Outages++
+					outageStart = probeStart
+				case available && !outageStart.IsZero():
+					endOutage(probeStart)
+					logrus.Infof("Rancher is available again at %s", url)
+				}
+			}
+		}
+
+		if !outageStart.IsZero() {
+			endOutage(time.Now())
+		}
+
+		downtime.MonitoredSeconds = time.Since(downtime.StartedAt).Seconds()
+		if downtime.MonitoredSeconds > 0 {
+			downtime.AvailabilityPercentage = 100 * (1 - downtime.DowntimeSeconds/downtime.MonitoredSeconds)
+		}
+
+		done <- downtime
+	}()
+
+	return func() Downtime {
+		cancel()
+		downtime := <-done
+
+		mutex.Lock()
+		report := reportFor(terraformOptions)
+		report.Downtime = append(report.Downtime, downtime)
+		write(report)
+		mutex.Unlock()
+
+		logrus.Infof("Rancher was unavailable for %.0fs during the %s, across %d outages (longest %.0fs, %.2f%% available)",
+			downtime.DowntimeSeconds, stage, downtime.Outages, downtime.LongestOutageSeconds, downtime.AvailabilityPercentage)
+
+		return downtime
+	}
+}
+
+// probe reports whether the given Rancher ping endpoint answers with pong.
+func probe(ctx context.Context, client *http.Client, url string) bool {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return false
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return false
+	}
+
+	return response.StatusCode == http.StatusOK && strings.TrimSpace(string(body)) == pingResponse
+}
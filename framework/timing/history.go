@@ -0,0 +1,125 @@
+package timing
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	defaultHistoryPath = "results/history/test_history.json"
+	historyFile        = "history/test_history.json"
+	historyPathEnv     = "TEST_HISTORY_PATH"
+)
+
+type Estimate struct {
+	Median time.Duration
+	Runs   int
+}
+
+type historyEntry struct {
+	Workflow        string  `json:"workflow"`
+	Job             string  `json:"job"`
+	Status          string  `json:"status"`
+	DurationSeconds float64 `json:"duration_seconds"`
+}
+
+// HistoricalEstimates returns the median duration of successful runs per job of the given workflow, read from the test
+// history file found by historyPath. Jobs without a recorded duration are omitted.
+func HistoricalEstimates(workflow string) (map[string]Estimate, error) {
+	path, err := historyPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []historyEntry
+	err = json.Unmarshal(data, &entries)
+	if err != nil {
+		return nil, err
+	}
+
+	durations := make(map[string][]float64)
+	for _, entry := range entries {
+		if entry.Workflow != workflow || entry.Status != StatusSuccess || entry.DurationSeconds <= 0 {
+			continue
+		}
+
+		durations[entry.Job] = append(durations[entry.Job], entry.DurationSeconds)
+	}
+
+	estimates := make(map[string]Estimate, len(durations))
+	for job, values := range durations {
+		estimates[job] = Estimate{
+			Median: time.Duration(Median(values) * float64(time.Second)),
+			Runs:   len(values),
+		}
+	}
+
+	return estimates, nil
+}
+
+// Median returns the median of the given values, or zero when there are none.
+func Median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+
+	return sorted[middle]
+}
+
+// historyPath is a helper function that returns the path of the test history file. TEST_HISTORY_PATH is used when set,
+// otherwise results/history is looked up from the working directory and its parents, so tests started from a package
+// directory find the one of the repository, and then under TIMING_RESULTS_DIR.
+func historyPath() (string, error) {
+	path := os.Getenv(historyPathEnv)
+	if path != "" {
+		return path, nil
+	}
+
+	var candidates []string
+
+	dir, err := os.Getwd()
+	if err == nil {
+		for {
+			candidates = append(candidates, filepath.Join(dir, defaultHistoryPath))
+
+			parent := filepath.Dir(dir)
+			if parent == dir {
+				break
+			}
+
+			dir = parent
+		}
+	}
+
+	resultsDir := os.Getenv(resultsDirEnv)
+	if resultsDir != "" {
+		candidates = append(candidates, filepath.Join(resultsDir, historyFile))
+	}
+
+	for _, candidate := range candidates {
+		_, err = os.Stat(candidate)
+		if err == nil {
+			return candidate, nil
+		}
+	}
+
+	return "", fmt.Errorf("test history not found, set %s or %s, tried: %s", historyPathEnv, resultsDirEnv, strings.Join(candidates, ", "))
+}
@@ -0,0 +1,274 @@
+package timing
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	Init    = "init"
+	Apply   = "apply"
+	Verify  = "verify"
+	Destroy = "destroy"
+
+	StatusInProgress = "in_progress"
+	StatusSuccess    = "success"
+	StatusFailure    = "failure"
+
+	defaultWorkflow   = "tfp-automation"
+	defaultResultsDir = "timing"
+	resultsDirEnv     = "TIMING_RESULTS_DIR"
+	workflowEnv       = "TIMING_WORKFLOW"
+)
+
+var unsafeFileChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+type Phase struct {
+	Name            string    `json:"name"`
+	Stage           string    `json:"stage,omitempty"`
+	StartedAt       time.Time `json:"started_at"`
+	DurationSeconds float64   `json:"duration_seconds"`
+	Failed          bool      `json:"failed,omitempty"`
+}
+
+type Instances struct {
+	Provider     string `json:"provider"`
+	InstanceType string `json:"instance_type,omitempty"`
+	Count        int    `json:"count"`
+}
+
+// Report is the per-setup timing record. Its JSON form is a superset of the test history payload consumed by the
+// test summary generator, so reports can be dropped into the results directory as-is.
+type Report struct {
+	Workflow        string      `json:"workflow"`
+	Job             string      `json:"job"`
+	Status          string      `json:"status"`
+	Timestamp       string      `json:"timestamp"`
+	DurationSeconds float64     `json:"duration_seconds"`
+	EstimatedCost   float64     `json:"estimated_cost,omitempty"`
+	Phases          []Phase     `json:"phases"`
+	Instances       []Instances `json:"instances,omitempty"`
+	Downtime        []Downtime  `json:"downtime,omitempty"`
+
+	startedAt time.Time
+	path      string
+}
+
+var (
+	mutex       sync.Mutex
+	defaultName [2]string
+	reports     = make(map[string]*Report)
+)
+
+// SetName sets the workflow and job names used for reports created from now on. Without a name, reports use the
+// TIMING_WORKFLOW environment variable and the base name of their Terraform directory.
+func SetName(workflow, job string) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	defaultName = [2]string{workflow, job}
+}
+
+// InitAndApplyE runs terraform init and apply, recording each as a separate phase for the given stage.
+func InitAndApplyE(t *testing.T, terraformOptions *terraform.Options, stage string) (string, error) {
+	start := time.Now()
+	output, err := terraform.InitE(t, terraformOptions)
+	record(terraformOptions, Init, stage, start, err)
+	if err != nil {
+		return output, err
+	}
+
+	start = time.Now()
+	output, err = terraform.ApplyE(t, terraformOptions)
+	record(terraformOptions, Apply, stage, start, err)
+
+	return output, err
+}
+
+// InitAndApply runs terraform init and apply for the given stage, failing the test on error.
+func InitAndApply(t *testing.T, terraformOptions *terraform.Options, stage string) string {
+	output, err := InitAndApplyE(t, terraformOptions, stage)
+	require.NoError(t, err)
+
+	return output
+}
+
+// DestroyE runs terraform destroy and records the destroy phase. The time between the last apply and the destroy is
+// recorded as the verification phase.
+func DestroyE(t *testing.T, terraformOptions *terraform.Options) (string, error) {
+	mutex.Lock()
+	report := reportFor(terraformOptions)
+	if len(report.Phases) > 0 {
+		last := report.Phases[len(report.Phases)-1]
+		lastEnd := last.StartedAt.Add(time.Duration(last.DurationSeconds * float64(time.Second)))
+		report.Phases = append(report.Phases, Phase{
+			Name:            Verify,
+			Stage:           "post-apply",
+			StartedAt:       lastEnd,
+			DurationSeconds: time.Since(lastEnd).Seconds(),
+		})
+	}
+	mutex.Unlock()
+
+	start := time.Now()
+	output, err := terraform.DestroyE(t, terraformOptions)
+	record(terraformOptions, Destroy, "", start, err)
+	Finish(terraformOptions, err)
+
+	return output, err
+}
+
+// AddInstances records the number and type of instances created for the setup, used for cost estimation.
+func AddInstances(terraformOptions *terraform.Options, provider, instanceType string, count int) {
+	if count <= 0 {
+		return
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	report := reportFor(terraformOptions)
+	for i, instances := range report.Instances {
+		if instances.Provider == provider && instances.InstanceType == instanceType {
+			report.Instances[i].Count += count
+			write(report)
+			return
+		}
+	}
+
+	report.Instances = append(report.Instances, Instances{Provider: provider, InstanceType: instanceType, Count: count})
+	write(report)
+}
+
+// Finish marks the timing report as complete and writes it to the results directory.
+func Finish(terraformOptions *terraform.Options, err error) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	report := reportFor(terraformOptions)
+	report.Status = StatusSuccess
+
+	if err != nil {
+		report.Status = StatusFailure
+	}
+
+	for _, phase := range report.Phases {
+		if phase.Failed {
+			report.Status = StatusFailure
+		}
+	}
+
+	write(report)
+	delete(reports, terraformOptions.TerraformDir)
+}
+
+// FinishAll finishes every timing report still in progress, used by setups that do not destroy their resources.
+func FinishAll(err error) {
+	mutex.Lock()
+	dirs := make([]string, 0, len(reports))
+	for dir := range reports {
+		dirs = append(dirs, dir)
+	}
+	mutex.Unlock()
+
+	for _, dir := range dirs {
+		Finish(&terraform.Options{TerraformDir: dir}, err)
+	}
+}
+
+// record adds a finished phase to the report of the given Terraform directory and rewrites the report file.
+func record(terraformOptions *terraform.Options, phase, stage string, start time.Time, err error) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	report := reportFor(terraformOptions)
+	report.Phases = append(report.Phases, Phase{
+		Name:            phase,
+		Stage:           stage,
+		StartedAt:       start.UTC(),
+		DurationSeconds: time.Since(start).Seconds(),
+		Failed:          err != nil,
+	})
+
+	write(report)
+}
+
+// reportFor returns the report for the Terraform directory, creating it when needed. The caller must hold the mutex.
+func reportFor(terraformOptions *terraform.Options) *Report {
+	key := terraformOptions.TerraformDir
+
+	report, ok := reports[key]
+	if ok {
+		return report
+	}
+
+	workflow, job := defaultName[0], defaultName[1]
+	if workflow == "" {
+		workflow = os.Getenv(workflowEnv)
+	}
+
+	if workflow == "" {
+		workflow = defaultWorkflow
+	}
+
+	if job == "" {
+		job = filepath.Base(key)
+	}
+
+	now := time.Now().UTC()
+	report = &Report{
+		Workflow:  workflow,
+		Job:       job,
+		Status:    StatusInProgress,
+		Timestamp: now.Format(time.RFC3339),
+		startedAt: now,
+	}
+
+	reports[key] = report
+
+	return report
+}
+
+// write stores the report as JSON. Failures are logged rather than returned so that timing never fails a test.
+func write(report *Report) {
+	report.DurationSeconds = time.Since(report.startedAt).Seconds()
+	report.EstimatedCost = EstimateCost(report.Instances, report.DurationSeconds, LoadRateTable())
+
+	dir := os.Getenv(resultsDirEnv)
+	if dir == "" {
+		dir = defaultResultsDir
+	}
+
+	if report.path == "" {
+		name := fmt.Sprintf("timing-%s-%s-%d.json", report.Workflow, report.Job, report.startedAt.Unix())
+		report.path = filepath.Join(dir, unsafeFileChars.ReplaceAllString(strings.ToLower(name), "-"))
+	}
+
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		logrus.Warningf("Unable to create timing results directory %s: %v", dir, err)
+		return
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		logrus.Warningf("Unable to encode timing report: %v", err)
+		return
+	}
+
+	err = os.WriteFile(report.path, data, 0o644)
+	if err != nil {
+		logrus.Warningf("Unable to write timing report %s: %v", report.path, err)
+	}
+}
@@ -0,0 +1 @@
+// Leave blank - main.tf will be set during testing
\ No newline at end of file
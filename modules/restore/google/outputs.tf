@@ -0,0 +1,3 @@
+output "google_load_balancer_ip_address" {
+  value = google_compute_address.google_compute_address.address
+}
\ No newline at end of file
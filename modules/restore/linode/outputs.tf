@@ -0,0 +1,3 @@
+output "linode_node_balancer_hostname" {
+  value = linode_nodebalancer.linode_nodebalancer.hostname
+}
\ No newline at end of file
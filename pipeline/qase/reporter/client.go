@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	upstream "github.com/qase-tms/qase-go/qase-api-client"
+	"github.com/rancher/tests/actions/qase"
+	"github.com/rancher/tfp-automation/pipeline/qase/standin"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	maxRetriesEnvVar  = "QASE_MAX_RETRIES"
+	tokenEnvVar       = "QASE_AUTOMATION_TOKEN"
+	defaultMaxRetries = 3
+)
+
+// retryBackoff is the delay before the first retry, doubled on every following attempt.
+var retryBackoff = 2 * time.Second
+
+// qaseClient is the subset of the Qase API used by the reporter. It allows the reporter to run against the Qase API,
+// a local stand-in or in dry-run mode.
+type qaseClient interface {
+	GetCases(projectID string, offset, limit int32) ([]upstream.TestCase, int32, error)
+	CreateTestRun(name, projectID, description string) (int64, error)
+	CreateResult(projectID string, runID int32, result upstream.ResultCreate) error
+	CompleteTestRun(projectID string, runID int32) error
+}
+
+// apiClient talks to the Qase API, retrying requests that fail with a transport error or a server error.
+type apiClient struct {
+	service    *qase.Service
+	maxRetries int
+}
+
+// newAPIClient is a function that wraps the Qase service, reading the number of retries from QASE_MAX_RETRIES.
+func newAPIClient(service *qase.Service) *apiClient {
+	maxRetries := defaultMaxRetries
+	if value := os.Getenv(maxRetriesEnvVar); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			logrus.Warningf("Invalid %s value %q, defaulting to %d", maxRetriesEnvVar, value, defaultMaxRetries)
+		} else {
+			maxRetries = parsed
+		}
+	}
+
+	return &apiClient{service: service, maxRetries: maxRetries}
+}
+
+// GetCases gets a single page of test cases, returning the cases and the number of cases in the page.
+func (c *apiClient) GetCases(projectID string, offset, limit int32) ([]upstream.TestCase, int32, error) {
+	var cases []upstream.TestCase
+	var count int32
+
+	err := c.retry(fmt.Sprintf("get cases at offset %d", offset), func() (*http.Response, error) {
+		casesRequest := c.service.Client.CasesAPI.GetCases(context.TODO(), projectID)
+		casesRequest = casesRequest.Offset(offset)
+		casesRequest = casesRequest.Limit(limit)
+
+		resp, httpResp, err := casesRequest.Execute()
+		if err != nil {
+			return httpResp, err
+		}
+
+		if resp == nil || resp.Result == nil || resp.Result.Count == nil {
+			return httpResp, errors.New("empty response from Qase when getting cases")
+		}
+
+		cases = resp.Result.Entities
+		count = *resp.Result.Count
+
+		return httpResp, nil
+	})
+
+	return cases, count, err
+}
+
+// CreateTestRun creates a test run and returns its ID. It is not retried, as a retry could create a duplicate run.
+func (c *apiClient) CreateTestRun(name, projectID, description string) (int64, error) {
+	resp, err := c.service.CreateTestRun(name, projectID, description)
+	if err != nil {
+		return 0, err
+	}
+
+	if resp == nil || resp.Result == nil || resp.Result.Id == nil {
+		return 0, errors.New("empty response from Qase when creating test run")
+	}
+
+	return *resp.Result.Id, nil
+}
+
+// CreateResult adds a test result to a test run.
+func (c *apiClient) CreateResult(projectID string, runID int32, result upstream.ResultCreate) error {
+	return c.retry(fmt.Sprintf("create result in run %d", runID), func() (*http.Response, error) {
+		resultRequest := c.service.Client.ResultsAPI.CreateResult(context.TODO(), projectID, runID)
+		resultRequest = resultRequest.ResultCreate(result)
+
+		_, httpResp, err := resultRequest.Execute()
+
+		return httpResp, err
+	})
+}
+
+// CompleteTestRun marks a test run as complete. The request is sent through the API client rather than the service, so
+// the response status is known and client errors are not retried.
+func (c *apiClient) CompleteTestRun(projectID string, runID int32) error {
+	return c.retry(fmt.Sprintf("complete run %d", runID), func() (*http.Response, error) {
+		_, httpResp, err := c.service.Client.RunsAPI.CompleteRun(context.TODO(), projectID, runID).Execute()
+
+		return httpResp, err
+	})
+}
+
+// retry runs the request until it succeeds, fails with a non-retryable status or runs out of retries.
+func (c *apiClient) retry(description string, request func() (*http.Response, error)) error {
+	backoff := retryBackoff
+
+	var err error
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		var resp *http.Response
+		resp, err = request()
+		if err == nil {
+			return nil
+		}
+
+		if !isRetryable(resp) || attempt == c.maxRetries {
+			break
+		}
+
+		logrus.Warningf("Failed to %s (attempt %d/%d), retrying in %s: %v", description, attempt+1, c.maxRetries+1, backoff, err)
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+
+	return fmt.Errorf("failed to %s: %w", description, err)
+}
+
+// isRetryable is a helper function that reports whether a failed request should be retried. Requests without a
+// response failed with a transport error before reaching Qase and are always retried, otherwise only server errors are.
+func isRetryable(resp *http.Response) bool {
+	if resp == nil {
+		return true
+	}
+
+	return resp.StatusCode >= http.StatusInternalServerError
+}
+
+// newDryRunReader is a function that returns the client a dry run reads test cases through. The Qase API is only used
+// when a token is available, otherwise the recorded test cases are served by the offline stand-in, so a dry run never
+// requires Qase credentials.
+func newDryRunReader() (qaseClient, func(), error) {
+	if os.Getenv(tokenEnvVar) != "" {
+		return newAPIClient(qase.SetupQaseClient()), func() {}, nil
+	}
+
+	logrus.Warningf("%s not provided, reading the recorded test cases of the Qase stand-in", tokenEnvVar)
+
+	server, err := standin.NewServer(standin.Fixtures)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return newAPIClient(server.Service()), server.Close, nil
+}
+
+// dryRunClient reads from Qase through the wrapped client but only logs the runs and results it would create.
+type dryRunClient struct {
+	qaseClient
+}
+
+// CreateTestRun logs the test run that would be created.
+func (c *dryRunClient) CreateTestRun(name, projectID, _ string) (int64, error) {
+	logrus.Infof("[dry-run] Would create test run %q in project %s", name, projectID)
+
+	return 0, nil
+}
+
+// CreateResult logs the test result that would be added to the run.
+func (c *dryRunClient) CreateResult(projectID string, runID int32, result upstream.ResultCreate) error {
+	var caseID int64
+	if result.CaseId != nil {
+		caseID = *result.CaseId
+	}
+
+	logrus.Infof("[dry-run] Would update run %d in project %s: case %d %s, params %v", runID, projectID, caseID, result.Status, result.Param)
+
+	return nil
+}
+
+// CompleteTestRun logs the test run that would be completed.
+func (c *dryRunClient) CompleteTestRun(projectID string, runID int32) error {
+	logrus.Infof("[dry-run] Would complete test run %d in project %s", runID, projectID)
+
+	return nil
+}
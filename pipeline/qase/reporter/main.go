@@ -2,8 +2,8 @@ package main
 
 import (
 	"bufio"
-	"context"
 	"errors"
+	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -42,7 +42,18 @@ const (
 	testResultsJSON  = "results.json"
 )
 
+type reporterConfig struct {
+	projectID         string
+	customFieldFilter string
+	schemaPrefix      string
+	resultsFile       string
+	pageSize          int32
+}
+
 func main() {
+	dryRun := flag.Bool("dry-run", false, "print the test runs and results that would be created or updated without writing to Qase")
+	flag.Parse()
+
 	logrus.Info("Running QASE reporter v2")
 	if projectIDEnvVar == "" {
 		logrus.Warningf("Project env var not provided, defaulting to %s", qaseactions.RancherManagerProjectID)
@@ -50,7 +61,26 @@ func main() {
 	}
 
 	if runIDEnvVar != "" || testRunName != "" {
-		qaseService := qase.SetupQaseClient()
+		var client qaseClient
+		if *dryRun {
+			dryRunReader, closeReader, err := newDryRunReader()
+			if err != nil {
+				logrus.Fatalf("error starting the Qase stand-in: %v", err)
+			}
+			defer closeReader()
+
+			client = &dryRunClient{qaseClient: dryRunReader}
+		} else {
+			client = newAPIClient(qase.SetupQaseClient())
+		}
+
+		cfg := reporterConfig{
+			projectID:         projectIDEnvVar,
+			customFieldFilter: customFieldFilterEnvVar,
+			schemaPrefix:      schemaPrefixEnvVar,
+			resultsFile:       qase.TestResultsJSON,
+			pageSize:          requestLimit,
+		}
 
 		runID := int64(0)
 		err := error(nil)
@@ -62,11 +92,11 @@ func main() {
 		runDescription := createRunDescription(buildUrl, rancherTestCommitID)
 
 		if testRunName != "" {
-			resp, err := qaseService.CreateTestRun(testRunName, projectIDEnvVar, runDescription)
+			createdRunID, err := client.CreateTestRun(testRunName, cfg.projectID, runDescription)
 			if err != nil {
 				logrus.Error("error creating test run: ", err)
 			} else {
-				runID = *resp.Result.Id
+				runID = createdRunID
 			}
 		}
 
@@ -74,14 +104,14 @@ func main() {
 			logrus.Fatalf("error reporting converting string to int64: %v", err)
 		}
 
-		err = reportTestQases(qaseService, int32(runID))
+		err = reportTestQases(client, cfg, int32(runID))
 		if err != nil {
 			logrus.Error("error reporting: ", err)
 		}
 
 		isCompleteRun, _ := strconv.ParseBool(testRunComplete)
 		if isCompleteRun {
-			err = qaseService.CompleteTestRun(projectIDEnvVar, int32(runID))
+			err = client.CompleteTestRun(cfg.projectID, int32(runID))
 			if err != nil {
 				logrus.Error("error update reporting: ", err)
 			}
@@ -92,25 +122,24 @@ func main() {
 }
 
 // getAllAutomationTestCases gets all qase tests in a project
-func getAllAutomationTestCases(qaseService *qase.Service) (map[string]upstream.TestCase, error) {
+func getAllAutomationTestCases(client qaseClient, cfg reporterConfig) (map[string]upstream.TestCase, error) {
 	testCases := []upstream.TestCase{}
 	testCaseNameMap := map[string]upstream.TestCase{}
 	var numOfTestsCases int32 = 1
-	offSetCount := 0
+	var offSetCount int32
 	for numOfTestsCases > 0 {
-		casesRequest := qaseService.Client.CasesAPI.GetCases(context.TODO(), projectIDEnvVar)
-
-		casesRequest = casesRequest.Offset(int32(offSetCount))
-		casesRequest = casesRequest.Limit(requestLimit)
-		resp, _, _ := casesRequest.Execute()
+		cases, count, err := client.GetCases(cfg.projectID, offSetCount, cfg.pageSize)
+		if err != nil {
+			return nil, err
+		}
 
-		testCases = append(testCases, resp.Result.Entities...)
-		numOfTestsCases = *resp.Result.Count
-		offSetCount += len(resp.Result.Entities)
+		testCases = append(testCases, cases...)
+		numOfTestsCases = count
+		offSetCount += int32(len(cases))
 	}
 
 	for _, testCase := range testCases {
-		if customFieldFilterEnvVar != "" && !hasCustomFieldValue(testCase.CustomFields, customFieldFilterEnvVar) {
+		if cfg.customFieldFilter != "" && !hasCustomFieldValue(testCase.CustomFields, cfg.customFieldFilter) {
 			continue
 		}
 
@@ -127,11 +156,12 @@ func getAllAutomationTestCases(qaseService *qase.Service) (map[string]upstream.T
 }
 
 // readTestResults converts the results.json file into an output object
-func readTestResults() ([]testresult.GoTestOutput, error) {
-	file, err := os.Open(qase.TestResultsJSON)
+func readTestResults(resultsFile string) ([]testresult.GoTestOutput, error) {
+	file, err := os.Open(resultsFile)
 	if err != nil {
 		return nil, err
 	}
+	defer file.Close()
 
 	fscanner := bufio.NewScanner(file)
 	outputLines := []testresult.GoTestOutput{}
@@ -194,15 +224,15 @@ func parseTestResults(outputs []testresult.GoTestOutput) map[string]*testresult.
 }
 
 // reportTestQases updates a qase test run with the results of a set of tests
-func reportTestQases(qaseService *qase.Service, testRunID int32) error {
-	resultsOutputs, err := readTestResults()
+func reportTestQases(client qaseClient, cfg reporterConfig, testRunID int32) error {
+	resultsOutputs, err := readTestResults(cfg.resultsFile)
 	if err != nil {
 		return err
 	}
 
 	goTestResults := parseTestResults(resultsOutputs)
 
-	qaseTestCases, err := getAllAutomationTestCases(qaseService)
+	qaseTestCases, err := getAllAutomationTestCases(client, cfg)
 	if err != nil {
 		return err
 	}
@@ -218,7 +248,7 @@ func reportTestQases(qaseService *qase.Service, testRunID int32) error {
 			}
 
 			fullPackagePath := filepath.Join(basepath, packagePath[1])
-			qaseProjects, err := qase.GetSchemasByPrefix(fullPackagePath, schemaPrefixEnvVar)
+			qaseProjects, err := qase.GetSchemasByPrefix(fullPackagePath, cfg.schemaPrefix)
 			if err != nil {
 				logrus.Warning(err)
 				continue
@@ -232,7 +262,7 @@ func reportTestQases(qaseService *qase.Service, testRunID int32) error {
 
 			// update test status
 			logrus.Infof("Updating run with %v", *testQase.Title)
-			err = updateTestInRun(qaseService.Client, *goTestResult, testQase, qaseTestSchema.Parameters, testRunID)
+			err = updateTestInRun(client, cfg.projectID, *goTestResult, testQase, qaseTestSchema.Parameters, testRunID)
 			if err != nil {
 				logrus.Warning(err)
 				continue
@@ -248,9 +278,8 @@ func reportTestQases(qaseService *qase.Service, testRunID int32) error {
 }
 
 // updateTestInRun updates the current qase test run with a test
-func updateTestInRun(client *upstream.APIClient, testResult testresult.GoTestResult, qaseTestCase upstream.TestCase, params []upstream.TestCaseParameterCreate, testRunID int32) error {
+func updateTestInRun(client qaseClient, projectID string, testResult testresult.GoTestResult, qaseTestCase upstream.TestCase, params []upstream.TestCaseParameterCreate, testRunID int32) error {
 	var elapsedTime int64
-	var err error
 	if testResult.Elapsed != "" {
 		floatTime, err := strconv.ParseFloat(testResult.Elapsed, 64)
 		if err != nil {
@@ -289,15 +318,7 @@ func updateTestInRun(client *upstream.APIClient, testResult testresult.GoTestRes
 		Comment: *upstream.NewNullableString(&testResult.StackTrace),
 	}
 
-	resultRequest := client.ResultsAPI.CreateResult(context.TODO(), projectIDEnvVar, testRunID)
-	resultRequest = resultRequest.ResultCreate(resultBody)
-
-	_, _, err = resultRequest.Execute()
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return client.CreateResult(projectID, testRunID, resultBody)
 }
 
 // getAutomationTestName gets the custom test name field
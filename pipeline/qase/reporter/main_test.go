@@ -0,0 +1,155 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"testing"
+	"time"
+
+	upstream "github.com/qase-tms/qase-go/qase-api-client"
+	"github.com/rancher/tfp-automation/pipeline/qase/standin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testProjectID = "RM"
+
+func newStandinClient(t *testing.T, maxRetries int) (*standin.Server, *apiClient) {
+	t.Helper()
+
+	retryBackoff = time.Millisecond
+
+	server, err := standin.NewServer(standin.Fixtures)
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+
+	return server, &apiClient{service: server.Service(), maxRetries: maxRetries}
+}
+
+func caseTitles(testCases map[string]upstream.TestCase) []string {
+	var titles []string
+	for _, testCase := range testCases {
+		titles = append(titles, *testCase.Title)
+	}
+
+	sort.Strings(titles)
+
+	return titles
+}
+
+func TestGetAllAutomationTestCasesPagination(t *testing.T) {
+	server, client := newStandinClient(t, 0)
+
+	testCases, err := getAllAutomationTestCases(client, reporterConfig{projectID: testProjectID, pageSize: 2})
+	require.NoError(t, err)
+
+	assert.Len(t, testCases, 5)
+
+	var offsets []string
+	for _, request := range server.Requests(http.MethodGet) {
+		assert.Equal(t, "/v1/case/"+testProjectID, request.Path)
+		assert.Equal(t, "2", request.Query.Get("limit"))
+		offsets = append(offsets, request.Query.Get("offset"))
+	}
+
+	assert.Equal(t, []string{"0", "2", "4", "5"}, offsets)
+}
+
+func TestGetAllAutomationTestCasesCustomFieldFilter(t *testing.T) {
+	_, client := newStandinClient(t, 0)
+
+	testCases, err := getAllAutomationTestCases(client, reporterConfig{projectID: testProjectID, pageSize: requestLimit, customFieldFilter: "Hostbusters"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"8_nodes_3_etcd_2_cp_3_worker", "RKE2_Rancher_Privileged", "RKE2_Rancher_Restricted"}, caseTitles(testCases))
+}
+
+func TestGetAllAutomationTestCasesRetries(t *testing.T) {
+	server, client := newStandinClient(t, 3)
+	server.FailNext(http.StatusBadGateway, http.StatusServiceUnavailable)
+
+	testCases, err := getAllAutomationTestCases(client, reporterConfig{projectID: testProjectID, pageSize: requestLimit})
+	require.NoError(t, err)
+
+	assert.Len(t, testCases, 5)
+	assert.Len(t, server.Requests(http.MethodGet), 4)
+}
+
+func TestGetAllAutomationTestCasesRetriesExhausted(t *testing.T) {
+	server, client := newStandinClient(t, 2)
+	server.FailNext(http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError)
+
+	_, err := getAllAutomationTestCases(client, reporterConfig{projectID: testProjectID, pageSize: requestLimit})
+	require.Error(t, err)
+
+	assert.Len(t, server.Requests(http.MethodGet), 3)
+}
+
+func TestGetAllAutomationTestCasesClientErrorNotRetried(t *testing.T) {
+	server, client := newStandinClient(t, 3)
+	server.FailNext(http.StatusUnauthorized)
+
+	_, err := getAllAutomationTestCases(client, reporterConfig{projectID: testProjectID, pageSize: requestLimit})
+	require.Error(t, err)
+
+	assert.Len(t, server.Requests(http.MethodGet), 1)
+}
+
+func TestCompleteTestRunClientErrorNotRetried(t *testing.T) {
+	server, client := newStandinClient(t, 3)
+	server.FailNext(http.StatusNotFound)
+
+	err := client.CompleteTestRun(testProjectID, 7)
+	require.Error(t, err)
+
+	assert.Len(t, server.Requests(http.MethodPost), 1)
+}
+
+func TestCompleteTestRunRetries(t *testing.T) {
+	server, client := newStandinClient(t, 3)
+	server.FailNext(http.StatusInternalServerError)
+
+	err := client.CompleteTestRun(testProjectID, 7)
+	require.NoError(t, err)
+
+	requests := server.Requests(http.MethodPost)
+	require.Len(t, requests, 2)
+	assert.Equal(t, "/v1/run/"+testProjectID+"/7/complete", requests[1].Path)
+}
+
+func TestCreateResult(t *testing.T) {
+	server, client := newStandinClient(t, 3)
+	server.FailNext(http.StatusServiceUnavailable)
+
+	caseID := int64(101)
+	err := client.CreateResult(testProjectID, 7, upstream.ResultCreate{CaseId: &caseID, Status: "passed"})
+	require.NoError(t, err)
+
+	requests := server.Requests(http.MethodPost)
+	require.Len(t, requests, 2)
+	assert.Equal(t, "/v1/result/"+testProjectID+"/7", requests[1].Path)
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(requests[1].Body, &body))
+	assert.EqualValues(t, caseID, body["case_id"])
+	assert.Equal(t, "passed", body["status"])
+}
+
+func TestDryRunDoesNotWrite(t *testing.T) {
+	server, client := newStandinClient(t, 0)
+	dryRun := &dryRunClient{qaseClient: client}
+
+	testCases, err := getAllAutomationTestCases(dryRun, reporterConfig{projectID: testProjectID, pageSize: requestLimit})
+	require.NoError(t, err)
+	assert.Len(t, testCases, 5)
+
+	runID, err := dryRun.CreateTestRun("dry run", testProjectID, "")
+	require.NoError(t, err)
+
+	caseID := int64(101)
+	require.NoError(t, dryRun.CreateResult(testProjectID, int32(runID), upstream.ResultCreate{CaseId: &caseID, Status: "failed"}))
+	require.NoError(t, dryRun.CompleteTestRun(testProjectID, int32(runID)))
+
+	assert.Empty(t, server.Requests(http.MethodPost))
+}
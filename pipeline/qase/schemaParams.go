@@ -7,6 +7,7 @@ import (
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/clustertypes"
 	"github.com/rancher/tfp-automation/defaults/modules"
+	customnodepools "github.com/rancher/tfp-automation/framework/set/provisioning/custom/nodepools"
 )
 
 // GetProvisioningSchemaParams gets a set of params from the terraform/terratest config and returns a qase params object
@@ -18,7 +19,7 @@ func GetProvisioningSchemaParams(terraform *config.TerraformConfig, terratest *c
 		getRancherType(terraform),
 		getCNIParam(terraform),
 		getAMIParam(terraform),
-		getWindowsAMIParam(terraform),
+		getWindowsAMIParam(terraform, terratest),
 		getK8sParam(terratest),
 		getTurtlesParam(terraform),
 	)
@@ -110,13 +111,24 @@ func getAMIParam(terraform *config.TerraformConfig) upstream.TestCaseParameterCr
 	return upstream.TestCaseParameterCreate{}
 }
 
-func getWindowsAMIParam(terraform *config.TerraformConfig) upstream.TestCaseParameterCreate {
-	if strings.Contains(terraform.Module, clustertypes.WINDOWS) && strings.Contains(terraform.Module, "2019") {
-		return upstream.TestCaseParameterCreate{ParameterSingle: &upstream.ParameterSingle{Title: "Windows2019AMI", Values: []string{terraform.AWSConfig.Windows2019AMI}}}
+func getWindowsAMIParam(terraform *config.TerraformConfig, terratest *config.TerratestConfig) upstream.TestCaseParameterCreate {
+	if !strings.Contains(terraform.Module, clustertypes.WINDOWS) {
+		return upstream.TestCaseParameterCreate{}
+	}
+
+	// The version falls back to the nodepools windowsVersion for the generic *_windows_custom modules.
+	version, err := customnodepools.WindowsVersion(terraform, terratest)
+	if err != nil {
+		return upstream.TestCaseParameterCreate{}
 	}
 
-	if strings.Contains(terraform.Module, clustertypes.WINDOWS) && strings.Contains(terraform.Module, "2022") {
+	switch version {
+	case config.Windows2019:
+		return upstream.TestCaseParameterCreate{ParameterSingle: &upstream.ParameterSingle{Title: "Windows2019AMI", Values: []string{terraform.AWSConfig.Windows2019AMI}}}
+	case config.Windows2022:
 		return upstream.TestCaseParameterCreate{ParameterSingle: &upstream.ParameterSingle{Title: "Windows2022AMI", Values: []string{terraform.AWSConfig.Windows2022AMI}}}
+	case config.Windows2025:
+		return upstream.TestCaseParameterCreate{ParameterSingle: &upstream.ParameterSingle{Title: "Windows2025AMI", Values: []string{terraform.AWSConfig.Windows2025AMI}}}
 	}
 
 	return upstream.TestCaseParameterCreate{}
@@ -1,11 +1,16 @@
 package main
 
 import (
+	"flag"
+	"io/fs"
+	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 
 	"github.com/rancher/tests/actions/qase"
 	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
 )
 
 var (
@@ -13,10 +18,78 @@ var (
 	basepath                = filepath.Join(filepath.Dir(callerFilePath), "..", "..", "..", "..")
 )
 
+const schemasDir = "schemas"
+
+// schemaUploader uploads the Qase schemas found under a base path.
+type schemaUploader interface {
+	Upload(basepath string) error
+}
+
+// apiUploader uploads the schemas to the Qase API.
+type apiUploader struct {
+	service *qase.Service
+}
+
+func (u *apiUploader) Upload(basepath string) error {
+	return qase.UploadSchemas(u.service, basepath)
+}
+
+// dryRunUploader logs the suites and cases that would be created or updated without talking to Qase.
+type dryRunUploader struct{}
+
+type schemaFile []struct {
+	Projects []string `yaml:"projects"`
+	Suite    string   `yaml:"suite"`
+	Cases    []struct {
+		Title string `yaml:"title"`
+	} `yaml:"cases"`
+}
+
+func (u *dryRunUploader) Upload(basepath string) error {
+	return filepath.WalkDir(basepath, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() && path != basepath && strings.HasPrefix(entry.Name(), ".") {
+			return filepath.SkipDir
+		}
+
+		if entry.IsDir() || filepath.Base(filepath.Dir(path)) != schemasDir || filepath.Ext(path) != ".yaml" {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		var schemas schemaFile
+		err = yaml.Unmarshal(data, &schemas)
+		if err != nil {
+			return err
+		}
+
+		for _, schema := range schemas {
+			for _, testCase := range schema.Cases {
+				logrus.Infof("[dry-run] Would create or update case %q in suite %q for projects %v (%s)", testCase.Title, schema.Suite, schema.Projects, path)
+			}
+		}
+
+		return nil
+	})
+}
+
 func main() {
-	client := qase.SetupQaseClient()
+	dryRun := flag.Bool("dry-run", false, "print the suites and cases that would be created or updated without writing to Qase")
+	flag.Parse()
+
+	var uploader schemaUploader = &dryRunUploader{}
+	if !*dryRun {
+		uploader = &apiUploader{service: qase.SetupQaseClient()}
+	}
 
-	err := qase.UploadSchemas(client, basepath)
+	err := uploader.Upload(basepath)
 	if err != nil {
 		logrus.Error(err)
 	}
@@ -0,0 +1,228 @@
+package standin
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	upstream "github.com/qase-tms/qase-go/qase-api-client"
+	"github.com/rancher/tests/actions/qase"
+)
+
+const (
+	apiPrefix = "/v1"
+
+	casesFixture    = "cases.json"
+	runFixture      = "run.json"
+	resultFixture   = "result.json"
+	completeFixture = "complete.json"
+)
+
+// Fixtures are responses recorded from the Qase API, used by default when no other fixtures are given.
+//
+//go:embed fixtures/*.json
+var Fixtures embed.FS
+
+// Request is a request received by the stand-in, kept so that tests can assert on what was sent to Qase.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Body   []byte
+}
+
+// Server is a local HTTP stand-in for the Qase API. It serves the recorded test cases page by page, accepts test runs
+// and results, and can be told to fail the next requests to exercise retries.
+type Server struct {
+	*httptest.Server
+
+	mutex     sync.Mutex
+	fixtures  fs.FS
+	cases     []json.RawMessage
+	requests  []Request
+	failures  []int
+	nextRunID int64
+}
+
+// NewServer is a function that starts a stand-in serving the given fixtures. Pass Fixtures to use the recorded ones.
+func NewServer(fixtures fs.FS) (*Server, error) {
+	// The embedded fixtures are nested under the fixtures directory, while a directory given by the caller may not be.
+	sub, err := fs.Sub(fixtures, "fixtures")
+	if err == nil {
+		_, err = fs.Stat(sub, casesFixture)
+		if err == nil {
+			fixtures = sub
+		}
+	}
+
+	data, err := fs.ReadFile(fixtures, casesFixture)
+	if err != nil {
+		return nil, err
+	}
+
+	var recorded struct {
+		Result struct {
+			Entities []json.RawMessage `json:"entities"`
+		} `json:"result"`
+	}
+
+	err = json.Unmarshal(data, &recorded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s fixture: %w", casesFixture, err)
+	}
+
+	server := &Server{
+		fixtures:  fixtures,
+		cases:     recorded.Result.Entities,
+		nextRunID: 1,
+	}
+
+	server.Server = httptest.NewServer(http.HandlerFunc(server.handle))
+
+	return server, nil
+}
+
+// APIURL returns the base URL to configure the Qase client with.
+func (s *Server) APIURL() string {
+	return s.URL + apiPrefix
+}
+
+// Service returns a Qase service whose client talks to the stand-in.
+func (s *Server) Service() *qase.Service {
+	cfg := upstream.NewConfiguration()
+	cfg.Servers = upstream.ServerConfigurations{upstream.ServerConfiguration{URL: s.APIURL()}}
+	cfg.HTTPClient = s.Client()
+
+	return &qase.Service{Client: upstream.NewAPIClient(cfg)}
+}
+
+// FailNext makes the next requests fail with the given status codes, one status code per request.
+func (s *Server) FailNext(statusCodes ...int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.failures = append(s.failures, statusCodes...)
+}
+
+// Requests returns the requests received so far, optionally filtered by method.
+func (s *Server) Requests(method string) []Request {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var requests []Request
+	for _, request := range s.requests {
+		if method == "" || request.Method == method {
+			requests = append(requests, request)
+		}
+	}
+
+	return requests
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	s.mutex.Lock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Query: r.URL.Query(), Body: body})
+
+	var failure int
+	if len(s.failures) > 0 {
+		failure, s.failures = s.failures[0], s.failures[1:]
+	}
+	s.mutex.Unlock()
+
+	if failure != 0 {
+		writeError(w, failure, "injected failure")
+		return
+	}
+
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, apiPrefix), "/"), "/")
+
+	switch {
+	case r.Method == http.MethodGet && len(parts) == 2 && parts[0] == "case":
+		s.getCases(w, r)
+	case r.Method == http.MethodPost && len(parts) == 2 && parts[0] == "run":
+		s.createRun(w)
+	case r.Method == http.MethodPost && len(parts) == 4 && parts[0] == "run" && parts[3] == "complete":
+		s.writeFixture(w, completeFixture, nil)
+	case r.Method == http.MethodPost && len(parts) == 3 && parts[0] == "result":
+		s.writeFixture(w, resultFixture, nil)
+	default:
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no stand-in route for %s %s", r.Method, r.URL.Path))
+	}
+}
+
+// getCases serves a page of the recorded test cases, honouring the offset and limit query parameters.
+func (s *Server) getCases(w http.ResponseWriter, r *http.Request) {
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 10
+	}
+
+	start := min(offset, len(s.cases))
+	end := min(start+limit, len(s.cases))
+	page := s.cases[start:end]
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"status": true,
+		"result": map[string]any{
+			"total":    len(s.cases),
+			"filtered": len(s.cases),
+			"count":    len(page),
+			"entities": page,
+		},
+	})
+}
+
+// createRun serves the recorded run fixture with a new run ID.
+func (s *Server) createRun(w http.ResponseWriter) {
+	s.mutex.Lock()
+	runID := s.nextRunID
+	s.nextRunID++
+	s.mutex.Unlock()
+
+	s.writeFixture(w, runFixture, func(result map[string]any) {
+		result["id"] = runID
+	})
+}
+
+// writeFixture writes a recorded response, letting the caller adjust its result before it is sent.
+func (s *Server) writeFixture(w http.ResponseWriter, name string, update func(result map[string]any)) {
+	data, err := fs.ReadFile(s.fixtures, name)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	var response map[string]any
+	err = json.Unmarshal(data, &response)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if result, ok := response["result"].(map[string]any); ok && update != nil {
+		update(result)
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	writeJSON(w, statusCode, map[string]any{"status": false, "errorMessage": message})
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
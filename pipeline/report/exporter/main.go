@@ -0,0 +1,56 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/rancher/tfp-automation/pipeline/report"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	testResultsJSON  = "results.json"
+	defaultOutputDir = "tfp-results"
+	maxExcerptLines  = 20
+)
+
+func main() {
+	input := flag.String("input", testResultsJSON, "go test -json output to convert")
+	output := flag.String("output", defaultOutputDir, "directory the JUnit XML and JSON reports are written to")
+	metadataDir := flag.String("metadata", report.MetadataDir(), "directory the suites recorded case metadata in")
+	excerpt := flag.Bool("excerpt", false, "print the error excerpt of the failed tests to stdout instead of writing the reports")
+	flag.Parse()
+
+	logrus.Infof("Exporting test results from %s", *input)
+
+	metadata, err := report.LoadMetadata(*metadataDir)
+	if err != nil {
+		logrus.Fatalf("error loading case metadata: %v", err)
+	}
+
+	file, err := os.Open(*input)
+	if err != nil {
+		logrus.Fatalf("error opening test results: %v", err)
+	}
+	defer file.Close()
+
+	suites, err := report.ParseGoTestJSON(file, metadata)
+	if err != nil {
+		logrus.Fatalf("error parsing test results: %v", err)
+	}
+
+	testReport := report.NewReport(suites)
+
+	if *excerpt {
+		fmt.Println(testReport.ErrorExcerpt(maxExcerptLines))
+		return
+	}
+
+	err = testReport.Write(*output)
+	if err != nil {
+		logrus.Fatalf("error writing test report: %v", err)
+	}
+
+	logrus.Infof("Exported %d tests (%d failed, %d skipped) to %s", testReport.Tests, testReport.Failures, testReport.Skipped, *output)
+}
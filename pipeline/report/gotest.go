@@ -0,0 +1,227 @@
+package report
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	actionRun    = "run"
+	actionOutput = "output"
+	actionPass   = "pass"
+	actionFail   = "fail"
+	actionSkip   = "skip"
+
+	// maxFailureOutput keeps the tail of very long failures, which is where Terraform and testify print the error.
+	maxFailureOutput = 64 * 1024
+	incompleteOutput = "test did not complete, the test binary timed out or panicked\n"
+)
+
+// testEvent is a single line of `go test -json` output, as written to results.json by gotestsum.
+type testEvent struct {
+	Time    time.Time `json:"Time"`
+	Action  string    `json:"Action"`
+	Package string    `json:"Package"`
+	Test    string    `json:"Test"`
+	Elapsed float64   `json:"Elapsed"`
+	Output  string    `json:"Output"`
+}
+
+type testRun struct {
+	name    string
+	status  string
+	elapsed float64
+	output  strings.Builder
+}
+
+type packageRun struct {
+	name    string
+	started time.Time
+	elapsed float64
+	failed  bool
+	tests   map[string]*testRun
+	order   []string
+}
+
+// ParseGoTestJSON converts `go test -json` output into one suite per package. Leaf tests are reported as cases, while
+// suite and table test parents, which aggregate the results of their subtests, are only reported when they fail on their
+// own, i.e. in SetupSuite or TearDownSuite. The metadata recorded by UpdateCaseMetadata is attached to the matching cases.
+func ParseGoTestJSON(reader io.Reader, metadata map[string]Metadata) ([]Suite, error) {
+	packages := make(map[string]*packageRun)
+	var packageOrder []string
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 1024*1024), 16*1024*1024)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || !strings.HasPrefix(line, "{") {
+			continue
+		}
+
+		var event testEvent
+		err := json.Unmarshal([]byte(line), &event)
+		if err != nil {
+			return nil, err
+		}
+
+		run, ok := packages[event.Package]
+		if !ok {
+			run = &packageRun{name: event.Package, started: event.Time, tests: make(map[string]*testRun)}
+			packages[event.Package] = run
+			packageOrder = append(packageOrder, event.Package)
+		}
+
+		if event.Test == "" {
+			if event.Action == actionPass || event.Action == actionFail {
+				run.elapsed = event.Elapsed
+				run.failed = event.Action == actionFail
+			}
+
+			continue
+		}
+
+		test, ok := run.tests[event.Test]
+		if !ok {
+			test = &testRun{name: event.Test}
+			run.tests[event.Test] = test
+			run.order = append(run.order, event.Test)
+		}
+
+		switch event.Action {
+		case actionOutput:
+			test.output.WriteString(event.Output)
+		case actionPass, actionFail, actionSkip:
+			test.status = event.Action
+			test.elapsed = event.Elapsed
+		}
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	suites := make([]Suite, 0, len(packageOrder))
+	for _, name := range packageOrder {
+		suite := packages[name].suite(metadata)
+		if len(suite.Cases) > 0 {
+			suites = append(suites, suite)
+		}
+	}
+
+	return suites, nil
+}
+
+// suite converts the package run into a report suite.
+func (p *packageRun) suite(metadata map[string]Metadata) Suite {
+	suite := Suite{
+		Name:            path.Base(p.name),
+		Package:         p.name,
+		DurationSeconds: p.elapsed,
+	}
+
+	if !p.started.IsZero() {
+		suite.Timestamp = p.started.UTC().Format(time.RFC3339)
+	}
+
+	names := make([]string, 0, len(p.order))
+	for _, name := range p.order {
+		if !p.hasSubtests(name) || p.failedOnItsOwn(name) {
+			names = append(names, name)
+		}
+	}
+
+	sort.SliceStable(names, func(i, j int) bool {
+		return names[i] < names[j]
+	})
+
+	for _, name := range names {
+		test := p.tests[name]
+		testCase := Case{
+			Name:            path.Base(name),
+			FullName:        name,
+			DurationSeconds: test.elapsed,
+			Metadata:        lookupMetadata(p.name, name, metadata),
+		}
+
+		switch test.status {
+		case actionPass:
+			testCase.Status = StatusPassed
+		case actionSkip:
+			testCase.Status = StatusSkipped
+		default:
+			testCase.Status = StatusFailed
+			testCase.FailureOutput = test.output.String()
+
+			if test.status == "" {
+				testCase.FailureOutput += incompleteOutput
+			}
+
+			testCase.FailureOutput = truncateTail(testCase.FailureOutput, maxFailureOutput)
+		}
+
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	return suite
+}
+
+// hasSubtests reports whether any other test of the package is nested under the given test.
+func (p *packageRun) hasSubtests(name string) bool {
+	for other := range p.tests {
+		if strings.HasPrefix(other, name+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// failedOnItsOwn reports whether the given parent test failed while none of its subtests did, so its failure is not
+// reported by any of them.
+func (p *packageRun) failedOnItsOwn(name string) bool {
+	if p.tests[name].status == actionPass || p.tests[name].status == actionSkip {
+		return false
+	}
+
+	for other, test := range p.tests {
+		if strings.HasPrefix(other, name+"/") && test.status != actionPass && test.status != actionSkip {
+			return false
+		}
+	}
+
+	return true
+}
+
+// truncateTail is a helper function that keeps at most the last maxBytes bytes of the given output, starting on a rune
+// boundary so the output stays valid UTF-8.
+func truncateTail(output string, maxBytes int) string {
+	if len(output) <= maxBytes {
+		return output
+	}
+
+	start := len(output) - maxBytes
+	for start < len(output) && !utf8.RuneStart(output[start]) {
+		start++
+	}
+
+	return output[start:]
+}
+
+// lookupMetadata is a helper function that finds the metadata of a test of the given package by its full name, then by
+// its table test name, which is the name suites pass to UpdateCaseMetadata.
+func lookupMetadata(pkg, testName string, metadata map[string]Metadata) Metadata {
+	prefix := packageKey(pkg) + "/"
+	if caseMetadata, ok := metadata[prefix+metadataKey(testName)]; ok {
+		return caseMetadata
+	}
+
+	return metadata[prefix+metadataKey(path.Base(testName))]
+}
@@ -0,0 +1,263 @@
+package report
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/defaults/providers/aws"
+	"github.com/rancher/tfp-automation/framework/set/defaults/providers/azure"
+	"github.com/rancher/tfp-automation/framework/set/defaults/providers/google"
+	"github.com/rancher/tfp-automation/framework/set/defaults/providers/harvester"
+	"github.com/rancher/tfp-automation/framework/set/defaults/providers/linode"
+	"github.com/rancher/tfp-automation/framework/set/defaults/providers/vsphere"
+)
+
+const (
+	MetadataDirEnvVar  = "REPORT_METADATA_DIR"
+	RunIDEnvVar        = "REPORT_RUN_ID"
+	defaultMetadataDir = "tfp-automation-report"
+	metadataExtension  = ".metadata.json"
+
+	githubRunIDEnvVar      = "GITHUB_RUN_ID"
+	githubRunAttemptEnvVar = "GITHUB_RUN_ATTEMPT"
+	localRunID             = "local"
+)
+
+var unsafeFileChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// Metadata describes the environment a test case ran against. It is recorded by the suites while they run and joined
+// with the go test output when the report is exported.
+type Metadata struct {
+	Module            string `json:"module,omitempty"`
+	Provider          string `json:"provider,omitempty"`
+	KubernetesVersion string `json:"kubernetes_version,omitempty"`
+	CNI               string `json:"cni,omitempty"`
+	RancherVersion    string `json:"rancher_version,omitempty"`
+}
+
+// NewMetadata gets the case metadata from the terraform/terratest config.
+func NewMetadata(terraform *config.TerraformConfig, terratest *config.TerratestConfig) Metadata {
+	metadata := Metadata{
+		Module:   terraform.Module,
+		Provider: terraform.Provider,
+		CNI:      terraform.CNI,
+	}
+
+	provider := moduleProvider(terraform.Module)
+	if provider != "" {
+		metadata.Provider = provider
+	}
+
+	if terratest != nil {
+		metadata.KubernetesVersion = getKubernetesVersion(terraform, terratest)
+	}
+
+	if terraform.Standalone != nil {
+		metadata.RancherVersion = terraform.Standalone.RancherTagVersion
+		if terraform.Standalone.UpgradedRancherTagVersion != "" {
+			metadata.RancherVersion = terraform.Standalone.UpgradedRancherTagVersion
+		}
+	}
+
+	return metadata
+}
+
+// UpdateCaseMetadata records the metadata of a test case so that the exporter can attach it to the case results. It
+// is the Qase independent counterpart of qase.UpdateSchemaParameters and should be called once the per-test config is
+// final, with the name the subtest was started with. The metadata is stored per package of the calling suite, so cases
+// sharing a name in different packages do not overwrite each other.
+func UpdateCaseMetadata(testName string, terraform *config.TerraformConfig, terratest *config.TerratestConfig) error {
+	dir := filepath.Join(MetadataDir(), packageKey(callerPackage()))
+
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(NewMetadata(terraform, terratest), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, metadataFileName(testName)), data, 0o644)
+}
+
+// LoadMetadata reads the metadata recorded for every test case in the given directory, keyed by the package and the
+// metadataKey of the case.
+func LoadMetadata(dir string) (map[string]Metadata, error) {
+	packages, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return map[string]Metadata{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := make(map[string]Metadata)
+	for _, pkg := range packages {
+		if !pkg.IsDir() {
+			continue
+		}
+
+		entries, err := os.ReadDir(filepath.Join(dir, pkg.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasSuffix(entry.Name(), metadataExtension) {
+				continue
+			}
+
+			data, err := os.ReadFile(filepath.Join(dir, pkg.Name(), entry.Name()))
+			if err != nil {
+				return nil, err
+			}
+
+			var caseMetadata Metadata
+			err = json.Unmarshal(data, &caseMetadata)
+			if err != nil {
+				return nil, err
+			}
+
+			metadata[pkg.Name()+"/"+strings.TrimSuffix(entry.Name(), metadataExtension)] = caseMetadata
+		}
+	}
+
+	return metadata, nil
+}
+
+// MetadataDir returns the directory the case metadata of the current run is stored in. It defaults to a directory
+// under the system temp directory so that suites and the exporter agree on it regardless of their working directory,
+// namespaced by REPORT_RUN_ID, or the GitHub run ID and attempt, so that runs do not read each other's metadata.
+func MetadataDir() string {
+	dir := os.Getenv(MetadataDirEnvVar)
+	if dir == "" {
+		dir = filepath.Join(os.TempDir(), defaultMetadataDir, runID())
+	}
+
+	return dir
+}
+
+// properties is a helper function that returns the metadata as JUnit properties, skipping empty values.
+func (m Metadata) properties() []junitProperty {
+	var properties []junitProperty
+
+	for _, property := range []junitProperty{
+		{Name: "module", Value: m.Module},
+		{Name: "provider", Value: m.Provider},
+		{Name: "kubernetes_version", Value: m.KubernetesVersion},
+		{Name: "cni", Value: m.CNI},
+		{Name: "rancher_version", Value: m.RancherVersion},
+	} {
+		if property.Value != "" {
+			properties = append(properties, property)
+		}
+	}
+
+	return properties
+}
+
+func getKubernetesVersion(terraform *config.TerraformConfig, terratest *config.TerratestConfig) string {
+	switch {
+	case terratest.KubernetesVersion != "":
+		return terratest.KubernetesVersion
+	case strings.Contains(terraform.Module, "aks"):
+		return terratest.AKSKubernetesVersion
+	case strings.Contains(terraform.Module, "eks"):
+		return terratest.EKSKubernetesVersion
+	case strings.Contains(terraform.Module, "gke"):
+		return terratest.GKEKubernetesVersion
+	}
+
+	return ""
+}
+
+// moduleProvider is a helper function that maps a module to the provider it provisions on, e.g. aws_rke2_custom and
+// aws_eks_hosted both map to aws. An unknown module maps to an empty provider.
+func moduleProvider(module string) string {
+	for _, provider := range []string{aws.Aws, azure.Azure, google.Google, harvester.Harvester, linode.Linode, vsphere.Vsphere} {
+		if strings.HasPrefix(module, provider+"_") {
+			return provider
+		}
+	}
+
+	return ""
+}
+
+// metadataKey is a helper function that maps a test name to the key its metadata is stored under. The name is first
+// rewritten the way the testing package rewrites subtest names, so a table test name and the name reported by go test
+// map to the same key.
+func metadataKey(testName string) string {
+	var name strings.Builder
+	for _, r := range testName {
+		switch {
+		case unicode.IsSpace(r):
+			name.WriteRune('_')
+		case !strconv.IsPrint(r):
+			name.WriteString(strings.Trim(strconv.QuoteRune(r), "'"))
+		default:
+			name.WriteRune(r)
+		}
+	}
+
+	return unsafeFileChars.ReplaceAllString(name.String(), "-")
+}
+
+// packageKey is a helper function that maps a package import path to the directory its metadata is stored in.
+func packageKey(pkg string) string {
+	return unsafeFileChars.ReplaceAllString(pkg, "-")
+}
+
+// callerPackage is a helper function that returns the import path of the package calling UpdateCaseMetadata, as
+// reported by go test. External test packages are mapped to the package they test.
+func callerPackage() string {
+	pcs := make([]uintptr, 1)
+	if runtime.Callers(3, pcs) == 0 {
+		return ""
+	}
+
+	frame, _ := runtime.CallersFrames(pcs).Next()
+
+	name := frame.Function
+	lastSlash := strings.LastIndex(name, "/")
+	dot := strings.Index(name[lastSlash+1:], ".")
+	if dot >= 0 {
+		name = name[:lastSlash+1+dot]
+	}
+
+	return strings.TrimSuffix(name, "_test")
+}
+
+// runID is a helper function that returns the identifier of the current run the metadata directory is namespaced by.
+func runID() string {
+	id := os.Getenv(RunIDEnvVar)
+	if id != "" {
+		return id
+	}
+
+	id = os.Getenv(githubRunIDEnvVar)
+	if id == "" {
+		return localRunID
+	}
+
+	attempt := os.Getenv(githubRunAttemptEnvVar)
+	if attempt != "" {
+		id += "-" + attempt
+	}
+
+	return id
+}
+
+// metadataFileName is a helper function that maps a test name to its metadata file name.
+func metadataFileName(testName string) string {
+	return metadataKey(testName) + metadataExtension
+}
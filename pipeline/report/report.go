@@ -0,0 +1,206 @@
+package report
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// SchemaVersion is bumped whenever a field of the JSON report is renamed or removed. New optional fields do not
+	// change the version.
+	SchemaVersion = 1
+
+	StatusPassed  = "passed"
+	StatusFailed  = "failed"
+	StatusSkipped = "skipped"
+
+	JSONFileName  = "results.json"
+	JUnitFileName = "junit.xml"
+)
+
+// Report is the stable JSON form of a test run, independent of any test management tool.
+type Report struct {
+	SchemaVersion   int     `json:"schema_version"`
+	GeneratedAt     string  `json:"generated_at"`
+	Tests           int     `json:"tests"`
+	Failures        int     `json:"failures"`
+	Skipped         int     `json:"skipped"`
+	DurationSeconds float64 `json:"duration_seconds"`
+	Suites          []Suite `json:"suites"`
+}
+
+type Suite struct {
+	Name            string  `json:"name"`
+	Package         string  `json:"package"`
+	Tests           int     `json:"tests"`
+	Failures        int     `json:"failures"`
+	Skipped         int     `json:"skipped"`
+	DurationSeconds float64 `json:"duration_seconds"`
+	Timestamp       string  `json:"timestamp,omitempty"`
+	Cases           []Case  `json:"cases"`
+}
+
+type Case struct {
+	Name            string  `json:"name"`
+	FullName        string  `json:"full_name"`
+	Status          string  `json:"status"`
+	DurationSeconds float64 `json:"duration_seconds"`
+	FailureOutput   string  `json:"failure_output,omitempty"`
+	Metadata
+}
+
+// NewReport builds a report from the given suites, computing the totals of every suite and of the run.
+func NewReport(suites []Suite) *Report {
+	report := &Report{
+		SchemaVersion: SchemaVersion,
+		GeneratedAt:   time.Now().UTC().Format(time.RFC3339),
+		Suites:        suites,
+	}
+
+	for i := range report.Suites {
+		suite := &report.Suites[i]
+		suite.Tests, suite.Failures, suite.Skipped = 0, 0, 0
+
+		for _, testCase := range suite.Cases {
+			suite.Tests++
+
+			switch testCase.Status {
+			case StatusFailed:
+				suite.Failures++
+			case StatusSkipped:
+				suite.Skipped++
+			}
+		}
+
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Skipped += suite.Skipped
+		report.DurationSeconds += suite.DurationSeconds
+	}
+
+	return report
+}
+
+// Write stores the report in the given directory as both JSON and JUnit XML.
+func (r *Report) Write(dir string) error {
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(filepath.Join(dir, JSONFileName), data, 0o644)
+	if err != nil {
+		return err
+	}
+
+	data, err = xml.MarshalIndent(r.junit(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, JUnitFileName), append([]byte(xml.Header), data...), 0o644)
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name       string           `xml:"name,attr"`
+	Classname  string           `xml:"classname,attr"`
+	Time       string           `xml:"time,attr"`
+	Properties *junitProperties `xml:"properties,omitempty"`
+	Failure    *junitMessage    `xml:"failure,omitempty"`
+	Skipped    *junitMessage    `xml:"skipped,omitempty"`
+}
+
+type junitProperties struct {
+	Properties []junitProperty `xml:"property"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitMessage struct {
+	Message  string `xml:"message,attr"`
+	Contents string `xml:",chardata"`
+}
+
+// junit converts the report to the JUnit XML layout understood by Jenkins and most CI systems. Case metadata is
+// stored as testcase properties.
+func (r *Report) junit() junitTestSuites {
+	suites := junitTestSuites{
+		Tests:    r.Tests,
+		Failures: r.Failures,
+		Skipped:  r.Skipped,
+		Time:     formatSeconds(r.DurationSeconds),
+	}
+
+	for _, suite := range r.Suites {
+		junitSuite := junitTestSuite{
+			Name:      suite.Name,
+			Tests:     suite.Tests,
+			Failures:  suite.Failures,
+			Skipped:   suite.Skipped,
+			Time:      formatSeconds(suite.DurationSeconds),
+			Timestamp: suite.Timestamp,
+		}
+
+		for _, testCase := range suite.Cases {
+			junitCase := junitTestCase{
+				Name:      testCase.FullName,
+				Classname: suite.Package,
+				Time:      formatSeconds(testCase.DurationSeconds),
+			}
+
+			properties := testCase.Metadata.properties()
+			if len(properties) > 0 {
+				junitCase.Properties = &junitProperties{Properties: properties}
+			}
+
+			switch testCase.Status {
+			case StatusFailed:
+				junitCase.Failure = &junitMessage{Message: "Failed", Contents: testCase.FailureOutput}
+			case StatusSkipped:
+				junitCase.Skipped = &junitMessage{Message: "Skipped"}
+			}
+
+			junitSuite.Cases = append(junitSuite.Cases, junitCase)
+		}
+
+		suites.Suites = append(suites.Suites, junitSuite)
+	}
+
+	return suites
+}
+
+// formatSeconds is a helper function that formats a duration in seconds the way JUnit expects it.
+func formatSeconds(seconds float64) string {
+	return fmt.Sprintf("%.3f", seconds)
+}
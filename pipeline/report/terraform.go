@@ -0,0 +1,59 @@
+package report
+
+import (
+	"strings"
+)
+
+const (
+	failLinePrefix = "--- FAIL"
+
+	// unmatchedTailLines is the number of trailing lines kept from Terraform output holding no error-relevant line.
+	unmatchedTailLines = 20
+)
+
+// FilterTerraformOutput retains only the error-relevant lines of Terraform output. When none of its lines match, the
+// last unmatchedTailLines lines are kept instead, as Terraform reports the failure at the end of its output.
+func FilterTerraformOutput(output string) string {
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+
+	var filtered []string
+	for _, line := range lines {
+		lower := strings.ToLower(line)
+		if strings.Contains(lower, "error") || strings.Contains(lower, "failed") {
+			filtered = append(filtered, line)
+		}
+	}
+
+	if len(filtered) == 0 {
+		return strings.Join(lines[max(len(lines)-unmatchedTailLines, 0):], "\n")
+	}
+
+	return strings.Join(filtered, "\n")
+}
+
+// ErrorExcerpt returns the error-relevant lines of the failed cases of the report, as filtered by FilterTerraformOutput,
+// capped at the given number of lines. The go test summary lines are dropped, since they carry no error detail.
+func (r *Report) ErrorExcerpt(maxLines int) string {
+	var excerpt []string
+	for _, suite := range r.Suites {
+		for _, testCase := range suite.Cases {
+			if testCase.Status != StatusFailed {
+				continue
+			}
+
+			for _, line := range strings.Split(FilterTerraformOutput(testCase.FailureOutput), "\n") {
+				line = strings.TrimRight(line, " \t")
+				if line == "" || strings.Contains(line, failLinePrefix) {
+					continue
+				}
+
+				excerpt = append(excerpt, line)
+				if len(excerpt) == maxLines {
+					return strings.Join(excerpt, "\n")
+				}
+			}
+		}
+	}
+
+	return strings.Join(excerpt, "\n")
+}
@@ -0,0 +1,9 @@
+#!/bin/bash
+set -e
+
+OS=$(uname | tr '[:upper:]' '[:lower:]')
+cd $(dirname $0)/../../
+
+echo "Building results exporter binary"
+env GOOS=${OS} GOARCH=amd64 CGO_ENABLED=0 go build -buildvcs=false -o exporter ./pipeline/report/exporter
+./exporter -input results.json -output tfp-results
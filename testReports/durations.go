@@ -0,0 +1,212 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/go-echarts/go-echarts/v2/charts"
+	"github.com/go-echarts/go-echarts/v2/opts"
+	"github.com/rancher/tfp-automation/framework/timing"
+	"github.com/sirupsen/logrus"
+)
+
+const durationsPath = "testReports/assets/durations.html"
+
+type phaseSummary struct {
+	Phase  string
+	Median string
+}
+
+type durationSummary struct {
+	Workflow   string
+	Job        string
+	Runs       int
+	Median     string
+	Slowest    string
+	MedianCost string
+	Instances  string
+	Phases     []phaseSummary
+}
+
+// summarizeDurations computes the median duration, cost and per-phase timings of successful runs for every
+// workflow/job pair that recorded timing data.
+func summarizeDurations(items []datedResult) []durationSummary {
+	byJob := make(map[string][]datedResult)
+	for _, item := range items {
+		if item.Status != statusSuccess || item.DurationSeconds <= 0 {
+			continue
+		}
+
+		key := jobKey(item.Workflow, item.Job)
+		byJob[key] = append(byJob[key], item)
+	}
+
+	summaries := make([]durationSummary, 0, len(byJob))
+	for _, runs := range byJob {
+		var durations, costs []float64
+		phaseDurations := make(map[string][]float64)
+		var phaseOrder []string
+
+		for _, run := range runs {
+			durations = append(durations, run.DurationSeconds)
+			if run.EstimatedCost > 0 {
+				costs = append(costs, run.EstimatedCost)
+			}
+
+			for _, phase := range run.Phases {
+				name := phase.Name
+				if phase.Stage != "" {
+					name = fmt.Sprintf("%s (%s)", phase.Name, phase.Stage)
+				}
+
+				if _, ok := phaseDurations[name]; !ok {
+					phaseOrder = append(phaseOrder, name)
+				}
+
+				phaseDurations[name] = append(phaseDurations[name], phase.DurationSeconds)
+			}
+		}
+
+		summary := durationSummary{
+			Workflow:  runs[0].Workflow,
+			Job:       runs[0].Job,
+			Runs:      len(runs),
+			Median:    formatSeconds(timing.Median(durations)),
+			Slowest:   formatSeconds(maxValue(durations)),
+			Instances: describeInstances(runs[len(runs)-1].Instances),
+		}
+
+		if len(costs) > 0 {
+			summary.MedianCost = fmt.Sprintf("$%.2f", timing.Median(costs))
+		}
+
+		for _, name := range phaseOrder {
+			summary.Phases = append(summary.Phases, phaseSummary{Phase: name, Median: formatSeconds(timing.Median(phaseDurations[name]))})
+		}
+
+		summaries = append(summaries, summary)
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		return jobKey(summaries[i].Workflow, summaries[i].Job) < jobKey(summaries[j].Workflow, summaries[j].Job)
+	})
+
+	return summaries
+}
+
+// renderDurations renders the duration and cost table that precedes the per-workflow charts.
+func renderDurations(w io.Writer, summaries []durationSummary) {
+	if len(summaries) == 0 {
+		return
+	}
+
+	durationsTmpl, err := template.ParseFiles(durationsPath)
+	if err != nil {
+		logrus.Error("Error reading durations.html:", err)
+		return
+	}
+
+	err = durationsTmpl.Execute(w, map[string]any{"Summaries": summaries})
+	if err != nil {
+		logrus.Error("Error rendering durations.html:", err)
+	}
+}
+
+// renderDurationTrendChart renders the daily median duration of each job in a workflow, when timing data is available.
+func renderDurationTrendChart(w io.Writer, trs []datedResult) {
+	dateSet := make(map[string]struct{})
+	jobByDate := make(map[string]map[string][]float64)
+
+	for _, r := range trs {
+		if r.DurationSeconds <= 0 {
+			continue
+		}
+
+		day := r.ObservedAt.UTC().Format("2006-01-02")
+		dateSet[day] = struct{}{}
+
+		if _, ok := jobByDate[r.Job]; !ok {
+			jobByDate[r.Job] = make(map[string][]float64)
+		}
+
+		jobByDate[r.Job][day] = append(jobByDate[r.Job][day], r.DurationSeconds/60)
+	}
+
+	if len(dateSet) == 0 {
+		return
+	}
+
+	dates := make([]string, 0, len(dateSet))
+	for date := range dateSet {
+		dates = append(dates, date)
+	}
+
+	sort.Strings(dates)
+
+	jobs := make([]string, 0, len(jobByDate))
+	for job := range jobByDate {
+		jobs = append(jobs, job)
+	}
+
+	sort.Strings(jobs)
+
+	line := charts.NewLine()
+	line.SetGlobalOptions(
+		charts.WithTooltipOpts(opts.Tooltip{Show: opts.Bool(true)}),
+		charts.WithXAxisOpts(opts.XAxis{Type: "category", Data: dates, Name: "Date"}),
+		charts.WithYAxisOpts(opts.YAxis{Type: "value", Name: "Duration (minutes)", Min: 0}),
+		charts.WithLegendOpts(opts.Legend{Show: opts.Bool(true)}),
+		charts.WithInitializationOpts(opts.Initialization{Width: "1200px", Height: "300px"}),
+	)
+
+	for _, job := range jobs {
+		series := make([]opts.LineData, 0, len(dates))
+		for _, date := range dates {
+			values := jobByDate[job][date]
+			if len(values) == 0 {
+				series = append(series, opts.LineData{Value: nil})
+				continue
+			}
+
+			series = append(series, opts.LineData{Value: timing.Median(values)})
+		}
+
+		line.AddSeries(job+" • duration", series, charts.WithLineChartOpts(opts.LineChart{Smooth: opts.Bool(true)}))
+	}
+
+	line.Render(w)
+}
+
+// describeInstances is a helper function that formats the instances of a run, e.g. "3x aws t3.xlarge".
+func describeInstances(instances []Instances) string {
+	parts := make([]string, 0, len(instances))
+	for _, instance := range instances {
+		parts = append(parts, strings.TrimSpace(fmt.Sprintf("%dx %s %s", instance.Count, instance.Provider, instance.InstanceType)))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// formatSeconds is a helper function that formats a number of seconds as minutes for the report tables.
+func formatSeconds(seconds float64) string {
+	if seconds < 60 {
+		return fmt.Sprintf("%.0fs", seconds)
+	}
+
+	return fmt.Sprintf("%.1f min", seconds/60)
+}
+
+// maxValue is a helper function that returns the largest of the given values.
+func maxValue(values []float64) float64 {
+	var largest float64
+	for _, value := range values {
+		if value > largest {
+			largest = value
+		}
+	}
+
+	return largest
+}
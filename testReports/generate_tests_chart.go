@@ -32,11 +32,29 @@ const (
 )
 
 type TestResult struct {
-	Date      string `json:"date"`
-	Status    string `json:"status"`
-	Workflow  string `json:"workflow"`
-	Job       string `json:"job"`
-	Timestamp string `json:"timestamp,omitempty"`
+	Date            string        `json:"date"`
+	Status          string        `json:"status"`
+	Workflow        string        `json:"workflow"`
+	Job             string        `json:"job"`
+	Timestamp       string        `json:"timestamp,omitempty"`
+	Error           string        `json:"error,omitempty"`
+	DurationSeconds float64       `json:"duration_seconds,omitempty"`
+	EstimatedCost   float64       `json:"estimated_cost,omitempty"`
+	Phases          []PhaseTiming `json:"phases,omitempty"`
+	Instances       []Instances   `json:"instances,omitempty"`
+}
+
+type PhaseTiming struct {
+	Name            string  `json:"name"`
+	Stage           string  `json:"stage,omitempty"`
+	DurationSeconds float64 `json:"duration_seconds"`
+	Failed          bool    `json:"failed,omitempty"`
+}
+
+type Instances struct {
+	Provider     string `json:"provider"`
+	InstanceType string `json:"instance_type,omitempty"`
+	Count        int    `json:"count"`
 }
 
 type datedResult struct {
@@ -54,12 +72,17 @@ func main() {
 	results := loadTestResults(resultsDir)
 	results = dedupeResults(results)
 	results = pruneStaleHistoryResults(results)
-	results = applyRetention(results, resolveRetentionDays())
+	results = applyRetention(results, resolveEnvInt("HISTORICAL_RETENTION_DAYS", defaultRetentionDay))
 	writeHistory(results, historyOutputPath)
 	workflowMap := groupByWorkflow(results)
 
 	var chartsHTML strings.Builder
 
+	flakyWindow := resolveEnvInt("FLAKY_WINDOW_RUNS", defaultFlakyWindow)
+	flakyMinFlips := resolveEnvInt("FLAKY_MIN_FLIPS", defaultFlakyMinFlips)
+	renderInsights(&chartsHTML, analyzeJobs(results, flakyWindow, flakyMinFlips), clusterFailures(results), flakyWindow)
+	renderDurations(&chartsHTML, summarizeDurations(results))
+
 	workflowNames := make([]string, 0, len(workflowMap))
 	for workflow := range workflowMap {
 		workflowNames = append(workflowNames, workflow)
@@ -268,18 +291,19 @@ func dedupeResults(items []datedResult) []datedResult {
 	return out
 }
 
-func resolveRetentionDays() int {
-	value := strings.TrimSpace(os.Getenv("HISTORICAL_RETENTION_DAYS"))
+// resolveEnvInt reads a positive integer from the given environment variable, falling back to the default value.
+func resolveEnvInt(name string, defaultValue int) int {
+	value := strings.TrimSpace(os.Getenv(name))
 	if value == "" {
-		return defaultRetentionDay
+		return defaultValue
 	}
 
-	days, err := strconv.Atoi(value)
-	if err != nil || days <= 0 {
-		return defaultRetentionDay
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed <= 0 {
+		return defaultValue
 	}
 
-	return days
+	return parsed
 }
 
 func applyRetention(items []datedResult, retentionDays int) []datedResult {
@@ -453,6 +477,7 @@ func renderPassRateTrendChart(w io.Writer, workflow string, trs []datedResult) {
 	})
 
 	line.Render(w)
+	renderDurationTrendChart(w, trs)
 	_, _ = w.Write([]byte("</div></div>"))
 }
 
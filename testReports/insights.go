@@ -0,0 +1,285 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	insightsPath = "testReports/assets/insights.html"
+
+	statusSuccess = "success"
+	statusFailure = "failure"
+
+	defaultFlakyWindow   = 10
+	defaultFlakyMinFlips = 3
+	maxSignatureLength   = 160
+	maxExampleLength     = 600
+)
+
+var (
+	terraformBoxChars = regexp.MustCompile(`^[\s│╷╵]+`)
+	uuidPattern       = regexp.MustCompile(`[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`)
+	timestampPattern  = regexp.MustCompile(`\d{4}-\d{2}-\d{2}[t ]\d{2}:\d{2}:\d{2}(\.\d+)?(z|[+-]\d{2}:?\d{2})?`)
+	ipv4Pattern       = regexp.MustCompile(`\b\d{1,3}(\.\d{1,3}){3}(:\d+)?\b`)
+	hexPattern        = regexp.MustCompile(`\b(0x)?[0-9a-f]{12,}\b`)
+	quotedPattern     = regexp.MustCompile(`"[^"]*"|'[^']*'`)
+	numberPattern     = regexp.MustCompile(`\d+`)
+	whitespacePattern = regexp.MustCompile(`\s+`)
+)
+
+type jobInsight struct {
+	Workflow          string
+	Job               string
+	Runs              int
+	Flips             int
+	Flaky             bool
+	CurrentStatus     string
+	CurrentStreak     int
+	LongestFailStreak int
+	Fixes             int
+	MeanTimeToFix     string
+	FailingSince      string
+}
+
+type failureCluster struct {
+	Signature string
+	Count     int
+	Jobs      []string
+	LastSeen  string
+	Example   string
+}
+
+// analyzeJobs computes flakiness, streaks and time-to-fix for every workflow/job pair. A job is flagged as flaky when
+// its pass/fail status flips at least minFlips times within its most recent window runs.
+func analyzeJobs(items []datedResult, window, minFlips int) []jobInsight {
+	byJob := make(map[string][]datedResult)
+	for _, item := range items {
+		if item.Status != statusSuccess && item.Status != statusFailure {
+			continue
+		}
+
+		key := jobKey(item.Workflow, item.Job)
+		byJob[key] = append(byJob[key], item)
+	}
+
+	insights := make([]jobInsight, 0, len(byJob))
+	for _, runs := range byJob {
+		sort.Slice(runs, func(i, j int) bool {
+			return runs[i].ObservedAt.Before(runs[j].ObservedAt)
+		})
+
+		insight := jobInsight{
+			Workflow: runs[0].Workflow,
+			Job:      runs[0].Job,
+			Runs:     len(runs),
+		}
+
+		recent := runs
+		if len(recent) > window {
+			recent = recent[len(recent)-window:]
+		}
+
+		for i := 1; i < len(recent); i++ {
+			if recent[i].Status != recent[i-1].Status {
+				insight.Flips++
+			}
+		}
+
+		insight.Flaky = insight.Flips >= minFlips
+
+		var failStart time.Time
+		var failStreak int
+		var totalToFix time.Duration
+
+		for _, run := range runs {
+			if run.Status == statusFailure {
+				if failStreak == 0 {
+					failStart = run.ObservedAt
+				}
+
+				failStreak++
+				if failStreak > insight.LongestFailStreak {
+					insight.LongestFailStreak = failStreak
+				}
+
+				continue
+			}
+
+			if failStreak > 0 {
+				totalToFix += run.ObservedAt.Sub(failStart)
+				insight.Fixes++
+			}
+
+			failStreak = 0
+		}
+
+		if insight.Fixes > 0 {
+			insight.MeanTimeToFix = formatDuration(totalToFix / time.Duration(insight.Fixes))
+		}
+
+		last := runs[len(runs)-1]
+		insight.CurrentStatus = last.Status
+		for i := len(runs) - 1; i >= 0 && runs[i].Status == last.Status; i-- {
+			insight.CurrentStreak++
+		}
+
+		if failStreak > 0 {
+			insight.FailingSince = failStart.UTC().Format("2006-01-02 15:04")
+		}
+
+		insights = append(insights, insight)
+	}
+
+	sort.Slice(insights, func(i, j int) bool {
+		if insights[i].Flaky != insights[j].Flaky {
+			return insights[i].Flaky
+		}
+
+		if insights[i].Flips != insights[j].Flips {
+			return insights[i].Flips > insights[j].Flips
+		}
+
+		return jobKey(insights[i].Workflow, insights[i].Job) < jobKey(insights[j].Workflow, insights[j].Job)
+	})
+
+	return insights
+}
+
+// clusterFailures groups failed runs that carry an error excerpt by their normalized error signature.
+func clusterFailures(items []datedResult) []failureCluster {
+	clusters := make(map[string]*failureCluster)
+	jobsBySignature := make(map[string]map[string]struct{})
+	lastSeen := make(map[string]time.Time)
+
+	for _, item := range items {
+		if item.Status != statusFailure || strings.TrimSpace(item.Error) == "" {
+			continue
+		}
+
+		signature := normalizeErrorSignature(item.Error)
+		if signature == "" {
+			continue
+		}
+
+		cluster, ok := clusters[signature]
+		if !ok {
+			cluster = &failureCluster{Signature: signature}
+			clusters[signature] = cluster
+			jobsBySignature[signature] = make(map[string]struct{})
+		}
+
+		cluster.Count++
+		jobsBySignature[signature][fmt.Sprintf("%s / %s", item.Workflow, item.Job)] = struct{}{}
+
+		if item.ObservedAt.After(lastSeen[signature]) {
+			lastSeen[signature] = item.ObservedAt
+			cluster.Example = truncate(strings.TrimSpace(item.Error), maxExampleLength)
+		}
+	}
+
+	out := make([]failureCluster, 0, len(clusters))
+	for signature, cluster := range clusters {
+		for job := range jobsBySignature[signature] {
+			cluster.Jobs = append(cluster.Jobs, job)
+		}
+
+		sort.Strings(cluster.Jobs)
+		cluster.LastSeen = lastSeen[signature].UTC().Format("2006-01-02 15:04")
+		out = append(out, *cluster)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+
+		return out[i].Signature < out[j].Signature
+	})
+
+	return out
+}
+
+// normalizeErrorSignature reduces an error excerpt to a stable signature. Terraform "Error:" lines are preferred, and
+// volatile tokens such as IDs, addresses, timestamps, quoted resource names and numbers are replaced with placeholders.
+func normalizeErrorSignature(message string) string {
+	var candidate string
+	for _, line := range strings.Split(message, "\n") {
+		line = strings.TrimSpace(terraformBoxChars.ReplaceAllString(line, ""))
+		if line == "" {
+			continue
+		}
+
+		if candidate == "" {
+			candidate = line
+		}
+
+		if strings.HasPrefix(strings.ToLower(line), "error:") {
+			candidate = line
+			break
+		}
+	}
+
+	signature := strings.ToLower(candidate)
+	signature = timestampPattern.ReplaceAllString(signature, "<time>")
+	signature = uuidPattern.ReplaceAllString(signature, "<uuid>")
+	signature = ipv4Pattern.ReplaceAllString(signature, "<ip>")
+	signature = hexPattern.ReplaceAllString(signature, "<hex>")
+	signature = quotedPattern.ReplaceAllString(signature, "<str>")
+	signature = numberPattern.ReplaceAllString(signature, "<n>")
+	signature = whitespacePattern.ReplaceAllString(signature, " ")
+
+	return truncate(strings.TrimSpace(signature), maxSignatureLength)
+}
+
+// renderInsights renders the flaky job and failure cluster tables that precede the per-workflow charts.
+func renderInsights(w io.Writer, insights []jobInsight, clusters []failureCluster, window int) {
+	insightsTmpl, err := template.ParseFiles(insightsPath)
+	if err != nil {
+		logrus.Error("Error reading insights.html:", err)
+		return
+	}
+
+	err = insightsTmpl.Execute(w, map[string]any{
+		"Jobs":     insights,
+		"Clusters": clusters,
+		"Window":   window,
+	})
+	if err != nil {
+		logrus.Error("Error rendering insights.html:", err)
+	}
+}
+
+// formatDuration is a helper function that formats a duration in days and hours for the report tables.
+func formatDuration(d time.Duration) string {
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+
+	if days > 0 {
+		return fmt.Sprintf("%dd %dh", days, hours)
+	}
+
+	if hours > 0 {
+		return fmt.Sprintf("%dh", hours)
+	}
+
+	return fmt.Sprintf("%dm", int(d.Minutes()))
+}
+
+// truncate is a helper function that shortens a string to the given number of characters, appending an ellipsis when
+// cut. It counts runes rather than bytes so multi-byte characters, such as the Terraform box drawing, are never split.
+func truncate(s string, length int) string {
+	runes := []rune(s)
+	if len(runes) <= length {
+		return s
+	}
+
+	return string(runes[:length]) + "..."
+}
@@ -0,0 +1,120 @@
+package backup
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/rancher/shepherd/clients/rancher"
+	"github.com/rancher/shepherd/pkg/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	backupSteveType      = "resources.cattle.io.backup"
+	filenameField        = "filename"
+	serverVersionSetting = "server-version"
+	statusField          = "status"
+)
+
+// RancherState holds the resources of a Rancher server that are expected to be migrated by a backup and its restore.
+type RancherState struct {
+	Users    []string
+	Clusters []string
+	Tokens   []string
+	Settings map[string]string
+}
+
+// GetRancherState is a function that returns the users, clusters and tokens of the given Rancher server, along with the
+// values of the given settings.
+func GetRancherState(client *rancher.Client, settings ...string) (*RancherState, error) {
+	state := &RancherState{Settings: map[string]string{}}
+
+	users, err := client.Management.User.ListAll(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range users.Data {
+		state.Users = append(state.Users, user.ID)
+	}
+
+	clusters, err := client.Management.Cluster.ListAll(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cluster := range clusters.Data {
+		state.Clusters = append(state.Clusters, cluster.Name)
+	}
+
+	tokens, err := client.Management.Token.ListAll(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, token := range tokens.Data {
+		state.Tokens = append(state.Tokens, token.Name)
+	}
+
+	for _, name := range settings {
+		setting, err := client.Management.Setting.ByID(name)
+		if err != nil {
+			return nil, err
+		}
+
+		state.Settings[name] = setting.Value
+	}
+
+	return state, nil
+}
+
+// BackupFilename is a function that returns the name of the file the given backup was stored as.
+func BackupFilename(client *rancher.Client, backupName string) (string, error) {
+	backupResp, err := client.Steve.SteveType(backupSteveType).ByID(backupName)
+	if err != nil {
+		return "", err
+	}
+
+	status, _ := backupResp.JSONResp[statusField].(map[string]any)
+	filename, _ := status[filenameField].(string)
+	if filename == "" {
+		return "", fmt.Errorf("the %s backup has no file", backupName)
+	}
+
+	return filename, nil
+}
+
+// VerifyRancherRestore validates that the users, clusters, tokens and settings of the backed up Rancher server were all
+// migrated to the restored one.
+func VerifyRancherRestore(t *testing.T, backedUp, restored *RancherState) {
+	for _, user := range backedUp.Users {
+		assert.True(t, slices.Contains(restored.Users, user), "user %s was not restored", user)
+	}
+
+	for _, cluster := range backedUp.Clusters {
+		assert.True(t, slices.Contains(restored.Clusters, cluster), "cluster %s was not restored", cluster)
+	}
+
+	for _, token := range backedUp.Tokens {
+		assert.True(t, slices.Contains(restored.Tokens, token), "token %s was not restored", token)
+	}
+
+	for name, value := range backedUp.Settings {
+		assert.Equal(t, value, restored.Settings[name], "setting %s was not restored", name)
+	}
+}
+
+// VerifyRestoredToken validates that the given token, created on the backed up Rancher server, still authenticates
+// against the restored one.
+func VerifyRestoredToken(t *testing.T, rancherConfig *rancher.Config, testSession *session.Session, token string) {
+	restoredConfig := *rancherConfig
+	restoredConfig.AdminToken = token
+
+	client, err := rancher.NewClientForConfig(token, &restoredConfig, testSession)
+	require.NoError(t, err)
+
+	_, err = client.Management.Setting.ByID(serverVersionSetting)
+	require.NoError(t, err, "the token was not accepted by the restored Rancher server")
+}
@@ -0,0 +1,125 @@
+package permutationsdata
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/rancher/shepherd/pkg/config/operations"
+	"github.com/rancher/shepherd/pkg/config/operations/permutations"
+	"github.com/rancher/tfp-automation/config"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	permutationsKey = "permutations"
+)
+
+// CreatePermutations creates a permutation for each entry of the terratest permutations field, permuting any field of
+// the config given its dotted YAML path, e.g. terratest.psact. The rules of an entry permute further fields only when
+// the entry is set to the given value, e.g. AMIs only for the ec2 modules. It returns no permutations when the field
+// is not set.
+func CreatePermutations(cattleConfig map[string]any) ([]permutations.Permutation, error) {
+	specs, err := loadPermutationSpecs(cattleConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return createPermutations(specs)
+}
+
+// PermutationKeyPaths returns the dotted YAML paths permuted by the terratest permutations field, rules included, so
+// they can be covered by a reduction.
+func PermutationKeyPaths(cattleConfig map[string]any) ([]string, error) {
+	specs, err := loadPermutationSpecs(cattleConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return specKeyPaths(specs), nil
+}
+
+// loadPermutationSpecs is a helper function that loads the terratest permutations field of the given config, if set.
+func loadPermutationSpecs(cattleConfig map[string]any) ([]config.PermutationSpec, error) {
+	specsKeyValue, err := operations.GetValue([]string{config.TerratestConfigurationFileKey, permutationsKey}, cattleConfig)
+	if err != nil {
+		return nil, nil
+	}
+
+	specsYAML, err := yaml.Marshal(specsKeyValue)
+	if err != nil {
+		return nil, err
+	}
+
+	var specs []config.PermutationSpec
+	err = yaml.Unmarshal(specsYAML, &specs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", permutationsKey, err)
+	}
+
+	return specs, nil
+}
+
+// createPermutations is a helper function that creates the permutations of the given specs, along with the
+// relationships of their rules.
+func createPermutations(specs []config.PermutationSpec) ([]permutations.Permutation, error) {
+	var specPermutations []permutations.Permutation
+	for _, spec := range specs {
+		if spec.Path == "" {
+			return nil, fmt.Errorf("a permutation must set the path of the permuted field")
+		}
+
+		if len(spec.Values) == 0 {
+			return nil, fmt.Errorf("the permutation of %s must set at least one value", spec.Path)
+		}
+
+		var relationships []permutations.Relationship
+		for _, rule := range spec.Rules {
+			if !containsValue(spec.Values, rule.When) {
+				return nil, fmt.Errorf("the rule of %s applies when it is %v, which is not one of its values", spec.Path, rule.When)
+			}
+
+			rulePermutations, err := createPermutations(rule.Then)
+			if err != nil {
+				return nil, err
+			}
+
+			relationships = append(relationships, permutations.CreateRelationship(rule.When, nil, nil, rulePermutations))
+		}
+
+		specPermutations = append(specPermutations, permutations.CreatePermutation(strings.Split(spec.Path, "."), spec.Values, relationships))
+	}
+
+	return specPermutations, nil
+}
+
+// specKeyPaths is a helper function that returns the paths of the given specs and of their rules, without duplicates.
+func specKeyPaths(specs []config.PermutationSpec) []string {
+	var keyPaths []string
+	for _, spec := range specs {
+		paths := []string{spec.Path}
+		for _, rule := range spec.Rules {
+			paths = append(paths, specKeyPaths(rule.Then)...)
+		}
+
+		for _, path := range paths {
+			if !slices.Contains(keyPaths, path) {
+				keyPaths = append(keyPaths, path)
+			}
+		}
+	}
+
+	return keyPaths
+}
+
+// containsValue is a helper function that reports whether the given values hold the given value, compared by their
+// printed form so that scalars decoded as different types still match.
+func containsValue(values []any, value any) bool {
+	for _, candidate := range values {
+		if fmt.Sprintf("%v", candidate) == fmt.Sprintf("%v", value) {
+			return true
+		}
+	}
+
+	return false
+}
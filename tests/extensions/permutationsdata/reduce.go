@@ -0,0 +1,203 @@
+package permutationsdata
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+
+	"github.com/rancher/shepherd/pkg/config/operations"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	PairwiseReduction = "pairwise"
+	NWiseReduction    = "nwise"
+)
+
+// reducedKeyPaths are the key paths of the permuted configs whose combinations are covered by a reduction.
+var reducedKeyPaths = [][]string{
+	{config.TerraformConfigurationFileKey, moduleKey},
+	{config.TerraformConfigurationFileKey, cniKey},
+	{config.TerratestConfigurationFileKey, k8sVersionKey},
+	{config.TerraformConfigurationFileKey, awsConfigKey, amiKey},
+	{config.TerratestConfigurationFileKey, nodepoolsKey},
+}
+
+// ReducePermutations reduces the given permuted configs to a subset covering every combination of values of any
+// strength of the module, CNI, Kubernetes version, AMI and node pools fields, e.g. every pair of values for a pairwise
+// reduction, instead of running the full product. Configs covering the most uncovered combinations are picked first,
+// with ties broken in an order derived from the seed, so the same seed always picks the same configs. The chosen
+// matrix is logged up front. The permuted configs are returned as is when no reduction mode is set. The given dotted
+// YAML paths, e.g. the ones of the terratest permutations field, are covered as well.
+func ReducePermutations(permutedConfigs []map[string]any, reduction *config.PermutationReduction, extraKeyPaths ...string) ([]map[string]any, error) {
+	if reduction == nil || reduction.Mode == "" {
+		return permutedConfigs, nil
+	}
+
+	strength, err := reductionStrength(reduction)
+	if err != nil {
+		return nil, err
+	}
+
+	keyPaths, configValues := permutedValues(permutedConfigs, extraKeyPaths)
+	if strength >= len(keyPaths) {
+		logrus.Infof("Running all %d permutations, as only %d fields vary (%s, strength %d):", len(permutedConfigs), len(keyPaths), reduction.Mode, strength)
+		logMatrix(keyPaths, configValues)
+
+		return permutedConfigs, nil
+	}
+
+	uncovered := map[string]bool{}
+	configTuples := make([][]string, len(permutedConfigs))
+	for i, values := range configValues {
+		for _, combination := range combinations(len(keyPaths), strength) {
+			tuple := []string{}
+			for _, index := range combination {
+				tuple = append(tuple, fmt.Sprintf("%d=%s", index, values[index]))
+			}
+
+			tupleKey := strings.Join(tuple, "\x00")
+			configTuples[i] = append(configTuples[i], tupleKey)
+			uncovered[tupleKey] = true
+		}
+	}
+
+	order := rand.New(rand.NewPCG(uint64(reduction.Seed), 0)).Perm(len(permutedConfigs))
+	picked := make([]bool, len(permutedConfigs))
+
+	var reducedConfigs []map[string]any
+	var reducedValues [][]string
+	for len(uncovered) > 0 {
+		best, bestCount := -1, 0
+		for _, i := range order {
+			if picked[i] {
+				continue
+			}
+
+			count := 0
+			for _, tuple := range configTuples[i] {
+				if uncovered[tuple] {
+					count++
+				}
+			}
+
+			if count > bestCount {
+				best, bestCount = i, count
+			}
+		}
+
+		picked[best] = true
+		for _, tuple := range configTuples[best] {
+			delete(uncovered, tuple)
+		}
+
+		reducedConfigs = append(reducedConfigs, permutedConfigs[best])
+		reducedValues = append(reducedValues, configValues[best])
+	}
+
+	logrus.Infof("Reduced %d permutations to %d (%s, strength %d, seed %d):", len(permutedConfigs), len(reducedConfigs), reduction.Mode, strength, reduction.Seed)
+	logMatrix(keyPaths, reducedValues)
+
+	return reducedConfigs, nil
+}
+
+// reductionStrength is a helper function that returns the number of fields whose combinations of values are covered by
+// the given reduction.
+func reductionStrength(reduction *config.PermutationReduction) (int, error) {
+	switch reduction.Mode {
+	case PairwiseReduction:
+		return 2, nil
+	case NWiseReduction:
+		if reduction.Strength < 1 {
+			return 0, fmt.Errorf("the strength of a %s reduction must be at least 1, got %d", NWiseReduction, reduction.Strength)
+		}
+
+		return reduction.Strength, nil
+	default:
+		return 0, fmt.Errorf("unsupported permutation reduction mode %s, expected %s or %s", reduction.Mode, PairwiseReduction, NWiseReduction)
+	}
+}
+
+// permutedValues is a helper function that returns the reduced key paths, along with the given extra ones, holding more
+// than one value across the given permuted configs, along with the values of each config for those key paths. Missing
+// values are kept as empty ones, as relationships only set some fields for some modules.
+func permutedValues(permutedConfigs []map[string]any, extraKeyPaths []string) ([]string, [][]string) {
+	candidateKeyPaths := slices.Clone(reducedKeyPaths)
+	for _, extraKeyPath := range extraKeyPaths {
+		keyPath := strings.Split(extraKeyPath, ".")
+		if !slices.ContainsFunc(candidateKeyPaths, func(candidate []string) bool { return slices.Equal(candidate, keyPath) }) {
+			candidateKeyPaths = append(candidateKeyPaths, keyPath)
+		}
+	}
+
+	allValues := make([][]string, len(permutedConfigs))
+	for i, permutedConfig := range permutedConfigs {
+		for _, keyPath := range candidateKeyPaths {
+			value, err := operations.GetValue(keyPath, permutedConfig)
+			if err != nil || value == nil {
+				allValues[i] = append(allValues[i], "")
+				continue
+			}
+
+			allValues[i] = append(allValues[i], fmt.Sprintf("%v", value))
+		}
+	}
+
+	var keyPaths []string
+	var varyingIndexes []int
+	for index, keyPath := range candidateKeyPaths {
+		distinctValues := map[string]bool{}
+		for _, values := range allValues {
+			distinctValues[values[index]] = true
+		}
+
+		if len(distinctValues) > 1 {
+			keyPaths = append(keyPaths, strings.Join(keyPath, "."))
+			varyingIndexes = append(varyingIndexes, index)
+		}
+	}
+
+	configValues := make([][]string, len(permutedConfigs))
+	for i, values := range allValues {
+		for _, index := range varyingIndexes {
+			configValues[i] = append(configValues[i], values[index])
+		}
+	}
+
+	return keyPaths, configValues
+}
+
+// combinations is a helper function that returns every combination of size k of the indexes 0 to n-1, in order.
+func combinations(n, k int) [][]int {
+	if k == 0 {
+		return [][]int{{}}
+	}
+
+	var result [][]int
+	for first := 0; first <= n-k; first++ {
+		for _, rest := range combinations(n-first-1, k-1) {
+			combination := []int{first}
+			for _, index := range rest {
+				combination = append(combination, first+1+index)
+			}
+
+			result = append(result, combination)
+		}
+	}
+
+	return result
+}
+
+// logMatrix is a helper function that logs the values of the given key paths for each chosen config.
+func logMatrix(keyPaths []string, configValues [][]string) {
+	for i, values := range configValues {
+		fields := []string{}
+		for index, keyPath := range keyPaths {
+			fields = append(fields, fmt.Sprintf("%s=%s", keyPath, values[index]))
+		}
+
+		logrus.Infof("  %d: %s", i+1, strings.Join(fields, ", "))
+	}
+}
@@ -1,6 +1,7 @@
 package permutationsdata
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/rancher/shepherd/pkg/config/operations"
@@ -10,7 +11,9 @@ import (
 )
 
 const (
-	k8sVersionKey = "kubernetesVersion"
+	k8sVersionKey      = "kubernetesVersion"
+	nodepoolsKey       = "nodepools"
+	nodepoolLayoutsKey = "nodepoolLayouts"
 )
 
 // CreateK8sRelationships creates a relationship between the terraform module field and the terratest kubernetesVersion
@@ -55,3 +58,23 @@ func CreateK8sRelationships(cattleConfig map[string]any) ([]permutations.Relatio
 
 	return k8sRelationships, nil
 }
+
+// CreateNodepoolsPermutation creates a permutation for the terratest nodepools field out of the terratest nodepoolLayouts
+// field, a list of node pool layouts. It returns nil when no layouts are set, so the nodepools field is used as is.
+func CreateNodepoolsPermutation(cattleConfig map[string]any) (*permutations.Permutation, error) {
+	layoutsKeyPath := []string{config.TerratestConfigurationFileKey, nodepoolLayoutsKey}
+	layoutsKeyValue, err := operations.GetValue(layoutsKeyPath, cattleConfig)
+	if err != nil {
+		return nil, nil
+	}
+
+	layouts, ok := layoutsKeyValue.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a list of node pool layouts", nodepoolLayoutsKey)
+	}
+
+	nodepoolsKeyPath := []string{config.TerratestConfigurationFileKey, nodepoolsKey}
+	nodepoolsPermutation := permutations.CreatePermutation(nodepoolsKeyPath, layouts, nil)
+
+	return &nodepoolsPermutation, nil
+}
@@ -0,0 +1,179 @@
+package provisioning
+
+import (
+	"context"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/norman/types"
+	"github.com/rancher/shepherd/clients/rancher"
+	"github.com/rancher/shepherd/extensions/clusters"
+	"github.com/rancher/shepherd/extensions/defaults"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/tests/extensions/postProvisioning"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	kwait "k8s.io/apimachinery/pkg/util/wait"
+)
+
+// HostedClusterLifecycle is a function that will run the lifecycle steps of a provisioned hosted cluster, rewriting its
+// main.tf file in the nested module for each of them. The control plane is upgraded to the given Kubernetes version first,
+// then the node pools. The given node pool is then added, the first node pool is scaled up and the added node pool is
+// deleted again. Each step waits for Rancher to sync the change and for the cluster to be active again.
+func HostedClusterLifecycle(t *testing.T, client, standardUserClient *rancher.Client, rancherConfig *rancher.Config,
+	terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig, terraformOptions *terraform.Options,
+	newFile *hclwrite.File, rootBody *hclwrite.Body, file *os.File, nestedRancherModuleDir, upgradedVersion string,
+	addedNodePool config.Nodepool) {
+	clusterID, err := clusters.GetClusterIDByName(client, terraformConfig.ResourcePrefix)
+	require.NoError(t, err)
+
+	for _, pool := range slices.Concat(terratestConfig.Nodepools, []config.Nodepool{addedNodePool}) {
+		require.NotEmpty(t, pool.Name, "the node pools of the hosted cluster lifecycle must be named")
+	}
+
+	applyStep := func(step string, synced func(nodePools []config.Nodepool, kubernetesVersion string) bool) {
+		logrus.Infof("%s (%s)", step, terraformConfig.ResourcePrefix)
+		UpdateHostedCluster(t, standardUserClient, rancherConfig, terraformConfig, terratestConfig, terraformOptions, newFile, rootBody,
+			file, nestedRancherModuleDir)
+
+		err := waitForHostedUpstreamSpec(client, clusterID, synced)
+		require.NoError(t, err, "%s was not synced by Rancher", step)
+
+		err = postProvisioning.IsClusterActive(client, clusterID)
+		require.NoError(t, err)
+	}
+
+	// The node pools are pinned to the current version, so only the control plane is upgraded.
+	terratestConfig.Nodepools = slices.Clone(terratestConfig.Nodepools)
+	for i := range terratestConfig.Nodepools {
+		terratestConfig.Nodepools[i].KubernetesVersion = terratestConfig.KubernetesVersion
+	}
+
+	terratestConfig.KubernetesVersion = upgradedVersion
+
+	applyStep("Upgrading the control plane to "+upgradedVersion, func(_ []config.Nodepool, kubernetesVersion string) bool {
+		return versionMatches(kubernetesVersion, upgradedVersion)
+	})
+
+	for i := range terratestConfig.Nodepools {
+		terratestConfig.Nodepools[i].KubernetesVersion = upgradedVersion
+	}
+
+	applyStep("Upgrading the node pools to "+upgradedVersion, func(nodePools []config.Nodepool, _ string) bool {
+		for _, pool := range nodePools {
+			if !versionMatches(pool.KubernetesVersion, upgradedVersion) {
+				return false
+			}
+		}
+
+		return len(nodePools) > 0
+	})
+
+	addedNodePool.KubernetesVersion = upgradedVersion
+	terratestConfig.Nodepools = append(terratestConfig.Nodepools, addedNodePool)
+
+	applyStep("Adding the node pool "+addedNodePool.Name, nodePoolNamesMatch(terratestConfig.Nodepools))
+
+	nodeCount, err := clusterNodeCount(client, clusterID)
+	require.NoError(t, err)
+
+	scaledNodePool := &terratestConfig.Nodepools[0]
+	if scaledNodePool.DesiredSize > 0 {
+		scaledNodePool.DesiredSize++
+		scaledNodePool.MaxSize = max(scaledNodePool.MaxSize, scaledNodePool.DesiredSize)
+	} else {
+		scaledNodePool.Quantity++
+	}
+
+	applyStep("Scaling up the node pool "+scaledNodePool.Name, nodePoolNamesMatch(terratestConfig.Nodepools))
+
+	// The upstream spec of some providers keeps the initial node count, so the scaling is verified through the nodes.
+	err = kwait.PollUntilContextTimeout(context.TODO(), 10*time.Second, defaults.TenMinuteTimeout, true, func(ctx context.Context) (bool, error) {
+		scaledNodeCount, err := clusterNodeCount(client, clusterID)
+		if err != nil {
+			return false, nil
+		}
+
+		return scaledNodeCount > nodeCount, nil
+	})
+	require.NoError(t, err, "the node pool %s was not scaled up", scaledNodePool.Name)
+
+	err = postProvisioning.AreNodesActive(client, clusterID)
+	require.NoError(t, err)
+
+	terratestConfig.Nodepools = terratestConfig.Nodepools[:len(terratestConfig.Nodepools)-1]
+
+	applyStep("Deleting the node pool "+addedNodePool.Name, nodePoolNamesMatch(terratestConfig.Nodepools))
+}
+
+// waitForHostedUpstreamSpec is a helper function that waits for the upstream spec of a hosted cluster, as synced by Rancher
+// from the cloud cluster, to satisfy the given condition.
+func waitForHostedUpstreamSpec(client *rancher.Client, clusterID string, synced func(nodePools []config.Nodepool, kubernetesVersion string) bool) error {
+	return kwait.PollUntilContextTimeout(context.TODO(), 30*time.Second, defaults.ThirtyMinuteTimeout, true, func(ctx context.Context) (bool, error) {
+		cluster, err := client.Management.Cluster.ByID(clusterID)
+		if err != nil {
+			return false, nil
+		}
+
+		nodePools, kubernetesVersion, _ := hostedUpstreamSpec(cluster)
+
+		return synced(nodePools, kubernetesVersion), nil
+	})
+}
+
+// nodePoolNamesMatch is a helper function that returns a condition on the upstream spec of a hosted cluster, holding once
+// its node pools are named after the given node pools.
+func nodePoolNamesMatch(nodePools []config.Nodepool) func([]config.Nodepool, string) bool {
+	expectedNames := []string{}
+	for _, pool := range nodePools {
+		expectedNames = append(expectedNames, pool.Name)
+	}
+
+	slices.Sort(expectedNames)
+
+	return func(syncedPools []config.Nodepool, _ string) bool {
+		syncedNames := []string{}
+		for _, pool := range syncedPools {
+			syncedNames = append(syncedNames, pool.Name)
+		}
+
+		slices.Sort(syncedNames)
+
+		return slices.Equal(syncedNames, expectedNames)
+	}
+}
+
+// versionMatches is a helper function that reports whether the given synced version matches the expected one. The cloud
+// providers may report a more precise version than the requested one, e.g. a patch version for a minor version, so the
+// expected version matches up to a version boundary: 1.31 matches 1.31.2 and 1.31-gke.100, but not 1.310.
+func versionMatches(syncedVersion, expectedVersion string) bool {
+	synced := strings.TrimPrefix(syncedVersion, "v")
+	expected := strings.TrimPrefix(expectedVersion, "v")
+
+	if synced == "" || !strings.HasPrefix(synced, expected) {
+		return false
+	}
+
+	rest := synced[len(expected):]
+
+	return rest == "" || strings.ContainsAny(rest[:1], ".-+")
+}
+
+// clusterNodeCount is a helper function that returns the number of nodes of the given cluster.
+func clusterNodeCount(client *rancher.Client, clusterID string) (int, error) {
+	nodes, err := client.Management.Node.ListAll(&types.ListOpts{
+		Filters: map[string]any{
+			"clusterId": clusterID,
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(nodes.Data), nil
+}
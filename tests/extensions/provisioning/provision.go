@@ -16,6 +16,8 @@ import (
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/providers"
 	framework "github.com/rancher/tfp-automation/framework/set"
+	"github.com/rancher/tfp-automation/framework/timing"
+	"github.com/rancher/tfp-automation/pipeline/report"
 	"github.com/stretchr/testify/require"
 )
 
@@ -40,7 +42,15 @@ func Provision(t *testing.T, client, standardUserClient *rancher.Client, rancher
 		GoogleDriverImport(t, terraformOptions)
 	}
 
-	output, err := terraform.InitAndApplyE(t, terraformOptions)
+	var nodeCount int64
+	for _, nodepool := range terratestConfig.Nodepools {
+		nodeCount += nodepool.Quantity
+	}
+
+	provider := strings.Split(terraformConfig.Module, "_")[0]
+	timing.AddInstances(terraformOptions, provider, timing.InstanceType(provider, terraformConfig), int(nodeCount))
+
+	output, err := timing.InitAndApplyE(t, terraformOptions, "downstream clusters")
 	if err != nil {
 		if output == "" {
 			var fatalErr retry.FatalError
@@ -51,7 +61,7 @@ func Provision(t *testing.T, client, standardUserClient *rancher.Client, rancher
 				}
 			}
 		}
-		require.NoError(t, err, "terraform apply failed. Output:\n%s", filterTerraformOutput(output))
+		require.NoError(t, err, "terraform apply failed. Output:\n%s", report.FilterTerraformOutput(output))
 	}
 
 	var clusterObjects []*steveV1.SteveAPIObject
@@ -64,18 +74,3 @@ func Provision(t *testing.T, client, standardUserClient *rancher.Client, rancher
 
 	return clusterObjects, customClusterName
 }
-
-// filterTerraformOutput retains only error-relevant lines from terraform output,
-func filterTerraformOutput(output string) string {
-	var filtered []string
-	for _, line := range strings.Split(output, "\n") {
-		lower := strings.ToLower(line)
-		if strings.Contains(lower, "error") || strings.Contains(lower, "failed") {
-			filtered = append(filtered, line)
-		}
-	}
-	if len(filtered) == 0 {
-		return output
-	}
-	return strings.Join(filtered, "\n")
-}
@@ -24,6 +24,7 @@ func DownstreamClusterModules(terraformConfig *config.TerraformConfig) (string,
 		k3sModule = modules.NodeDriverAWSK3S
 	case azure.Azure:
 		rke2Module = modules.NodeDriverAzureRKE2
+		rke2Windows = modules.CustomAzureRKE2Windows
 		k3sModule = modules.NodeDriverAzureK3S
 	case google.Google:
 		rke2Module = modules.NodeDriverGoogleRKE2
@@ -36,6 +37,7 @@ func DownstreamClusterModules(terraformConfig *config.TerraformConfig) (string,
 		k3sModule = modules.NodeDriverLinodeK3S
 	case vsphere.Vsphere:
 		rke2Module = modules.NodeDriverVsphereRKE2
+		rke2Windows = modules.CustomVsphereRKE2Windows
 		k3sModule = modules.NodeDriverVsphereK3S
 	default:
 		panic("Unsupported provider: " + terraformConfig.DownstreamClusterProvider)
@@ -52,6 +54,18 @@ func ImportedClusterModules(terraformConfig *config.TerraformConfig) (string, st
 		rke2Module = modules.ImportedAWSRKE2
 		rke2Windows = modules.ImportedAWSRKE2Windows2022
 		k3sModule = modules.ImportedAWSK3S
+	case azure.Azure:
+		rke2Module = modules.ImportedAzureRKE2
+		k3sModule = modules.ImportedAzureK3S
+	case google.Google:
+		rke2Module = modules.ImportedGoogleRKE2
+		k3sModule = modules.ImportedGoogleK3S
+	case harvester.Harvester:
+		rke2Module = modules.ImportedHarvesterRKE2
+		k3sModule = modules.ImportedHarvesterK3S
+	case linode.Linode:
+		rke2Module = modules.ImportedLinodeRKE2
+		k3sModule = modules.ImportedLinodeK3S
 	case vsphere.Vsphere:
 		rke2Module = modules.ImportedVsphereRKE2
 		k3sModule = modules.ImportedVsphereK3S
@@ -101,20 +115,42 @@ func verifyModule(module string) bool {
 		modules.NodeDriverVsphereRKE2,
 		modules.NodeDriverVsphereK3S,
 		modules.CustomAWSRKE2,
+		modules.CustomAWSRKE2Windows,
 		modules.CustomAWSRKE2Windows2019,
 		modules.CustomAWSRKE2Windows2022,
+		modules.CustomAWSRKE2Windows2025,
 		modules.CustomAWSK3S,
 		modules.CustomVsphereRKE2,
+		modules.CustomVsphereRKE2Windows,
 		modules.CustomVsphereK3S,
+		modules.CustomAzureRKE2,
+		modules.CustomAzureRKE2Windows,
+		modules.CustomAzureK3S,
+		modules.CustomGoogleRKE2,
+		modules.CustomGoogleK3S,
+		modules.CustomHarvesterRKE2,
+		modules.CustomHarvesterK3S,
+		modules.CustomLinodeRKE2,
+		modules.CustomLinodeK3S,
 		modules.AirgapAWSRKE2,
 		modules.AirgapAWSRKE2Windows2022,
 		modules.AirgapAWSK3S,
 		modules.ImportedAWSRKE2,
+		modules.ImportedAWSRKE2Windows,
 		modules.ImportedAWSRKE2Windows2019,
 		modules.ImportedAWSRKE2Windows2022,
+		modules.ImportedAWSRKE2Windows2025,
 		modules.ImportedAWSK3S,
 		modules.ImportedVsphereRKE2,
 		modules.ImportedVsphereK3S,
+		modules.ImportedAzureRKE2,
+		modules.ImportedAzureK3S,
+		modules.ImportedGoogleRKE2,
+		modules.ImportedGoogleK3S,
+		modules.ImportedHarvesterRKE2,
+		modules.ImportedHarvesterK3S,
+		modules.ImportedLinodeRKE2,
+		modules.ImportedLinodeK3S,
 	}
 
 	return slices.Contains(supportedModules, module)
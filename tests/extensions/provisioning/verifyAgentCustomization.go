@@ -0,0 +1,370 @@
+package provisioning
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rancher/shepherd/clients/rancher"
+	steveV1 "github.com/rancher/shepherd/clients/rancher/v1"
+	"github.com/rancher/shepherd/extensions/clusters"
+	"github.com/rancher/shepherd/extensions/defaults"
+	"github.com/rancher/shepherd/extensions/defaults/namespaces"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/stevetypes"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	kwait "k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	clusterAgentApp        = "cattle-cluster-agent"
+	cattleSystemNamespace  = "cattle-system"
+	fleetAgentID           = "cattle-fleet-system/fleet-agent"
+	pdbSteveType           = "policy.poddisruptionbudget"
+	priorityClassSteveType = "scheduling.k8s.io.priorityclass"
+	statefulSetSteveType   = "apps.statefulset"
+)
+
+// AgentCustomized reports whether any agent env vars, cluster level cloud credential or agent deployment customization
+// is set in the given config.
+func AgentCustomized(terraformConfig *config.TerraformConfig) bool {
+	return len(terraformConfig.AgentEnvVars) > 0 || terraformConfig.CloudCredentialSecretName != "" ||
+		terraformConfig.ClusterAgentCustomization != nil || terraformConfig.FleetAgentCustomization != nil
+}
+
+// VerifyAgentCustomization validates that the agents of the downstream cluster picked up the settings of the config.
+// The cluster agent must carry the agent env vars and its deployment customization, the fleet agent its deployment
+// customization, and the priority class and pod disruption budget of the cluster agent must match its scheduling
+// customization. The agents are redeployed by Rancher once the cluster is active, so their pod templates are polled.
+func VerifyAgentCustomization(t *testing.T, client *rancher.Client, terraformConfig *config.TerraformConfig) {
+	clusterName := terraformConfig.ResourcePrefix
+
+	if terraformConfig.CloudCredentialSecretName != "" {
+		logrus.Infof("Verifying the cluster cloud credential (%s)", clusterName)
+		cluster, _, err := clusters.GetProvisioningClusterByName(client, clusterName, namespaces.FleetDefault)
+		require.NoError(t, err)
+
+		// Rancher accepts the secret name with or without its namespace prefix, so only the name is compared.
+		secretName := terraformConfig.CloudCredentialSecretName[strings.LastIndex(terraformConfig.CloudCredentialSecretName, ":")+1:]
+		require.True(t, strings.HasSuffix(cluster.Spec.CloudCredentialSecretName, secretName), "cluster cloud credential is %s, expected %s",
+			cluster.Spec.CloudCredentialSecretName, terraformConfig.CloudCredentialSecretName)
+	}
+
+	clusterID, err := clusters.GetClusterIDByName(client, clusterName)
+	require.NoError(t, err)
+
+	steveClient, err := client.Steve.ProxyDownstream(clusterID)
+	require.NoError(t, err)
+
+	clusterAgent := terraformConfig.ClusterAgentCustomization
+	if len(terraformConfig.AgentEnvVars) > 0 || clusterAgent != nil {
+		logrus.Infof("Verifying the cluster agent customization (%s)", clusterName)
+		err = waitForAgentTemplate(steveClient, stevetypes.Deployment, clusterAgentID, func(podSpec *corev1.PodSpec) error {
+			err := verifyAgentEnvVars(podSpec, terraformConfig.AgentEnvVars)
+			if err != nil {
+				return err
+			}
+
+			if clusterAgent == nil {
+				return nil
+			}
+
+			return verifyAgentPodSpec(podSpec, clusterAgent)
+		})
+		require.NoError(t, err)
+	}
+
+	if clusterAgent != nil && clusterAgent.SchedulingCustomization != nil {
+		logrus.Infof("Verifying the cluster agent scheduling customization (%s)", clusterName)
+		err = waitForAgentScheduling(steveClient, clusterAgent.SchedulingCustomization)
+		require.NoError(t, err)
+	}
+
+	if terraformConfig.FleetAgentCustomization != nil {
+		logrus.Infof("Verifying the fleet agent customization (%s)", clusterName)
+		err = waitForAgentTemplate(steveClient, statefulSetSteveType, fleetAgentID, func(podSpec *corev1.PodSpec) error {
+			return verifyAgentPodSpec(podSpec, terraformConfig.FleetAgentCustomization)
+		})
+		require.NoError(t, err)
+	}
+}
+
+// waitForAgentTemplate is a helper function that waits for the pod template of the given agent deployment or statefulset
+// to pass the given check, returning the last failed check on timeout.
+func waitForAgentTemplate(steveClient *steveV1.Client, steveType, id string, check func(podSpec *corev1.PodSpec) error) error {
+	var checkErr error
+
+	err := kwait.PollUntilContextTimeout(context.TODO(), 10*time.Second, defaults.TenMinuteTimeout, true, func(ctx context.Context) (bool, error) {
+		agentResp, err := steveClient.SteveType(steveType).ByID(id)
+		if err != nil {
+			checkErr = err
+			return false, nil
+		}
+
+		var podSpec *corev1.PodSpec
+		if steveType == statefulSetSteveType {
+			statefulSet := &appsv1.StatefulSet{}
+			err = steveV1.ConvertToK8sType(agentResp.JSONResp, statefulSet)
+			podSpec = &statefulSet.Spec.Template.Spec
+		} else {
+			deployment := &appsv1.Deployment{}
+			err = steveV1.ConvertToK8sType(agentResp.JSONResp, deployment)
+			podSpec = &deployment.Spec.Template.Spec
+		}
+
+		if err != nil {
+			return false, err
+		}
+
+		checkErr = check(podSpec)
+
+		return checkErr == nil, nil
+	})
+	if err != nil && checkErr != nil {
+		return fmt.Errorf("%s: %w", id, checkErr)
+	}
+
+	return err
+}
+
+// verifyAgentPodSpec is a helper function that validates the tolerations, affinity and resource requirements of the pod
+// template of an agent against its deployment customization.
+func verifyAgentPodSpec(podSpec *corev1.PodSpec, customization *config.AgentDeploymentCustomization) error {
+	for _, toleration := range customization.AppendTolerations {
+		if !hasToleration(podSpec.Tolerations, toleration) {
+			return fmt.Errorf("toleration %s is missing", toleration.Key)
+		}
+	}
+
+	if len(customization.OverrideAffinity) > 0 {
+		err := verifyAgentAffinity(podSpec.Affinity, customization.OverrideAffinity)
+		if err != nil {
+			return err
+		}
+	}
+
+	if customization.OverrideResourceRequirements != nil {
+		if len(podSpec.Containers) == 0 {
+			return fmt.Errorf("the agent has no containers")
+		}
+
+		err := verifyAgentResources(podSpec.Containers[0].Resources, customization.OverrideResourceRequirements)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// hasToleration is a helper function that reports whether the given tolerations contain the expected toleration.
+func hasToleration(tolerations []corev1.Toleration, expected config.AgentToleration) bool {
+	for _, toleration := range tolerations {
+		if toleration.Key != expected.Key || toleration.Value != expected.Value {
+			continue
+		}
+
+		if expected.Operator != "" && string(toleration.Operator) != expected.Operator {
+			continue
+		}
+
+		if expected.Effect != "" && string(toleration.Effect) != expected.Effect {
+			continue
+		}
+
+		if expected.Seconds > 0 && (toleration.TolerationSeconds == nil || *toleration.TolerationSeconds != expected.Seconds) {
+			continue
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// verifyAgentAffinity is a helper function that validates the affinity of an agent against the expected affinity. Both
+// are compared in their JSON form, once the expected affinity is decoded into the Kubernetes type.
+func verifyAgentAffinity(affinity *corev1.Affinity, expected map[string]any) error {
+	expectedJSON, err := json.Marshal(expected)
+	if err != nil {
+		return err
+	}
+
+	expectedAffinity := &corev1.Affinity{}
+	err = json.Unmarshal(expectedJSON, expectedAffinity)
+	if err != nil {
+		return err
+	}
+
+	expectedJSON, err = json.Marshal(expectedAffinity)
+	if err != nil {
+		return err
+	}
+
+	actualJSON, err := json.Marshal(affinity)
+	if err != nil {
+		return err
+	}
+
+	if string(actualJSON) != string(expectedJSON) {
+		return fmt.Errorf("affinity is %s, expected %s", actualJSON, expectedJSON)
+	}
+
+	return nil
+}
+
+// verifyAgentResources is a helper function that validates the resource requirements of an agent container.
+func verifyAgentResources(resources corev1.ResourceRequirements, expected *config.AgentResourceRequirements) error {
+	quantities := []struct {
+		list     corev1.ResourceList
+		name     corev1.ResourceName
+		expected string
+	}{
+		{resources.Limits, corev1.ResourceCPU, expected.CPULimit},
+		{resources.Requests, corev1.ResourceCPU, expected.CPURequest},
+		{resources.Limits, corev1.ResourceMemory, expected.MemoryLimit},
+		{resources.Requests, corev1.ResourceMemory, expected.MemoryRequest},
+	}
+
+	for _, quantity := range quantities {
+		if quantity.expected == "" {
+			continue
+		}
+
+		expectedQuantity, err := resource.ParseQuantity(quantity.expected)
+		if err != nil {
+			return err
+		}
+
+		actualQuantity, ok := quantity.list[quantity.name]
+		if !ok || actualQuantity.Cmp(expectedQuantity) != 0 {
+			return fmt.Errorf("%s is %s, expected %s", quantity.name, actualQuantity.String(), quantity.expected)
+		}
+	}
+
+	return nil
+}
+
+// verifyAgentEnvVars is a helper function that validates that the cluster agent container carries the agent env vars.
+func verifyAgentEnvVars(podSpec *corev1.PodSpec, envVars []config.AgentEnvVar) error {
+	if len(envVars) == 0 {
+		return nil
+	}
+
+	if len(podSpec.Containers) == 0 {
+		return fmt.Errorf("the cluster agent has no containers")
+	}
+
+	env := map[string]string{}
+	for _, envVar := range podSpec.Containers[0].Env {
+		env[envVar.Name] = envVar.Value
+	}
+
+	for _, envVar := range envVars {
+		value, ok := env[envVar.Name]
+		if !ok || value != envVar.Value {
+			return fmt.Errorf("env var %s is %q, expected %q", envVar.Name, value, envVar.Value)
+		}
+	}
+
+	return nil
+}
+
+// waitForAgentScheduling is a helper function that waits for the priority class and the pod disruption budget of the
+// cluster agent to match its scheduling customization.
+func waitForAgentScheduling(steveClient *steveV1.Client, scheduling *config.AgentSchedulingCustomization) error {
+	var priorityClassName string
+
+	if scheduling.PriorityClass != nil {
+		err := waitForAgentTemplate(steveClient, stevetypes.Deployment, clusterAgentID, func(podSpec *corev1.PodSpec) error {
+			if podSpec.PriorityClassName == "" {
+				return fmt.Errorf("the cluster agent has no priority class")
+			}
+
+			priorityClassName = podSpec.PriorityClassName
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		priorityClassResp, err := steveClient.SteveType(priorityClassSteveType).ByID(priorityClassName)
+		if err != nil {
+			return err
+		}
+
+		priorityClass := &schedulingv1.PriorityClass{}
+		err = steveV1.ConvertToK8sType(priorityClassResp.JSONResp, priorityClass)
+		if err != nil {
+			return err
+		}
+
+		if priorityClass.Value != int32(scheduling.PriorityClass.Value) {
+			return fmt.Errorf("priority class %s has value %d, expected %d", priorityClassName, priorityClass.Value, scheduling.PriorityClass.Value)
+		}
+
+		expectedPolicy := scheduling.PriorityClass.PreemptionPolicy
+		if expectedPolicy != "" && (priorityClass.PreemptionPolicy == nil || string(*priorityClass.PreemptionPolicy) != expectedPolicy) {
+			return fmt.Errorf("priority class %s does not have the preemption policy %s", priorityClassName, expectedPolicy)
+		}
+	}
+
+	if scheduling.PodDisruptionBudget != nil {
+		var pdbErr error
+
+		err := kwait.PollUntilContextTimeout(context.TODO(), 10*time.Second, defaults.TenMinuteTimeout, true, func(ctx context.Context) (bool, error) {
+			pdbErr = verifyAgentPDB(steveClient, scheduling.PodDisruptionBudget)
+
+			return pdbErr == nil, nil
+		})
+		if err != nil && pdbErr != nil {
+			return pdbErr
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// verifyAgentPDB is a helper function that validates the pod disruption budget selecting the cluster agent.
+func verifyAgentPDB(steveClient *steveV1.Client, expected *config.AgentPodDisruptionBudget) error {
+	pdbList, err := steveClient.SteveType(pdbSteveType).List(nil)
+	if err != nil {
+		return err
+	}
+
+	for _, pdbResp := range pdbList.Data {
+		pdb := &policyv1.PodDisruptionBudget{}
+		err = steveV1.ConvertToK8sType(pdbResp.JSONResp, pdb)
+		if err != nil {
+			return err
+		}
+
+		if pdb.Namespace != cattleSystemNamespace || pdb.Spec.Selector == nil || pdb.Spec.Selector.MatchLabels["app"] != clusterAgentApp {
+			continue
+		}
+
+		if expected.MinAvailable != "" && (pdb.Spec.MinAvailable == nil || pdb.Spec.MinAvailable.String() != expected.MinAvailable) {
+			return fmt.Errorf("pod disruption budget %s does not have minAvailable %s", pdb.Name, expected.MinAvailable)
+		}
+
+		if expected.MaxUnavailable != "" && (pdb.Spec.MaxUnavailable == nil || pdb.Spec.MaxUnavailable.String() != expected.MaxUnavailable) {
+			return fmt.Errorf("pod disruption budget %s does not have maxUnavailable %s", pdb.Name, expected.MaxUnavailable)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("no pod disruption budget selects the cluster agent")
+}
@@ -0,0 +1,216 @@
+package provisioning
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rancher/shepherd/clients/rancher"
+	steveV1 "github.com/rancher/shepherd/clients/rancher/v1"
+	"github.com/rancher/shepherd/extensions/clusters"
+	"github.com/rancher/shepherd/extensions/defaults"
+	namegen "github.com/rancher/shepherd/pkg/namegenerator"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/defaults/stevetypes"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kwait "k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	nginxImage       = "nginx:stable"
+	pvcSteveType     = "persistentvolumeclaim"
+	volumeMountPath  = "/usr/share/nginx/html"
+	volumeRequestGiB = "1Gi"
+)
+
+// VerifyCloudProvider validates that the cloud provider of the cluster provisions volumes and load balancers. A workload
+// mounting a PVC of the default storage class is exposed by a service, and the PVC binding is awaited. The service is a
+// LoadBalancer whose external address is awaited on the providers with load balancer support; the vSphere cloud
+// provider has none, so vSphere clusters only verify the volume.
+func VerifyCloudProvider(t *testing.T, client *rancher.Client, terraformConfig *config.TerraformConfig) {
+	clusterID, err := clusters.GetClusterIDByName(client, terraformConfig.ResourcePrefix)
+	require.NoError(t, err)
+
+	steveClient, err := client.Steve.ProxyDownstream(clusterID)
+	require.NoError(t, err)
+
+	name := namegen.AppendRandomString("cloud-provider")
+
+	createdObjects := []*steveV1.SteveAPIObject{}
+	defer func() {
+		for _, object := range createdObjects {
+			err := steveClient.SteveType(object.Type).Delete(object)
+			if err != nil {
+				logrus.Warnf("Failed to delete %s %s: %v", object.Type, object.Name, err)
+			}
+		}
+	}()
+
+	logrus.Infof("Creating the cloud provider workload (%s)", name)
+	loadBalancer := supportsLoadBalancer(terraformConfig.Module)
+
+	objects, err := createCloudProviderWorkload(steveClient, name, loadBalancer)
+	createdObjects = append(createdObjects, objects...)
+	require.NoError(t, err)
+
+	logrus.Infof("Verifying the PVC is bound (%s)", name)
+	err = waitForPVCBound(steveClient, name)
+	require.NoError(t, err)
+
+	err = waitForDeployment(steveClient, name)
+	require.NoError(t, err)
+
+	if !loadBalancer {
+		return
+	}
+
+	logrus.Infof("Verifying the LoadBalancer service has an external address (%s)", name)
+	err = waitForLoadBalancer(steveClient, name)
+	require.NoError(t, err)
+}
+
+// supportsLoadBalancer is a helper function that reports whether the cloud provider of the given module provisions
+// load balancers.
+func supportsLoadBalancer(module string) bool {
+	switch module {
+	case modules.NodeDriverAWSRKE2, modules.NodeDriverAzureRKE2, modules.NodeDriverHarvesterRKE2:
+		return true
+	default:
+		return false
+	}
+}
+
+// createCloudProviderWorkload is a helper function that creates a PVC of the default storage class, an nginx deployment
+// mounting it and a service exposing the deployment, which is a LoadBalancer if requested. The objects are created in
+// the order they must be deleted in, so the PVC is released last.
+func createCloudProviderWorkload(steveClient *steveV1.Client, name string, loadBalancer bool) ([]*steveV1.SteveAPIObject, error) {
+	labels := map[string]string{appLabel: name}
+	replicas := int32(1)
+
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: defaultNamespace},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(volumeRequestGiB)},
+			},
+		},
+	}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: defaultNamespace},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					NodeSelector: map[string]string{corev1.LabelOSStable: linuxOS},
+					Containers: []corev1.Container{
+						{
+							Name:         name,
+							Image:        nginxImage,
+							Ports:        []corev1.ContainerPort{{ContainerPort: 80}},
+							VolumeMounts: []corev1.VolumeMount{{Name: name, MountPath: volumeMountPath}},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: name,
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: name},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	serviceType := corev1.ServiceTypeClusterIP
+	if loadBalancer {
+		serviceType = corev1.ServiceTypeLoadBalancer
+	}
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: defaultNamespace},
+		Spec: corev1.ServiceSpec{
+			Type:     serviceType,
+			Selector: labels,
+			Ports:    []corev1.ServicePort{{Port: 80}},
+		},
+	}
+
+	objects := []*steveV1.SteveAPIObject{}
+
+	serviceResp, err := steveClient.SteveType(stevetypes.Service).Create(service)
+	if err != nil {
+		return objects, err
+	}
+
+	objects = append(objects, serviceResp)
+
+	deploymentResp, err := steveClient.SteveType(stevetypes.Deployment).Create(deployment)
+	if err != nil {
+		return objects, err
+	}
+
+	objects = append(objects, deploymentResp)
+
+	pvcResp, err := steveClient.SteveType(pvcSteveType).Create(pvc)
+	if err != nil {
+		return objects, err
+	}
+
+	return append(objects, pvcResp), nil
+}
+
+// waitForPVCBound is a helper function that waits for the given PVC to be bound to a volume provisioned by the CSI driver.
+// The default storage classes wait for the first consumer, so the PVC is only bound once the pod is scheduled.
+func waitForPVCBound(steveClient *steveV1.Client, name string) error {
+	return kwait.PollUntilContextTimeout(context.TODO(), 10*time.Second, defaults.TenMinuteTimeout, true, func(ctx context.Context) (bool, error) {
+		pvcResp, err := steveClient.SteveType(pvcSteveType).ByID(defaultNamespace + "/" + name)
+		if err != nil {
+			return false, nil
+		}
+
+		pvc := &corev1.PersistentVolumeClaim{}
+		err = steveV1.ConvertToK8sType(pvcResp.JSONResp, pvc)
+		if err != nil {
+			return false, err
+		}
+
+		return pvc.Status.Phase == corev1.ClaimBound, nil
+	})
+}
+
+// waitForLoadBalancer is a helper function that waits for the given LoadBalancer service to be assigned an external
+// address by the cloud controller manager.
+func waitForLoadBalancer(steveClient *steveV1.Client, name string) error {
+	return kwait.PollUntilContextTimeout(context.TODO(), 10*time.Second, defaults.TenMinuteTimeout, true, func(ctx context.Context) (bool, error) {
+		serviceResp, err := steveClient.SteveType(stevetypes.Service).ByID(defaultNamespace + "/" + name)
+		if err != nil {
+			return false, nil
+		}
+
+		service := &corev1.Service{}
+		err = steveV1.ConvertToK8sType(serviceResp.JSONResp, service)
+		if err != nil {
+			return false, err
+		}
+
+		for _, ingress := range service.Status.LoadBalancer.Ingress {
+			if ingress.IP != "" || ingress.Hostname != "" {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	})
+}
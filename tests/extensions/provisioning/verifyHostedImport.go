@@ -0,0 +1,153 @@
+package provisioning
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/shepherd/clients/rancher"
+	management "github.com/rancher/shepherd/clients/rancher/generated/management/v3"
+	"github.com/rancher/shepherd/extensions/clusters"
+	"github.com/rancher/shepherd/extensions/defaults"
+	"github.com/rancher/tfp-automation/config"
+	framework "github.com/rancher/tfp-automation/framework/set"
+	"github.com/rancher/tfp-automation/framework/timing"
+	"github.com/stretchr/testify/require"
+	kwait "k8s.io/apimachinery/pkg/util/wait"
+)
+
+// VerifyHostedImport validates that Rancher took ownership of an imported hosted cluster, by waiting for the hosted config
+// of the cluster to be marked as imported and for the node pools of the cloud cluster to be synced to its upstream spec.
+func VerifyHostedImport(t *testing.T, client *rancher.Client, clusterName string) {
+	clusterID, err := clusters.GetClusterIDByName(client, clusterName)
+	require.NoError(t, err)
+
+	err = kwait.PollUntilContextTimeout(context.TODO(), 10*time.Second, defaults.TenMinuteTimeout, true, func(ctx context.Context) (bool, error) {
+		cluster, err := client.Management.Cluster.ByID(clusterID)
+		if err != nil {
+			return false, nil
+		}
+
+		nodePools, _, imported := hostedUpstreamSpec(cluster)
+
+		return imported && len(nodePools) > 0, nil
+	})
+	require.NoError(t, err, "Rancher did not take ownership of the imported cluster %s", clusterName)
+}
+
+// HostedImportNodePools is a function that returns the node pools of an imported hosted cluster, as synced by Rancher from
+// the cloud cluster, along with the Kubernetes version of the cluster. Rancher replaces the node pools of the cloud
+// cluster with the ones of the hosted config once any are set, so edits must keep the returned pools.
+func HostedImportNodePools(client *rancher.Client, clusterName string) ([]config.Nodepool, string, error) {
+	clusterID, err := clusters.GetClusterIDByName(client, clusterName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	cluster, err := client.Management.Cluster.ByID(clusterID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	nodePools, kubernetesVersion, _ := hostedUpstreamSpec(cluster)
+	if len(nodePools) == 0 {
+		return nil, "", fmt.Errorf("no node pools were synced for the imported cluster %s", clusterName)
+	}
+
+	return nodePools, kubernetesVersion, nil
+}
+
+// UpdateHostedCluster is a function that will regenerate the main.tf file of a hosted cluster from the given configs and
+// apply the changes, e.g. to edit the node pools of the cluster.
+func UpdateHostedCluster(t *testing.T, standardUserClient *rancher.Client, rancherConfig *rancher.Config, terraformConfig *config.TerraformConfig,
+	terratestConfig *config.TerratestConfig, terraformOptions *terraform.Options, newFile *hclwrite.File, rootBody *hclwrite.Body,
+	file *os.File, nestedRancherModuleDir string) {
+	_, _, err := framework.ConfigTF(standardUserClient, rancherConfig, terratestConfig, "", terraformConfig, newFile, rootBody, file, false, false, false, "", nestedRancherModuleDir)
+	require.NoError(t, err)
+
+	_, err = timing.InitAndApplyE(t, terraformOptions, "hosted cluster update")
+	require.NoError(t, err)
+}
+
+// VerifyHostedNodePoolNames validates that the node pools of a hosted cluster, as synced by Rancher from the cloud cluster,
+// are named after the given node pools.
+func VerifyHostedNodePoolNames(t *testing.T, client *rancher.Client, clusterName string, nodePools []config.Nodepool) {
+	clusterID, err := clusters.GetClusterIDByName(client, clusterName)
+	require.NoError(t, err)
+
+	err = waitForHostedUpstreamSpec(client, clusterID, nodePoolNamesMatch(nodePools))
+	require.NoError(t, err, "the node pools of cluster %s were not synced", clusterName)
+}
+
+// hostedUpstreamSpec is a helper function that returns the node pools and Kubernetes version of the upstream spec of a
+// hosted cluster, along with whether its hosted config is imported.
+func hostedUpstreamSpec(cluster *management.Cluster) ([]config.Nodepool, string, bool) {
+	nodePools := []config.Nodepool{}
+
+	switch {
+	case cluster.EKSStatus != nil && cluster.EKSStatus.UpstreamSpec != nil:
+		upstreamSpec := cluster.EKSStatus.UpstreamSpec
+		for _, nodeGroup := range upstreamSpec.NodeGroups {
+			nodePools = append(nodePools, config.Nodepool{
+				Name:              valueOf(nodeGroup.NodegroupName),
+				InstanceType:      nodeGroup.InstanceType,
+				DiskSize:          valueOf(nodeGroup.DiskSize),
+				DesiredSize:       valueOf(nodeGroup.DesiredSize),
+				MaxSize:           valueOf(nodeGroup.MaxSize),
+				MinSize:           valueOf(nodeGroup.MinSize),
+				Labels:            nodeGroup.Labels,
+				KubernetesVersion: valueOf(nodeGroup.Version),
+			})
+		}
+
+		return nodePools, valueOf(upstreamSpec.KubernetesVersion), cluster.EKSConfig != nil && cluster.EKSConfig.Imported
+	case cluster.AKSStatus != nil && cluster.AKSStatus.UpstreamSpec != nil:
+		upstreamSpec := cluster.AKSStatus.UpstreamSpec
+		for _, nodePool := range upstreamSpec.NodePools {
+			nodePools = append(nodePools, config.Nodepool{
+				Name:              valueOf(nodePool.Name),
+				Mode:              nodePool.Mode,
+				InstanceType:      nodePool.VMSize,
+				Quantity:          valueOf(nodePool.Count),
+				MaxPodsConstraint: valueOf(nodePool.MaxPods),
+				KubernetesVersion: valueOf(nodePool.OrchestratorVersion),
+			})
+		}
+
+		return nodePools, valueOf(upstreamSpec.KubernetesVersion), cluster.AKSConfig != nil && cluster.AKSConfig.Imported
+	case cluster.GKEStatus != nil && cluster.GKEStatus.UpstreamSpec != nil:
+		upstreamSpec := cluster.GKEStatus.UpstreamSpec
+		for _, nodePool := range upstreamSpec.NodePools {
+			pool := config.Nodepool{
+				Name:              valueOf(nodePool.Name),
+				Quantity:          valueOf(nodePool.InitialNodeCount),
+				MaxPodsConstraint: valueOf(nodePool.MaxPodsConstraint),
+				KubernetesVersion: valueOf(nodePool.Version),
+			}
+
+			if nodePool.Config != nil {
+				pool.InstanceType = nodePool.Config.MachineType
+			}
+
+			nodePools = append(nodePools, pool)
+		}
+
+		return nodePools, valueOf(upstreamSpec.KubernetesVersion), cluster.GKEConfig != nil && cluster.GKEConfig.Imported
+	}
+
+	return nodePools, "", false
+}
+
+// valueOf is a helper function that returns the value of the given pointer, or the zero value when it is nil.
+func valueOf[T any](value *T) T {
+	var zero T
+	if value == nil {
+		return zero
+	}
+
+	return *value
+}
@@ -0,0 +1,190 @@
+package provisioning
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/rancher/shepherd/clients/rancher"
+	steveV1 "github.com/rancher/shepherd/clients/rancher/v1"
+	"github.com/rancher/shepherd/extensions/clusters"
+	namegen "github.com/rancher/shepherd/pkg/namegenerator"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/stevetypes"
+	"github.com/rancher/tfp-automation/framework/set/provisioning/hosted"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	pauseImage         = "registry.k8s.io/pause:3.10"
+	schedulingReplicas = int32(2)
+)
+
+// VerifyHostedNodePoolScheduling validates the scheduling of the labeled node pools of a hosted cluster. A workload
+// selecting the labels of each pool, and tolerating its taints, must run on that pool only, while a workload without
+// tolerations must stay off the tainted pools.
+func VerifyHostedNodePoolScheduling(t *testing.T, client *rancher.Client, clusterName string, nodePools []config.Nodepool) {
+	clusterID, err := clusters.GetClusterIDByName(client, clusterName)
+	require.NoError(t, err)
+
+	steveClient, err := client.Steve.ProxyDownstream(clusterID)
+	require.NoError(t, err)
+
+	createdObjects := []*steveV1.SteveAPIObject{}
+	defer func() {
+		for _, object := range createdObjects {
+			err := steveClient.SteveType(object.Type).Delete(object)
+			if err != nil {
+				logrus.Warnf("Failed to delete %s %s: %v", object.Type, object.Name, err)
+			}
+		}
+	}()
+
+	taintedLabels := []map[string]string{}
+	for _, pool := range nodePools {
+		if len(pool.Labels) == 0 {
+			continue
+		}
+
+		tolerations, err := poolTolerations(pool.Taints)
+		require.NoError(t, err)
+
+		name := namegen.AppendRandomString("pool-scheduling")
+
+		logrus.Infof("Verifying a workload is scheduled on the node pool labeled %v (%s)", pool.Labels, name)
+		deployment, err := createSchedulingWorkload(steveClient, name, pool.Labels, tolerations)
+		if deployment != nil {
+			createdObjects = append(createdObjects, deployment)
+		}
+		require.NoError(t, err)
+
+		err = waitForDeployment(steveClient, name)
+		require.NoError(t, err)
+
+		err = verifyPodsNodeLabels(steveClient, name, pool.Labels, true)
+		require.NoError(t, err)
+
+		if len(pool.Taints) > 0 {
+			taintedLabels = append(taintedLabels, pool.Labels)
+		}
+	}
+
+	if len(taintedLabels) == 0 {
+		return
+	}
+
+	name := namegen.AppendRandomString("untolerated")
+
+	logrus.Infof("Verifying a workload without tolerations stays off the tainted node pools (%s)", name)
+	deployment, err := createSchedulingWorkload(steveClient, name, nil, nil)
+	if deployment != nil {
+		createdObjects = append(createdObjects, deployment)
+	}
+	require.NoError(t, err)
+
+	err = waitForDeployment(steveClient, name)
+	require.NoError(t, err)
+
+	for _, labels := range taintedLabels {
+		err = verifyPodsNodeLabels(steveClient, name, labels, false)
+		require.NoError(t, err)
+	}
+}
+
+// poolTolerations is a helper function that returns the tolerations of the given node pool taints, in the
+// key=value:Effect format taken by the hosted node pools.
+func poolTolerations(taints []string) ([]corev1.Toleration, error) {
+	tolerations := []corev1.Toleration{}
+	for _, taint := range taints {
+		key, value, effect, err := hosted.ParseTaint(taint)
+		if err != nil {
+			return nil, err
+		}
+
+		tolerations = append(tolerations, corev1.Toleration{
+			Key:      key,
+			Operator: corev1.TolerationOpEqual,
+			Value:    value,
+			Effect:   corev1.TaintEffect(effect),
+		})
+	}
+
+	return tolerations, nil
+}
+
+// createSchedulingWorkload is a helper function that creates a pause deployment with the given node selector and
+// tolerations.
+func createSchedulingWorkload(steveClient *steveV1.Client, name string, nodeSelector map[string]string, tolerations []corev1.Toleration) (*steveV1.SteveAPIObject, error) {
+	labels := map[string]string{appLabel: name}
+	replicas := schedulingReplicas
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: defaultNamespace},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					NodeSelector: nodeSelector,
+					Tolerations:  tolerations,
+					Containers:   []corev1.Container{{Name: name, Image: pauseImage}},
+				},
+			},
+		},
+	}
+
+	return steveClient.SteveType(stevetypes.Deployment).Create(deployment)
+}
+
+// verifyPodsNodeLabels is a helper function that verifies whether the pods of the given workload run on nodes carrying
+// all of the given labels, or on nodes not carrying them when expected is false.
+func verifyPodsNodeLabels(steveClient *steveV1.Client, name string, labels map[string]string, expected bool) error {
+	query := url.Values{"labelSelector": {appLabel + "=" + name}}
+
+	podList, err := steveClient.SteveType(podSteveType).NamespacedSteveClient(defaultNamespace).List(query)
+	if err != nil {
+		return err
+	}
+
+	if len(podList.Data) == 0 {
+		return fmt.Errorf("no pods found for the %s workload", name)
+	}
+
+	for _, podResp := range podList.Data {
+		pod := &corev1.Pod{}
+		err = steveV1.ConvertToK8sType(podResp.JSONResp, pod)
+		if err != nil {
+			return err
+		}
+
+		nodeResp, err := steveClient.SteveType(nodeSteveType).ByID(pod.Spec.NodeName)
+		if err != nil {
+			return err
+		}
+
+		node := &corev1.Node{}
+		err = steveV1.ConvertToK8sType(nodeResp.JSONResp, node)
+		if err != nil {
+			return err
+		}
+
+		matches := true
+		for key, value := range labels {
+			if node.Labels[key] != value {
+				matches = false
+				break
+			}
+		}
+
+		if matches != expected {
+			return fmt.Errorf("pod %s is scheduled on node %s, expected it to match the node pool labels %v: %t", pod.Name, node.Name, labels, expected)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package provisioning
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rancher/shepherd/clients/rancher"
+	"github.com/rancher/shepherd/extensions/defaults"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/resources/upgrade"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	kwait "k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	localCluster = "local"
+)
+
+// VerifyLocalClusterUpgrade validates that the local cluster, running Rancher, reports the upgraded Kubernetes version
+// once its upgrade was requested through the standalone config. It does nothing otherwise.
+func VerifyLocalClusterUpgrade(t *testing.T, client *rancher.Client, terraformConfig *config.TerraformConfig) {
+	if !terraformConfig.Standalone.UpgradeLocalCluster {
+		return
+	}
+
+	upgradedVersion, err := upgrade.UpgradedLocalClusterVersion(terraformConfig)
+	require.NoError(t, err)
+
+	logrus.Infof("Verifying the local cluster runs %s", upgradedVersion)
+	err = kwait.PollUntilContextTimeout(context.TODO(), 10*time.Second, defaults.TenMinuteTimeout, true, func(ctx context.Context) (bool, error) {
+		cluster, err := client.Management.Cluster.ByID(localCluster)
+		if err != nil {
+			return false, nil
+		}
+
+		return cluster.Version != nil && cluster.Version.GitVersion == upgradedVersion, nil
+	})
+	require.NoError(t, err, "the local cluster was not upgraded to %s", upgradedVersion)
+}
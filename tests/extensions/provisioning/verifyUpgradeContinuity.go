@@ -0,0 +1,225 @@
+package provisioning
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rancher/shepherd/clients/rancher"
+	steveV1 "github.com/rancher/shepherd/clients/rancher/v1"
+	"github.com/rancher/shepherd/extensions/clusters"
+	"github.com/rancher/shepherd/extensions/defaults"
+	namegen "github.com/rancher/shepherd/pkg/namegenerator"
+	"github.com/rancher/tfp-automation/defaults/stevetypes"
+	"github.com/rancher/tfp-automation/tests/extensions/postProvisioning"
+	"github.com/sirupsen/logrus"
+	appsv1 "k8s.io/api/apps/v1"
+	kwait "k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	agentImageSetting  = "agent-image"
+	clusterAgentID     = "cattle-system/cattle-cluster-agent"
+	continuityPassed   = "PASS"
+	continuityFailed   = "FAIL"
+	continuityWorkload = "continuity"
+)
+
+// UpgradeContinuity holds the state of a downstream cluster recorded before a Rancher upgrade, along with the results of
+// its continuity checks after the upgrade.
+type UpgradeContinuity struct {
+	ClusterName     string
+	Module          string
+	Workload        string
+	WorkloadUID     string
+	PreAgentImage   string
+	PostAgentImage  string
+	Active          bool
+	WorkloadsIntact bool
+	AgentUpdated    bool
+	Errors          []string
+}
+
+// Passed reports whether the downstream cluster passed all of its continuity checks.
+func (u *UpgradeContinuity) Passed() bool {
+	return u.Active && u.WorkloadsIntact && u.AgentUpdated
+}
+
+// PrepareUpgradeContinuity is a function that will deploy a workload on the given downstream cluster ahead of a Rancher
+// upgrade, and record it along with the image of the cluster agent, so they can be compared after the upgrade.
+func PrepareUpgradeContinuity(client *rancher.Client, clusterName, module string) (*UpgradeContinuity, error) {
+	clusterID, err := clusters.GetClusterIDByName(client, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	steveClient, err := client.Steve.ProxyDownstream(clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	name := namegen.AppendRandomString(continuityWorkload)
+
+	deployment, err := createSchedulingWorkload(steveClient, name, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = waitForDeployment(steveClient, name)
+	if err != nil {
+		return nil, err
+	}
+
+	agentImage, _, err := clusterAgentImage(steveClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UpgradeContinuity{
+		ClusterName:   clusterName,
+		Module:        module,
+		Workload:      name,
+		WorkloadUID:   string(deployment.UID),
+		PreAgentImage: agentImage,
+	}, nil
+}
+
+// UpgradedAgentImage is a function that returns the cluster agent image of the upgraded Rancher, which the agents of the
+// downstream clusters are expected to be updated to.
+func UpgradedAgentImage(client *rancher.Client) (string, error) {
+	return FetchSetting(client, agentImageSetting)
+}
+
+// VerifyUpgradeContinuity is a function that will run the continuity checks of the given downstream cluster after a
+// Rancher upgrade. The cluster must return to active, the workload deployed before the upgrade must be the same one and
+// ready, and the cluster agent must be rolled out with the given agent image. The failures are recorded rather than
+// asserted, so every cluster is checked and reported.
+func VerifyUpgradeContinuity(client *rancher.Client, continuity *UpgradeContinuity, expectedAgentImage string) {
+	clusterID, err := clusters.GetClusterIDByName(client, continuity.ClusterName)
+	if err != nil {
+		continuity.Errors = append(continuity.Errors, err.Error())
+		return
+	}
+
+	err = postProvisioning.IsClusterActive(client, clusterID)
+	if err != nil {
+		continuity.Errors = append(continuity.Errors, fmt.Sprintf("cluster is not active: %v", err))
+		return
+	}
+
+	continuity.Active = true
+
+	steveClient, err := client.Steve.ProxyDownstream(clusterID)
+	if err != nil {
+		continuity.Errors = append(continuity.Errors, err.Error())
+		return
+	}
+
+	err = verifyContinuityWorkload(steveClient, continuity)
+	if err != nil {
+		continuity.Errors = append(continuity.Errors, err.Error())
+	} else {
+		continuity.WorkloadsIntact = true
+	}
+
+	err = kwait.PollUntilContextTimeout(context.TODO(), 10*time.Second, defaults.TenMinuteTimeout, true, func(ctx context.Context) (bool, error) {
+		agentImage, rolledOut, err := clusterAgentImage(steveClient)
+		if err != nil {
+			return false, nil
+		}
+
+		continuity.PostAgentImage = agentImage
+
+		return rolledOut && agentImage == expectedAgentImage, nil
+	})
+	if err != nil {
+		continuity.Errors = append(continuity.Errors, fmt.Sprintf("cluster agent runs %s, expected %s", continuity.PostAgentImage, expectedAgentImage))
+	} else {
+		continuity.AgentUpdated = true
+	}
+
+	deploymentResp, err := steveClient.SteveType(stevetypes.Deployment).ByID(defaultNamespace + "/" + continuity.Workload)
+	if err == nil {
+		err = steveClient.SteveType(stevetypes.Deployment).Delete(deploymentResp)
+	}
+
+	if err != nil {
+		logrus.Warnf("Failed to delete the %s workload: %v", continuity.Workload, err)
+	}
+}
+
+// UpgradeContinuityReport is a function that returns the continuity report of the given downstream clusters, one row
+// per cluster.
+func UpgradeContinuityReport(continuities []*UpgradeContinuity) string {
+	var report strings.Builder
+
+	writer := tabwriter.NewWriter(&report, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "CLUSTER\tMODULE\tACTIVE\tWORKLOADS\tAGENT\tAGENT IMAGE\tRESULT\tERRORS")
+
+	for _, continuity := range continuities {
+		result := continuityPassed
+		if !continuity.Passed() {
+			result = continuityFailed
+		}
+
+		fmt.Fprintf(writer, "%s\t%s\t%t\t%t\t%t\t%s -> %s\t%s\t%s\n", continuity.ClusterName, continuity.Module, continuity.Active,
+			continuity.WorkloadsIntact, continuity.AgentUpdated, continuity.PreAgentImage, continuity.PostAgentImage, result,
+			strings.Join(continuity.Errors, "; "))
+	}
+
+	writer.Flush()
+
+	return report.String()
+}
+
+// verifyContinuityWorkload is a helper function that verifies the workload deployed before the upgrade was not recreated
+// and that all of its replicas are ready.
+func verifyContinuityWorkload(steveClient *steveV1.Client, continuity *UpgradeContinuity) error {
+	deploymentResp, err := steveClient.SteveType(stevetypes.Deployment).ByID(defaultNamespace + "/" + continuity.Workload)
+	if err != nil {
+		return fmt.Errorf("workload %s is gone: %w", continuity.Workload, err)
+	}
+
+	deployment := &appsv1.Deployment{}
+	err = steveV1.ConvertToK8sType(deploymentResp.JSONResp, deployment)
+	if err != nil {
+		return err
+	}
+
+	if string(deployment.UID) != continuity.WorkloadUID {
+		return fmt.Errorf("workload %s was recreated", continuity.Workload)
+	}
+
+	return waitForDeployment(steveClient, continuity.Workload)
+}
+
+// clusterAgentImage is a helper function that returns the image of the cluster agent of a downstream cluster, along with
+// whether its deployment is fully rolled out.
+func clusterAgentImage(steveClient *steveV1.Client) (string, bool, error) {
+	deploymentResp, err := steveClient.SteveType(stevetypes.Deployment).ByID(clusterAgentID)
+	if err != nil {
+		return "", false, err
+	}
+
+	deployment := &appsv1.Deployment{}
+	err = steveV1.ConvertToK8sType(deploymentResp.JSONResp, deployment)
+	if err != nil {
+		return "", false, err
+	}
+
+	if len(deployment.Spec.Template.Spec.Containers) == 0 {
+		return "", false, fmt.Errorf("the cluster agent has no containers")
+	}
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+
+	rolledOut := deployment.Status.ObservedGeneration >= deployment.Generation && deployment.Status.UpdatedReplicas == replicas &&
+		deployment.Status.AvailableReplicas == replicas
+
+	return deployment.Spec.Template.Spec.Containers[0].Image, rolledOut, nil
+}
@@ -0,0 +1,251 @@
+package provisioning
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/rancher/shepherd/clients/rancher"
+	steveV1 "github.com/rancher/shepherd/clients/rancher/v1"
+	"github.com/rancher/shepherd/extensions/clusters"
+	"github.com/rancher/shepherd/extensions/defaults"
+	namegen "github.com/rancher/shepherd/pkg/namegenerator"
+	"github.com/rancher/tfp-automation/defaults/stevetypes"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kwait "k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// agnhost is published for both Linux and Windows Server, so the same image is used on both operating systems.
+	agnhostImage = "registry.k8s.io/e2e-test-images/agnhost:2.53"
+	agnhostPort  = 8080
+
+	appLabel         = "app"
+	defaultNamespace = "default"
+	jobSteveType     = "batch.job"
+	linuxOS          = "linux"
+	nodeSteveType    = "node"
+	podSteveType     = "pod"
+	windowsOS        = "windows"
+)
+
+// VerifyWindowsWorkloads validates that a workload is scheduled on the Windows nodes using a node selector, and that the
+// cluster CNI passes traffic from the Linux pods to the Windows pods and from the Windows pods to the Linux pods.
+func VerifyWindowsWorkloads(t *testing.T, client *rancher.Client, clusterName string) {
+	clusterID, err := clusters.GetClusterIDByName(client, clusterName)
+	require.NoError(t, err)
+
+	steveClient, err := client.Steve.ProxyDownstream(clusterID)
+	require.NoError(t, err)
+
+	linuxName := namegen.AppendRandomString("linux-netexec")
+	windowsName := namegen.AppendRandomString("windows-netexec")
+
+	createdObjects := []*steveV1.SteveAPIObject{}
+	defer func() {
+		for _, object := range createdObjects {
+			err := steveClient.SteveType(object.Type).Delete(object)
+			if err != nil {
+				logrus.Warnf("Failed to delete %s %s: %v", object.Type, object.Name, err)
+			}
+		}
+	}()
+
+	for _, workload := range []struct{ name, os string }{{linuxName, linuxOS}, {windowsName, windowsOS}} {
+		logrus.Infof("Creating the %s workload (%s)", workload.os, workload.name)
+		objects, err := createNetexecWorkload(steveClient, workload.name, workload.os)
+		createdObjects = append(createdObjects, objects...)
+		require.NoError(t, err)
+
+		err = waitForDeployment(steveClient, workload.name)
+		require.NoError(t, err)
+	}
+
+	logrus.Infof("Verifying the Windows pods are scheduled on the Windows nodes")
+	err = verifyPodsOS(steveClient, windowsName, windowsOS)
+	require.NoError(t, err)
+
+	for _, connection := range []struct{ os, target string }{{linuxOS, windowsName}, {windowsOS, linuxName}} {
+		logrus.Infof("Verifying traffic from a %s pod to the %s service", connection.os, connection.target)
+		job, err := createConnectJob(steveClient, connection.os, connection.target)
+		if job != nil {
+			createdObjects = append(createdObjects, job)
+		}
+		require.NoError(t, err)
+
+		err = waitForJob(steveClient, job.Name)
+		require.NoError(t, err)
+	}
+}
+
+// createNetexecWorkload is a helper function that creates an agnhost netexec deployment pinned to the given operating
+// system with a node selector, along with the service exposing it.
+func createNetexecWorkload(steveClient *steveV1.Client, name, os string) ([]*steveV1.SteveAPIObject, error) {
+	labels := map[string]string{appLabel: name}
+	replicas := int32(1)
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: defaultNamespace},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					NodeSelector: map[string]string{corev1.LabelOSStable: os},
+					Containers: []corev1.Container{
+						{
+							Name:  name,
+							Image: agnhostImage,
+							Args:  []string{"netexec", fmt.Sprintf("--http-port=%d", agnhostPort)},
+							Ports: []corev1.ContainerPort{{ContainerPort: agnhostPort}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: defaultNamespace},
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeClusterIP,
+			Selector: labels,
+			Ports:    []corev1.ServicePort{{Port: agnhostPort}},
+		},
+	}
+
+	objects := []*steveV1.SteveAPIObject{}
+
+	deploymentResp, err := steveClient.SteveType(stevetypes.Deployment).Create(deployment)
+	if err != nil {
+		return objects, err
+	}
+
+	objects = append(objects, deploymentResp)
+
+	serviceResp, err := steveClient.SteveType(stevetypes.Service).Create(service)
+	if err != nil {
+		return objects, err
+	}
+
+	return append(objects, serviceResp), nil
+}
+
+// createConnectJob is a helper function that creates a job on the given operating system, which connects to the given
+// service through the cluster network.
+func createConnectJob(steveClient *steveV1.Client, os, target string) (*steveV1.SteveAPIObject, error) {
+	name := namegen.AppendRandomString(os + "-connect")
+	backoffLimit := int32(6)
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: defaultNamespace},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					NodeSelector:  map[string]string{corev1.LabelOSStable: os},
+					Containers: []corev1.Container{
+						{
+							Name:  name,
+							Image: agnhostImage,
+							Args:  []string{"connect", fmt.Sprintf("%s:%d", target, agnhostPort), "--timeout=10s"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return steveClient.SteveType(jobSteveType).Create(job)
+}
+
+// waitForDeployment is a helper function that waits for all replicas of the given deployment to be ready. Windows images
+// are large, so the timeout accounts for the first image pull on the Windows nodes.
+func waitForDeployment(steveClient *steveV1.Client, name string) error {
+	return kwait.PollUntilContextTimeout(context.TODO(), 10*time.Second, defaults.ThirtyMinuteTimeout, true, func(ctx context.Context) (bool, error) {
+		deploymentResp, err := steveClient.SteveType(stevetypes.Deployment).ByID(defaultNamespace + "/" + name)
+		if err != nil {
+			return false, nil
+		}
+
+		deployment := &appsv1.Deployment{}
+		err = steveV1.ConvertToK8sType(deploymentResp.JSONResp, deployment)
+		if err != nil {
+			return false, err
+		}
+
+		return deployment.Spec.Replicas != nil && deployment.Status.ReadyReplicas == *deployment.Spec.Replicas, nil
+	})
+}
+
+// waitForJob is a helper function that waits for the given job to succeed, failing as soon as the job fails.
+func waitForJob(steveClient *steveV1.Client, name string) error {
+	return kwait.PollUntilContextTimeout(context.TODO(), 10*time.Second, defaults.ThirtyMinuteTimeout, true, func(ctx context.Context) (bool, error) {
+		jobResp, err := steveClient.SteveType(jobSteveType).ByID(defaultNamespace + "/" + name)
+		if err != nil {
+			return false, nil
+		}
+
+		job := &batchv1.Job{}
+		err = steveV1.ConvertToK8sType(jobResp.JSONResp, job)
+		if err != nil {
+			return false, err
+		}
+
+		for _, condition := range job.Status.Conditions {
+			if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+				return false, fmt.Errorf("job %s failed: %s", name, condition.Message)
+			}
+		}
+
+		return job.Status.Succeeded > 0, nil
+	})
+}
+
+// verifyPodsOS is a helper function that verifies the pods of the given workload run on nodes of the given operating system.
+func verifyPodsOS(steveClient *steveV1.Client, name, os string) error {
+	query := url.Values{"labelSelector": {appLabel + "=" + name}}
+
+	podList, err := steveClient.SteveType(podSteveType).NamespacedSteveClient(defaultNamespace).List(query)
+	if err != nil {
+		return err
+	}
+
+	if len(podList.Data) == 0 {
+		return fmt.Errorf("no pods found for the %s workload", name)
+	}
+
+	for _, podResp := range podList.Data {
+		pod := &corev1.Pod{}
+		err = steveV1.ConvertToK8sType(podResp.JSONResp, pod)
+		if err != nil {
+			return err
+		}
+
+		nodeResp, err := steveClient.SteveType(nodeSteveType).ByID(pod.Spec.NodeName)
+		if err != nil {
+			return err
+		}
+
+		node := &corev1.Node{}
+		err = steveV1.ConvertToK8sType(nodeResp.JSONResp, node)
+		if err != nil {
+			return err
+		}
+
+		if node.Labels[corev1.LabelOSStable] != os {
+			return fmt.Errorf("pod %s is scheduled on the %s node %s, expected a %s node", pod.Name, node.Labels[corev1.LabelOSStable], node.Name, os)
+		}
+	}
+
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/rancher/shepherd/clients/rancher"
 	"github.com/rancher/tfp-automation/config"
 	framework "github.com/rancher/tfp-automation/framework/set"
+	"github.com/rancher/tfp-automation/framework/timing"
 	"github.com/stretchr/testify/require"
 )
 
@@ -21,5 +22,5 @@ func AuthConfig(t *testing.T, rancherConfig *rancher.Config, terraformConfig *co
 	err := framework.AuthConfig(rancherConfig, configMap, newFile, rootBody, file)
 	require.NoError(t, err)
 
-	terraform.InitAndApply(t, terraformOptions)
+	timing.InitAndApply(t, terraformOptions, "auth provider")
 }
@@ -21,6 +21,7 @@ import (
 	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 	tfpQase "github.com/rancher/tfp-automation/pipeline/qase"
 	"github.com/rancher/tfp-automation/pipeline/qase/results"
+	"github.com/rancher/tfp-automation/pipeline/report"
 	nested "github.com/rancher/tfp-automation/tests/extensions/nestedModules"
 	"github.com/rancher/tfp-automation/tests/extensions/provisioning"
 
@@ -36,6 +37,7 @@ type TfpEKSProvisioningTestSuite struct {
 	client                     *rancher.Client
 	standardUserClient         *rancher.Client
 	session                    *session.Session
+	serverNodeOne              string
 	cattleConfig               map[string]any
 	rancherConfig              *rancher.Config
 	terraformConfig            *config.TerraformConfig
@@ -50,7 +52,7 @@ func (s *TfpEKSProvisioningTestSuite) SetupSuite() {
 	s.session = testSession
 	s.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
 
-	s.client, _, s.standaloneTerraformOptions, s.terraformOptions, s.cattleConfig = setupstandard.SetupRancher(s.T(), s.session, keypath.HostedKeyPath, s.cattleConfig)
+	s.client, s.serverNodeOne, s.standaloneTerraformOptions, s.terraformOptions, s.cattleConfig = setupstandard.SetupRancher(s.T(), s.session, keypath.HostedKeyPath, s.cattleConfig)
 	s.rancherConfig, s.terraformConfig, s.terratestConfig, s.standaloneConfig = config.LoadTFPConfigs(s.cattleConfig)
 }
 
@@ -68,25 +70,49 @@ func (s *TfpEKSProvisioningTestSuite) TestTfpProvisioningEKSCluster() {
 
 	eksNodePools := []config.Nodepool{{DiskSize: 100, InstanceType: s.terraformConfig.AWSConfig.AWSInstanceType, DesiredSize: 3, MaxSize: 3, MinSize: 3}}
 
+	// EKS node groups do not take taints through Rancher, so the user pool is only told apart by its labels.
+	eksLabeledNodePools := []config.Nodepool{
+		{DiskSize: 100, InstanceType: s.terraformConfig.AWSConfig.AWSInstanceType, DesiredSize: 1, MaxSize: 1, MinSize: 1},
+		{DiskSize: 100, InstanceType: s.terraformConfig.AWSConfig.AWSInstanceType, DesiredSize: 1, MaxSize: 2, MinSize: 1, Labels: map[string]string{"pool": "user"}},
+	}
+
+	eksLifecycleNodePools := []config.Nodepool{
+		{Name: "system", DiskSize: 100, InstanceType: s.terraformConfig.AWSConfig.AWSInstanceType, DesiredSize: 2, MaxSize: 2, MinSize: 2},
+	}
+	eksAddedNodePool := config.Nodepool{Name: "user", DiskSize: 100, InstanceType: s.terraformConfig.AWSConfig.AWSInstanceType, DesiredSize: 2, MaxSize: 2, MinSize: 2}
+
 	tests := []struct {
 		name              string
 		module            string
 		nodePools         []config.Nodepool
 		kubernetesVersion string
+		hostedImport      bool
+		lifecycle         bool
 	}{
-		{"EKS_Hosted_Cluster", modules.HostedAWSEKS, eksNodePools, s.terratestConfig.EKSKubernetesVersion},
+		{"EKS_Hosted_Cluster", modules.HostedAWSEKS, eksNodePools, s.terratestConfig.EKSKubernetesVersion, false, false},
+		{"EKS_Hosted_Cluster_Labeled_Pool", modules.HostedAWSEKS, eksLabeledNodePools, s.terratestConfig.EKSKubernetesVersion, false, false},
+		{"EKS_Hosted_Import_Cluster", modules.HostedAWSEKS, nil, s.terratestConfig.EKSKubernetesVersion, true, false},
+		{"EKS_Hosted_Cluster_Lifecycle", modules.HostedAWSEKS, eksLifecycleNodePools, s.terratestConfig.EKSKubernetesVersion, false, true},
 	}
 
 	for _, tt := range tests {
 		s.T().Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
+			if tt.lifecycle && s.terratestConfig.UpgradedEKSKubernetesVersion == "" {
+				t.Skip("upgradedEKSKubernetesVersion is not set, skipping the hosted cluster lifecycle")
+			}
+
 			rancher, terraform, terratest, _ := config.LoadTFPConfigs(s.cattleConfig)
 			rancher.AdminToken = standardToken
 			terraform.Module = tt.module
 			terratest.Nodepools = tt.nodePools
 			terratest.KubernetesVersion = tt.kubernetesVersion
 
+			if tt.hostedImport {
+				terraform.HostedImport = &config.HostedImport{Bastion: s.serverNodeOne}
+			}
+
 			nestedRancherModuleDir, perTestTerraformOptions, err := nested.CreateNestedModules(s.terraformConfig, s.terratestConfig, s.terraformOptions, tt.name, configs.NestedRancherModuleDir)
 			require.NoError(t, err)
 			defer os.RemoveAll(nestedRancherModuleDir)
@@ -101,6 +127,11 @@ func (s *TfpEKSProvisioningTestSuite) TestTfpProvisioningEKSCluster() {
 			_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, s.terratestConfig.PathToRepo, "")
 			defer cleanup.Cleanup(s.T(), perTestTerraformOptions, keyPath)
 
+			err = report.UpdateCaseMetadata(tt.name, terraform, terratest)
+			if err != nil {
+				logrus.Warningf("Failed to record case metadata %s", err)
+			}
+
 			logrus.Infof("Provisioning cluster (%s)", terraform.ResourcePrefix)
 			clusters, _ := provisioning.Provision(s.T(), s.client, s.standardUserClient, rancher, terraform, terratest, perTestTerraformOptions, newFile, rootBody, file, false, false, true, "", nestedRancherModuleDir)
 
@@ -116,6 +147,35 @@ func (s *TfpEKSProvisioningTestSuite) TestTfpProvisioningEKSCluster() {
 			err = pods.VerifyClusterPods(s.client, clusters[0])
 			require.NoError(s.T(), err)
 
+			logrus.Infof("Verifying node pool scheduling (%s)", clusters[0].Name)
+			provisioning.VerifyHostedNodePoolScheduling(s.T(), s.client, terraform.ResourcePrefix, terratest.Nodepools)
+
+			if tt.hostedImport {
+				logrus.Infof("Verifying Rancher took ownership of the imported cluster (%s)", clusters[0].Name)
+				provisioning.VerifyHostedImport(s.T(), s.client, terraform.ResourcePrefix)
+
+				importedNodePools, kubernetesVersion, err := provisioning.HostedImportNodePools(s.client, terraform.ResourcePrefix)
+				require.NoError(s.T(), err)
+
+				terratest.KubernetesVersion = kubernetesVersion
+				terratest.Nodepools = append(importedNodePools, config.Nodepool{Name: "user-pool", DiskSize: 100, InstanceType: s.terraformConfig.AWSConfig.AWSInstanceType, DesiredSize: 1, MaxSize: 1, MinSize: 1})
+
+				logrus.Infof("Adding a node pool to the imported cluster (%s)", clusters[0].Name)
+				provisioning.UpdateHostedCluster(s.T(), s.standardUserClient, rancher, terraform, terratest, perTestTerraformOptions, newFile, rootBody,
+					file, nestedRancherModuleDir)
+
+				provisioning.VerifyHostedNodePoolNames(s.T(), s.client, terraform.ResourcePrefix, terratest.Nodepools)
+
+				logrus.Infof("Verifying the cluster is ready (%s)", clusters[0].Name)
+				err = provisioningActions.VerifyClusterReadyV3(s.client, clusters[0].Name)
+				require.NoError(s.T(), err)
+			}
+
+			if tt.lifecycle {
+				provisioning.HostedClusterLifecycle(s.T(), s.client, s.standardUserClient, rancher, terraform, terratest, perTestTerraformOptions, newFile,
+					rootBody, file, nestedRancherModuleDir, s.terratestConfig.UpgradedEKSKubernetesVersion, eksAddedNodePool)
+			}
+
 			params := tfpQase.GetProvisioningSchemaParams(s.terraformConfig, s.terratestConfig)
 			err = qase.UpdateSchemaParameters(tt.name, params)
 			if err != nil {
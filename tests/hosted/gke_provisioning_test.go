@@ -21,6 +21,7 @@ import (
 	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 	tfpQase "github.com/rancher/tfp-automation/pipeline/qase"
 	"github.com/rancher/tfp-automation/pipeline/qase/results"
+	"github.com/rancher/tfp-automation/pipeline/report"
 	nested "github.com/rancher/tfp-automation/tests/extensions/nestedModules"
 	"github.com/rancher/tfp-automation/tests/extensions/provisioning"
 
@@ -36,6 +37,7 @@ type TfpGKEProvisioningTestSuite struct {
 	client                     *rancher.Client
 	standardUserClient         *rancher.Client
 	session                    *session.Session
+	serverNodeOne              string
 	cattleConfig               map[string]any
 	rancherConfig              *rancher.Config
 	terraformConfig            *config.TerraformConfig
@@ -50,7 +52,7 @@ func (s *TfpGKEProvisioningTestSuite) SetupSuite() {
 	s.session = testSession
 	s.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
 
-	s.client, _, s.standaloneTerraformOptions, s.terraformOptions, s.cattleConfig = setupstandard.SetupRancher(s.T(), s.session, keypath.HostedKeyPath, s.cattleConfig)
+	s.client, s.serverNodeOne, s.standaloneTerraformOptions, s.terraformOptions, s.cattleConfig = setupstandard.SetupRancher(s.T(), s.session, keypath.HostedKeyPath, s.cattleConfig)
 	s.rancherConfig, s.terraformConfig, s.terratestConfig, s.standaloneConfig = config.LoadTFPConfigs(s.cattleConfig)
 }
 
@@ -67,26 +69,46 @@ func (s *TfpGKEProvisioningTestSuite) TestTfpProvisioningGKECluster() {
 	standardToken := standardUserToken.Token
 
 	gkeNodePools := []config.Nodepool{{Quantity: 1, MaxPodsConstraint: 110}}
+	gkeTaintedNodePools := []config.Nodepool{
+		{Quantity: 1, MaxPodsConstraint: 110},
+		{Quantity: 1, MaxPodsConstraint: 110, Labels: map[string]string{"pool": "user"}, Taints: []string{"dedicated=user:NoSchedule"}},
+	}
+
+	gkeLifecycleNodePools := []config.Nodepool{{Quantity: 1, Name: "system", MaxPodsConstraint: 110}}
+	gkeAddedNodePool := config.Nodepool{Quantity: 1, Name: "user", MaxPodsConstraint: 110}
 
 	tests := []struct {
 		name              string
 		module            string
 		nodePools         []config.Nodepool
 		kubernetesVersion string
+		hostedImport      bool
+		lifecycle         bool
 	}{
-		{"GKE_Hosted_Cluster", modules.HostedGoogleGKE, gkeNodePools, s.terratestConfig.GKEKubernetesVersion},
+		{"GKE_Hosted_Cluster", modules.HostedGoogleGKE, gkeNodePools, s.terratestConfig.GKEKubernetesVersion, false, false},
+		{"GKE_Hosted_Cluster_Tainted_Pool", modules.HostedGoogleGKE, gkeTaintedNodePools, s.terratestConfig.GKEKubernetesVersion, false, false},
+		{"GKE_Hosted_Import_Cluster", modules.HostedGoogleGKE, nil, s.terratestConfig.GKEKubernetesVersion, true, false},
+		{"GKE_Hosted_Cluster_Lifecycle", modules.HostedGoogleGKE, gkeLifecycleNodePools, s.terratestConfig.GKEKubernetesVersion, false, true},
 	}
 
 	for _, tt := range tests {
 		s.T().Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
+			if tt.lifecycle && s.terratestConfig.UpgradedGKEKubernetesVersion == "" {
+				t.Skip("upgradedGKEKubernetesVersion is not set, skipping the hosted cluster lifecycle")
+			}
+
 			rancher, terraform, terratest, _ := config.LoadTFPConfigs(s.cattleConfig)
 			rancher.AdminToken = standardToken
 			terraform.Module = tt.module
 			terratest.Nodepools = tt.nodePools
 			terratest.KubernetesVersion = tt.kubernetesVersion
 
+			if tt.hostedImport {
+				terraform.HostedImport = &config.HostedImport{Bastion: s.serverNodeOne}
+			}
+
 			nestedRancherModuleDir, perTestTerraformOptions, err := nested.CreateNestedModules(s.terraformConfig, s.terratestConfig, s.terraformOptions, tt.name, configs.NestedRancherModuleDir)
 			require.NoError(t, err)
 			defer os.RemoveAll(nestedRancherModuleDir)
@@ -101,6 +123,11 @@ func (s *TfpGKEProvisioningTestSuite) TestTfpProvisioningGKECluster() {
 			_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, s.terratestConfig.PathToRepo, "")
 			defer cleanup.Cleanup(s.T(), perTestTerraformOptions, keyPath)
 
+			err = report.UpdateCaseMetadata(tt.name, terraform, terratest)
+			if err != nil {
+				logrus.Warningf("Failed to record case metadata %s", err)
+			}
+
 			logrus.Infof("Provisioning cluster (%s)", terraform.ResourcePrefix)
 			clusters, _ := provisioning.Provision(s.T(), s.client, s.standardUserClient, rancher, terraform, terratest, perTestTerraformOptions, newFile, rootBody, file, false, false, true, "", nestedRancherModuleDir)
 
@@ -116,6 +143,35 @@ func (s *TfpGKEProvisioningTestSuite) TestTfpProvisioningGKECluster() {
 			err = pods.VerifyClusterPods(s.client, clusters[0])
 			require.NoError(s.T(), err)
 
+			logrus.Infof("Verifying node pool scheduling (%s)", clusters[0].Name)
+			provisioning.VerifyHostedNodePoolScheduling(s.T(), s.client, terraform.ResourcePrefix, terratest.Nodepools)
+
+			if tt.hostedImport {
+				logrus.Infof("Verifying Rancher took ownership of the imported cluster (%s)", clusters[0].Name)
+				provisioning.VerifyHostedImport(s.T(), s.client, terraform.ResourcePrefix)
+
+				importedNodePools, kubernetesVersion, err := provisioning.HostedImportNodePools(s.client, terraform.ResourcePrefix)
+				require.NoError(s.T(), err)
+
+				terratest.KubernetesVersion = kubernetesVersion
+				terratest.Nodepools = append(importedNodePools, config.Nodepool{Quantity: 1, Name: "user-pool", MaxPodsConstraint: 110})
+
+				logrus.Infof("Adding a node pool to the imported cluster (%s)", clusters[0].Name)
+				provisioning.UpdateHostedCluster(s.T(), s.standardUserClient, rancher, terraform, terratest, perTestTerraformOptions, newFile, rootBody,
+					file, nestedRancherModuleDir)
+
+				provisioning.VerifyHostedNodePoolNames(s.T(), s.client, terraform.ResourcePrefix, terratest.Nodepools)
+
+				logrus.Infof("Verifying the cluster is ready (%s)", clusters[0].Name)
+				err = provisioningActions.VerifyClusterReadyV3(s.client, clusters[0].Name)
+				require.NoError(s.T(), err)
+			}
+
+			if tt.lifecycle {
+				provisioning.HostedClusterLifecycle(s.T(), s.client, s.standardUserClient, rancher, terraform, terratest, perTestTerraformOptions, newFile,
+					rootBody, file, nestedRancherModuleDir, s.terratestConfig.UpgradedGKEKubernetesVersion, gkeAddedNodePool)
+			}
+
 			params := tfpQase.GetProvisioningSchemaParams(s.terraformConfig, s.terratestConfig)
 			err = qase.UpdateSchemaParameters(tt.name, params)
 			if err != nil {
@@ -2,9 +2,11 @@ package cli
 
 import (
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/rancher/shepherd/pkg/config"
+	"github.com/rancher/tfp-automation/framework/timing"
 	"github.com/rancher/tfp-automation/tests/infrastructure/clusters"
 
 	setupairgap "github.com/rancher/tfp-automation/tests/infrastructure/ranchers/setup/airgap"
@@ -20,6 +22,7 @@ import (
 	upgradeproxy "github.com/rancher/tfp-automation/tests/infrastructure/ranchers/upgrade/proxy"
 	upgradestandard "github.com/rancher/tfp-automation/tests/infrastructure/ranchers/upgrade/standard"
 	"github.com/rancher/tfp-automation/tests/infrastructure/registries"
+	share "github.com/rancher/tfp-automation/tests/infrastructure/state"
 	"github.com/stretchr/testify/require"
 )
 
@@ -66,17 +69,22 @@ func RunCLI() int {
 	// If there are two arguments, we assume this is a Rancher setup. If only one, it's a cluster or registry setup.
 	if len(os.Args) > 2 {
 		key += ":" + os.Args[2]
+		share.StartTiming(strings.TrimPrefix(key, "--"))
 
 		if setupFunc, ok := setupRancherFuncs[key]; ok {
 			cattleConfig := config.LoadConfigFromFile(os.Getenv(config.ConfigEnvironmentKey))
 			err := setupFunc(t, "", cattleConfig)
+			timing.FinishAll(err)
 			require.NoError(t, err)
 
 			return 0
 		}
 	} else {
+		share.StartTiming(strings.TrimPrefix(key, "--"))
+
 		if setupFunc, ok := setupClusterFuncs[key]; ok {
 			err := setupFunc(t, "")
+			timing.FinishAll(err)
 			require.NoError(t, err)
 
 			return 0
@@ -84,6 +92,7 @@ func RunCLI() int {
 
 		if setupFunc, ok := setupRegistryFuncs[key]; ok {
 			err := setupFunc(t, "")
+			timing.FinishAll(err)
 			require.NoError(t, err)
 
 			return 0
@@ -15,6 +15,8 @@ import (
 	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 	registry "github.com/rancher/tfp-automation/framework/set/resources/registries/createRegistry"
 	"github.com/rancher/tfp-automation/framework/set/resources/sanity"
+	"github.com/rancher/tfp-automation/framework/set/resources/topology"
+	"github.com/rancher/tfp-automation/framework/timing"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
 )
@@ -44,33 +46,41 @@ func CreateAirgappedK3SCluster(t *testing.T, provider string) error {
 	tfBlock := rootBody.AppendNewBlock(terraformConst, nil)
 	tfBlockBody := tfBlock.Body()
 
-	instances := []string{serverOne, serverTwo, serverThree}
+	nodes, err := topology.Nodes(terraformConfig)
+	require.NoError(t, err)
+
+	instances := []string{bastion, registryInstance}
+
+	topology.CreateHostOutputs(rootBody, terraformConfig, instances...)
+	topology.CreateOutputs(rootBody, terraformConfig, nodes)
+
+	timing.AddInstances(terraformOptions, terraformConfig.Provider, timing.InstanceType(terraformConfig.Provider, terraformConfig), len(instances)+len(nodes))
 
 	providerTunnel := providers.TunnelToProvider(terraformConfig.Provider)
-	file, err := providerTunnel.CreateNonAirgap(file, newFile, tfBlockBody, rootBody, terraformConfig, terratestConfig, instances)
+	require.NotNil(t, providerTunnel.CreateAirgap, "airgap setups are not supported on the %s provider", terraformConfig.Provider)
+
+	file, err = providerTunnel.CreateAirgap(file, newFile, tfBlockBody, rootBody, terraformConfig, terratestConfig, instances)
 	require.NoError(t, err)
 
-	terraform.InitAndApply(t, terraformOptions)
+	timing.InitAndApply(t, terraformOptions, "resources")
 
-	registryPublicIP := terraform.Output(t, terraformOptions, registryPublicIP)
-	bastionPublicIP := terraform.Output(t, terraformOptions, bastionPublicIP)
-	serverOnePrivateIP := terraform.Output(t, terraformOptions, serverOnePrivateIP)
-	serverTwoPrivateIP := terraform.Output(t, terraformOptions, serverTwoPrivateIP)
-	serverThreePrivateIP := terraform.Output(t, terraformOptions, serverThreePrivateIP)
+	registryPublicDNS := terraform.Output(t, terraformOptions, topology.PublicDNSOutput(registryInstance))
+	bastionPublicDNS := terraform.Output(t, terraformOptions, bastionPublicDNS)
+	nodes = topology.LoadAddresses(t, terraformOptions, nodes)
 
 	file = sanity.OpenFile(file, keyPath)
 	logrus.Infof("Creating registry...")
-	file, err = registry.CreateUnauthenticatedRegistry(file, newFile, rootBody, terraformConfig, terratestConfig, registryPublicIP, unauthRegistry, unauthGlobalRegistryRoute53FQDN, false)
+	file, err = registry.CreateUnauthenticatedRegistry(file, newFile, rootBody, terraformConfig, terratestConfig, registryPublicDNS, unauthRegistry, registryPublicDNS, false)
 	require.NoError(t, err)
 
-	terraform.InitAndApply(t, terraformOptions)
+	timing.InitAndApply(t, terraformOptions, "registry")
 
 	file = sanity.OpenFile(file, keyPath)
 	logrus.Infof("Creating airgap K3S cluster...")
-	file, err = k3s.CreateAirgapK3SCluster(file, newFile, rootBody, terraformConfig, terratestConfig, bastionPublicIP, registryPublicIP, serverOnePrivateIP, serverTwoPrivateIP, serverThreePrivateIP)
+	file, err = k3s.CreateAirgapK3SCluster(file, newFile, rootBody, terraformConfig, terratestConfig, bastionPublicDNS, registryPublicDNS, nodes)
 	require.NoError(t, err)
 
-	terraform.InitAndApply(t, terraformOptions)
+	timing.InitAndApply(t, terraformOptions, "airgap k3s cluster")
 
 	return nil
 }
@@ -14,6 +14,8 @@ import (
 	"github.com/rancher/tfp-automation/framework/set/resources/providers"
 	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 	"github.com/rancher/tfp-automation/framework/set/resources/sanity"
+	"github.com/rancher/tfp-automation/framework/set/resources/topology"
+	"github.com/rancher/tfp-automation/framework/timing"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
 )
@@ -43,13 +45,20 @@ func CreateDualStackRKE2Cluster(t *testing.T, provider string) error {
 	tfBlock := rootBody.AppendNewBlock(terraformConst, nil)
 	tfBlockBody := tfBlock.Body()
 
-	instances := []string{serverOne, serverTwo, serverThree}
+	nodes, err := topology.FixedNodes(terraformConfig, "dual-stack")
+	require.NoError(t, err)
+
+	instances := topology.Names(nodes)
+
+	topology.CreateOutputs(rootBody, terraformConfig, nodes)
+
+	timing.AddInstances(terraformOptions, terraformConfig.Provider, timing.InstanceType(terraformConfig.Provider, terraformConfig), len(instances))
 
 	providerTunnel := providers.TunnelToProvider(terraformConfig.Provider)
-	file, err := providerTunnel.CreateNonAirgap(file, newFile, tfBlockBody, rootBody, terraformConfig, terratestConfig, instances)
+	file, err = providerTunnel.CreateNonAirgap(file, newFile, tfBlockBody, rootBody, terraformConfig, terratestConfig, instances)
 	require.NoError(t, err)
 
-	terraform.InitAndApply(t, terraformOptions)
+	timing.InitAndApply(t, terraformOptions, "resources")
 
 	serverOnePublicIP := terraform.Output(t, terraformOptions, serverOnePublicIP)
 	serverOnePrivateIP := terraform.Output(t, terraformOptions, serverOnePrivateIP)
@@ -61,7 +70,7 @@ func CreateDualStackRKE2Cluster(t *testing.T, provider string) error {
 	file, err = rke2.CreateRKE2Cluster(file, newFile, rootBody, terraformConfig, terratestConfig, serverOnePublicIP, serverOnePrivateIP, serverTwoPublicIP, serverThreePublicIP)
 	require.NoError(t, err)
 
-	terraform.InitAndApply(t, terraformOptions)
+	timing.InitAndApply(t, terraformOptions, "rke2 cluster")
 
 	return nil
 }
@@ -15,6 +15,8 @@ import (
 	"github.com/rancher/tfp-automation/framework/set/resources/proxy/k3s/squid"
 	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 	"github.com/rancher/tfp-automation/framework/set/resources/sanity"
+	"github.com/rancher/tfp-automation/framework/set/resources/topology"
+	"github.com/rancher/tfp-automation/framework/timing"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
 )
@@ -44,13 +46,19 @@ func CreateProxyK3SCluster(t *testing.T, provider string) error {
 	tfBlock := rootBody.AppendNewBlock(terraformConst, nil)
 	tfBlockBody := tfBlock.Body()
 
+	nodes, err := topology.FixedNodes(terraformConfig, "proxy")
+	require.NoError(t, err)
+
 	instances := []string{bastion}
+	topology.CreateHostOutputs(rootBody, terraformConfig, append(instances, topology.Names(nodes)...)...)
+
+	timing.AddInstances(terraformOptions, terraformConfig.Provider, timing.InstanceType(terraformConfig.Provider, terraformConfig), len(instances)+len(nodes))
 
 	providerTunnel := providers.TunnelToProvider(terraformConfig.Provider)
-	file, err := providerTunnel.CreateNonAirgap(file, newFile, tfBlockBody, rootBody, terraformConfig, terratestConfig, instances)
+	file, err = providerTunnel.CreateNonAirgap(file, newFile, tfBlockBody, rootBody, terraformConfig, terratestConfig, instances)
 	require.NoError(t, err)
 
-	terraform.InitAndApply(t, terraformOptions)
+	timing.InitAndApply(t, terraformOptions, "resources")
 
 	bastionPublicDNS := terraform.Output(t, terraformOptions, bastionPublicDNS)
 	bastionPrivateIP := terraform.Output(t, terraformOptions, bastionPrivateIP)
@@ -63,14 +71,14 @@ func CreateProxyK3SCluster(t *testing.T, provider string) error {
 	file, err = squid.CreateSquidProxy(file, newFile, rootBody, terraformConfig, terratestConfig, bastionPublicDNS, serverOnePrivateIP, serverTwoPrivateIP, serverThreePrivateIP)
 	require.NoError(t, err)
 
-	terraform.InitAndApply(t, terraformOptions)
+	timing.InitAndApply(t, terraformOptions, "squid proxy")
 
 	file = sanity.OpenFile(file, keyPath)
 	logrus.Infof("Creating K3S cluster...")
 	file, err = k3s.CreateK3SCluster(file, newFile, rootBody, terraformConfig, terratestConfig, bastionPublicDNS, bastionPrivateIP, serverOnePrivateIP, serverTwoPrivateIP, serverThreePrivateIP)
 	require.NoError(t, err)
 
-	terraform.InitAndApply(t, terraformOptions)
+	timing.InitAndApply(t, terraformOptions, "k3s cluster")
 
 	return nil
 }
@@ -4,7 +4,6 @@ import (
 	"os"
 	"testing"
 
-	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	shepherdConfig "github.com/rancher/shepherd/pkg/config"
 	"github.com/rancher/tfp-automation/config"
@@ -14,21 +13,19 @@ import (
 	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 	"github.com/rancher/tfp-automation/framework/set/resources/rke2"
 	"github.com/rancher/tfp-automation/framework/set/resources/sanity"
+	"github.com/rancher/tfp-automation/framework/set/resources/topology"
+	"github.com/rancher/tfp-automation/framework/timing"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
 )
 
 const (
-	bastion     = "bastion"
-	serverOne   = "server1"
-	serverTwo   = "server2"
-	serverThree = "server3"
+	bastion          = "bastion"
+	registryInstance = "registry"
 
 	serverOnePublicIP = "server1_public_ip"
-	registryPublicIP  = "registry_public_ip"
 
-	unauthRegistry                  = "unauth_registry"
-	unauthGlobalRegistryRoute53FQDN = "unauth_global_registry_route_53_fqdn"
+	unauthRegistry = "unauth_registry"
 
 	bastionPublicDNS     = "bastion_public_dns"
 	bastionPrivateIP     = "bastion_private_ip"
@@ -67,25 +64,28 @@ func CreateRKE2Cluster(t *testing.T, provider string) error {
 	tfBlock := rootBody.AppendNewBlock(terraformConst, nil)
 	tfBlockBody := tfBlock.Body()
 
-	instances := []string{serverOne, serverTwo, serverThree}
+	nodes, err := topology.Nodes(terraformConfig)
+	require.NoError(t, err)
+
+	topology.CreateOutputs(rootBody, terraformConfig, nodes)
+
+	instances := topology.Names(nodes)
+	timing.AddInstances(terraformOptions, terraformConfig.Provider, timing.InstanceType(terraformConfig.Provider, terraformConfig), len(instances))
 
 	providerTunnel := providers.TunnelToProvider(terraformConfig.Provider)
-	file, err := providerTunnel.CreateNonAirgap(file, newFile, tfBlockBody, rootBody, terraformConfig, terratestConfig, instances)
+	file, err = providerTunnel.CreateNonAirgap(file, newFile, tfBlockBody, rootBody, terraformConfig, terratestConfig, instances)
 	require.NoError(t, err)
 
-	terraform.InitAndApply(t, terraformOptions)
+	timing.InitAndApply(t, terraformOptions, "resources")
 
-	serverOnePublicIP := terraform.Output(t, terraformOptions, serverOnePublicIP)
-	serverOnePrivateIP := terraform.Output(t, terraformOptions, serverOnePrivateIP)
-	serverTwoPublicIP := terraform.Output(t, terraformOptions, serverTwoPublicIP)
-	serverThreePublicIP := terraform.Output(t, terraformOptions, serverThreePublicIP)
+	nodes = topology.LoadAddresses(t, terraformOptions, nodes)
 
 	file = sanity.OpenFile(file, keyPath)
 	logrus.Infof("Creating RKE2 cluster...")
-	file, err = rke2.CreateRKE2Cluster(file, newFile, rootBody, terraformConfig, terratestConfig, serverOnePublicIP, serverOnePrivateIP, serverTwoPublicIP, serverThreePublicIP)
+	file, err = rke2.CreateRKE2Cluster(file, newFile, rootBody, terraformConfig, terratestConfig, nodes)
 	require.NoError(t, err)
 
-	terraform.InitAndApply(t, terraformOptions)
+	timing.InitAndApply(t, terraformOptions, "rke2 cluster")
 
 	return nil
 }
@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"net/http"
 	"os"
-	"strings"
 
 	shepherdConfig "github.com/rancher/shepherd/pkg/config"
 	"github.com/rancher/tfp-automation/config"
@@ -88,11 +87,11 @@ func ConfirmHandler(w http.ResponseWriter, r *http.Request) {
 		share.State.Mutex.Lock()
 
 		if clustertype != "" {
-			share.State.StageMsg = strings.Join(share.ClusterStageMessage, "\n")
+			share.State.StageMsg = share.StageMessage(share.ClusterStageMessage)
 		} else if ranchertype != "" {
-			share.State.StageMsg = strings.Join(share.RancherStageMessage, "\n")
+			share.State.StageMsg = share.StageMessage(share.RancherStageMessage)
 		} else if registrytype != "" {
-			share.State.StageMsg = strings.Join(share.RegistryStageMessage, "\n")
+			share.State.StageMsg = share.StageMessage(share.RegistryStageMessage)
 		}
 
 		share.State.ErrorMsg = ""
@@ -18,16 +18,16 @@ func ProviderHandler(w http.ResponseWriter, r *http.Request) {
 		if clustertype != "" {
 			switch clustertype {
 			case "airgap-rke2", "airgap-k3s", "dual-rke2", "dual-k3s", "ipv6-rke2", "ipv6-k3s", "proxy-rke2", "proxy-k3s":
-				installOptions = []string{"aws"}
+				installOptions = []string{"aws", "harvester", "vsphere"}
 			default:
 				installOptions = []string{"aws", "linode", "vsphere"}
 			}
 		} else if ranchertype != "" {
 			switch ranchertype {
-			case "airgap", "dual", "ipv6", "registry":
-				installOptions = []string{"aws"}
+			case "registry", "airgap", "dual", "ipv6":
+				installOptions = []string{"aws", "harvester", "vsphere"}
 			case "proxy":
-				installOptions = []string{"aws", "linode"}
+				installOptions = []string{"aws", "harvester", "linode", "vsphere"}
 			default:
 				installOptions = []string{"aws", "linode", "vsphere"}
 			}
@@ -33,6 +33,14 @@ func InstallTypeHandler(w http.ResponseWriter, r *http.Request) {
 
 		var installOptions []string
 
+		// Hosted and Registry Rancher only support fresh installs
+		switch ranchertype {
+		case "hosted", "registry":
+			installOptions = []string{"fresh"}
+		default:
+			installOptions = []string{"fresh", "upgrade"}
+		}
+
 		data := struct {
 			Selection      string
 			RancherType    string
@@ -43,14 +51,6 @@ func InstallTypeHandler(w http.ResponseWriter, r *http.Request) {
 			InstallOptions: installOptions,
 		}
 
-		// Dual-stack, IPv6, and Registry Rancher only support fresh installs
-		switch ranchertype {
-		case "dual", "ipv6", "registry":
-			installOptions = []string{"fresh"}
-		default:
-			installOptions = []string{"fresh", "upgrade"}
-		}
-
 		Templates.ExecuteTemplate(w, "installtype", data)
 	} else {
 		Templates.ExecuteTemplate(w, "installtype", nil)
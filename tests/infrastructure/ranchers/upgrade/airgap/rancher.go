@@ -63,13 +63,15 @@ func UpgradingAirgapRancher(t *testing.T, provider string, cattleConfig map[stri
 	_, upgradeKeyPath := rancher2.SetKeyPath(keypath.UpgradeKeyPath, terratestConfig.PathToRepo, terraformConfig.Provider)
 	upgradeTerraformOptions := framework.Setup(t, terraformConfig, terratestConfig, upgradeKeyPath)
 
-	err = upgrade.CreateMainTF(t, upgradeTerraformOptions, upgradeKeyPath, rancherConfig, terraformConfig, terratestConfig, "", "", bastion, registry)
+	err = upgrade.CreateMainTF(t, upgradeTerraformOptions, upgradeKeyPath, rancherConfig, terraformConfig, terratestConfig, "", "", bastion, registry, nil)
 	require.NoError(t, err)
 
 	standaloneTerraformOptions := framework.Setup(t, terraformConfig, terratestConfig, keypath.AirgapKeyPath)
 	client, err = ranchersetup.PostRancherSetup(t, standaloneTerraformOptions, rancherConfig, testSession, terraformConfig.Standalone.RancherHostname, keyPath, true)
 	require.NoError(t, err)
 
+	provisioning.VerifyLocalClusterUpgrade(t, client, terraformConfig)
+
 	return nil
 }
 
@@ -85,7 +87,7 @@ func UpgradeAirgapRancher(t *testing.T, client *rancher.Client, bastion, registr
 	_, keyPath := rancher2.SetKeyPath(keypath.UpgradeKeyPath, terratestConfig.PathToRepo, terraformConfig.Provider)
 	upgradeTerraformOptions := framework.Setup(t, terraformConfig, terratestConfig, keyPath)
 
-	err = upgrade.CreateMainTF(t, upgradeTerraformOptions, keyPath, rancherConfig, terraformConfig, terratestConfig, "", "", bastion, registry)
+	err = upgrade.CreateMainTF(t, upgradeTerraformOptions, keyPath, rancherConfig, terraformConfig, terratestConfig, "", "", bastion, registry, nil)
 	require.NoError(t, err)
 
 	tunnel.StopBastionSSHTunnel()
@@ -102,6 +104,8 @@ func UpgradeAirgapRancher(t *testing.T, client *rancher.Client, bastion, registr
 	client, err = ranchersetup.PostRancherSetup(t, standaloneTerraformOptions, rancherConfig, session, standaloneConfig.RancherHostname, keyPath, true)
 	require.NoError(t, err)
 
+	provisioning.VerifyLocalClusterUpgrade(t, client, terraformConfig)
+
 	updatedCattleConfig, err := ranchersetup.UpdateRancherConfigMap(cattleConfig, client)
 	require.NoError(t, err)
 
@@ -47,14 +47,19 @@ func UpgradingDualStackRancher(t *testing.T, provider string, cattleConfig map[s
 	_, upgradeKeyPath := rancher2.SetKeyPath(keypath.UpgradeKeyPath, terratestConfig.PathToRepo, terraformConfig.Provider)
 	upgradeTerraformOptions := framework.Setup(t, terraformConfig, terratestConfig, upgradeKeyPath)
 
-	err = upgrade.CreateMainTF(t, upgradeTerraformOptions, upgradeKeyPath, rancherConfig, terraformConfig, terratestConfig, serverNodeOne, "", "", "")
+	nodes, err := upgrade.LocalClusterNodes(t, terraformConfig, terraformOptions)
+	require.NoError(t, err)
+
+	err = upgrade.CreateMainTF(t, upgradeTerraformOptions, upgradeKeyPath, rancherConfig, terraformConfig, terratestConfig, serverNodeOne, "", "", "", nodes)
 	require.NoError(t, err)
 
 	standaloneTerraformOptions := framework.Setup(t, terraformConfig, terratestConfig, keypath.DualStackKeyPath)
 
-	_, err = ranchersetup.PostRancherSetup(t, standaloneTerraformOptions, rancherConfig, testSession, terraformConfig.Standalone.RancherHostname, keyPath, true)
+	client, err := ranchersetup.PostRancherSetup(t, standaloneTerraformOptions, rancherConfig, testSession, terraformConfig.Standalone.RancherHostname, keyPath, true)
 	require.NoError(t, err)
 
+	provisioning.VerifyLocalClusterUpgrade(t, client, terraformConfig)
+
 	return nil
 }
 
@@ -70,7 +75,13 @@ func UpgradeDualStackRancher(t *testing.T, client *rancher.Client, serverNodeOne
 	_, keyPath := rancher2.SetKeyPath(keypath.UpgradeKeyPath, terratestConfig.PathToRepo, terraformConfig.Provider)
 	upgradeTerraformOptions := framework.Setup(t, terraformConfig, terratestConfig, keyPath)
 
-	err = upgrade.CreateMainTF(t, upgradeTerraformOptions, keyPath, rancherConfig, terraformConfig, terratestConfig, serverNodeOne, "", "", "")
+	_, setupKeyPath := rancher2.SetKeyPath(keypath.DualStackKeyPath, terratestConfig.PathToRepo, terraformConfig.Provider)
+	setupTerraformOptions := framework.Setup(t, terraformConfig, terratestConfig, setupKeyPath)
+
+	nodes, err := upgrade.LocalClusterNodes(t, terraformConfig, setupTerraformOptions)
+	require.NoError(t, err)
+
+	err = upgrade.CreateMainTF(t, upgradeTerraformOptions, keyPath, rancherConfig, terraformConfig, terratestConfig, serverNodeOne, "", "", "", nodes)
 	require.NoError(t, err)
 
 	session = session.NewSession()
@@ -79,6 +90,8 @@ func UpgradeDualStackRancher(t *testing.T, client *rancher.Client, serverNodeOne
 	client, err = ranchersetup.PostRancherSetup(t, standaloneTerraformOptions, rancherConfig, session, terraformConfig.Standalone.RancherHostname, keyPath, true)
 	require.NoError(t, err)
 
+	provisioning.VerifyLocalClusterUpgrade(t, client, terraformConfig)
+
 	updatedCattleConfig, err := ranchersetup.UpdateRancherConfigMap(cattleConfig, client)
 	require.NoError(t, err)
 
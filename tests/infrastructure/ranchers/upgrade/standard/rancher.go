@@ -57,7 +57,10 @@ func UpgradingRancher(t *testing.T, provider string, cattleConfig map[string]any
 	_, upgradeKeyPath := rancher2.SetKeyPath(keypath.UpgradeKeyPath, terratestConfig.PathToRepo, terraformConfig.Provider)
 	upgradeTerraformOptions := framework.Setup(t, terraformConfig, terratestConfig, upgradeKeyPath)
 
-	err = upgrade.CreateMainTF(t, upgradeTerraformOptions, upgradeKeyPath, rancherConfig, terraformConfig, terratestConfig, serverNodeOne, "", "", "")
+	nodes, err := upgrade.LocalClusterNodes(t, terraformConfig, terraformOptions)
+	require.NoError(t, err)
+
+	err = upgrade.CreateMainTF(t, upgradeTerraformOptions, upgradeKeyPath, rancherConfig, terraformConfig, terratestConfig, serverNodeOne, "", "", "", nodes)
 	require.NoError(t, err)
 
 	standaloneTerraformOptions := framework.Setup(t, terraformConfig, terratestConfig, keypath.SanityKeyPath)
@@ -70,6 +73,8 @@ func UpgradingRancher(t *testing.T, provider string, cattleConfig map[string]any
 		require.NoError(t, err)
 	}
 
+	provisioning.VerifyLocalClusterUpgrade(t, client, terraformConfig)
+
 	return nil
 }
 
@@ -85,7 +90,13 @@ func UpgradeRancher(t *testing.T, client *rancher.Client, serverNodeOne string,
 	_, keyPath := rancher2.SetKeyPath(keypath.UpgradeKeyPath, terratestConfig.PathToRepo, terraformConfig.Provider)
 	upgradeTerraformOptions := framework.Setup(t, terraformConfig, terratestConfig, keyPath)
 
-	err = upgrade.CreateMainTF(t, upgradeTerraformOptions, keyPath, rancherConfig, terraformConfig, terratestConfig, serverNodeOne, "", "", "")
+	_, setupKeyPath := rancher2.SetKeyPath(keypath.SanityKeyPath, terratestConfig.PathToRepo, terraformConfig.Provider)
+	setupTerraformOptions := framework.Setup(t, terraformConfig, terratestConfig, setupKeyPath)
+
+	nodes, err := upgrade.LocalClusterNodes(t, terraformConfig, setupTerraformOptions)
+	require.NoError(t, err)
+
+	err = upgrade.CreateMainTF(t, upgradeTerraformOptions, keyPath, rancherConfig, terraformConfig, terratestConfig, serverNodeOne, "", "", "", nodes)
 	require.NoError(t, err)
 
 	session = session.NewSession()
@@ -94,6 +105,8 @@ func UpgradeRancher(t *testing.T, client *rancher.Client, serverNodeOne string,
 	client, err = ranchersetup.PostRancherSetup(t, standaloneTerraformOptions, rancherConfig, session, terraformConfig.Standalone.RancherHostname, keyPath, true)
 	require.NoError(t, err)
 
+	provisioning.VerifyLocalClusterUpgrade(t, client, terraformConfig)
+
 	updatedCattleConfig, err := ranchersetup.UpdateRancherConfigMap(cattleConfig, client)
 	require.NoError(t, err)
 
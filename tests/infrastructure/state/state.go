@@ -1,6 +1,18 @@
 package state
 
-import "sync"
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/rancher/tfp-automation/framework/timing"
+)
+
+const (
+	estimateSeparator = " : ~"
+	infrastructure    = "infrastructure"
+)
 
 type appState struct {
 	StageMsg string
@@ -39,3 +51,91 @@ var RegistryStageMessage = []string{
 	"\nECR : ~50 minutes",
 	"\n\nThe registry access information will be displayed once the setup successfully finishes.",
 }
+
+// SetupLabels maps each CLI and web setup key to the label used in the stage messages and timing reports.
+var SetupLabels = map[string]string{
+	"airgap-rke2":       "Airgap RKE2/K3S Cluster",
+	"airgap-k3s":        "Airgap RKE2/K3S Cluster",
+	"dual-rke2":         "Dualstack RKE2/K3S Cluster",
+	"dual-k3s":          "Dualstack RKE2/K3S Cluster",
+	"ipv6-rke2":         "IPv6 RKE2/K3S Cluster",
+	"ipv6-k3s":          "IPv6 RKE2/K3S Cluster",
+	"normal-rke2":       "Normal RKE2/K3S Cluster",
+	"normal-k3s":        "Normal RKE2/K3S Cluster",
+	"proxy-rke2":        "Proxy RKE2/K3S Cluster",
+	"proxy-k3s":         "Proxy RKE2/K3S Cluster",
+	"airgap:fresh":      "Airgap Rancher",
+	"airgap:upgrade":    "Airgap Rancher Upgrade",
+	"dual:fresh":        "Dualstack Rancher",
+	"dual:upgrade":      "Dualstack Rancher Upgrade",
+	"hosted:fresh":      "Hosted Rancher",
+	"ipv6:fresh":        "IPv6 Rancher",
+	"ipv6:upgrade":      "IPv6 Rancher Upgrade",
+	"normal:fresh":      "Normal Rancher",
+	"normal:upgrade":    "Normal Rancher Upgrade",
+	"proxy:fresh":       "Proxy Rancher",
+	"proxy:upgrade":     "Proxy Rancher Upgrade",
+	"registry:fresh":    "Registry Rancher",
+	"registries-all":    "All Registries",
+	"registries-auth":   "Authenticated Registry",
+	"registries-unauth": "Non-Authenticated Registry",
+	"registries-ecr":    "ECR",
+}
+
+// StartTiming names the timing reports of the given setup after its setup key, which is the job name the test history
+// records them under.
+func StartTiming(setupKey string) {
+	timing.SetName(infrastructure, setupKey)
+}
+
+// StageMessage joins the given stage message, replacing the hard-coded estimates with the median duration of past
+// successful runs when the test history has recorded any.
+func StageMessage(messages []string) string {
+	jobEstimates, err := timing.HistoricalEstimates(infrastructure)
+	if err != nil {
+		return strings.Join(messages, "\n")
+	}
+
+	estimates := labelEstimates(jobEstimates)
+
+	lines := make([]string, 0, len(messages))
+	for _, message := range messages {
+		index := strings.Index(message, estimateSeparator)
+		if index < 0 {
+			lines = append(lines, message)
+			continue
+		}
+
+		label := strings.TrimSpace(message[:index])
+		estimate, ok := estimates[label]
+		if !ok {
+			lines = append(lines, message)
+			continue
+		}
+
+		minutes := int(math.Round(estimate.Median.Minutes()))
+		lines = append(lines, fmt.Sprintf("%s%s%d minutes (median of %d runs)", message[:index], estimateSeparator, minutes, estimate.Runs))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// labelEstimates is a helper function that maps the estimates of each job to the stage message label of its setup key.
+// When several setups share a label, such as the RKE2 and K3S flavours of a cluster, the estimate with the most runs
+// is kept.
+func labelEstimates(jobEstimates map[string]timing.Estimate) map[string]timing.Estimate {
+	estimates := make(map[string]timing.Estimate)
+	for setupKey, label := range SetupLabels {
+		estimate, ok := jobEstimates[setupKey]
+		if !ok {
+			continue
+		}
+
+		current, ok := estimates[label]
+		if !ok || estimate.Runs > current.Runs {
+			estimates[label] = estimate
+		}
+	}
+
+	return estimates
+}
@@ -8,6 +8,7 @@ import (
 
 	shepherdConfig "github.com/rancher/shepherd/pkg/config"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/timing"
 	"github.com/rancher/tfp-automation/tests/infrastructure/clusters"
 
 	setupairgap "github.com/rancher/tfp-automation/tests/infrastructure/ranchers/setup/airgap"
@@ -17,6 +18,8 @@ import (
 	setupregistry "github.com/rancher/tfp-automation/tests/infrastructure/ranchers/setup/registry"
 	setupstandard "github.com/rancher/tfp-automation/tests/infrastructure/ranchers/setup/standard"
 	upgradeairgap "github.com/rancher/tfp-automation/tests/infrastructure/ranchers/upgrade/airgap"
+	upgradedualstack "github.com/rancher/tfp-automation/tests/infrastructure/ranchers/upgrade/dualstack"
+	upgradeipv6 "github.com/rancher/tfp-automation/tests/infrastructure/ranchers/upgrade/ipv6"
 	upgradeproxy "github.com/rancher/tfp-automation/tests/infrastructure/ranchers/upgrade/proxy"
 	upgradestandard "github.com/rancher/tfp-automation/tests/infrastructure/ranchers/upgrade/standard"
 	"github.com/rancher/tfp-automation/tests/infrastructure/registries"
@@ -52,12 +55,20 @@ var setupRancherFuncs = map[string]map[string]func(*testing.T, string) error{
 			cattleConfig := shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
 			return setupdualstack.CreateDualStackRancher(t, provider, cattleConfig)
 		},
+		"upgrade": func(t *testing.T, provider string) error {
+			cattleConfig := shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+			return upgradedualstack.UpgradingDualStackRancher(t, provider, cattleConfig)
+		},
 	},
 	"ipv6": {
 		"fresh": func(t *testing.T, provider string) error {
 			cattleConfig := shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
 			return setupipv6.CreateIPv6Rancher(t, provider, cattleConfig)
 		},
+		"upgrade": func(t *testing.T, provider string) error {
+			cattleConfig := shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+			return upgradeipv6.UpgradingIPv6Rancher(t, provider, cattleConfig)
+		},
 	},
 	"normal": {
 		"fresh": func(t *testing.T, provider string) error {
@@ -106,13 +117,15 @@ func RunClusterSetupWeb(provider, providerversion, clustertype string) error {
 
 	share.State.Mutex.Lock()
 
-	share.State.StageMsg = strings.Join(share.ClusterStageMessage, "\n")
+	share.State.StageMsg = share.StageMessage(share.ClusterStageMessage)
 	share.State.ErrorMsg = ""
 	share.State.Mutex.Unlock()
 
 	var setupErr error
 	if setupFunc, ok := setupClusterFuncs[clustertype]; ok {
+		share.StartTiming(clustertype)
 		setupErr = setupFunc(t, provider)
+		timing.FinishAll(setupErr)
 	}
 
 	if setupErr != nil {
@@ -151,14 +164,16 @@ func RunRancherSetupWeb(provider, providerversion, ranchertype, installtype stri
 	t := &testing.T{}
 
 	share.State.Mutex.Lock()
-	share.State.StageMsg = strings.Join(share.RancherStageMessage, "\n")
+	share.State.StageMsg = share.StageMessage(share.RancherStageMessage)
 	share.State.ErrorMsg = ""
 	share.State.Mutex.Unlock()
 
 	var setupErr error
 	if installMap, ok := setupRancherFuncs[ranchertype]; ok {
 		if setupFunc, ok := installMap[installtype]; ok {
+			share.StartTiming(ranchertype + ":" + installtype)
 			setupErr = setupFunc(t, provider)
+			timing.FinishAll(setupErr)
 		}
 	}
 
@@ -199,13 +214,15 @@ func RunRegistrySetupWeb(provider, providerversion, registrytype string) error {
 
 	share.State.Mutex.Lock()
 
-	share.State.StageMsg = strings.Join(share.RegistryStageMessage, "\n")
+	share.State.StageMsg = share.StageMessage(share.RegistryStageMessage)
 	share.State.ErrorMsg = ""
 	share.State.Mutex.Unlock()
 
 	var setupErr error
 	if setupFunc, ok := setupRegistryFuncs[registrytype]; ok {
+		share.StartTiming(registrytype)
 		setupErr = setupFunc(t, provider)
+		timing.FinishAll(setupErr)
 	}
 
 	if setupErr != nil {
@@ -0,0 +1,194 @@
+//go:build validation
+
+package backup
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/rancher/shepherd/clients/rancher"
+	shepherdConfig "github.com/rancher/shepherd/pkg/config"
+	namegen "github.com/rancher/shepherd/pkg/namegenerator"
+	"github.com/rancher/shepherd/pkg/session"
+	provisioningActions "github.com/rancher/tests/actions/provisioning"
+	"github.com/rancher/tests/actions/qase"
+	"github.com/rancher/tests/validation/provisioning/resources/standarduser"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/configs"
+	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/framework"
+	"github.com/rancher/tfp-automation/framework/cleanup"
+	backupResources "github.com/rancher/tfp-automation/framework/set/resources/backup"
+	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/rancher/tfp-automation/framework/set/resources/topology"
+	tfpQase "github.com/rancher/tfp-automation/pipeline/qase"
+	"github.com/rancher/tfp-automation/pipeline/qase/results"
+	"github.com/rancher/tfp-automation/pipeline/report"
+	"github.com/rancher/tfp-automation/tests/extensions/backup"
+	nested "github.com/rancher/tfp-automation/tests/extensions/nestedModules"
+	"github.com/rancher/tfp-automation/tests/extensions/provisioning"
+	ranchersetup "github.com/rancher/tfp-automation/tests/infrastructure/ranchers/setup"
+	setupstandard "github.com/rancher/tfp-automation/tests/infrastructure/ranchers/setup/standard"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	restorePrefixSuffix = "-dr"
+	uiIssuesSetting     = "ui-issues"
+	uiIssuesValue       = "https://github.com/rancher/tfp-automation/issues"
+)
+
+type BackupRestoreTestSuite struct {
+	suite.Suite
+	client                     *rancher.Client
+	standardUserClient         *rancher.Client
+	session                    *session.Session
+	serverNodeOne              string
+	cattleConfig               map[string]any
+	rancherConfig              *rancher.Config
+	terraformConfig            *config.TerraformConfig
+	terratestConfig            *config.TerratestConfig
+	standaloneTerraformOptions *terraform.Options
+	terraformOptions           *terraform.Options
+}
+
+func (b *BackupRestoreTestSuite) SetupSuite() {
+	testSession := session.NewSession()
+	b.session = testSession
+	b.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+
+	b.client, b.serverNodeOne, b.standaloneTerraformOptions, b.terraformOptions, b.cattleConfig = setupstandard.SetupRancher(b.T(), b.session, keypath.SanityKeyPath, b.cattleConfig)
+	b.rancherConfig, b.terraformConfig, b.terratestConfig, _ = config.LoadTFPConfigs(b.cattleConfig)
+}
+
+func (b *BackupRestoreTestSuite) TestTfpBackupRestoreMigration() {
+	var err error
+	var testUser, testPassword string
+
+	b.standardUserClient, testUser, testPassword, err = standarduser.CreateStandardUser(b.client)
+	require.NoError(b.T(), err)
+
+	standardUserToken, err := ranchersetup.CreateStandardUserToken(b.T(), b.terraformOptions, b.rancherConfig, testUser, testPassword)
+	require.NoError(b.T(), err)
+
+	standardToken := standardUserToken.Token
+
+	testName := "Backup_Restore_Migration"
+
+	rancherConfig, terraformConfig, terratestConfig, _ := config.LoadTFPConfigs(b.cattleConfig)
+	rancherConfig.AdminToken = standardToken
+
+	if terraformConfig.Module == "" {
+		terraformConfig.Module = modules.NodeDriverAWSRKE2
+	}
+
+	if len(terratestConfig.Nodepools) == 0 {
+		terratestConfig.Nodepools = []config.Nodepool{config.AllRolesNodePool}
+	}
+
+	nestedRancherModuleDir, perTestTerraformOptions, err := nested.CreateNestedModules(b.terraformConfig, b.terratestConfig, b.terraformOptions, testName, configs.NestedRancherModuleDir)
+	require.NoError(b.T(), err)
+	defer os.RemoveAll(nestedRancherModuleDir)
+
+	newFile, rootBody, file := rancher2.InitializeNestedMainTFs(nestedRancherModuleDir)
+	defer file.Close()
+
+	terratestConfig, err = provisioning.GetK8sVersion(b.standardUserClient, terraformConfig, terratestConfig)
+	require.NoError(b.T(), err)
+
+	terraformConfig = provisioning.UniquifyTerraform(terraformConfig)
+
+	_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, b.terratestConfig.PathToRepo, "")
+	defer cleanup.Cleanup(b.T(), perTestTerraformOptions, keyPath)
+
+	err = report.UpdateCaseMetadata(testName, terraformConfig, terratestConfig)
+	if err != nil {
+		logrus.Warningf("Failed to record case metadata %s", err)
+	}
+
+	logrus.Infof("Provisioning cluster (%s)", terraformConfig.ResourcePrefix)
+	clusters, _ := provisioning.Provision(b.T(), b.client, b.standardUserClient, rancherConfig, terraformConfig, terratestConfig, perTestTerraformOptions, newFile, rootBody, file, false, false, true, "", nestedRancherModuleDir)
+
+	logrus.Infof("Verifying the cluster is ready (%s)", clusters[0].Name)
+	err = provisioningActions.VerifyClusterReadyV3(b.client, clusters[0].Name)
+	require.NoError(b.T(), err)
+
+	setting, err := b.client.Management.Setting.ByID(uiIssuesSetting)
+	require.NoError(b.T(), err)
+
+	_, err = b.client.Management.Setting.Update(setting, map[string]any{"value": uiIssuesValue})
+	require.NoError(b.T(), err)
+
+	backedUpState, err := backup.GetRancherState(b.client, uiIssuesSetting)
+	require.NoError(b.T(), err)
+
+	// The MinIO stand-in runs on the first server, which the new local cluster reaches through its private IP.
+	nodes, err := topology.Nodes(b.terraformConfig)
+	require.NoError(b.T(), err)
+
+	nodes = topology.LoadAddresses(b.T(), b.standaloneTerraformOptions, nodes)
+	backupResources.SetBackupDefaults(b.terraformConfig, nodes[0].PrivateIP)
+
+	backupName := namegen.AppendRandomString("tfp-backup")
+
+	_, backupKeyPath := rancher2.SetKeyPath(keypath.BackupKeyPath, b.terratestConfig.PathToRepo, "")
+	backupTerraformOptions := framework.Setup(b.T(), b.terraformConfig, b.terratestConfig, backupKeyPath)
+	defer cleanup.Cleanup(b.T(), backupTerraformOptions, backupKeyPath)
+
+	err = backupResources.CreateBackupMainTF(b.T(), backupTerraformOptions, backupKeyPath, b.rancherConfig, b.terraformConfig, b.serverNodeOne, backupName)
+	require.NoError(b.T(), err)
+
+	backupFilename, err := backup.BackupFilename(b.client, backupName)
+	require.NoError(b.T(), err)
+
+	restoreTerraformConfig := *b.terraformConfig
+	restoreStandaloneConfig := *b.terraformConfig.Standalone
+	restoreTerraformConfig.Standalone = &restoreStandaloneConfig
+	restoreTerraformConfig.ResourcePrefix += restorePrefixSuffix
+	restoreTerraformConfig.Standalone.RancherHostname = restoreStandaloneConfig.RancherBackup.RestoreRancherHostname
+
+	_, restoreKeyPath := rancher2.SetKeyPath(keypath.RestoreKeyPath, b.terratestConfig.PathToRepo, restoreTerraformConfig.Provider)
+	restoreTerraformOptions := framework.Setup(b.T(), &restoreTerraformConfig, b.terratestConfig, restoreKeyPath)
+	defer cleanup.Cleanup(b.T(), restoreTerraformOptions, restoreKeyPath)
+
+	logrus.Infof("Migrating Rancher to a new local cluster (%s)", restoreTerraformConfig.Standalone.RancherHostname)
+	_, err = backupResources.CreateRestoreMainTF(b.T(), restoreTerraformOptions, restoreKeyPath, b.rancherConfig, &restoreTerraformConfig, b.terratestConfig,
+		backupName, backupFilename)
+	require.NoError(b.T(), err)
+
+	restoredRancherConfig := *b.rancherConfig
+	restoredRancherConfig.Host = restoreTerraformConfig.Standalone.RancherHostname
+
+	adminToken, err := ranchersetup.CreateAdminToken(b.T(), restoreTerraformOptions, &restoredRancherConfig)
+	require.NoError(b.T(), err)
+
+	restoredRancherConfig.AdminToken = adminToken.Token
+
+	restoredClient, err := rancher.NewClientForConfig(restoredRancherConfig.AdminToken, &restoredRancherConfig, b.session)
+	require.NoError(b.T(), err)
+
+	restoredState, err := backup.GetRancherState(restoredClient, uiIssuesSetting)
+	require.NoError(b.T(), err)
+
+	logrus.Infof("Verifying the users, clusters, tokens and settings were migrated (%s)", restoredRancherConfig.Host)
+	backup.VerifyRancherRestore(b.T(), backedUpState, restoredState)
+	backup.VerifyRestoredToken(b.T(), &restoredRancherConfig, b.session, standardToken)
+
+	params := tfpQase.GetProvisioningSchemaParams(b.terraformConfig, b.terratestConfig)
+	err = qase.UpdateSchemaParameters(testName, params)
+	if err != nil {
+		logrus.Warningf("Failed to upload schema parameters %s", err)
+	}
+
+	if b.terratestConfig.LocalQaseReporting {
+		results.ReportTest(b.terratestConfig)
+	}
+}
+
+func TestBackupRestoreTestSuite(t *testing.T) {
+	suite.Run(t, new(BackupRestoreTestSuite))
+}
@@ -62,6 +62,8 @@ func (o *OSValidationTestSuite) SetupSuite() {
 	o.cattleConfig, err = configDefaults.LoadPackageDefaults(o.cattleConfig, "")
 	require.NoError(o.T(), err)
 
+	o.rancherConfig, o.terraformConfig, o.terratestConfig, _ = config.LoadTFPConfigs(o.cattleConfig)
+
 	modulePermutation, err := permutationsdata.CreateModulePermutation(o.cattleConfig)
 	require.NoError(o.T(), err)
 
@@ -76,8 +78,30 @@ func (o *OSValidationTestSuite) SetupSuite() {
 	cniPermutation, err := permutationsdata.CreateCNIPermutation(o.cattleConfig)
 	require.NoError(o.T(), err)
 
-	permutedConfigs, err := permutations.Permute([]permutations.Permutation{*modulePermutation, *cniPermutation}, o.cattleConfig)
+	configPermutations := []permutations.Permutation{*modulePermutation, *cniPermutation}
+
+	nodepoolsPermutation, err := permutationsdata.CreateNodepoolsPermutation(o.cattleConfig)
+	require.NoError(o.T(), err)
+
+	if nodepoolsPermutation != nil {
+		configPermutations = append(configPermutations, *nodepoolsPermutation)
+	}
+
+	genericPermutations, err := permutationsdata.CreatePermutations(o.cattleConfig)
+	require.NoError(o.T(), err)
+
+	configPermutations = append(configPermutations, genericPermutations...)
+
+	genericKeyPaths, err := permutationsdata.PermutationKeyPaths(o.cattleConfig)
+	require.NoError(o.T(), err)
+
+	permutedConfigs, err := permutations.Permute(configPermutations, o.cattleConfig)
+	require.NoError(o.T(), err)
+	require.NotEmpty(o.T(), permutedConfigs, "no permutations were generated from the config")
+
+	permutedConfigs, err = permutationsdata.ReducePermutations(permutedConfigs, o.terratestConfig.PermutationReduction, genericKeyPaths...)
 	require.NoError(o.T(), err)
+	require.NotEmpty(o.T(), permutedConfigs, "no permutations are left after the reduction")
 
 	o.permutedConfigs = make([]map[string]any, 0, len(permutedConfigs))
 	for _, permutedConfig := range permutedConfigs {
@@ -24,6 +24,7 @@ import (
 	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 	tfpQase "github.com/rancher/tfp-automation/pipeline/qase"
 	"github.com/rancher/tfp-automation/pipeline/qase/results"
+	"github.com/rancher/tfp-automation/pipeline/report"
 	nested "github.com/rancher/tfp-automation/tests/extensions/nestedModules"
 	"github.com/rancher/tfp-automation/tests/extensions/provisioning"
 
@@ -106,6 +107,11 @@ func (p *DynamicTfpProvisionTestSuite) TestTfpProvisionDynamicInput() {
 
 			terraform = provisioning.UniquifyTerraform(terraform)
 
+			err = report.UpdateCaseMetadata(tt.name, terraform, terratest)
+			if err != nil {
+				logrus.Warningf("Failed to record case metadata %s", err)
+			}
+
 			tt.name = tt.name + " Module: " + p.terraformConfig.Module + " Kubernetes version: " + terratest.KubernetesVersion
 
 			_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, p.terratestConfig.PathToRepo, "")
@@ -126,6 +132,16 @@ func (p *DynamicTfpProvisionTestSuite) TestTfpProvisionDynamicInput() {
 			err = pods.VerifyClusterPods(p.client, clusters[0])
 			require.NoError(p.T(), err)
 
+			if terraform.EnableCloudProvider {
+				logrus.Infof("Verifying the cloud provider (%s)", clusters[0].Name)
+				provisioning.VerifyCloudProvider(p.T(), p.client, terraform)
+			}
+
+			if provisioning.AgentCustomized(terraform) {
+				logrus.Infof("Verifying the agent customization (%s)", clusters[0].Name)
+				provisioning.VerifyAgentCustomization(p.T(), p.client, terraform)
+			}
+
 			params := tfpQase.GetProvisioningSchemaParams(p.terraformConfig, p.terratestConfig)
 			err = qase.UpdateSchemaParameters(tt.name, params)
 			if err != nil {
@@ -27,6 +27,7 @@ import (
 	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 	tfpQase "github.com/rancher/tfp-automation/pipeline/qase"
 	"github.com/rancher/tfp-automation/pipeline/qase/results"
+	"github.com/rancher/tfp-automation/pipeline/report"
 	nested "github.com/rancher/tfp-automation/tests/extensions/nestedModules"
 	"github.com/rancher/tfp-automation/tests/extensions/provisioning"
 
@@ -116,6 +117,11 @@ func (p *ProvisionCustomTestSuite) TestTfpProvisionCustom() {
 			_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, p.terratestConfig.PathToRepo, "")
 			defer cleanup.Cleanup(p.T(), perTestTerraformOptions, keyPath)
 
+			err = report.UpdateCaseMetadata(tt.name, terraform, terratest)
+			if err != nil {
+				logrus.Warningf("Failed to record case metadata %s", err)
+			}
+
 			logrus.Infof("Provisioning cluster (%s)", terraform.ResourcePrefix)
 			clusters, customClusterName := provisioning.Provision(p.T(), p.client, p.standardUserClient, rancher, terraform, terratest, perTestTerraformOptions, newFile, rootBody, file, false, false, true, "", nestedRancherModuleDir)
 
@@ -131,6 +137,11 @@ func (p *ProvisionCustomTestSuite) TestTfpProvisionCustom() {
 			err = pods.VerifyClusterPods(p.client, clusters[0])
 			require.NoError(p.T(), err)
 
+			if provisioning.AgentCustomized(terraform) {
+				logrus.Infof("Verifying the agent customization (%s)", clusters[0].Name)
+				provisioning.VerifyAgentCustomization(p.T(), p.client, terraform)
+			}
+
 			if strings.Contains(terraform.Module, clustertypes.WINDOWS) {
 				logrus.Infof("Provisioning cluster (%s)", terraform.ResourcePrefix)
 				clusters, _ = provisioning.Provision(p.T(), p.client, p.standardUserClient, rancher, terraform, terratest, perTestTerraformOptions, newFile, rootBody, file, true, true, true, customClusterName, nestedRancherModuleDir)
@@ -146,6 +157,9 @@ func (p *ProvisionCustomTestSuite) TestTfpProvisionCustom() {
 				logrus.Infof("Verifying cluster pods (%s)", clusters[0].Name)
 				err = pods.VerifyClusterPods(p.client, clusters[0])
 				require.NoError(p.T(), err)
+
+				logrus.Infof("Verifying Windows workloads (%s)", clusters[0].Name)
+				provisioning.VerifyWindowsWorkloads(p.T(), p.client, terraform.ResourcePrefix)
 			}
 
 			params := tfpQase.GetProvisioningSchemaParams(p.terraformConfig, p.terratestConfig)
@@ -0,0 +1,173 @@
+//go:build validation
+
+package provisioning
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/rancher/shepherd/clients/rancher"
+	shepherdConfig "github.com/rancher/shepherd/pkg/config"
+	"github.com/rancher/shepherd/pkg/session"
+	clusterActions "github.com/rancher/tests/actions/clusters"
+	configDefaults "github.com/rancher/tests/actions/config/defaults"
+	provisioningActions "github.com/rancher/tests/actions/provisioning"
+	"github.com/rancher/tests/actions/qase"
+	"github.com/rancher/tests/actions/workloads/pods"
+	"github.com/rancher/tests/validation/provisioning/resources/standarduser"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/configs"
+	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/vsphere"
+	"github.com/rancher/tfp-automation/framework"
+	cleanup "github.com/rancher/tfp-automation/framework/cleanup"
+	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	tfpQase "github.com/rancher/tfp-automation/pipeline/qase"
+	"github.com/rancher/tfp-automation/pipeline/qase/results"
+	"github.com/rancher/tfp-automation/pipeline/report"
+	nested "github.com/rancher/tfp-automation/tests/extensions/nestedModules"
+	"github.com/rancher/tfp-automation/tests/extensions/provisioning"
+
+	ranchersetup "github.com/rancher/tfp-automation/tests/infrastructure/ranchers/setup"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ProvisionVsphereCreationTypesTestSuite struct {
+	suite.Suite
+	client             *rancher.Client
+	standardUserClient *rancher.Client
+	session            *session.Session
+	cattleConfig       map[string]any
+	rancherConfig      *rancher.Config
+	terraformConfig    *config.TerraformConfig
+	terratestConfig    *config.TerratestConfig
+	terraformOptions   *terraform.Options
+}
+
+func (p *ProvisionVsphereCreationTypesTestSuite) SetupSuite() {
+	var err error
+
+	p.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+
+	p.cattleConfig, err = configDefaults.LoadPackageDefaults(p.cattleConfig, "")
+	require.NoError(p.T(), err)
+
+	p.rancherConfig, p.terraformConfig, p.terratestConfig, _ = config.LoadTFPConfigs(p.cattleConfig)
+
+	testSession := session.NewSession()
+	p.session = testSession
+
+	_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, p.terratestConfig.PathToRepo, "")
+	terraformOptions := framework.Setup(p.T(), p.terraformConfig, p.terratestConfig, keyPath)
+
+	p.terraformOptions = terraformOptions
+
+	client, err := ranchersetup.PostRancherSetup(p.T(), p.terraformOptions, p.rancherConfig, p.session, p.rancherConfig.Host, keyPath, false)
+	require.NoError(p.T(), err)
+
+	p.client = client
+}
+
+func (p *ProvisionVsphereCreationTypesTestSuite) TestTfpProvisionVsphereCreationTypes() {
+	if p.terraformConfig.Module != modules.NodeDriverVsphereRKE2 && p.terraformConfig.Module != modules.NodeDriverVsphereK3S {
+		p.T().Skip("The vSphere creation types are only tested with the vSphere node driver modules")
+	}
+
+	var err error
+	var testUser, testPassword string
+
+	p.standardUserClient, testUser, testPassword, err = standarduser.CreateStandardUser(p.client)
+	require.NoError(p.T(), err)
+
+	standardUserToken, err := ranchersetup.CreateStandardUserToken(p.T(), p.terraformOptions, p.rancherConfig, testUser, testPassword)
+	require.NoError(p.T(), err)
+
+	standardToken := standardUserToken.Token
+
+	nodeRolesDedicated := []config.Nodepool{config.EtcdNodePool, config.ControlPlaneNodePool, config.WorkerNodePool}
+
+	tests := []struct {
+		name         string
+		creationType string
+		cloneFrom    string
+	}{
+		{"Vsphere_Template", vsphere.CreationTypeTemplate, p.terraformConfig.VsphereConfig.CloneFrom},
+		{"Vsphere_VM", vsphere.CreationTypeVM, p.terraformConfig.VsphereConfig.VMCloneFrom},
+		{"Vsphere_Library", vsphere.CreationTypeLibrary, p.terraformConfig.VsphereConfig.LibraryCloneFrom},
+		{"Vsphere_Legacy", vsphere.CreationTypeLegacy, ""},
+	}
+
+	for _, tt := range tests {
+		p.T().Run(tt.name, func(t *testing.T) {
+			switch {
+			case tt.creationType == vsphere.CreationTypeLegacy && p.terraformConfig.VsphereConfig.Boot2dockerURL == "":
+				t.Skip("vsphereConfig.boot2dockerURL is not set")
+			case tt.creationType == vsphere.CreationTypeLibrary && p.terraformConfig.VsphereConfig.ContentLibrary == "":
+				t.Skip("vsphereConfig.contentLibrary is not set")
+			case tt.creationType != vsphere.CreationTypeLegacy && tt.cloneFrom == "":
+				t.Skipf("No clone source is set for the %s creation type", tt.creationType)
+			}
+
+			t.Parallel()
+
+			rancher, terraform, terratest, _ := config.LoadTFPConfigs(p.cattleConfig)
+			rancher.AdminToken = standardToken
+			terratest.Nodepools = nodeRolesDedicated
+			terraform.VsphereConfig.CreationType = tt.creationType
+			terraform.VsphereConfig.CloneFrom = tt.cloneFrom
+
+			nestedRancherModuleDir, perTestTerraformOptions, err := nested.CreateNestedModules(p.terraformConfig, p.terratestConfig, p.terraformOptions, tt.name, configs.NestedRancherModuleDir)
+			require.NoError(t, err)
+			defer os.RemoveAll(nestedRancherModuleDir)
+
+			newFile, rootBody, file := rancher2.InitializeNestedMainTFs(nestedRancherModuleDir)
+			defer file.Close()
+
+			terratest, err = provisioning.GetK8sVersion(p.client, terraform, terratest)
+			require.NoError(p.T(), err)
+
+			terraform = provisioning.UniquifyTerraform(terraform)
+
+			_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, p.terratestConfig.PathToRepo, "")
+			defer cleanup.Cleanup(p.T(), perTestTerraformOptions, keyPath)
+
+			err = report.UpdateCaseMetadata(tt.name, terraform, terratest)
+			if err != nil {
+				logrus.Warningf("Failed to record case metadata %s", err)
+			}
+
+			logrus.Infof("Provisioning cluster (%s) with the %s creation type", terraform.ResourcePrefix, tt.creationType)
+			clusters, _ := provisioning.Provision(p.T(), p.client, p.standardUserClient, rancher, terraform, terratest, perTestTerraformOptions, newFile, rootBody, file, false, false, false, "", nestedRancherModuleDir)
+
+			logrus.Infof("Verifying the cluster is ready (%s)", clusters[0].Name)
+			err = provisioningActions.VerifyClusterReady(p.client, clusters[0])
+			require.NoError(p.T(), err)
+
+			logrus.Infof("Verifying service account token secret (%s)", clusters[0].Name)
+			err = clusterActions.VerifyServiceAccountTokenSecret(p.client, clusters[0].Name)
+			require.NoError(p.T(), err)
+
+			logrus.Infof("Verifying cluster pods (%s)", clusters[0].Name)
+			err = pods.VerifyClusterPods(p.client, clusters[0])
+			require.NoError(p.T(), err)
+
+			params := tfpQase.GetProvisioningSchemaParams(p.terraformConfig, p.terratestConfig)
+			err = qase.UpdateSchemaParameters(tt.name, params)
+			if err != nil {
+				logrus.Warningf("Failed to upload schema parameters %s", err)
+			}
+		})
+	}
+
+	if p.terratestConfig.LocalQaseReporting {
+		results.ReportTest(p.terratestConfig)
+	}
+}
+
+func TestProvisionVsphereCreationTypesTestSuite(t *testing.T) {
+	suite.Run(t, new(ProvisionVsphereCreationTypesTestSuite))
+}
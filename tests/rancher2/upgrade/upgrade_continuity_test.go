@@ -0,0 +1,173 @@
+//go:build validation
+
+package upgrade
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/rancher/shepherd/clients/rancher"
+	shepherdConfig "github.com/rancher/shepherd/pkg/config"
+	"github.com/rancher/shepherd/pkg/session"
+	provisioningActions "github.com/rancher/tests/actions/provisioning"
+	"github.com/rancher/tests/actions/qase"
+	"github.com/rancher/tests/validation/provisioning/resources/standarduser"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/configs"
+	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/framework/cleanup"
+	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	tfpQase "github.com/rancher/tfp-automation/pipeline/qase"
+	"github.com/rancher/tfp-automation/pipeline/qase/results"
+	"github.com/rancher/tfp-automation/pipeline/report"
+	nested "github.com/rancher/tfp-automation/tests/extensions/nestedModules"
+	"github.com/rancher/tfp-automation/tests/extensions/provisioning"
+	ranchersetup "github.com/rancher/tfp-automation/tests/infrastructure/ranchers/setup"
+	setupstandard "github.com/rancher/tfp-automation/tests/infrastructure/ranchers/setup/standard"
+	upgradestandard "github.com/rancher/tfp-automation/tests/infrastructure/ranchers/upgrade/standard"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type UpgradeContinuityTestSuite struct {
+	suite.Suite
+	client                     *rancher.Client
+	standardUserClient         *rancher.Client
+	session                    *session.Session
+	serverNodeOne              string
+	cattleConfig               map[string]any
+	rancherConfig              *rancher.Config
+	terraformConfig            *config.TerraformConfig
+	terratestConfig            *config.TerratestConfig
+	standaloneConfig           *config.Standalone
+	standaloneTerraformOptions *terraform.Options
+	terraformOptions           *terraform.Options
+}
+
+func (u *UpgradeContinuityTestSuite) SetupSuite() {
+	testSession := session.NewSession()
+	u.session = testSession
+	u.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+
+	u.client, u.serverNodeOne, u.standaloneTerraformOptions, u.terraformOptions, u.cattleConfig = setupstandard.SetupRancher(u.T(), u.session, keypath.SanityKeyPath, u.cattleConfig)
+	u.rancherConfig, u.terraformConfig, u.terratestConfig, u.standaloneConfig = config.LoadTFPConfigs(u.cattleConfig)
+}
+
+func (u *UpgradeContinuityTestSuite) TestTfpUpgradeContinuity() {
+	var err error
+	var testUser, testPassword string
+
+	u.standardUserClient, testUser, testPassword, err = standarduser.CreateStandardUser(u.client)
+	require.NoError(u.T(), err)
+
+	standardUserToken, err := ranchersetup.CreateStandardUserToken(u.T(), u.terraformOptions, u.rancherConfig, testUser, testPassword)
+	require.NoError(u.T(), err)
+
+	standardToken := standardUserToken.Token
+
+	upgradeModules := u.terratestConfig.UpgradeModules
+	if len(upgradeModules) == 0 {
+		upgradeModules = []string{modules.NodeDriverAWSRKE2, modules.CustomAWSRKE2, modules.ImportedAWSRKE2}
+	}
+
+	testName := "Upgrade_Continuity"
+
+	continuities := []*provisioning.UpgradeContinuity{}
+	caseNames := []string{}
+	for _, module := range upgradeModules {
+		rancher, terraform, terratest, _ := config.LoadTFPConfigs(u.cattleConfig)
+		rancher.AdminToken = standardToken
+		terraform.Module = module
+
+		switch module {
+		case modules.HostedAzureAKS:
+			terratest.KubernetesVersion = terratest.AKSKubernetesVersion
+			terratest.Nodepools = []config.Nodepool{{Quantity: 3}}
+		case modules.HostedAWSEKS:
+			terratest.KubernetesVersion = terratest.EKSKubernetesVersion
+			terratest.Nodepools = []config.Nodepool{{DiskSize: 100, InstanceType: terraform.AWSConfig.AWSInstanceType, DesiredSize: 3, MaxSize: 3, MinSize: 3}}
+		case modules.HostedGoogleGKE:
+			terratest.KubernetesVersion = terratest.GKEKubernetesVersion
+			terratest.Nodepools = []config.Nodepool{{Quantity: 1, MaxPodsConstraint: 110}}
+		default:
+			if len(terratest.Nodepools) == 0 {
+				terratest.Nodepools = []config.Nodepool{config.AllRolesNodePool}
+			}
+		}
+
+		caseName := testName + "_" + strings.ReplaceAll(module, "_", "-")
+
+		nestedRancherModuleDir, perTestTerraformOptions, err := nested.CreateNestedModules(u.terraformConfig, u.terratestConfig, u.terraformOptions, caseName, configs.NestedRancherModuleDir)
+		require.NoError(u.T(), err)
+		defer os.RemoveAll(nestedRancherModuleDir)
+
+		newFile, rootBody, file := rancher2.InitializeNestedMainTFs(nestedRancherModuleDir)
+		defer file.Close()
+
+		terratest, err = provisioning.GetK8sVersion(u.standardUserClient, terraform, terratest)
+		require.NoError(u.T(), err)
+
+		terraform = provisioning.UniquifyTerraform(terraform)
+
+		_, keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath, u.terratestConfig.PathToRepo, "")
+		defer cleanup.Cleanup(u.T(), perTestTerraformOptions, keyPath)
+
+		err = report.UpdateCaseMetadata(caseName, terraform, terratest)
+		if err != nil {
+			logrus.Warningf("Failed to record case metadata %s", err)
+		}
+
+		logrus.Infof("Provisioning cluster (%s)", terraform.ResourcePrefix)
+		clusters, _ := provisioning.Provision(u.T(), u.client, u.standardUserClient, rancher, terraform, terratest, perTestTerraformOptions, newFile, rootBody, file, false, false, true, "", nestedRancherModuleDir)
+
+		logrus.Infof("Verifying the cluster is ready (%s)", clusters[0].Name)
+		err = provisioningActions.VerifyClusterReadyV3(u.client, clusters[0].Name)
+		require.NoError(u.T(), err)
+
+		logrus.Infof("Deploying a workload ahead of the upgrade (%s)", clusters[0].Name)
+		continuity, err := provisioning.PrepareUpgradeContinuity(u.client, clusters[0].Name, module)
+		require.NoError(u.T(), err)
+
+		continuities = append(continuities, continuity)
+		caseNames = append(caseNames, caseName)
+	}
+
+	logrus.Infof("Upgrading Rancher to %s", u.standaloneConfig.UpgradedRancherTagVersion)
+	u.client, u.cattleConfig, _, _ = upgradestandard.UpgradeRancher(u.T(), u.client, u.serverNodeOne, u.session, u.cattleConfig)
+
+	upgradedAgentImage, err := provisioning.UpgradedAgentImage(u.client)
+	require.NoError(u.T(), err)
+
+	for _, continuity := range continuities {
+		logrus.Infof("Verifying the cluster continuity after the upgrade (%s)", continuity.ClusterName)
+		provisioning.VerifyUpgradeContinuity(u.client, continuity, upgradedAgentImage)
+	}
+
+	logrus.Infof("Upgrade continuity report:\n%s", provisioning.UpgradeContinuityReport(continuities))
+
+	// Each module is reported as its own case, matching the metadata recorded under its case name.
+	for i, continuity := range continuities {
+		u.Run(caseNames[i], func() {
+			require.True(u.T(), continuity.Passed(), "cluster %s (%s) did not survive the upgrade: %s", continuity.ClusterName, continuity.Module,
+				strings.Join(continuity.Errors, "; "))
+		})
+	}
+
+	params := tfpQase.GetProvisioningSchemaParams(u.terraformConfig, u.terratestConfig)
+	err = qase.UpdateSchemaParameters(testName, params)
+	if err != nil {
+		logrus.Warningf("Failed to upload schema parameters %s", err)
+	}
+
+	if u.terratestConfig.LocalQaseReporting {
+		results.ReportTest(u.terratestConfig)
+	}
+}
+
+func TestUpgradeContinuityTestSuite(t *testing.T) {
+	suite.Run(t, new(UpgradeContinuityTestSuite))
+}
@@ -0,0 +1,344 @@
+package main
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+)
+
+const (
+	markdownTemplatePath = "weeklyPRReports/assets/digest.md"
+	htmlTemplatePath     = "weeklyPRReports/assets/digest.html"
+
+	stateMerged = "MERGED"
+	stateOpen   = "OPEN"
+	stateClosed = "CLOSED"
+
+	areaOther = "other"
+	noLabel   = "unlabeled"
+)
+
+// areas lists the areas PRs are grouped by, in report order. A PR belongs to an area when a word of its title, one of
+// its labels or one of its changed file paths matches a keyword.
+var areas = []struct {
+	Name     string
+	Keywords []string
+}{
+	{"provider", []string{"aws", "azure", "gce", "google", "harvester", "linode", "vsphere", "provider", "providers"}},
+	{"airgap", []string{"airgap", "airgapped"}},
+	{"registry", []string{"registry", "registries", "ecr"}},
+	{"hosted", []string{"hosted", "aks", "eks", "gke"}},
+	{"qase", []string{"qase"}},
+}
+
+type digest struct {
+	GeneratedAt string
+	Since       time.Time
+	Until       time.Time
+	StaleDays   int
+	Total       int
+	States      []group
+	Areas       []group
+	Labels      []group
+	Stale       []prView
+	MergeStats  *mergeStats
+	History     *historyCorrelation
+}
+
+type group struct {
+	Name string
+	PRs  []prView
+}
+
+type prView struct {
+	Number    int
+	Title     string
+	URL       string
+	State     string
+	Author    string
+	Draft     bool
+	Labels    []string
+	Areas     []string
+	MergeTime string
+	IdleDays  int
+}
+
+type mergeStats struct {
+	Count   int
+	Mean    string
+	Median  string
+	P90     string
+	Fastest prView
+	Slowest prView
+}
+
+// buildDigest groups the PRs updated within the window by state, area and label, flags stale open PRs and computes
+// the merge-time statistics of the PRs merged within the window.
+func buildDigest(prs []PR, now time.Time, windowDays, staleDays int) *digest {
+	weekly := &digest{
+		GeneratedAt: now.Format("2006-01-02 15:04 MST"),
+		Since:       now.AddDate(0, 0, -windowDays),
+		Until:       now,
+		StaleDays:   staleDays,
+	}
+
+	byState := make(map[string][]prView)
+	byArea := make(map[string][]prView)
+	byLabel := make(map[string][]prView)
+
+	var merged []PR
+	for _, pr := range prs {
+		view := newPRView(pr, now)
+		weekly.Total++
+
+		byState[pr.State] = append(byState[pr.State], view)
+
+		for _, area := range view.Areas {
+			byArea[area] = append(byArea[area], view)
+		}
+
+		if len(view.Labels) == 0 {
+			byLabel[noLabel] = append(byLabel[noLabel], view)
+		}
+
+		for _, label := range view.Labels {
+			byLabel[label] = append(byLabel[label], view)
+		}
+
+		if pr.State == stateOpen && view.IdleDays >= staleDays {
+			weekly.Stale = append(weekly.Stale, view)
+		}
+
+		if pr.State == stateMerged && !pr.MergedAt.IsZero() && !pr.MergedAt.Before(weekly.Since) {
+			merged = append(merged, pr)
+		}
+	}
+
+	for _, state := range []string{stateMerged, stateOpen, stateClosed} {
+		if len(byState[state]) > 0 {
+			weekly.States = append(weekly.States, group{Name: strings.ToLower(state), PRs: sortPRs(byState[state])})
+		}
+	}
+
+	for _, area := range areas {
+		if len(byArea[area.Name]) > 0 {
+			weekly.Areas = append(weekly.Areas, group{Name: area.Name, PRs: sortPRs(byArea[area.Name])})
+		}
+	}
+
+	if len(byArea[areaOther]) > 0 {
+		weekly.Areas = append(weekly.Areas, group{Name: areaOther, PRs: sortPRs(byArea[areaOther])})
+	}
+
+	labels := make([]string, 0, len(byLabel))
+	for label := range byLabel {
+		labels = append(labels, label)
+	}
+
+	sort.Strings(labels)
+	for _, label := range labels {
+		weekly.Labels = append(weekly.Labels, group{Name: label, PRs: sortPRs(byLabel[label])})
+	}
+
+	sort.Slice(weekly.Stale, func(i, j int) bool {
+		return weekly.Stale[i].IdleDays > weekly.Stale[j].IdleDays
+	})
+
+	weekly.MergeStats = computeMergeStats(merged, now)
+
+	return weekly
+}
+
+// newPRView flattens a PR into the fields shown in the digest.
+func newPRView(pr PR, now time.Time) prView {
+	view := prView{
+		Number: pr.Number,
+		Title:  pr.Title,
+		URL:    pr.URL,
+		State:  strings.ToLower(pr.State),
+		Author: pr.Author.Login,
+		Draft:  pr.IsDraft,
+		Areas:  classifyAreas(pr),
+	}
+
+	for _, label := range pr.Labels {
+		view.Labels = append(view.Labels, label.Name)
+	}
+
+	if !pr.MergedAt.IsZero() && !pr.CreatedAt.IsZero() {
+		view.MergeTime = formatDuration(pr.MergedAt.Sub(pr.CreatedAt))
+	}
+
+	lastActivity := pr.UpdatedAt
+	if lastActivity.IsZero() {
+		lastActivity = pr.CreatedAt
+	}
+
+	if !lastActivity.IsZero() {
+		view.IdleDays = int(now.Sub(lastActivity).Hours() / 24)
+	}
+
+	return view
+}
+
+// classifyAreas returns the areas a PR belongs to, or the other area when none matches.
+func classifyAreas(pr PR) []string {
+	words := make(map[string]struct{})
+	addWords := func(text string) {
+		for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			words[word] = struct{}{}
+		}
+	}
+
+	addWords(pr.Title)
+	for _, label := range pr.Labels {
+		addWords(label.Name)
+	}
+
+	for _, file := range pr.Files {
+		addWords(file.Path)
+	}
+
+	var matched []string
+	for _, area := range areas {
+		for _, keyword := range area.Keywords {
+			if _, ok := words[keyword]; ok {
+				matched = append(matched, area.Name)
+				break
+			}
+		}
+	}
+
+	if len(matched) == 0 {
+		matched = append(matched, areaOther)
+	}
+
+	return matched
+}
+
+// computeMergeStats computes the time from creation to merge of the given PRs.
+func computeMergeStats(merged []PR, now time.Time) *mergeStats {
+	var durations []time.Duration
+	var fastest, slowest PR
+
+	for _, pr := range merged {
+		if pr.CreatedAt.IsZero() {
+			continue
+		}
+
+		duration := pr.MergedAt.Sub(pr.CreatedAt)
+		if len(durations) == 0 || duration < fastest.MergedAt.Sub(fastest.CreatedAt) {
+			fastest = pr
+		}
+
+		if len(durations) == 0 || duration > slowest.MergedAt.Sub(slowest.CreatedAt) {
+			slowest = pr
+		}
+
+		durations = append(durations, duration)
+	}
+
+	if len(durations) == 0 {
+		return nil
+	}
+
+	sort.Slice(durations, func(i, j int) bool {
+		return durations[i] < durations[j]
+	})
+
+	var total time.Duration
+	for _, duration := range durations {
+		total += duration
+	}
+
+	return &mergeStats{
+		Count:   len(durations),
+		Mean:    formatDuration(total / time.Duration(len(durations))),
+		Median:  formatDuration(percentile(durations, 50)),
+		P90:     formatDuration(percentile(durations, 90)),
+		Fastest: newPRView(fastest, now),
+		Slowest: newPRView(slowest, now),
+	}
+}
+
+// renderDigest renders the digest with the given template. HTML templates are escaped, Markdown templates are not.
+func renderDigest(outputPath, templatePath string, weekly *digest) error {
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	funcs := map[string]any{
+		"date":  func(t time.Time) string { return t.Format("2006-01-02") },
+		"join":  strings.Join,
+		"title": capitalize,
+	}
+
+	if filepath.Ext(templatePath) == ".html" {
+		tmpl, err := htmltemplate.New(filepath.Base(templatePath)).Funcs(funcs).ParseFiles(templatePath)
+		if err != nil {
+			return err
+		}
+
+		return tmpl.Execute(file, weekly)
+	}
+
+	tmpl, err := template.New(filepath.Base(templatePath)).Funcs(funcs).ParseFiles(templatePath)
+	if err != nil {
+		return err
+	}
+
+	return tmpl.Execute(file, weekly)
+}
+
+// capitalize is a helper function that upper-cases the first letter of a group name.
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// sortPRs is a helper function that orders PRs by number, newest first.
+func sortPRs(prs []prView) []prView {
+	sort.Slice(prs, func(i, j int) bool {
+		return prs[i].Number > prs[j].Number
+	})
+
+	return prs
+}
+
+// percentile is a helper function that returns the nearest-rank percentile of sorted durations.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	rank = max(0, min(rank, len(sorted)-1))
+
+	return sorted[rank]
+}
+
+// formatDuration is a helper function that formats a duration in days and hours.
+func formatDuration(d time.Duration) string {
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+
+	if days > 0 {
+		return fmt.Sprintf("%dd %dh", days, hours)
+	}
+
+	if hours > 0 {
+		return fmt.Sprintf("%dh", hours)
+	}
+
+	return fmt.Sprintf("%dm", int(d.Minutes()))
+}
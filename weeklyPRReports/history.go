@@ -0,0 +1,197 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+)
+
+const (
+	statusSuccess = "success"
+	statusFailure = "failure"
+)
+
+// historyResult is the subset of a test history entry written by the test summary generator that the digest needs.
+type historyResult struct {
+	Date      string `json:"date"`
+	Status    string `json:"status"`
+	Workflow  string `json:"workflow"`
+	Job       string `json:"job"`
+	Timestamp string `json:"timestamp,omitempty"`
+
+	observedAt time.Time
+}
+
+type historyCorrelation struct {
+	WindowHours int
+	Days        []dayPassRate
+	Merges      []mergeImpact
+}
+
+type dayPassRate struct {
+	Date     string
+	Runs     int
+	PassRate string
+	Merges   int
+}
+
+type mergeImpact struct {
+	PR         prView
+	MergedAt   string
+	RunsBefore int
+	RunsAfter  int
+	Before     string
+	After      string
+	Delta      string
+	Regression bool
+}
+
+// loadHistory reads the test history. A missing history file is not an error, the digest is then built without it.
+func loadHistory(path string) ([]historyResult, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var history []historyResult
+	err = json.Unmarshal(data, &history)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]historyResult, 0, len(history))
+	for _, result := range history {
+		if result.Status != statusSuccess && result.Status != statusFailure {
+			continue
+		}
+
+		result.observedAt = parseObservedAt(result)
+		if result.observedAt.IsZero() {
+			continue
+		}
+
+		results = append(results, result)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].observedAt.Before(results[j].observedAt)
+	})
+
+	return results, nil
+}
+
+// correlateHistory computes the daily pass rate over the digest window and, for every PR merged within it, the pass
+// rate of the runs in the given window before and after the merge.
+func correlateHistory(prs []PR, history []historyResult, since, until time.Time, window time.Duration) *historyCorrelation {
+	correlation := &historyCorrelation{WindowHours: int(window.Hours())}
+
+	mergesByDay := make(map[string]int)
+	var merged []PR
+	for _, pr := range prs {
+		if pr.State != stateMerged || pr.MergedAt.IsZero() || pr.MergedAt.Before(since) {
+			continue
+		}
+
+		mergesByDay[pr.MergedAt.UTC().Format("2006-01-02")]++
+		merged = append(merged, pr)
+	}
+
+	for day := since.UTC().Truncate(24 * time.Hour); !day.After(until); day = day.AddDate(0, 0, 1) {
+		passed, runs := passRate(history, day, day.AddDate(0, 0, 1))
+		date := day.Format("2006-01-02")
+
+		correlation.Days = append(correlation.Days, dayPassRate{
+			Date:     date,
+			Runs:     runs,
+			PassRate: formatRate(passed, runs),
+			Merges:   mergesByDay[date],
+		})
+	}
+
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].MergedAt.Before(merged[j].MergedAt)
+	})
+
+	for _, pr := range merged {
+		passedBefore, runsBefore := passRate(history, pr.MergedAt.Add(-window), pr.MergedAt)
+		passedAfter, runsAfter := passRate(history, pr.MergedAt, pr.MergedAt.Add(window))
+
+		impact := mergeImpact{
+			PR:         newPRView(pr, until),
+			MergedAt:   pr.MergedAt.UTC().Format("2006-01-02 15:04"),
+			RunsBefore: runsBefore,
+			RunsAfter:  runsAfter,
+			Before:     formatRate(passedBefore, runsBefore),
+			After:      formatRate(passedAfter, runsAfter),
+			Delta:      "-",
+		}
+
+		if runsBefore > 0 && runsAfter > 0 {
+			delta := 100 * (float64(passedAfter)/float64(runsAfter) - float64(passedBefore)/float64(runsBefore))
+			impact.Delta = fmt.Sprintf("%+.1f%%", delta)
+			impact.Regression = delta < 0
+		}
+
+		correlation.Merges = append(correlation.Merges, impact)
+	}
+
+	return correlation
+}
+
+// passRate is a helper function that counts the passed and total runs observed in [from, to).
+func passRate(history []historyResult, from, to time.Time) (int, int) {
+	var passed, runs int
+	for _, result := range history {
+		if result.observedAt.Before(from) || !result.observedAt.Before(to) {
+			continue
+		}
+
+		runs++
+		if result.Status == statusSuccess {
+			passed++
+		}
+	}
+
+	return passed, runs
+}
+
+// formatRate is a helper function that formats a pass rate as a percentage, or a dash when there were no runs.
+func formatRate(passed, runs int) string {
+	if runs == 0 {
+		return "-"
+	}
+
+	return fmt.Sprintf("%.1f%%", 100*float64(passed)/float64(runs))
+}
+
+// parseObservedAt is a helper function that parses the time of a history entry, preferring its timestamp over the
+// date. It accepts the same layouts as the test summary generator.
+func parseObservedAt(result historyResult) time.Time {
+	if result.Timestamp != "" {
+		ts, err := time.Parse(time.RFC3339, result.Timestamp)
+		if err == nil {
+			return ts
+		}
+	}
+
+	layouts := []string{
+		"January 02, 2006 at 03:04 PM",
+		time.RFC3339,
+		"2006-01-02",
+	}
+
+	for _, layout := range layouts {
+		ts, err := time.Parse(layout, result.Date)
+		if err == nil {
+			return ts
+		}
+	}
+
+	return time.Time{}
+}
@@ -2,24 +2,65 @@ package main
 
 import (
 	"encoding/json"
+	"flag"
 	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
 
+const (
+	markdownFile = "weekly_digest.md"
+	htmlFile     = "weekly_digest.html"
+
+	defaultHistoryPath      = "results/history/test_history.json"
+	defaultWindowDays       = 7
+	defaultStaleDays        = 14
+	defaultCorrelationHours = 72
+)
+
 type PR struct {
-	Title string `json:"title"`
-	URL   string `json:"url"`
-	State string `json:"state"`
+	Number    int       `json:"number"`
+	Title     string    `json:"title"`
+	URL       string    `json:"url"`
+	State     string    `json:"state"`
+	IsDraft   bool      `json:"isDraft"`
+	Author    Author    `json:"author"`
+	Labels    []Label   `json:"labels"`
+	Files     []File    `json:"files"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	MergedAt  time.Time `json:"mergedAt"`
+	ClosedAt  time.Time `json:"closedAt"`
+}
+
+type Author struct {
+	Login string `json:"login"`
+}
+
+type Label struct {
+	Name string `json:"name"`
+}
+
+type File struct {
+	Path string `json:"path"`
 }
 
 func main() {
-	if len(os.Args) != 2 {
-		logrus.Error("Usage: weekly_reports <prs.json>")
+	outputDir := flag.String("output", ".", "directory the Markdown and HTML digests are written to")
+	historyPath := flag.String("history", defaultHistoryPath, "test history used to correlate merges with pass-rate changes, skipped when missing")
+	windowDays := flag.Int("days", defaultWindowDays, "number of days covered by the digest")
+	staleDays := flag.Int("stale-days", defaultStaleDays, "days without updates after which an open PR is reported as stale")
+	correlationHours := flag.Int("correlation-hours", defaultCorrelationHours, "hours before and after a merge compared for pass-rate changes")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		logrus.Error("Usage: weekly_reports [flags] <prs.json>")
 		os.Exit(1)
 	}
 
-	prsPath := os.Args[1]
+	prsPath := flag.Arg(0)
 
 	data, err := os.ReadFile(prsPath)
 	if err != nil {
@@ -28,8 +69,38 @@ func main() {
 	}
 
 	var prs []PR
-	if err := json.Unmarshal(data, &prs); err != nil {
+	err = json.Unmarshal(data, &prs)
+	if err != nil {
 		logrus.Errorf("Error parsing JSON: %v", err)
 		os.Exit(1)
 	}
+
+	now := time.Now().UTC()
+	weekly := buildDigest(prs, now, *windowDays, *staleDays)
+
+	history, err := loadHistory(*historyPath)
+	if err != nil {
+		logrus.Warningf("Skipping test history correlation: %v", err)
+	} else if len(history) > 0 {
+		weekly.History = correlateHistory(prs, history, weekly.Since, now, time.Duration(*correlationHours)*time.Hour)
+	}
+
+	err = os.MkdirAll(*outputDir, 0o755)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	markdownPath := filepath.Join(*outputDir, markdownFile)
+	err = renderDigest(markdownPath, markdownTemplatePath, weekly)
+	if err != nil {
+		logrus.Fatalf("Error writing %s: %v", markdownPath, err)
+	}
+
+	htmlPath := filepath.Join(*outputDir, htmlFile)
+	err = renderDigest(htmlPath, htmlTemplatePath, weekly)
+	if err != nil {
+		logrus.Fatalf("Error writing %s: %v", htmlPath, err)
+	}
+
+	logrus.Infof("Weekly digest written to %s and %s", markdownPath, htmlPath)
 }